@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/archive"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+var _ chains.Registrant = &chainTracker{}
+
+// chainTracker keeps track of the chains running on this node so that their
+// state can be described in database archives.
+type chainTracker struct {
+	lock    sync.Mutex
+	names   []string
+	engines []common.Engine
+}
+
+func (c *chainTracker) RegisterChain(name string, engine common.Engine) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.names = append(c.names, name)
+	c.engines = append(c.engines, engine)
+}
+
+// chainInfos returns the current state of every registered chain. Assumes
+// that none of the chains' context locks are held.
+func (c *chainTracker) chainInfos() ([]archive.ChainInfo, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	infos := make([]archive.ChainInfo, len(c.engines))
+	for i, engine := range c.engines {
+		ctx := engine.Context()
+		infos[i] = archive.ChainInfo{
+			ID:   ctx.ChainID,
+			Name: c.names[i],
+		}
+
+		// Only linear chains have a well defined last accepted height.
+		vm, ok := engine.GetVM().(block.ChainVM)
+		if !ok {
+			continue
+		}
+
+		ctx.Lock.Lock()
+		lastAcceptedID, err := vm.LastAccepted()
+		if err != nil {
+			ctx.Lock.Unlock()
+			return nil, err
+		}
+		lastAccepted, err := vm.GetBlock(lastAcceptedID)
+		ctx.Lock.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		infos[i].LastAccepted = lastAcceptedID
+		infos[i].Height = json.Uint64(lastAccepted.Height())
+	}
+	return infos, nil
+}
@@ -6,9 +6,11 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
@@ -29,6 +31,19 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	ExportDatabase(ctx context.Context, name string, options ...rpc.Option) (string, error)
+	GetDatabaseExport(ctx context.Context, name string, options ...rpc.Option) (*DatabaseExport, error)
+	GetDatabaseStats(ctx context.Context, countKeys bool, options ...rpc.Option) ([]DatabasePrefixStats, error)
+	BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, reason string, options ...rpc.Option) error
+	BanIP(ctx context.Context, ip string, duration time.Duration, reason string, options ...rpc.Option) error
+	UnbanNode(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	UnbanIP(ctx context.Context, ip string, options ...rpc.Option) error
+	ListBans(context.Context, ...rpc.Option) ([]BanInfo, error)
+	PinPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error
+	UnpinPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	GetPinnedPeers(context.Context, ...rpc.Option) ([]PinnedPeer, error)
+	SetAllowedNodeIDs(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) error
+	GetAllowedNodeIDs(context.Context, ...rpc.Option) ([]ids.NodeID, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -140,3 +155,92 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) ExportDatabase(ctx context.Context, name string, options ...rpc.Option) (string, error) {
+	res := &ExportDatabaseReply{}
+	err := c.requester.SendRequest(ctx, "exportDatabase", &ExportDatabaseArgs{
+		Name: name,
+	}, res, options...)
+	return res.Path, err
+}
+
+func (c *client) GetDatabaseExport(ctx context.Context, name string, options ...rpc.Option) (*DatabaseExport, error) {
+	res := &DatabaseExport{}
+	err := c.requester.SendRequest(ctx, "getDatabaseExport", &GetDatabaseExportArgs{
+		Name: name,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetDatabaseStats(ctx context.Context, countKeys bool, options ...rpc.Option) ([]DatabasePrefixStats, error) {
+	res := &GetDatabaseStatsReply{}
+	err := c.requester.SendRequest(ctx, "getDatabaseStats", &GetDatabaseStatsArgs{
+		CountKeys: countKeys,
+	}, res, options...)
+	return res.Prefixes, err
+}
+
+func (c *client) BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, reason string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "banPeer", &BanPeerArgs{
+		PeerArgs: PeerArgs{NodeID: nodeID},
+		Duration: json.Uint64(duration / time.Second),
+		Reason:   reason,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanIP(ctx context.Context, ip string, duration time.Duration, reason string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "banPeer", &BanPeerArgs{
+		PeerArgs: PeerArgs{IP: ip},
+		Duration: json.Uint64(duration / time.Second),
+		Reason:   reason,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanNode(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "unbanPeer", &PeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanIP(ctx context.Context, ip string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "unbanPeer", &PeerArgs{
+		IP: ip,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ListBans(ctx context.Context, options ...rpc.Option) ([]BanInfo, error) {
+	res := &ListBansReply{}
+	err := c.requester.SendRequest(ctx, "listBans", struct{}{}, res, options...)
+	return res.Bans, err
+}
+
+func (c *client) PinPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "pinPeer", &PinPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnpinPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "unpinPeer", &UnpinPeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetPinnedPeers(ctx context.Context, options ...rpc.Option) ([]PinnedPeer, error) {
+	res := &GetPinnedPeersReply{}
+	err := c.requester.SendRequest(ctx, "getPinnedPeers", struct{}{}, res, options...)
+	return res.PinnedPeers, err
+}
+
+func (c *client) SetAllowedNodeIDs(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "setAllowedNodeIDs", &AllowedNodeIDs{
+		NodeIDs: nodeIDs,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetAllowedNodeIDs(ctx context.Context, options ...rpc.Option) ([]ids.NodeID, error) {
+	res := &AllowedNodeIDs{}
+	err := c.requester.SendRequest(ctx, "getAllowedNodeIDs", struct{}{}, res, options...)
+	return res.NodeIDs, err
+}
@@ -7,10 +7,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/archive"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
@@ -64,6 +66,24 @@ func (mc *mockClient) SendRequest(ctx context.Context, method string, params int
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *ExportDatabaseReply:
+		response := mc.response.(*ExportDatabaseReply)
+		*p = *response
+	case *DatabaseExport:
+		response := mc.response.(*DatabaseExport)
+		*p = *response
+	case *GetDatabaseStatsReply:
+		response := mc.response.(*GetDatabaseStatsReply)
+		*p = *response
+	case *ListBansReply:
+		response := mc.response.(*ListBansReply)
+		*p = *response
+	case *GetPinnedPeersReply:
+		response := mc.response.(*GetPinnedPeersReply)
+		*p = *response
+	case *AllowedNodeIDs:
+		response := mc.response.(*AllowedNodeIDs)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -385,3 +405,152 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestExportDatabase(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedPath := "/exports/archive.gz"
+		mockClient := client{requester: NewMockClient(&ExportDatabaseReply{
+			DatabaseExport: DatabaseExport{Path: expectedPath},
+		}, nil)}
+
+		path, err := mockClient.ExportDatabase(context.Background(), "archive.gz")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPath, path)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ExportDatabaseReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ExportDatabase(context.Background(), "archive.gz")
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetDatabaseExport(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedExport := DatabaseExport{
+			Path: "/exports/archive.gz",
+			Done: true,
+			Manifest: &archive.Manifest{
+				NetworkID: 12345,
+				Databases: []string{"v1.4.5"},
+			},
+		}
+		mockClient := client{requester: NewMockClient(&expectedExport, nil)}
+
+		export, err := mockClient.GetDatabaseExport(context.Background(), "archive.gz")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedExport, *export)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&DatabaseExport{}, errors.New("some error"))}
+
+		_, err := mockClient.GetDatabaseExport(context.Background(), "archive.gz")
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetDatabaseStats(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedStats := []DatabasePrefixStats{
+			{
+				Name:   "P",
+				Prefix: "0x1234",
+				Keys:   5,
+				Size:   100,
+			},
+		}
+		mockClient := client{requester: NewMockClient(&GetDatabaseStatsReply{
+			Prefixes: expectedStats,
+		}, nil)}
+
+		stats, err := mockClient.GetDatabaseStats(context.Background(), true)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStats, stats)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetDatabaseStatsReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetDatabaseStats(context.Background(), true)
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestListBans(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedBans := []BanInfo{
+			{
+				IP:     "1.2.3.4",
+				Reason: "spamming",
+				Expiry: time.Unix(1000, 0).UTC(),
+			},
+		}
+		mockClient := client{requester: NewMockClient(&ListBansReply{
+			Bans: expectedBans,
+		}, nil)}
+
+		bans, err := mockClient.ListBans(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBans, bans)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ListBansReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ListBans(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetPinnedPeers(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedPinnedPeers := []PinnedPeer{
+			{
+				NodeID: ids.GenerateTestNodeID(),
+				IP:     "1.2.3.4:9651",
+			},
+		}
+		mockClient := client{requester: NewMockClient(&GetPinnedPeersReply{
+			PinnedPeers: expectedPinnedPeers,
+		}, nil)}
+
+		pinnedPeers, err := mockClient.GetPinnedPeers(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPinnedPeers, pinnedPeers)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetPinnedPeersReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetPinnedPeers(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetAllowedNodeIDs(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedNodeIDs := []ids.NodeID{ids.GenerateTestNodeID()}
+		mockClient := client{requester: NewMockClient(&AllowedNodeIDs{
+			NodeIDs: expectedNodeIDs,
+		}, nil)}
+
+		nodeIDs, err := mockClient.GetAllowedNodeIDs(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedNodeIDs, nodeIDs)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&AllowedNodeIDs{}, errors.New("some error"))}
+
+		_, err := mockClient.GetAllowedNodeIDs(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database/archive"
+)
+
+var (
+	errExportInProgress = errors.New("a database export is already in progress")
+	errUnknownExport    = errors.New("unknown database export")
+)
+
+// DatabaseExport describes the progress of a database export
+type DatabaseExport struct {
+	// Path is the file that the archive is written to.
+	Path string `json:"path"`
+	// Done is true once the export has finished, whether or not it succeeded.
+	Done bool `json:"done"`
+	// Error is the reason the export failed, if it did.
+	Error string `json:"error,omitempty"`
+	// Manifest describes the archive once it has been successfully written.
+	Manifest *archive.Manifest `json:"manifest,omitempty"`
+}
+
+// exportTracker keeps track of the database exports started on this node.
+// Only one export may run at a time, as an export holds snapshots of the
+// databases until it finishes.
+type exportTracker struct {
+	lock    sync.Mutex
+	running bool
+	// archive name -> export
+	exports map[string]DatabaseExport
+}
+
+// start records that an export to [path] has started under [name]. Returns an
+// error if an export is already running.
+func (e *exportTracker) start(name, path string) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.running {
+		return errExportInProgress
+	}
+	if e.exports == nil {
+		e.exports = make(map[string]DatabaseExport)
+	}
+	e.running = true
+	e.exports[name] = DatabaseExport{Path: path}
+	return nil
+}
+
+// finish records the result of the running export.
+func (e *exportTracker) finish(name string, manifest *archive.Manifest, err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	export := e.exports[name]
+	export.Done = true
+	if err != nil {
+		export.Error = err.Error()
+	} else {
+		export.Manifest = manifest
+	}
+	e.running = false
+	e.exports[name] = export
+}
+
+// get returns the progress of the export named [name].
+func (e *exportTracker) get(name string) (DatabaseExport, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	export, ok := e.exports[name]
+	if !ok {
+		return DatabaseExport{}, errUnknownExport
+	}
+	return export, nil
+}
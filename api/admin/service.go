@@ -5,21 +5,33 @@ package admin
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
 	"path"
-
-	"github.com/gorilla/rpc/v2"
+	"path/filepath"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/archive"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/meterdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
 )
@@ -32,8 +44,17 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong      = errors.New("alias length is too long")
+	errNoLogLevel        = errors.New("need to specify either displayLevel or logLevel")
+	errNoName            = errors.New("need to specify a name")
+	errInvalidExportName = errors.New("export name must be a file name")
+	errExportsDisabled   = errors.New("database exports are disabled")
+	errNoDBStats         = errors.New("database doesn't report prefix statistics")
+	errNoPeer            = errors.New("need to specify either nodeID or ip")
+	errBothPeers         = errors.New("can't specify both nodeID and ip")
+	errInvalidIP         = errors.New("invalid ip")
+	errNoNodeID          = errors.New("need to specify a nodeID")
+	errNotPinned         = errors.New("peer isn't pinned")
 )
 
 type Config struct {
@@ -41,31 +62,50 @@ type Config struct {
 	ProfileDir   string
 	LogFactory   logging.Factory
 	NodeConfig   interface{}
+	NetworkID    uint32
+	DBManager    manager.Manager
 	ChainManager chains.Manager
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+
+	ReputationManager reputation.Manager
+	Network           network.Network
+
+	// ExportDir is the directory that database archives are written to.
+	// Database exports are disabled if it is empty.
+	ExportDir string
+	// SecretDBPrefixes are the prefixes of the keys in the node's databases
+	// that must not be exported.
+	SecretDBPrefixes [][]byte
 }
 
 // Admin is the API service for node admin management
 type Admin struct {
 	Config
 	profiler profiler.Profiler
+	chains   chainTracker
+	exports  exportTracker
 }
 
 // NewService returns a new admin API service.
 // All of the fields in [config] must be set.
 func NewService(config Config) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
-	if err := newServer.RegisterService(&Admin{
+	admin := &Admin{
 		Config:   config,
 		profiler: profiler.New(config.ProfileDir),
-	}, "admin"); err != nil {
+	}
+	if err := newServer.RegisterService(admin, "admin"); err != nil {
 		return nil, err
 	}
+	config.ChainManager.AddRegistrant(&admin.chains)
 	return &common.HTTPHandler{Handler: newServer}, nil
 }
 
@@ -296,3 +336,367 @@ func (service *Admin) LoadVMs(_ *http.Request, _ *struct{}, reply *LoadVMsReply)
 	reply.NewVMs, err = ids.GetRelevantAliases(service.VMManager, loadedVMs)
 	return err
 }
+
+// ExportDatabaseArgs are the arguments for calling ExportDatabase
+type ExportDatabaseArgs struct {
+	// Name is the name of the archive file, which is written to the node's
+	// database export directory. The file must not already exist.
+	Name string `json:"name"`
+}
+
+// ExportDatabaseReply describes the export started by ExportDatabase
+type ExportDatabaseReply struct {
+	DatabaseExport
+}
+
+// ExportDatabase starts writing a compressed, checksummed archive of a
+// consistent view of the node's databases to the file [args.Name] in the
+// node's database export directory. The archive is written in the background
+// and its progress can be queried with GetDatabaseExport. The archive's
+// manifest lists the running chains along with their last accepted heights,
+// which the archived databases contain at least. Secrets, such as the
+// keystore, aren't exported.
+func (service *Admin) ExportDatabase(_ *http.Request, args *ExportDatabaseArgs, reply *ExportDatabaseReply) error {
+	service.Log.Debug("Admin: ExportDatabase called with Name: %s", args.Name)
+
+	switch {
+	case service.ExportDir == "":
+		return errExportsDisabled
+	case args.Name == "":
+		return errNoName
+	case args.Name != filepath.Base(args.Name) || args.Name == "." || args.Name == "..":
+		return errInvalidExportName
+	}
+
+	// The chains' state is fetched before the databases are snapshotted so
+	// that the archive contains at least the reported state.
+	chainInfos, err := service.chains.chainInfos()
+	if err != nil {
+		return fmt.Errorf("couldn't get chain state: %w", err)
+	}
+
+	if err := os.MkdirAll(service.ExportDir, perms.ReadWriteExecute); err != nil {
+		return fmt.Errorf("couldn't create export directory: %w", err)
+	}
+	path := filepath.Join(service.ExportDir, args.Name)
+	if err := service.exports.start(args.Name, path); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perms.ReadWrite)
+	if err != nil {
+		service.exports.finish(args.Name, nil, err)
+		return err
+	}
+
+	manifest := &archive.Manifest{
+		NodeVersion: version.Current.String(),
+		NetworkID:   json.Uint32(service.NetworkID),
+		Timestamp:   time.Now().UTC(),
+		Chains:      chainInfos,
+	}
+	go func() {
+		err := archive.Export(f, manifest, service.DBManager.GetDatabases(), service.SecretDBPrefixes)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+			service.Log.Warn("couldn't export database to %s: %s", path, err)
+		} else {
+			service.Log.Info("exported database to %s", path)
+		}
+		service.exports.finish(args.Name, manifest, err)
+	}()
+
+	reply.Path = path
+	return nil
+}
+
+// GetDatabaseExportArgs are the arguments for calling GetDatabaseExport
+type GetDatabaseExportArgs struct {
+	// Name is the name of the archive file passed to ExportDatabase.
+	Name string `json:"name"`
+}
+
+// GetDatabaseExport returns the progress of the database export that writes
+// the archive [args.Name].
+func (service *Admin) GetDatabaseExport(_ *http.Request, args *GetDatabaseExportArgs, reply *DatabaseExport) error {
+	service.Log.Debug("Admin: GetDatabaseExport called with Name: %s", args.Name)
+
+	export, err := service.exports.get(args.Name)
+	if err != nil {
+		return err
+	}
+	*reply = export
+	return nil
+}
+
+// DatabasePrefixStats describes the storage used by a registered database
+// prefix
+type DatabasePrefixStats struct {
+	Name string `json:"name"`
+	// Prefix is the hex encoding of the prefix. The empty prefix, "0x", is
+	// the whole database.
+	Prefix string `json:"prefix"`
+
+	// CountKeys is true if [Keys] and [Size] are calculated for the prefix.
+	// The keys of a whole chain are never counted.
+	CountKeys bool `json:"countKeys"`
+	// UpdatedAt is when [Keys] and [Size] were last calculated.
+	UpdatedAt time.Time   `json:"updatedAt"`
+	Keys      json.Uint64 `json:"keys"`
+	Size      json.Uint64 `json:"size"`
+	// DiskSize is the database's last estimate of the disk space used by the
+	// prefix.
+	DiskSize json.Uint64 `json:"diskSize"`
+
+	BytesWritten json.Uint64 `json:"bytesWritten"`
+	Puts         json.Uint64 `json:"puts"`
+	Deletes      json.Uint64 `json:"deletes"`
+}
+
+// GetDatabaseStatsArgs are the arguments for calling GetDatabaseStats
+type GetDatabaseStatsArgs struct {
+	// CountKeys requests that the keys of the prefixes are counted before the
+	// stats are returned. This iterates over every counted key, so it can
+	// take a long time.
+	CountKeys bool `json:"countKeys"`
+}
+
+// GetDatabaseStatsReply are the results from calling GetDatabaseStats
+type GetDatabaseStatsReply struct {
+	Prefixes []DatabasePrefixStats `json:"prefixes"`
+}
+
+// GetDatabaseStats returns the storage used by each of the registered
+// prefixes of the node's database. The disk sizes are estimated periodically
+// in the background, so they may be slightly out of date. The keys are only
+// counted if [args.CountKeys] is set.
+func (service *Admin) GetDatabaseStats(_ *http.Request, args *GetDatabaseStatsArgs, reply *GetDatabaseStatsReply) error {
+	service.Log.Debug("Admin: GetDatabaseStats called with CountKeys: %t", args.CountKeys)
+
+	db, ok := service.DBManager.Current().Database.(interface {
+		PrefixStats() []meterdb.PrefixStats
+		UpdatePrefixStats() error
+	})
+	if !ok {
+		return errNoDBStats
+	}
+	if args.CountKeys {
+		if err := db.UpdatePrefixStats(); err != nil {
+			return err
+		}
+	}
+	stats := db.PrefixStats()
+
+	reply.Prefixes = make([]DatabasePrefixStats, len(stats))
+	for i, stat := range stats {
+		prefix, err := formatting.Encode(formatting.HexNC, stat.Prefix)
+		if err != nil {
+			return err
+		}
+		reply.Prefixes[i] = DatabasePrefixStats{
+			Name:         stat.Name,
+			Prefix:       prefix,
+			CountKeys:    stat.CountKeys,
+			UpdatedAt:    stat.UpdatedAt,
+			Keys:         json.Uint64(stat.Keys),
+			Size:         json.Uint64(stat.Size),
+			DiskSize:     json.Uint64(stat.DiskSize),
+			BytesWritten: json.Uint64(stat.BytesWritten),
+			Puts:         json.Uint64(stat.Puts),
+			Deletes:      json.Uint64(stat.Deletes),
+		}
+	}
+	return nil
+}
+
+// PeerArgs identify a peer by either its node ID or its IP
+type PeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// parse returns the IP of the peer, or nil if the peer is identified by its
+// node ID
+func (args *PeerArgs) parse() (net.IP, error) {
+	switch {
+	case args.NodeID == ids.EmptyNodeID && args.IP == "":
+		return nil, errNoPeer
+	case args.NodeID != ids.EmptyNodeID && args.IP != "":
+		return nil, errBothPeers
+	case args.IP == "":
+		return nil, nil
+	}
+	ip := net.ParseIP(args.IP)
+	if ip == nil {
+		return nil, fmt.Errorf("%w: %q", errInvalidIP, args.IP)
+	}
+	return ip, nil
+}
+
+// BanPeerArgs are the arguments for calling BanPeer
+type BanPeerArgs struct {
+	PeerArgs
+	// Duration is the number of seconds the peer is banned for. If 0, the
+	// node's default ban duration is used.
+	Duration json.Uint64 `json:"duration"`
+	Reason   string      `json:"reason"`
+}
+
+// BanPeer bans the peer with the given node ID or IP. Connections to banned
+// node IDs are closed and no new connections to banned node IDs or IPs are
+// made. Bans are persisted across restarts.
+func (service *Admin) BanPeer(_ *http.Request, args *BanPeerArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: BanPeer called with NodeID: %s, IP: %q, Duration: %d, Reason: %q", args.NodeID, args.IP, args.Duration, args.Reason)
+
+	ip, err := args.parse()
+	if err != nil {
+		return err
+	}
+	duration := time.Duration(args.Duration) * time.Second
+	if ip != nil {
+		return service.ReputationManager.BanIP(ip, duration, args.Reason)
+	}
+	return service.ReputationManager.BanNode(args.NodeID, duration, args.Reason)
+}
+
+// UnbanPeer lifts the ban of the peer with the given node ID or IP
+func (service *Admin) UnbanPeer(_ *http.Request, args *PeerArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: UnbanPeer called with NodeID: %s, IP: %q", args.NodeID, args.IP)
+
+	ip, err := args.parse()
+	if err != nil {
+		return err
+	}
+	if ip != nil {
+		return service.ReputationManager.UnbanIP(ip)
+	}
+	return service.ReputationManager.UnbanNode(args.NodeID)
+}
+
+// BanInfo describes the ban of a node ID or an IP
+type BanInfo struct {
+	NodeID string    `json:"nodeID,omitempty"`
+	IP     string    `json:"ip,omitempty"`
+	Reason string    `json:"reason"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// ListBansReply are the results from calling ListBans
+type ListBansReply struct {
+	Bans []BanInfo `json:"bans"`
+}
+
+// ListBans returns the current bans, ordered by when they expire
+func (service *Admin) ListBans(_ *http.Request, _ *struct{}, reply *ListBansReply) error {
+	service.Log.Debug("Admin: ListBans called")
+
+	bans := service.ReputationManager.Bans()
+	reply.Bans = make([]BanInfo, len(bans))
+	for i, ban := range bans {
+		info := BanInfo{
+			Reason: ban.Reason,
+			Expiry: ban.Expiry.UTC(),
+		}
+		if ban.IP != nil {
+			info.IP = ban.IP.String()
+		} else {
+			info.NodeID = ban.NodeID.String()
+		}
+		reply.Bans[i] = info
+	}
+	return nil
+}
+
+// PinPeerArgs are the arguments for calling PinPeer
+type PinPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// IP is the IP:port the peer is connected to at
+	IP string `json:"ip"`
+}
+
+// PinPeer makes the node always attempt to stay connected to the given peer.
+// Pinned peers are allowed even if they aren't in the allowed node IDs. Peers
+// pinned through the API aren't pinned after a restart.
+func (service *Admin) PinPeer(_ *http.Request, args *PinPeerArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: PinPeer called with NodeID: %s, IP: %q", args.NodeID, args.IP)
+
+	if args.NodeID == ids.EmptyNodeID {
+		return errNoNodeID
+	}
+	ip, err := ips.ToIPPort(args.IP)
+	if err != nil {
+		return fmt.Errorf("%w: %q", errInvalidIP, args.IP)
+	}
+	service.Network.PinPeer(args.NodeID, ip)
+	return nil
+}
+
+// UnpinPeerArgs are the arguments for calling UnpinPeer
+type UnpinPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// UnpinPeer stops the node from always attempting to stay connected to the
+// given peer
+func (service *Admin) UnpinPeer(_ *http.Request, args *UnpinPeerArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: UnpinPeer called with NodeID: %s", args.NodeID)
+
+	if !service.Network.UnpinPeer(args.NodeID) {
+		return errNotPinned
+	}
+	return nil
+}
+
+// PinnedPeer describes a peer that the node always attempts to stay connected
+// to
+type PinnedPeer struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// GetPinnedPeersReply are the results from calling GetPinnedPeers
+type GetPinnedPeersReply struct {
+	PinnedPeers []PinnedPeer `json:"pinnedPeers"`
+}
+
+// GetPinnedPeers returns the pinned peers, sorted by node ID
+func (service *Admin) GetPinnedPeers(_ *http.Request, _ *struct{}, reply *GetPinnedPeersReply) error {
+	service.Log.Debug("Admin: GetPinnedPeers called")
+
+	pinnedPeers := service.Network.PinnedPeers()
+	reply.PinnedPeers = make([]PinnedPeer, len(pinnedPeers))
+	for i, pinnedPeer := range pinnedPeers {
+		reply.PinnedPeers[i] = PinnedPeer{
+			NodeID: pinnedPeer.NodeID,
+			IP:     pinnedPeer.IP.String(),
+		}
+	}
+	return nil
+}
+
+// AllowedNodeIDs are the node IDs that the node is allowed to connect to
+type AllowedNodeIDs struct {
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// SetAllowedNodeIDs replaces the node IDs that the node is allowed to connect
+// to. If no node IDs are given, all nodes are allowed. Connections to nodes
+// that are no longer allowed are closed shortly after. The allowed node IDs
+// are reset to the node's configuration after a restart.
+func (service *Admin) SetAllowedNodeIDs(_ *http.Request, args *AllowedNodeIDs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: SetAllowedNodeIDs called with %d NodeIDs", len(args.NodeIDs))
+
+	service.Network.SetAllowedNodeIDs(args.NodeIDs)
+	return nil
+}
+
+// GetAllowedNodeIDs returns the sorted node IDs that the node is allowed to
+// connect to. If empty, all nodes are allowed.
+func (service *Admin) GetAllowedNodeIDs(_ *http.Request, _ *struct{}, reply *AllowedNodeIDs) error {
+	service.Log.Debug("Admin: GetAllowedNodeIDs called")
+
+	reply.NodeIDs = service.Network.AllowedNodeIDs()
+	return nil
+}
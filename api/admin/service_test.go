@@ -5,14 +5,29 @@ package admin
 
 import (
 	"errors"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/archive"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/reputation"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
 )
@@ -120,3 +135,240 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	assert.Equal(t, err, errOops)
 }
+
+func TestServiceExportDatabase(t *testing.T) {
+	assert := assert.New(t)
+
+	dbManager := manager.NewMemDB(version.CurrentDatabase)
+	db := dbManager.Current().Database
+	assert.NoError(db.Put([]byte("key"), []byte("value")))
+	assert.NoError(db.Put([]byte("secret"), []byte("value")))
+
+	exportDir := t.TempDir()
+	admin := &Admin{Config: Config{
+		Log:              logging.NoLog{},
+		NetworkID:        constants.LocalID,
+		DBManager:        dbManager,
+		ExportDir:        exportDir,
+		SecretDBPrefixes: [][]byte{[]byte("secret")},
+	}}
+
+	// Archives can only be written to the export directory.
+	err := admin.ExportDatabase(nil, &ExportDatabaseArgs{Name: "../archive.gz"}, &ExportDatabaseReply{})
+	assert.ErrorIs(err, errInvalidExportName)
+
+	reply := ExportDatabaseReply{}
+	assert.NoError(admin.ExportDatabase(nil, &ExportDatabaseArgs{Name: "archive.gz"}, &reply))
+	path := filepath.Join(exportDir, "archive.gz")
+	assert.Equal(path, reply.Path)
+
+	var export DatabaseExport
+	for !export.Done {
+		assert.NoError(admin.GetDatabaseExport(nil, &GetDatabaseExportArgs{Name: "archive.gz"}, &export))
+		time.Sleep(time.Millisecond)
+	}
+	assert.Empty(export.Error)
+	assert.EqualValues(constants.LocalID, export.Manifest.NetworkID)
+	assert.Equal([]string{version.CurrentDatabase.String()}, export.Manifest.Databases)
+
+	f, err := os.Open(path)
+	assert.NoError(err)
+	defer f.Close()
+
+	r, err := archive.NewReader(f)
+	assert.NoError(err)
+	assert.Equal(*export.Manifest, *r.Manifest())
+
+	restored := memdb.New()
+	assert.NoError(r.Restore(func(*version.Semantic) (database.Database, error) {
+		return &unclosableDB{Database: restored}, nil
+	}))
+	value, err := restored.Get([]byte("key"))
+	assert.NoError(err)
+	assert.Equal([]byte("value"), value)
+	_, err = restored.Get([]byte("secret"))
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	// Exporting to an existing file should fail rather than overwrite it.
+	err = admin.ExportDatabase(nil, &ExportDatabaseArgs{Name: "archive.gz"}, &ExportDatabaseReply{})
+	assert.Error(err)
+}
+
+type unclosableDB struct {
+	*memdb.Database
+}
+
+func (*unclosableDB) Close() error { return nil }
+
+func TestServiceGetDatabaseStats(t *testing.T) {
+	assert := assert.New(t)
+
+	dbManager := manager.NewMemDB(version.CurrentDatabase)
+	admin := &Admin{Config: Config{
+		Log:       logging.NoLog{},
+		DBManager: dbManager,
+	}}
+
+	// The node's database must be metered to report its stats.
+	err := admin.GetDatabaseStats(nil, &GetDatabaseStatsArgs{}, &GetDatabaseStatsReply{})
+	assert.ErrorIs(err, errNoDBStats)
+
+	meterDBManager, err := dbManager.NewMeterDBManager("db", prometheus.NewRegistry())
+	assert.NoError(err)
+	admin.DBManager = meterDBManager
+
+	db := meterDBManager.Current().Database
+	assert.NoError(database.RegisterPrefix(db, "chain", []byte{0x12}, true))
+	assert.NoError(db.Put([]byte{0x12, 0x34}, []byte{0x56}))
+
+	// The keys are only counted when requested.
+	reply := GetDatabaseStatsReply{}
+	assert.NoError(admin.GetDatabaseStats(nil, &GetDatabaseStatsArgs{}, &reply))
+	assert.Len(reply.Prefixes, 1)
+	assert.True(reply.Prefixes[0].UpdatedAt.IsZero())
+	assert.Zero(reply.Prefixes[0].Keys)
+
+	reply = GetDatabaseStatsReply{}
+	assert.NoError(admin.GetDatabaseStats(nil, &GetDatabaseStatsArgs{CountKeys: true}, &reply))
+	assert.Len(reply.Prefixes, 1)
+	assert.False(reply.Prefixes[0].UpdatedAt.IsZero())
+	reply.Prefixes[0].UpdatedAt = time.Time{}
+	assert.Equal([]DatabasePrefixStats{
+		{
+			Name:         "chain",
+			Prefix:       "0x12",
+			CountKeys:    true,
+			Keys:         1,
+			Size:         3,
+			BytesWritten: 3,
+			Puts:         1,
+		},
+	}, reply.Prefixes)
+}
+
+func TestServiceBanPeer(t *testing.T) {
+	assert := assert.New(t)
+
+	reputationManager, err := reputation.NewManager(
+		reputation.Config{
+			BanThreshold:              100,
+			BanDuration:               time.Hour,
+			ScoreHalflife:             time.Hour,
+			MaxScores:                 100,
+			SyncFrequency:             time.Minute,
+			ToleratedViolations:       10,
+			ToleratedViolationsWindow: time.Minute,
+		},
+		memdb.New(),
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+	)
+	assert.NoError(err)
+	admin := &Admin{Config: Config{
+		Log:               logging.NoLog{},
+		ReputationManager: reputationManager,
+	}}
+
+	nodeID := ids.GenerateTestNodeID()
+	ip := net.IPv4(1, 2, 3, 4)
+
+	err = admin.BanPeer(nil, &BanPeerArgs{}, nil)
+	assert.ErrorIs(err, errNoPeer)
+	err = admin.BanPeer(nil, &BanPeerArgs{PeerArgs: PeerArgs{NodeID: nodeID, IP: ip.String()}}, nil)
+	assert.ErrorIs(err, errBothPeers)
+	err = admin.BanPeer(nil, &BanPeerArgs{PeerArgs: PeerArgs{IP: "not an ip"}}, nil)
+	assert.ErrorIs(err, errInvalidIP)
+
+	err = admin.BanPeer(nil, &BanPeerArgs{PeerArgs: PeerArgs{NodeID: nodeID}, Reason: "misbehaving"}, nil)
+	assert.NoError(err)
+	err = admin.BanPeer(nil, &BanPeerArgs{PeerArgs: PeerArgs{IP: ip.String()}, Duration: 7200, Reason: "spamming"}, nil)
+	assert.NoError(err)
+	assert.True(reputationManager.IsBanned(nodeID))
+	assert.True(reputationManager.IsIPBanned(ip))
+
+	reply := ListBansReply{}
+	assert.NoError(admin.ListBans(nil, nil, &reply))
+	assert.Len(reply.Bans, 2)
+	assert.Equal(nodeID.String(), reply.Bans[0].NodeID)
+	assert.Empty(reply.Bans[0].IP)
+	assert.Equal("misbehaving", reply.Bans[0].Reason)
+	assert.Equal(ip.String(), reply.Bans[1].IP)
+	assert.Empty(reply.Bans[1].NodeID)
+	assert.Equal("spamming", reply.Bans[1].Reason)
+	assert.Equal(time.Hour, reply.Bans[1].Expiry.Sub(reply.Bans[0].Expiry).Round(time.Minute))
+
+	assert.NoError(admin.UnbanPeer(nil, &PeerArgs{NodeID: nodeID}, nil))
+	assert.NoError(admin.UnbanPeer(nil, &PeerArgs{IP: ip.String()}, nil))
+	assert.False(reputationManager.IsBanned(nodeID))
+	assert.False(reputationManager.IsIPBanned(ip))
+
+	reply = ListBansReply{}
+	assert.NoError(admin.ListBans(nil, nil, &reply))
+	assert.Empty(reply.Bans)
+}
+
+// testNetwork records the static peers set through the admin API
+type testNetwork struct {
+	network.Network
+
+	pinnedPeers    []network.PinnedPeer
+	allowedNodeIDs []ids.NodeID
+}
+
+func (n *testNetwork) PinPeer(nodeID ids.NodeID, ip ips.IPPort) {
+	n.pinnedPeers = append(n.pinnedPeers, network.PinnedPeer{
+		NodeID: nodeID,
+		IP:     ip,
+	})
+}
+
+func (n *testNetwork) UnpinPeer(nodeID ids.NodeID) bool {
+	for i, pinnedPeer := range n.pinnedPeers {
+		if pinnedPeer.NodeID == nodeID {
+			n.pinnedPeers = append(n.pinnedPeers[:i], n.pinnedPeers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (n *testNetwork) PinnedPeers() []network.PinnedPeer { return n.pinnedPeers }
+
+func (n *testNetwork) SetAllowedNodeIDs(nodeIDs []ids.NodeID) { n.allowedNodeIDs = nodeIDs }
+
+func (n *testNetwork) AllowedNodeIDs() []ids.NodeID { return n.allowedNodeIDs }
+
+func TestServiceStaticPeers(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: &testNetwork{},
+	}}
+	nodeID := ids.GenerateTestNodeID()
+
+	err := admin.PinPeer(nil, &PinPeerArgs{IP: "1.2.3.4:9651"}, nil)
+	assert.ErrorIs(err, errNoNodeID)
+	err = admin.PinPeer(nil, &PinPeerArgs{NodeID: nodeID, IP: "1.2.3.4"}, nil)
+	assert.ErrorIs(err, errInvalidIP)
+
+	err = admin.PinPeer(nil, &PinPeerArgs{NodeID: nodeID, IP: "1.2.3.4:9651"}, nil)
+	assert.NoError(err)
+	pinnedReply := GetPinnedPeersReply{}
+	assert.NoError(admin.GetPinnedPeers(nil, nil, &pinnedReply))
+	assert.Equal([]PinnedPeer{
+		{
+			NodeID: nodeID,
+			IP:     "1.2.3.4:9651",
+		},
+	}, pinnedReply.PinnedPeers)
+
+	assert.NoError(admin.UnpinPeer(nil, &UnpinPeerArgs{NodeID: nodeID}, nil))
+	assert.ErrorIs(admin.UnpinPeer(nil, &UnpinPeerArgs{NodeID: nodeID}, nil), errNotPinned)
+
+	assert.NoError(admin.SetAllowedNodeIDs(nil, &AllowedNodeIDs{NodeIDs: []ids.NodeID{nodeID}}, nil))
+	allowedReply := AllowedNodeIDs{}
+	assert.NoError(admin.GetAllowedNodeIDs(nil, nil, &allowedReply))
+	assert.Equal([]ids.NodeID{nodeID}, allowedReply.NodeIDs)
+}
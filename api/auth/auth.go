@@ -10,14 +10,18 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 
-	"github.com/gorilla/rpc/v2"
+	"golang.org/x/crypto/argon2"
 
+	"github.com/ava-labs/avalanchego/api/openrpc"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
@@ -35,6 +39,15 @@ const (
 	defaultTokenLifespan = time.Hour * 12
 
 	maxEndpoints = 128
+
+	// Method that a token must allow calling to issue other tokens
+	newTokenMethod = "auth.newToken"
+
+	// signingKeyLen is the number of bytes of the key that signs tokens
+	signingKeyLen = 32
+	// signingKeyDomain separates the derivation of the key that signs tokens
+	// from the derivation of the password hash
+	signingKeyDomain = "avalanchego auth token signing key"
 )
 
 var (
@@ -52,6 +65,13 @@ var (
 	errNoPassword                  = errors.New("no password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errUnknownRole                 = errors.New("unknown role")
+	errTooManyScopes               = fmt.Errorf("can only name at most %d scopes", maxScopes)
+	errInvalidScope                = errors.New("invalid scope")
+	errLifespanTooLong             = errors.New("token lifespan exceeds the maximum lifespan of its role")
+	errRoleNotDelegable            = errors.New("a token can only issue tokens for roles lower than its own")
+	errOutlivesToken               = errors.New("a token can't issue tokens that expire after it")
+	errExceedsToken                = errors.New("a token can't issue tokens with permissions it doesn't have")
 
 	_ Auth = &auth{}
 )
@@ -60,8 +80,24 @@ type Auth interface {
 	// Create and return a new token that allows access to each API endpoint for
 	// [duration] such that the API's path ends with an element of [endpoints].
 	// If one of the elements of [endpoints] is "*", all APIs are accessible.
+	// The token is issued for the admin role.
 	NewToken(pw string, duration time.Duration, endpoints []string) (string, error)
 
+	// Create and return a new token for [options.Principal] that allows
+	// calling the methods allowed by both [options.Role] and
+	// [options.Scopes].
+	NewScopedToken(pw string, options TokenOptions) (string, error)
+
+	// Create and return a new token as NewScopedToken does, but authorized by
+	// [token] rather than by the password. [token] must allow calling
+	// auth.newToken and the new token must be for a lower role than [token]'s
+	// and must not outlive [token]. This allows handing out tokens without
+	// handing out the password.
+	NewDelegatedToken(token string, options TokenOptions) (string, error)
+
+	// Returns the unexpired tokens issued under the current password.
+	ListTokens(pw string) ([]TokenInfo, error)
+
 	// Revokes [token]; it will not be accepted as authorization for future API
 	// calls. If the token is invalid, this is a no-op.  If a token is revoked
 	// and then the password is changed, and then changed back to the current
@@ -69,15 +105,17 @@ type Auth interface {
 	// re-used before previously revoked tokens have expired.
 	RevokeToken(pw, token string) error
 
-	// Authenticates [token] for access to [url].
-	AuthenticateToken(token, url string) error
+	// Authenticates [token] for access to [url] and for calling each of
+	// [methods].
+	AuthenticateToken(token, url string, methods []string) error
 
 	// Change the password required to create and revoke tokens.
 	// [oldPW] is the current password.
 	// [newPW] is the new password. It can't be the empty string and it can't be
 	//         unreasonably long.
 	// Changing the password makes tokens issued under a previous password
-	// invalid.
+	// invalid. The password configured when the node starts replaces the
+	// changed password.
 	ChangePassword(oldPW, newPW string) error
 
 	// Create the API endpoint for this auth handler.
@@ -86,6 +124,25 @@ type Auth interface {
 	// WrapHandler wraps an http.Handler. Before passing a request to the
 	// provided handler, the auth token is authenticated.
 	WrapHandler(h http.Handler) http.Handler
+
+	// Authenticates the auth tokens of gRPC calls.
+	GRPCAuthorizer
+}
+
+// TokenOptions describes a token to issue
+type TokenOptions struct {
+	// Name of the principal the token is issued to, such as the name of a
+	// person or of a service. Only used to describe the token.
+	Principal string
+	Role      Role
+	// Scopes of the methods the token allows calling. If empty, the token
+	// allows calling every method that [Role] allows.
+	Scopes []string
+	// Endpoints the token allows access to. If empty, the token allows access
+	// to every endpoint.
+	Endpoints []string
+	// Lifespan of the token. If zero, the default lifespan of [Role] is used.
+	Lifespan time.Duration
 }
 
 type auth struct {
@@ -98,47 +155,171 @@ type auth struct {
 	lock sync.RWMutex
 	// Can be changed via API call.
 	password password.Hash
+	// Key that signs tokens. It's derived from the password rather than
+	// persisted, so that it can't be read from the database.
+	signingKey []byte
 	// Set of token IDs that have been revoked
 	revoked map[string]struct{}
+	// Persists the password and the issued tokens
+	tokens *tokenStore
 }
 
-func New(log logging.Logger, endpoint, pw string) (Auth, error) {
-	a := &auth{
-		log:      log,
-		endpoint: endpoint,
-		revoked:  make(map[string]struct{}),
+// New returns an Auth that persists its tokens in [db]. If [pw] is the
+// password that the tokens in [db] were issued under, the unexpired tokens
+// remain valid and the expired tokens are deleted from [db]. Otherwise, every
+// token is deleted from [db].
+func New(log logging.Logger, db database.Database, endpoint, pw string) (Auth, error) {
+	a := newAuth(log, db, endpoint)
+	hash, err := a.tokens.getPassword()
+	switch {
+	case err == nil && hash.Check(pw):
+		a.password = hash
+		a.signingKey = deriveSigningKey(pw, hash)
+		return a, a.loadTokens()
+	case err != nil && err != database.ErrNotFound:
+		return nil, err
+	}
+
+	if err := a.setPassword(pw); err != nil {
+		return nil, err
+	}
+	return a, a.tokens.putPassword(a.password)
+}
+
+// NewFromHash returns an Auth that keeps its tokens in memory. As the password
+// isn't known, tokens are signed with a random key.
+func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) (Auth, error) {
+	a := newAuth(log, memdb.New(), endpoint)
+	a.password = pw
+	a.signingKey = make([]byte, signingKeyLen)
+	if _, err := rand.Read(a.signingKey); err != nil {
+		return nil, fmt.Errorf("failed to generate the token signing key due to %w", err)
 	}
-	return a, a.password.Set(pw)
+	return a, nil
 }
 
-func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
+func newAuth(log logging.Logger, db database.Database, endpoint string) *auth {
 	return &auth{
 		log:      log,
 		endpoint: endpoint,
-		password: pw,
 		revoked:  make(map[string]struct{}),
+		tokens:   newTokenStore(db),
 	}
 }
 
+// loadTokens removes the expired tokens from the database and marks the
+// revoked tokens as revoked
+func (a *auth) loadTokens() error {
+	if err := a.deleteExpiredTokens(); err != nil {
+		return err
+	}
+	return a.tokens.iterate(func(id string, record *tokenRecord) error {
+		if record.Revoked {
+			a.revoked[id] = struct{}{}
+		}
+		return nil
+	})
+}
+
+// deleteExpiredTokens removes the expired tokens from the database. Assumes
+// [a.lock] is held, unless called during initialization.
+func (a *auth) deleteExpiredTokens() error {
+	now := a.clock.Time().Unix()
+	return a.tokens.deleteTokens(func(id string, record *tokenRecord) bool {
+		if record.ExpiresAt >= now {
+			return false
+		}
+		delete(a.revoked, id)
+		return true
+	})
+}
+
 func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (string, error) {
 	if pw == "" {
 		return "", errNoPassword
 	}
-	if l := len(endpoints); l == 0 {
+	if len(endpoints) == 0 {
 		return "", errNoEndpoints
-	} else if l > maxEndpoints {
-		return "", errTooManyEndpoints
 	}
+	return a.NewScopedToken(pw, TokenOptions{
+		Role:      RoleAdmin,
+		Endpoints: endpoints,
+		Lifespan:  duration,
+	})
+}
 
-	a.lock.RLock()
-	defer a.lock.RUnlock()
+func (a *auth) NewScopedToken(pw string, options TokenOptions) (string, error) {
+	if pw == "" {
+		return "", errNoPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
 
 	if !a.password.Check(pw) {
 		return "", errWrongPassword
 	}
+	return a.newToken(options)
+}
+
+func (a *auth) NewDelegatedToken(tokenStr string, options TokenOptions) (string, error) {
+	if tokenStr == "" {
+		return "", errNoToken
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	claims, err := a.parseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	if !claims.allows(newTokenMethod) {
+		return "", errTokenInsufficientPermission
+	}
+
+	role, ok := roles[options.Role]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", errUnknownRole, options.Role)
+	}
+	if role.rank >= roles[claims.Role].rank {
+		return "", errRoleNotDelegable
+	}
+	lifespan, err := tokenLifespan(options)
+	if err != nil {
+		return "", err
+	}
+	if a.clock.Time().Add(lifespan).Unix() > claims.ExpiresAt {
+		return "", errOutlivesToken
+	}
+	options.Lifespan = lifespan
+	// The new token can only allow what [token] allows.
+	if options.Endpoints, err = delegatedEndpoints(claims.Endpoints, options.Endpoints); err != nil {
+		return "", err
+	}
+	if options.Scopes, err = delegatedScopes(claims.Scopes, options.Scopes); err != nil {
+		return "", err
+	}
+	return a.newToken(options)
+}
+
+// newToken issues a token described by [options]. Assumes [a.lock] is held.
+func (a *auth) newToken(options TokenOptions) (string, error) {
+	permissions := Permissions{
+		Role:      options.Role,
+		Scopes:    options.Scopes,
+		Endpoints: options.Endpoints,
+	}
+	if err := permissions.Verify(); err != nil {
+		return "", err
+	}
+	lifespan, err := tokenLifespan(options)
+	if err != nil {
+		return "", err
+	}
 
 	canAccessAll := false
-	for _, endpoint := range endpoints {
+	for _, endpoint := range options.Endpoints {
 		if endpoint == "*" {
 			canAccessAll = true
 			break
@@ -151,19 +332,69 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 	}
 	id := base64.URLEncoding.EncodeToString(idBytes[:])
 
+	now := a.clock.Time()
 	claims := endpointClaims{
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: a.clock.Time().Add(duration).Unix(),
+			ExpiresAt: now.Add(lifespan).Unix(),
 			Id:        id,
+			IssuedAt:  now.Unix(),
+			Subject:   options.Principal,
 		},
+		Role:   options.Role,
+		Scopes: options.Scopes,
 	}
 	if canAccessAll {
 		claims.Endpoints = []string{"*"}
 	} else {
-		claims.Endpoints = endpoints
+		claims.Endpoints = options.Endpoints
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	return token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	tokenStr, err := token.SignedString(a.signingKey) // Sign the token and get its string repr.
+	if err != nil {
+		return "", err
+	}
+
+	err = a.tokens.putToken(id, &tokenRecord{
+		Principal: options.Principal,
+		Role:      string(options.Role),
+		Scopes:    options.Scopes,
+		Endpoints: claims.Endpoints,
+		IssuedAt:  claims.IssuedAt,
+		ExpiresAt: claims.ExpiresAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("couldn't persist token: %w", err)
+	}
+	return tokenStr, nil
+}
+
+func (a *auth) ListTokens(pw string) ([]TokenInfo, error) {
+	if pw == "" {
+		return nil, errNoPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return nil, errWrongPassword
+	}
+	if err := a.deleteExpiredTokens(); err != nil {
+		return nil, err
+	}
+
+	tokens := []TokenInfo{}
+	err := a.tokens.iterate(func(id string, record *tokenRecord) error {
+		tokens = append(tokens, record.info(id))
+		return nil
+	})
+	sort.Slice(tokens, func(i, j int) bool {
+		if !tokens[i].IssuedAt.Equal(tokens[j].IssuedAt) {
+			return tokens[i].IssuedAt.Before(tokens[j].IssuedAt)
+		}
+		return tokens[i].ID < tokens[j].ID
+	})
+	return tokens, err
 }
 
 func (a *auth) RevokeToken(tokenStr, pw string) error {
@@ -197,37 +428,52 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
 	a.revoked[claims.Id] = struct{}{}
-	return nil
+
+	record, err := a.tokens.getToken(claims.Id)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	record.Revoked = true
+	return a.tokens.putToken(claims.Id, record)
 }
 
-func (a *auth) AuthenticateToken(tokenStr, url string) error {
+func (a *auth) AuthenticateToken(tokenStr, url string, methods []string) error {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
+	claims, err := a.parseToken(tokenStr)
+	if err != nil {
+		return err
+	}
+
+	// Make sure this token gives access to the requested endpoint and methods
+	permissions := claims.permissions()
+	return permissions.authorize(url, methods)
+}
+
+// parseToken returns the claims of [tokenStr] if it's valid and not revoked.
+// Assumes [a.lock] is held.
+func (a *auth) parseToken(tokenStr string) (*endpointClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
 	if err != nil { // Probably because signature wrong
-		return err
+		return nil, err
 	}
 
-	// Make sure this token gives access to the requested endpoint
 	claims, ok := token.Claims.(*endpointClaims)
 	if !ok {
 		// Error is intentionally dropped here as there is nothing left to do
 		// with it.
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
 
 	_, revoked := a.revoked[claims.Id]
 	if revoked {
-		return errTokenRevoked
+		return nil, errTokenRevoked
 	}
-
-	for _, endpoint := range claims.Endpoints {
-		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
-			return nil
-		}
-	}
-	return errTokenInsufficientPermission
+	return claims, nil
 }
 
 func (a *auth) ChangePassword(oldPW, newPW string) error {
@@ -244,18 +490,21 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 	if err := password.IsValid(newPW, password.OK); err != nil {
 		return err
 	}
-	if err := a.password.Set(newPW); err != nil {
+	if err := a.setPassword(newPW); err != nil {
 		return err
 	}
 
 	// All the revoked tokens are now invalid; no need to mark specifically as
 	// revoked.
 	a.revoked = make(map[string]struct{})
-	return nil
+	return a.tokens.putPassword(a.password)
 }
 
 func (a *auth) CreateHandler() (http.Handler, error) {
-	server := rpc.NewServer()
+	server, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -273,22 +522,13 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 			return
 		}
 
-		// Should be "Bearer AUTH.TOKEN.HERE"
-		rawHeader := r.Header.Get(headerKey)
-		if rawHeader == "" {
-			writeUnauthorizedResponse(w, errNoToken)
-			return
-		}
-		if !strings.HasPrefix(rawHeader, headerValStart) {
-			// Error is intentionally dropped here as there is nothing left to
-			// do with it.
-			writeUnauthorizedResponse(w, errAuthHeaderNotParsable)
+		tokenStr, err := getToken(r)
+		if err != nil {
+			writeUnauthorizedResponse(w, err)
 			return
 		}
-		// Returns actual auth token. Slice guaranteed to not go OOB
-		tokenStr := rawHeader[len(headerValStart):]
 
-		if err := a.AuthenticateToken(tokenStr, r.URL.Path); err != nil {
+		if err := a.AuthenticateToken(tokenStr, r.URL.Path, requestMethods(r)); err != nil {
 			writeUnauthorizedResponse(w, err)
 			return
 		}
@@ -297,10 +537,58 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 	})
 }
 
+// getToken returns the auth token in the header of [r]
+func getToken(r *http.Request) (string, error) {
+	// Should be "Bearer AUTH.TOKEN.HERE"
+	rawHeader := r.Header.Get(headerKey)
+	if rawHeader == "" {
+		return "", errNoToken
+	}
+	if !strings.HasPrefix(rawHeader, headerValStart) {
+		return "", errAuthHeaderNotParsable
+	}
+	// Returns actual auth token. Slice guaranteed to not go OOB
+	return rawHeader[len(headerValStart):], nil
+}
+
+// tokenLifespan returns the lifespan of the token described by [options]
+func tokenLifespan(options TokenOptions) (time.Duration, error) {
+	policy := roles[options.Role].policy
+	switch {
+	case options.Lifespan == 0:
+		return policy.DefaultLifespan, nil
+	case options.Lifespan > policy.MaxLifespan:
+		return 0, fmt.Errorf("%w: %s > %s", errLifespanTooLong, options.Lifespan, policy.MaxLifespan)
+	default:
+		return options.Lifespan, nil
+	}
+}
+
 // getTokenKey returns the key to use when making and parsing tokens
 func (a *auth) getTokenKey(t *jwt.Token) (interface{}, error) {
 	if t.Method != jwt.SigningMethodHS256 {
 		return nil, errInvalidSigningMethod
 	}
-	return a.password.Password[:], nil
+	return a.signingKey, nil
+}
+
+// setPassword sets the password to [pw] and derives the key that signs the
+// tokens issued under it. Assumes [a.lock] is held, unless called during
+// initialization.
+func (a *auth) setPassword(pw string) error {
+	hash := password.Hash{}
+	if err := hash.Set(pw); err != nil {
+		return err
+	}
+	a.password = hash
+	a.signingKey = deriveSigningKey(pw, hash)
+	return nil
+}
+
+// deriveSigningKey returns the key that signs the tokens issued under [pw],
+// whose hash is [hash]. The key is derived with a different salt than [hash],
+// so it can't be computed from the persisted hash.
+func deriveSigningKey(pw string, hash password.Hash) []byte {
+	salt := append([]byte(signingKeyDomain), hash.Salt[:]...)
+	return argon2.IDKey([]byte(pw), salt, 1, 64*1024, 4, signingKeyLen)
 }
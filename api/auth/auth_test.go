@@ -4,6 +4,7 @@
 package auth
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
 )
@@ -34,11 +36,19 @@ func init() {
 	}
 }
 
+func newTestAuth(t *testing.T) *auth {
+	a, err := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a.(*auth)
+}
+
 // Always returns 200 (http.StatusOK)
 var dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
 
 func TestNewTokenWrongPassword(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	_, err := auth.NewToken("", defaultTokenLifespan, []string{"endpoint1, endpoint2"})
 	assert.Error(t, err, "should have failed because password is wrong")
@@ -48,7 +58,7 @@ func TestNewTokenWrongPassword(t *testing.T) {
 }
 
 func TestNewTokenHappyPath(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	now := time.Now()
 	auth.clock.Set(now)
@@ -62,7 +72,7 @@ func TestNewTokenHappyPath(t *testing.T) {
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(*jwt.Token) (interface{}, error) {
 		auth.lock.RLock()
 		defer auth.lock.RUnlock()
-		return auth.password.Password[:], nil
+		return auth.signingKey, nil
 	})
 	assert.NoError(t, err, "couldn't parse new token")
 
@@ -75,7 +85,7 @@ func TestNewTokenHappyPath(t *testing.T) {
 }
 
 func TestTokenHasWrongSig(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"endpoint1", "endpoint2", "endpoint3"}
@@ -100,7 +110,7 @@ func TestTokenHasWrongSig(t *testing.T) {
 }
 
 func TestChangePassword(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	password2 := "fejhkefjhefjhefhje" // #nosec G101
 	var err error
@@ -128,7 +138,7 @@ func TestChangePassword(t *testing.T) {
 }
 
 func TestRevokeToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -141,7 +151,7 @@ func TestRevokeToken(t *testing.T) {
 }
 
 func TestWrapHandlerHappyPath(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -160,7 +170,7 @@ func TestWrapHandlerHappyPath(t *testing.T) {
 }
 
 func TestWrapHandlerRevokedToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -184,7 +194,7 @@ func TestWrapHandlerRevokedToken(t *testing.T) {
 }
 
 func TestWrapHandlerExpiredToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	auth.clock.Set(time.Now().Add(-2 * defaultTokenLifespan))
 
@@ -207,7 +217,7 @@ func TestWrapHandlerExpiredToken(t *testing.T) {
 }
 
 func TestWrapHandlerNoAuthToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -222,7 +232,7 @@ func TestWrapHandlerNoAuthToken(t *testing.T) {
 }
 
 func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info"}
@@ -244,7 +254,7 @@ func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
 }
 
 func TestWrapHandlerAuthEndpoint(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics", "", "/foo", "/ext/info/foo"}
@@ -260,7 +270,7 @@ func TestWrapHandlerAuthEndpoint(t *testing.T) {
 }
 
 func TestWrapHandlerAccessAll(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token that allows access to all endpoints
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics", "", "/foo", "/ext/foo/info"}
@@ -285,7 +295,7 @@ func TestWriteUnauthorizedResponse(t *testing.T) {
 }
 
 func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -309,7 +319,7 @@ func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
 }
 
 func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -327,7 +337,7 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		Endpoints: endpoints,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS512, &claims)
-	tokenStr, err := token.SignedString(auth.password.Password[:])
+	tokenStr, err := token.SignedString(auth.signingKey)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -344,3 +354,393 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		assert.Regexp(t, unAuthorizedResponseRegex, rr.Body.String())
 	}
 }
+
+// post calls [handler] with [body] as the given auth token
+func post(handler http.Handler, tokenStr, endpoint, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://127.0.0.1:9650%s", endpoint), strings.NewReader(body))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestWrapHandlerScopedToken(t *testing.T) {
+	assert := assert.New(t)
+
+	auth := newTestAuth(t)
+	readOnly, err := auth.NewScopedToken(testPassword, TokenOptions{
+		Principal: "dashboard",
+		Role:      RoleReadOnly,
+	})
+	assert.NoError(err)
+	scoped, err := auth.NewScopedToken(testPassword, TokenOptions{
+		Principal: "monitoring",
+		Role:      RoleWallet,
+		Scopes:    []string{"info.peers", "health.*", "avm.send"},
+		Endpoints: []string{"/ext/info", "/ext/health"},
+	})
+	assert.NoError(err)
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+	tests := []struct {
+		name     string
+		token    string
+		endpoint string
+		body     string
+		code     int
+	}{
+		{
+			name:     "read-only method",
+			token:    readOnly,
+			endpoint: "/ext/bc/X",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"avm.getBalance"}`,
+			code:     http.StatusOK,
+		},
+		{
+			name:     "read-only method, different case",
+			token:    readOnly,
+			endpoint: "/ext/info",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"info.Peers"}`,
+			code:     http.StatusOK,
+		},
+		{
+			name:     "write method",
+			token:    readOnly,
+			endpoint: "/ext/bc/X",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"avm.send"}`,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "admin method",
+			token:    readOnly,
+			endpoint: "/ext/admin",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"admin.lockProfile"}`,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "batch with a write method",
+			token:    readOnly,
+			endpoint: "/ext/bc/X",
+			body:     `[{"jsonrpc":"2.0","id":1,"method":"avm.getBalance"},{"jsonrpc":"2.0","id":2,"method":"avm.send"}]`,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "not a JSON-RPC call",
+			token:    readOnly,
+			endpoint: "/ext/bc/X",
+			body:     "",
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "scoped method",
+			token:    scoped,
+			endpoint: "/ext/info",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"info.peers"}`,
+			code:     http.StatusOK,
+		},
+		{
+			name:     "method outside of scopes",
+			token:    scoped,
+			endpoint: "/ext/info",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"info.getNodeID"}`,
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "scoped method on another endpoint",
+			token:    scoped,
+			endpoint: "/ext/bc/X",
+			body:     `{"jsonrpc":"2.0","id":1,"method":"avm.send"}`,
+			code:     http.StatusUnauthorized,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rr := post(wrappedHandler, test.token, test.endpoint, test.body)
+			assert.Equal(test.code, rr.Code)
+			if test.code == http.StatusUnauthorized {
+				assert.Contains(rr.Body.String(), errTokenInsufficientPermission.Error())
+			}
+		})
+	}
+
+	// Requests that aren't JSON-RPC calls, such as metrics scrapes, are only
+	// restricted by endpoints.
+	req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9650/ext/metrics", nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", readOnly))
+	rr := httptest.NewRecorder()
+	wrappedHandler.ServeHTTP(rr, req)
+	assert.Equal(http.StatusOK, rr.Code)
+
+	// The methods called over a websocket can't be determined.
+	req = httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9650/ext/bc/C/ws", nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", readOnly))
+	req.Header.Add("Connection", "upgrade")
+	req.Header.Add("Upgrade", "websocket")
+	rr = httptest.NewRecorder()
+	wrappedHandler.ServeHTTP(rr, req)
+	assert.Equal(http.StatusUnauthorized, rr.Code)
+}
+
+func TestNewScopedTokenInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TokenOptions
+		err     error
+	}{
+		{
+			name:    "unknown role",
+			options: TokenOptions{Role: "root"},
+			err:     errUnknownRole,
+		},
+		{
+			name: "lifespan too long",
+			options: TokenOptions{
+				Role:     RoleAdmin,
+				Lifespan: 25 * time.Hour,
+			},
+			err: errLifespanTooLong,
+		},
+		{
+			name: "invalid scope",
+			options: TokenOptions{
+				Role:   RoleReadOnly,
+				Scopes: []string{"info.["},
+			},
+			err: errInvalidScope,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auth := newTestAuth(t)
+			_, err := auth.NewScopedToken(testPassword, test.options)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestNewDelegatedToken(t *testing.T) {
+	assert := assert.New(t)
+
+	auth := newTestAuth(t)
+	now := time.Now()
+	auth.clock.Set(now)
+
+	// A token that can issue tokens for the info API, such as for an ops
+	// team.
+	issuer, err := auth.NewScopedToken(testPassword, TokenOptions{
+		Principal: "ops",
+		Role:      RoleAdmin,
+		Scopes:    []string{newTokenMethod, "info.*"},
+	})
+	assert.NoError(err)
+
+	readOnly, err := auth.NewDelegatedToken(issuer, TokenOptions{
+		Principal: "dashboard",
+		Role:      RoleReadOnly,
+		Lifespan:  time.Hour,
+	})
+	assert.NoError(err)
+	assert.NoError(auth.AuthenticateToken(readOnly, "/ext/info", []string{"info.peers"}))
+	// The issuer can't call anything other than auth.newToken and the info
+	// API itself.
+	assert.ErrorIs(auth.AuthenticateToken(issuer, "/ext/health", []string{"health.health"}), errTokenInsufficientPermission)
+
+	_, err = auth.NewDelegatedToken(issuer, TokenOptions{Role: RoleAdmin})
+	assert.ErrorIs(err, errRoleNotDelegable)
+
+	_, err = auth.NewDelegatedToken(issuer, TokenOptions{
+		Role:     RoleReadOnly,
+		Lifespan: 24 * time.Hour,
+	})
+	assert.ErrorIs(err, errOutlivesToken)
+
+	_, err = auth.NewDelegatedToken(readOnly, TokenOptions{Role: RoleReadOnly})
+	assert.ErrorIs(err, errTokenInsufficientPermission)
+
+	assert.NoError(auth.RevokeToken(issuer, testPassword))
+	_, err = auth.NewDelegatedToken(issuer, TokenOptions{Role: RoleReadOnly})
+	assert.ErrorIs(err, errTokenRevoked)
+}
+
+func TestNewDelegatedTokenPermissions(t *testing.T) {
+	assert := assert.New(t)
+
+	auth := newTestAuth(t)
+	issuer, err := auth.NewScopedToken(testPassword, TokenOptions{
+		Role:      RoleAdmin,
+		Scopes:    []string{newTokenMethod, "info.*", "avm.getBalance"},
+		Endpoints: []string{"/ext/info", "/ext/bc/X"},
+	})
+	assert.NoError(err)
+
+	// The issued token inherits the permissions of the issuer.
+	inherited, err := auth.NewDelegatedToken(issuer, TokenOptions{Role: RoleReadOnly})
+	assert.NoError(err)
+	assert.NoError(auth.AuthenticateToken(inherited, "/ext/info", []string{"info.peers"}))
+	assert.NoError(auth.AuthenticateToken(inherited, "/ext/bc/X", []string{"avm.getBalance"}))
+	assert.ErrorIs(auth.AuthenticateToken(inherited, "/ext/bc/P", []string{"platform.getHeight"}), errTokenInsufficientPermission)
+	assert.ErrorIs(auth.AuthenticateToken(inherited, "/ext/bc/X", []string{"avm.getTx"}), errTokenInsufficientPermission)
+
+	// The issued token may have a subset of the permissions of the issuer.
+	narrowed, err := auth.NewDelegatedToken(issuer, TokenOptions{
+		Role:      RoleReadOnly,
+		Scopes:    []string{"info.peers"},
+		Endpoints: []string{"/ext/info"},
+	})
+	assert.NoError(err)
+	assert.NoError(auth.AuthenticateToken(narrowed, "/ext/info", []string{"info.peers"}))
+	assert.ErrorIs(auth.AuthenticateToken(narrowed, "/ext/info", []string{"info.getNodeID"}), errTokenInsufficientPermission)
+
+	tests := []TokenOptions{
+		{Role: RoleReadOnly, Scopes: []string{"*"}},
+		{Role: RoleReadOnly, Scopes: []string{"avm.*"}},
+		{Role: RoleReadOnly, Scopes: []string{"*.getBalance"}},
+		{Role: RoleReadOnly, Scopes: []string{"info.*", "platform.getHeight"}},
+		{Role: RoleReadOnly, Endpoints: []string{"*"}},
+		{Role: RoleReadOnly, Endpoints: []string{"/ext/bc/P"}},
+	}
+	for _, options := range tests {
+		_, err := auth.NewDelegatedToken(issuer, options)
+		assert.ErrorIs(err, errExceedsToken, "options: %+v", options)
+	}
+}
+
+func TestReadOnlyScopes(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(RoleReadOnly.allows("avm.getBalance"))
+	assert.True(RoleReadOnly.allows("platform.getHeight"))
+	assert.True(RoleReadOnly.allows("eth_getBalance"))
+	assert.False(RoleReadOnly.allows("admin.getChainAliases"))
+	assert.False(RoleReadOnly.allows("auth.getTokens"))
+	assert.False(RoleReadOnly.allows("keystore.getUser"))
+	// A "*" doesn't match across a ".".
+	assert.False(RoleReadOnly.allows("avm.x.getBalance"))
+	assert.False(RoleReadOnly.allows(unknownMethod))
+}
+
+func TestSigningKeyIsNotPersisted(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	a, err := New(logging.NoLog{}, db, "auth", testPassword)
+	assert.NoError(err)
+	signingKey := a.(*auth).signingKey
+
+	// The database shouldn't contain the key that signs tokens.
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		assert.False(bytes.Contains(it.Value(), signingKey))
+	}
+	assert.NoError(it.Error())
+
+	// The key should be derived again from the password after a restart.
+	a, err = New(logging.NoLog{}, db, "auth", testPassword)
+	assert.NoError(err)
+	assert.Equal(signingKey, a.(*auth).signingKey)
+}
+
+func TestTokensPersist(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	a, err := New(logging.NoLog{}, db, "auth", testPassword)
+	assert.NoError(err)
+
+	kept, err := a.NewScopedToken(testPassword, TokenOptions{
+		Principal: "dashboard",
+		Role:      RoleReadOnly,
+	})
+	assert.NoError(err)
+	revoked, err := a.NewToken(testPassword, defaultTokenLifespan, []string{"*"})
+	assert.NoError(err)
+	assert.NoError(a.RevokeToken(revoked, testPassword))
+
+	// Restarting with the same password should keep the tokens.
+	a, err = New(logging.NoLog{}, db, "auth", testPassword)
+	assert.NoError(err)
+	assert.NoError(a.AuthenticateToken(kept, "/ext/info", []string{"info.peers"}))
+	assert.ErrorIs(a.AuthenticateToken(revoked, "/ext/info", nil), errTokenRevoked)
+
+	tokens, err := a.ListTokens(testPassword)
+	assert.NoError(err)
+	assert.Len(tokens, 2)
+	principals := map[string]TokenInfo{}
+	for _, token := range tokens {
+		principals[token.Principal] = token
+	}
+	assert.Equal(RoleReadOnly, principals["dashboard"].Role)
+	assert.False(principals["dashboard"].Revoked)
+	assert.Equal(RoleAdmin, principals[""].Role)
+	assert.True(principals[""].Revoked)
+
+	_, err = a.ListTokens("notThePassword")
+	assert.ErrorIs(err, errWrongPassword)
+
+	// Restarting with a different password should remove the tokens.
+	password2 := "fejhkefjhefjhefhje" // #nosec G101
+	a, err = New(logging.NoLog{}, db, "auth", password2)
+	assert.NoError(err)
+	assert.Error(a.AuthenticateToken(kept, "/ext/info", []string{"info.peers"}))
+	tokens, err = a.ListTokens(password2)
+	assert.NoError(err)
+	assert.Empty(tokens)
+}
+
+func TestNewDeletesExpiredTokens(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	a, err := New(logging.NoLog{}, db, "auth", testPassword)
+	assert.NoError(err)
+
+	// Issue a token that expired an hour ago.
+	a.(*auth).clock.Set(time.Now().Add(-2 * time.Hour))
+	_, err = a.NewScopedToken(testPassword, TokenOptions{
+		Role:     RoleReadOnly,
+		Lifespan: time.Hour,
+	})
+	assert.NoError(err)
+	_, err = a.NewScopedToken(testPassword, TokenOptions{
+		Role:     RoleReadOnly,
+		Lifespan: 3 * time.Hour,
+	})
+	assert.NoError(err)
+
+	// Restarting should delete the expired token from the database.
+	_, err = New(logging.NoLog{}, db, "auth", testPassword)
+	assert.NoError(err)
+	count := 0
+	assert.NoError(newTokenStore(db).iterate(func(_ string, record *tokenRecord) error {
+		assert.Greater(record.ExpiresAt, time.Now().Unix())
+		count++
+		return nil
+	}))
+	assert.Equal(1, count)
+}
+
+func TestListTokensRemovesExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	auth := newTestAuth(t)
+	now := time.Now()
+	auth.clock.Set(now)
+
+	_, err := auth.NewScopedToken(testPassword, TokenOptions{
+		Role:     RoleReadOnly,
+		Lifespan: time.Hour,
+	})
+	assert.NoError(err)
+	_, err = auth.NewScopedToken(testPassword, TokenOptions{
+		Role:     RoleReadOnly,
+		Lifespan: 2 * time.Hour,
+	})
+	assert.NoError(err)
+
+	auth.clock.Set(now.Add(90 * time.Minute))
+	tokens, err := auth.ListTokens(testPassword)
+	assert.NoError(err)
+	assert.Len(tokens, 1)
+	assert.Equal(now.Add(2*time.Hour).Unix(), tokens[0].ExpiresAt.Unix())
+}
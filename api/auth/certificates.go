@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	errNoCertificateIdentity   = errors.New("must specify exactly one of subject and fingerprint")
+	errInvalidFingerprint      = errors.New("fingerprint must be a hex encoded SHA-256 hash")
+	errDuplicateCertificate    = errors.New("certificate is mapped more than once")
+	errNoCertificatePermission = errors.New("the provided client certificate does not allow access to this endpoint")
+)
+
+// CertificateConfig maps the TLS certificates of API clients to the
+// permissions they're granted
+type CertificateConfig struct {
+	Certificates []CertificatePermissions `json:"certificates"`
+	// Permissions of clients that don't present a mapped certificate, if auth
+	// tokens aren't required. If nil, such clients aren't restricted.
+	Default *Permissions `json:"default"`
+}
+
+// CertificatePermissions grants permissions to the clients that present a
+// certificate. The certificate is identified by exactly one of the common name
+// of its subject and its fingerprint.
+type CertificatePermissions struct {
+	Permissions
+
+	// Common name of the certificate's subject
+	Subject string `json:"subject"`
+	// Hex encoded SHA-256 hash of the certificate. Colons between bytes are
+	// allowed.
+	Fingerprint string `json:"fingerprint"`
+	// Name of the principal that holds the certificate. Only used to describe
+	// the mapping.
+	Principal string `json:"principal"`
+}
+
+// CertificateAuthorizer authorizes API calls by the TLS certificates of the
+// clients that make them. The TLS certificates must have been verified by the
+// API server.
+//
+// A client that presents a mapped certificate is granted the certificate's
+// permissions, and doesn't need an auth token. Otherwise, the client is
+// authorized by its auth token if auth tokens are required, or else is granted
+// the default permissions.
+type CertificateAuthorizer struct {
+	bySubject     map[string]*CertificatePermissions
+	byFingerprint map[string]*CertificatePermissions
+	defaults      *Permissions
+	// Authorizes clients that don't present a mapped certificate, if not nil
+	tokens Auth
+}
+
+// NewCertificateAuthorizer returns a CertificateAuthorizer that grants the
+// permissions of [config]. If [tokens] isn't nil, clients that don't present a
+// mapped certificate must provide an auth token.
+func NewCertificateAuthorizer(config CertificateConfig, tokens Auth) (*CertificateAuthorizer, error) {
+	a := &CertificateAuthorizer{
+		bySubject:     make(map[string]*CertificatePermissions),
+		byFingerprint: make(map[string]*CertificatePermissions),
+		defaults:      config.Default,
+		tokens:        tokens,
+	}
+	if a.defaults != nil {
+		if err := a.defaults.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid default permissions: %w", err)
+		}
+	}
+	for i := range config.Certificates {
+		cert := &config.Certificates[i]
+		if err := cert.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid permissions of certificate %d: %w", i, err)
+		}
+
+		var (
+			key     string
+			mapping map[string]*CertificatePermissions
+		)
+		switch {
+		case cert.Subject != "" && cert.Fingerprint == "":
+			key, mapping = cert.Subject, a.bySubject
+		case cert.Subject == "" && cert.Fingerprint != "":
+			fingerprint, err := normalizeFingerprint(cert.Fingerprint)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate %d: %w", i, err)
+			}
+			key, mapping = fingerprint, a.byFingerprint
+		default:
+			return nil, fmt.Errorf("invalid certificate %d: %w", i, errNoCertificateIdentity)
+		}
+		if _, ok := mapping[key]; ok {
+			return nil, fmt.Errorf("%w: %q", errDuplicateCertificate, key)
+		}
+		mapping[key] = cert
+	}
+	return a, nil
+}
+
+func (a *CertificateAuthorizer) WrapHandler(h http.Handler) http.Handler {
+	fallback := h
+	if a.tokens != nil {
+		fallback = a.tokens.WrapHandler(h)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		permissions, ok := a.permissions(r)
+		switch {
+		case ok:
+		case a.tokens == nil && a.defaults != nil:
+			permissions = a.defaults
+		default:
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		if err := permissions.authorize(r.URL.Path, requestMethods(r)); err != nil {
+			writeUnauthorizedResponse(w, errNoCertificatePermission)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// permissions returns the permissions of the verified certificate presented by
+// the client that made [r], if it's mapped
+func (a *CertificateAuthorizer) permissions(r *http.Request) (*Permissions, bool) {
+	if r.TLS == nil {
+		return nil, false
+	}
+	return a.certificatePermissions(r.TLS.VerifiedChains)
+}
+
+// certificatePermissions returns the permissions of the certificate verified
+// by [verifiedChains], if it's mapped
+func (a *CertificateAuthorizer) certificatePermissions(verifiedChains [][]*x509.Certificate) (*Permissions, bool) {
+	if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+		return nil, false
+	}
+	cert := verifiedChains[0][0]
+	if mapped, ok := a.byFingerprint[fingerprint(cert)]; ok {
+		return &mapped.Permissions, true
+	}
+	if mapped, ok := a.bySubject[cert.Subject.CommonName]; ok {
+		return &mapped.Permissions, true
+	}
+	return nil, false
+}
+
+// fingerprint returns the hex encoded SHA-256 hash of [cert]
+func fingerprint(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(hash[:])
+}
+
+// normalizeFingerprint returns [fingerprint] in the format returned by
+// fingerprint
+func normalizeFingerprint(fingerprint string) (string, error) {
+	fingerprint = strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+	hash, err := hex.DecodeString(fingerprint)
+	if err != nil || len(hash) != sha256.Size {
+		return "", errInvalidFingerprint
+	}
+	return fingerprint, nil
+}
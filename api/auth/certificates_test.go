@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// certRequest returns a request to [endpoint] that calls [method], made by a
+// client that presented [cert]
+func certRequest(cert *x509.Certificate, endpoint, method string) *http.Request {
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q}`, method)
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("https://127.0.0.1:9650%s", endpoint), strings.NewReader(body))
+	if cert != nil {
+		req.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}
+	}
+	return req
+}
+
+func TestCertificateAuthorizer(t *testing.T) {
+	assert := assert.New(t)
+
+	readOnlyCert := &x509.Certificate{
+		Raw:     []byte("read-only"),
+		Subject: pkix.Name{CommonName: "dashboard.internal"},
+	}
+	adminCert := &x509.Certificate{
+		Raw:     []byte("admin"),
+		Subject: pkix.Name{CommonName: "ops.internal"},
+	}
+	unmappedCert := &x509.Certificate{
+		Raw:     []byte("unmapped"),
+		Subject: pkix.Name{CommonName: "other.internal"},
+	}
+	adminHash := sha256.Sum256(adminCert.Raw)
+	adminFingerprint := make([]string, len(adminHash))
+	for i, b := range adminHash {
+		adminFingerprint[i] = fmt.Sprintf("%02X", b)
+	}
+
+	config := CertificateConfig{
+		Certificates: []CertificatePermissions{
+			{
+				Permissions: Permissions{Role: RoleReadOnly},
+				Subject:     readOnlyCert.Subject.CommonName,
+			},
+			{
+				Permissions: Permissions{
+					Role:      RoleAdmin,
+					Endpoints: []string{"/ext/admin", "/ext/keystore"},
+				},
+				Fingerprint: strings.Join(adminFingerprint, ":"),
+			},
+		},
+	}
+
+	authorizer, err := NewCertificateAuthorizer(config, nil)
+	assert.NoError(err)
+	handler := authorizer.WrapHandler(dummyHandler)
+
+	tests := []struct {
+		name     string
+		cert     *x509.Certificate
+		endpoint string
+		method   string
+		code     int
+	}{
+		{
+			name:     "read-only method",
+			cert:     readOnlyCert,
+			endpoint: "/ext/info",
+			method:   "info.peers",
+			code:     http.StatusOK,
+		},
+		{
+			name:     "read-only cert calling admin method",
+			cert:     readOnlyCert,
+			endpoint: "/ext/admin",
+			method:   "admin.lockProfile",
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "admin method",
+			cert:     adminCert,
+			endpoint: "/ext/admin",
+			method:   "admin.lockProfile",
+			code:     http.StatusOK,
+		},
+		{
+			name:     "admin cert outside of endpoints",
+			cert:     adminCert,
+			endpoint: "/ext/bc/X",
+			method:   "avm.send",
+			code:     http.StatusUnauthorized,
+		},
+		{
+			name:     "unmapped cert",
+			cert:     unmappedCert,
+			endpoint: "/ext/admin",
+			method:   "admin.lockProfile",
+			code:     http.StatusOK,
+		},
+		{
+			name:     "no cert",
+			endpoint: "/ext/admin",
+			method:   "admin.lockProfile",
+			code:     http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, certRequest(test.cert, test.endpoint, test.method))
+			assert.Equal(test.code, rr.Code)
+			if test.code == http.StatusUnauthorized {
+				assert.Contains(rr.Body.String(), errNoCertificatePermission.Error())
+			}
+		})
+	}
+
+	// Clients without a mapped certificate should get the default permissions.
+	config.Default = &Permissions{Role: RoleReadOnly}
+	authorizer, err = NewCertificateAuthorizer(config, nil)
+	assert.NoError(err)
+	handler = authorizer.WrapHandler(dummyHandler)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, certRequest(unmappedCert, "/ext/admin", "admin.lockProfile"))
+	assert.Equal(http.StatusUnauthorized, rr.Code)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, certRequest(nil, "/ext/info", "info.peers"))
+	assert.Equal(http.StatusOK, rr.Code)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, certRequest(adminCert, "/ext/admin", "admin.lockProfile"))
+	assert.Equal(http.StatusOK, rr.Code)
+}
+
+func TestCertificateAuthorizerWithTokens(t *testing.T) {
+	assert := assert.New(t)
+
+	tokens := newTestAuth(t)
+	cert := &x509.Certificate{
+		Raw:     []byte("admin"),
+		Subject: pkix.Name{CommonName: "ops.internal"},
+	}
+	authorizer, err := NewCertificateAuthorizer(CertificateConfig{
+		Certificates: []CertificatePermissions{{
+			Permissions: Permissions{Role: RoleAdmin},
+			Subject:     cert.Subject.CommonName,
+		}},
+		// Ignored, as clients without a mapped certificate need auth tokens.
+		Default: &Permissions{Role: RoleAdmin},
+	}, tokens)
+	assert.NoError(err)
+	handler := authorizer.WrapHandler(dummyHandler)
+
+	// A mapped certificate doesn't need an auth token.
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, certRequest(cert, "/ext/admin", "admin.lockProfile"))
+	assert.Equal(http.StatusOK, rr.Code)
+
+	// Other clients do.
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, certRequest(nil, "/ext/admin", "admin.lockProfile"))
+	assert.Equal(http.StatusUnauthorized, rr.Code)
+	assert.Contains(rr.Body.String(), errNoToken.Error())
+
+	tokenStr, err := tokens.NewScopedToken(testPassword, TokenOptions{Role: RoleAdmin})
+	assert.NoError(err)
+	req := certRequest(nil, "/ext/admin", "admin.lockProfile")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(http.StatusOK, rr.Code)
+}
+
+func TestNewCertificateAuthorizerInvalidConfig(t *testing.T) {
+	fingerprint := strings.Repeat("ab", sha256.Size)
+	tests := []struct {
+		name   string
+		config CertificateConfig
+		err    error
+	}{
+		{
+			name: "no identity",
+			config: CertificateConfig{
+				Certificates: []CertificatePermissions{{
+					Permissions: Permissions{Role: RoleAdmin},
+				}},
+			},
+			err: errNoCertificateIdentity,
+		},
+		{
+			name: "subject and fingerprint",
+			config: CertificateConfig{
+				Certificates: []CertificatePermissions{{
+					Permissions: Permissions{Role: RoleAdmin},
+					Subject:     "ops.internal",
+					Fingerprint: fingerprint,
+				}},
+			},
+			err: errNoCertificateIdentity,
+		},
+		{
+			name: "invalid fingerprint",
+			config: CertificateConfig{
+				Certificates: []CertificatePermissions{{
+					Permissions: Permissions{Role: RoleAdmin},
+					Fingerprint: "abcd",
+				}},
+			},
+			err: errInvalidFingerprint,
+		},
+		{
+			name: "duplicate fingerprint",
+			config: CertificateConfig{
+				Certificates: []CertificatePermissions{
+					{
+						Permissions: Permissions{Role: RoleAdmin},
+						Fingerprint: fingerprint,
+					},
+					{
+						Permissions: Permissions{Role: RoleReadOnly},
+						Fingerprint: strings.ToUpper(fingerprint),
+					},
+				},
+			},
+			err: errDuplicateCertificate,
+		},
+		{
+			name: "unknown role",
+			config: CertificateConfig{
+				Certificates: []CertificatePermissions{{
+					Permissions: Permissions{Role: "root"},
+					Subject:     "ops.internal",
+				}},
+			},
+			err: errUnknownRole,
+		},
+		{
+			name: "invalid default",
+			config: CertificateConfig{
+				Default: &Permissions{
+					Role:   RoleReadOnly,
+					Scopes: []string{"["},
+				},
+			},
+			err: errInvalidScope,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewCertificateAuthorizer(test.config, nil)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -4,15 +4,148 @@
 package auth
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/golang-jwt/jwt"
 )
 
 // Custom claim type used for API access token
+//
+// The principal the token was issued to is the subject of the token.
 type endpointClaims struct {
 	jwt.StandardClaims
 
 	// Each element is an endpoint that the token allows access to
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
+	// If endpoints is empty, allows access to all API endpoints
 	Endpoints []string `json:"endpoints,omitempty"`
+
+	// Role of the principal the token was issued to
+	Role Role `json:"role"`
+
+	// Each element is a scope of the methods that the token allows calling,
+	// in addition to the restrictions of [Role]
+	// If scopes is empty, allows calling every method that [Role] allows
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// allows returns true if the token allows calling [method]
+func (c *endpointClaims) allows(method string) bool {
+	return allowsMethod(c.Role, c.Scopes, method)
+}
+
+// permissions returns the permissions granted by the token
+func (c *endpointClaims) permissions() Permissions {
+	return Permissions{
+		Role:      c.Role,
+		Scopes:    c.Scopes,
+		Endpoints: c.Endpoints,
+	}
+}
+
+// Permissions to call the API
+type Permissions struct {
+	Role Role `json:"role"`
+	// Scopes of the methods that may be called, in addition to the
+	// restrictions of [Role]. If empty, every method that [Role] allows may be
+	// called.
+	Scopes []string `json:"scopes"`
+	// Endpoints that may be accessed. If empty, every endpoint may be
+	// accessed.
+	Endpoints []string `json:"endpoints"`
+}
+
+// Verify returns an error if [p] isn't well-formed
+func (p *Permissions) Verify() error {
+	if _, ok := roles[p.Role]; !ok {
+		return fmt.Errorf("%w: %q", errUnknownRole, p.Role)
+	}
+	if len(p.Endpoints) > maxEndpoints {
+		return errTooManyEndpoints
+	}
+	return verifyScopes(p.Scopes)
+}
+
+// authorize returns an error unless [p] allows access to [url] and calling
+// each of [methods]
+func (p *Permissions) authorize(url string, methods []string) error {
+	if !allowsEndpoint(p.Endpoints, url) {
+		return errTokenInsufficientPermission
+	}
+	for _, method := range methods {
+		if !allowsMethod(p.Role, p.Scopes, method) {
+			return fmt.Errorf("%w: %q", errTokenInsufficientPermission, method)
+		}
+	}
+	return nil
+}
+
+// allowsMethod returns true if both [role] and [scopes] allow calling
+// [method]. An empty [scopes] allows every method.
+func allowsMethod(role Role, scopes []string, method string) bool {
+	return role.allows(method) && (len(scopes) == 0 || matchesScope(scopes, method))
+}
+
+// allowsEndpoint returns true if an element of [endpoints] allows access to
+// [url]. An empty [endpoints] allows access to every endpoint.
+func allowsEndpoint(endpoints []string, url string) bool {
+	if len(endpoints) == 0 {
+		return true
+	}
+	for _, endpoint := range endpoints {
+		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// delegatedEndpoints returns the endpoints of a token issued by a token that
+// allows access to [parent]. The issued token allows access to [requested], or
+// to [parent] if [requested] is empty. Returns an error if [requested] allows
+// access to an endpoint that [parent] doesn't.
+func delegatedEndpoints(parent, requested []string) ([]string, error) {
+	if allowsEndpoint(parent, "*") {
+		// [parent] allows access to every endpoint.
+		return requested, nil
+	}
+	if len(requested) == 0 {
+		return parent, nil
+	}
+	for _, endpoint := range requested {
+		// Any URL that ends with [endpoint] also ends with an element of
+		// [parent].
+		if endpoint == "*" || !allowsEndpoint(parent, endpoint) {
+			return nil, fmt.Errorf("%w: endpoint %q", errExceedsToken, endpoint)
+		}
+	}
+	return requested, nil
+}
+
+// delegatedScopes returns the scopes of a token issued by a token with the
+// scopes [parent]. The issued token has the scopes [requested], or [parent] if
+// [requested] is empty. Returns an error if [requested] matches a method that
+// [parent] doesn't.
+func delegatedScopes(parent, requested []string) ([]string, error) {
+	if len(parent) == 0 {
+		return requested, nil
+	}
+	if len(requested) == 0 {
+		return parent, nil
+	}
+	for _, scope := range requested {
+		covered := false
+		for _, parentScope := range parent {
+			if coversScope(parentScope, scope) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return nil, fmt.Errorf("%w: scope %q", errExceedsToken, scope)
+		}
+	}
+	return requested, nil
 }
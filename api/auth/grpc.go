@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	_ GRPCAuthorizer = &auth{}
+	_ GRPCAuthorizer = &CertificateAuthorizer{}
+)
+
+// GRPCAuthorizer authorizes the calls of the gRPC API with the same
+// credentials as the calls of the HTTP API. The auth token is passed as the
+// "authorization" metadata of the call, as "Bearer TOKEN.GOES.HERE".
+type GRPCAuthorizer interface {
+	// AuthorizeGRPC returns an error if the call [ctx] isn't allowed to access
+	// the API at [url] and to call [method].
+	AuthorizeGRPC(ctx context.Context, url, method string) error
+}
+
+func (a *auth) AuthorizeGRPC(ctx context.Context, url, method string) error {
+	tokenStr, err := getGRPCToken(ctx)
+	if err != nil {
+		return err
+	}
+	return a.AuthenticateToken(tokenStr, url, []string{method})
+}
+
+func (a *CertificateAuthorizer) AuthorizeGRPC(ctx context.Context, url, method string) error {
+	var permissions *Permissions
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			permissions, _ = a.certificatePermissions(tlsInfo.State.VerifiedChains)
+		}
+	}
+	switch {
+	case permissions != nil:
+	case a.tokens != nil:
+		return a.tokens.AuthorizeGRPC(ctx, url, method)
+	case a.defaults != nil:
+		permissions = a.defaults
+	default:
+		return nil
+	}
+
+	if err := permissions.authorize(url, []string{method}); err != nil {
+		return errNoCertificatePermission
+	}
+	return nil
+}
+
+// getGRPCToken returns the auth token in the metadata of the call [ctx]
+func getGRPCToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errNoToken
+	}
+	// Metadata keys are lowercase
+	values := md.Get(strings.ToLower(headerKey))
+	if len(values) == 0 || values[0] == "" {
+		return "", errNoToken
+	}
+	if !strings.HasPrefix(values[0], headerValStart) {
+		return "", errAuthHeaderNotParsable
+	}
+	return values[0][len(headerValStart):], nil
+}
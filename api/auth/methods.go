@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"bytes"
+	"net/http"
+
+	stdjson "encoding/json"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// unknownMethod is the method of requests whose methods can't be determined.
+// It's only matched by the "*" scope.
+const unknownMethod = ""
+
+type call struct {
+	Method string `json:"method"`
+}
+
+// requestMethods returns the JSON-RPC methods called by [r], which may be a
+// batch of calls. The body of [r] isn't consumed.
+//
+// Requests other than POSTs, such as metrics scrapes, don't call any methods.
+// Websocket connections can call methods after they're established, so their
+// methods can't be determined.
+func requestMethods(r *http.Request) []string {
+	if websocket.IsWebSocketUpgrade(r) {
+		return []string{unknownMethod}
+	}
+	if r.Method != http.MethodPost || r.Body == nil {
+		return nil
+	}
+
+	body, err := json.RequestBody(r)
+	if err != nil {
+		return []string{unknownMethod}
+	}
+
+	if trimmed := bytes.TrimLeft(body, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		calls := []call{}
+		if err := stdjson.Unmarshal(body, &calls); err != nil {
+			return []string{unknownMethod}
+		}
+		methods := make([]string, len(calls))
+		for i, call := range calls {
+			methods[i] = call.Method
+		}
+		return methods
+	}
+
+	single := call{}
+	if err := stdjson.Unmarshal(body, &single); err != nil {
+		return []string{unknownMethod}
+	}
+	return []string{single.Method}
+}
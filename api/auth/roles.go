@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// RoleReadOnly allows calls that only read state, such as the info and
+	// health APIs and the getters of the chain APIs.
+	RoleReadOnly Role = "read-only"
+	// RoleWallet allows the calls of [RoleReadOnly] and the calls of the chain
+	// and keystore APIs that issue transactions.
+	RoleWallet Role = "wallet"
+	// RoleAdmin allows every call, including the calls of the admin and auth
+	// APIs.
+	RoleAdmin Role = "admin"
+
+	maxScopes = 128
+)
+
+var (
+	readOnlyScopes = []string{
+		"info.*",
+		"rpc.discover",
+		"health.*",
+		"index.*",
+		// Only the getters of the chain APIs. The admin, auth and keystore
+		// APIs have getters that expose secrets or node internals.
+		"avm.get*",
+		"platform.get*",
+		"avax.get*",
+		"eth_get*",
+		"eth_call",
+		"eth_chainId",
+		"eth_blockNumber",
+		"eth_estimateGas",
+		"eth_gasPrice",
+		"net_*",
+		"web3_*",
+	}
+
+	roles = map[Role]roleInfo{
+		RoleReadOnly: {
+			rank:   0,
+			scopes: readOnlyScopes,
+			policy: ExpirationPolicy{
+				DefaultLifespan: defaultTokenLifespan,
+				MaxLifespan:     30 * 24 * time.Hour,
+			},
+		},
+		RoleWallet: {
+			rank: 1,
+			scopes: append([]string{
+				"avm.*",
+				"platform.*",
+				"wallet.*",
+				"keystore.*",
+				"avax.*",
+				"eth_*",
+				"personal_*",
+			}, readOnlyScopes...),
+			policy: ExpirationPolicy{
+				DefaultLifespan: defaultTokenLifespan,
+				MaxLifespan:     7 * 24 * time.Hour,
+			},
+		},
+		RoleAdmin: {
+			rank:   2,
+			scopes: []string{"*"},
+			policy: ExpirationPolicy{
+				DefaultLifespan: defaultTokenLifespan,
+				MaxLifespan:     24 * time.Hour,
+			},
+		},
+	}
+)
+
+// Role of the principal a token is issued to. The role determines which API
+// methods the token can call and how long the token can live.
+type Role string
+
+// ExpirationPolicy of the tokens issued for a role
+type ExpirationPolicy struct {
+	// Lifespan of a token if one isn't requested
+	DefaultLifespan time.Duration `json:"defaultLifespan"`
+	// Maximum lifespan of a token
+	MaxLifespan time.Duration `json:"maxLifespan"`
+}
+
+type roleInfo struct {
+	// Roles with a higher rank allow every call that lower ranked roles allow.
+	rank uint8
+	// Scopes of the calls the role allows
+	scopes []string
+	policy ExpirationPolicy
+}
+
+// Policy returns the expiration policy of the tokens issued for [r]
+func (r Role) Policy() (ExpirationPolicy, bool) {
+	info, ok := roles[r]
+	return info.policy, ok
+}
+
+// allows returns true if [r] allows calling [method]
+func (r Role) allows(method string) bool {
+	info, ok := roles[r]
+	return ok && matchesScope(info.scopes, method)
+}
+
+// matchesScope returns true if an element of [scopes] matches [method].
+//
+// A scope is the name of a method, such as "info.peers", or a pattern that
+// matches the names of methods, such as "admin.*". The scope "*" matches every
+// method. Otherwise, each "."-separated part of a scope is matched against the
+// same part of the method using the syntax of path.Match, so a "*" never
+// matches across a ".". Scopes are case insensitive.
+func matchesScope(scopes []string, method string) bool {
+	methodParts := strings.Split(strings.ToLower(method), ".")
+	for _, scope := range scopes {
+		if scope == "*" {
+			return true
+		}
+		scopeParts := strings.Split(strings.ToLower(scope), ".")
+		if len(scopeParts) != len(methodParts) {
+			continue
+		}
+		matched := true
+		for i, part := range scopeParts {
+			if ok, _ := path.Match(part, methodParts[i]); !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// coversScope returns true if every method that [scope] matches is also
+// matched by [parent]. It may return false for patterns that are covered in
+// ways that are hard to determine, such as "a?.*" and "[ab]*.*".
+func coversScope(parent, scope string) bool {
+	if parent == "*" {
+		return true
+	}
+	if scope == "*" {
+		return false
+	}
+	parentParts := strings.Split(strings.ToLower(parent), ".")
+	scopeParts := strings.Split(strings.ToLower(scope), ".")
+	if len(parentParts) != len(scopeParts) {
+		return false
+	}
+	for i, part := range scopeParts {
+		switch {
+		case parentParts[i] == "*" || parentParts[i] == part:
+		case strings.ContainsAny(part, `*?[\`):
+			// [part] is a pattern that [parentParts[i]] may not cover.
+			return false
+		default:
+			if matched, _ := path.Match(parentParts[i], part); !matched {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// verifyScopes returns an error if [scopes] can't be given to a token
+func verifyScopes(scopes []string) error {
+	if len(scopes) > maxScopes {
+		return errTooManyScopes
+	}
+	for _, scope := range scopes {
+		if _, err := path.Match(scope, ""); err != nil || scope == "" {
+			return fmt.Errorf("%w: %q", errInvalidScope, scope)
+		}
+	}
+	return nil
+}
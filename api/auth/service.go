@@ -5,8 +5,10 @@ package auth
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/json"
 )
 
 // Service that serves the Auth API functionality.
@@ -24,19 +26,67 @@ type NewTokenArgs struct {
 	// ["/ext/bc/X", "/ext/admin"] then the token holder can hit the X-Chain API
 	// and the admin API. If [Endpoints] contains an element "*" then the token
 	// allows access to all API endpoints. [Endpoints] must have between 1 and
-	// [maxEndpoints] elements
+	// [maxEndpoints] elements, unless [Role] is given, in which case an empty
+	// [Endpoints] allows access to all API endpoints
 	Endpoints []string `json:"endpoints"`
+	// Principal the token is issued to, such as "ops-dashboard"
+	Principal string `json:"principal"`
+	// Role of [Principal], which is one of "read-only", "wallet" and "admin".
+	// If empty, the token is issued for the admin role and is restricted only
+	// by [Endpoints].
+	Role Role `json:"role"`
+	// Scopes of the methods that may be called with this token e.g. if scopes
+	// is ["info.peers", "health.*"] then the token holder can call info.peers
+	// and every method of the health API that [Role] allows. If [Scopes] is
+	// empty then every method that [Role] allows may be called.
+	Scopes []string `json:"scopes"`
+	// Lifespan of the token in seconds. If zero, the default lifespan of
+	// [Role] is used.
+	Lifespan json.Uint64 `json:"lifespan"`
 }
 
 type Token struct {
 	Token string `json:"token"` // The new token. Expires in [TokenLifespan].
 }
 
-func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) error {
+// NewToken issues a token. It's authorized by the password or, if the password
+// isn't given, by the auth token in the header of the request, which can only
+// issue tokens for lower roles than its own.
+func (s *Service) NewToken(r *http.Request, args *NewTokenArgs, reply *Token) error {
 	s.auth.log.Debug("Auth: NewToken called")
 
 	var err error
-	reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	if args.Role == "" {
+		reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+		return err
+	}
+
+	options := TokenOptions{
+		Principal: args.Principal,
+		Role:      args.Role,
+		Scopes:    args.Scopes,
+		Endpoints: args.Endpoints,
+		Lifespan:  time.Duration(args.Lifespan) * time.Second,
+	}
+	if args.Password.Password == "" {
+		if tokenStr, err := getToken(r); err == nil {
+			reply.Token, err = s.auth.NewDelegatedToken(tokenStr, options)
+			return err
+		}
+	}
+	reply.Token, err = s.auth.NewScopedToken(args.Password.Password, options)
+	return err
+}
+
+type ListTokensReply struct {
+	Tokens []TokenInfo `json:"tokens"`
+}
+
+func (s *Service) ListTokens(_ *http.Request, args *Password, reply *ListTokensReply) error {
+	s.auth.log.Debug("Auth: ListTokens called")
+
+	var err error
+	reply.Tokens, err = s.auth.ListTokens(args.Password)
 	return err
 }
 
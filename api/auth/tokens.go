@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/password"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const codecVersion = 0
+
+var (
+	passwordKey  = []byte("password")
+	tokensPrefix = []byte("tokens")
+
+	tokenCodec codec.Manager
+)
+
+func init() {
+	tokenCodec = codec.NewDefaultManager()
+	if err := tokenCodec.RegisterCodec(codecVersion, linearcodec.NewDefault()); err != nil {
+		panic(err)
+	}
+}
+
+// TokenInfo describes an issued token
+type TokenInfo struct {
+	ID        string    `json:"id"`
+	Principal string    `json:"principal"`
+	Role      Role      `json:"role"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	Endpoints []string  `json:"endpoints,omitempty"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Revoked   bool      `json:"revoked"`
+}
+
+// tokenRecord is the persisted description of an issued token
+type tokenRecord struct {
+	Principal string   `serialize:"true"`
+	Role      string   `serialize:"true"`
+	Scopes    []string `serialize:"true"`
+	Endpoints []string `serialize:"true"`
+	IssuedAt  int64    `serialize:"true"`
+	ExpiresAt int64    `serialize:"true"`
+	Revoked   bool     `serialize:"true"`
+}
+
+func (r *tokenRecord) info(id string) TokenInfo {
+	return TokenInfo{
+		ID:        id,
+		Principal: r.Principal,
+		Role:      Role(r.Role),
+		Scopes:    r.Scopes,
+		Endpoints: r.Endpoints,
+		IssuedAt:  time.Unix(r.IssuedAt, 0),
+		ExpiresAt: time.Unix(r.ExpiresAt, 0),
+		Revoked:   r.Revoked,
+	}
+}
+
+// tokenStore persists the password hash and the tokens issued with it, so
+// that tokens stay valid and revoked across restarts.
+type tokenStore struct {
+	db     database.Database
+	tokens database.Database
+}
+
+func newTokenStore(db database.Database) *tokenStore {
+	return &tokenStore{
+		db:     db,
+		tokens: prefixdb.New(tokensPrefix, db),
+	}
+}
+
+// getPassword returns the persisted password hash, or database.ErrNotFound if
+// there isn't one.
+func (s *tokenStore) getPassword() (password.Hash, error) {
+	hash := password.Hash{}
+	hashBytes, err := s.db.Get(passwordKey)
+	if err != nil {
+		return hash, err
+	}
+	_, err = tokenCodec.Unmarshal(hashBytes, &hash)
+	return hash, err
+}
+
+// putPassword persists [hash] and removes the tokens issued with the previous
+// password hash, as they can no longer be authenticated.
+func (s *tokenStore) putPassword(hash password.Hash) error {
+	if err := s.deleteTokens(func(string, *tokenRecord) bool { return true }); err != nil {
+		return err
+	}
+	hashBytes, err := tokenCodec.Marshal(codecVersion, &hash)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(passwordKey, hashBytes)
+}
+
+func (s *tokenStore) getToken(id string) (*tokenRecord, error) {
+	recordBytes, err := s.tokens.Get([]byte(id))
+	if err != nil {
+		return nil, err
+	}
+	record := &tokenRecord{}
+	_, err = tokenCodec.Unmarshal(recordBytes, record)
+	return record, err
+}
+
+func (s *tokenStore) putToken(id string, record *tokenRecord) error {
+	recordBytes, err := tokenCodec.Marshal(codecVersion, record)
+	if err != nil {
+		return err
+	}
+	return s.tokens.Put([]byte(id), recordBytes)
+}
+
+// iterate calls [f] with every persisted token
+func (s *tokenStore) iterate(f func(id string, record *tokenRecord) error) error {
+	it := s.tokens.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		record := &tokenRecord{}
+		if _, err := tokenCodec.Unmarshal(it.Value(), record); err != nil {
+			return err
+		}
+		if err := f(string(it.Key()), record); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// deleteTokens removes the persisted tokens that [shouldDelete] returns true
+// for
+func (s *tokenStore) deleteTokens(shouldDelete func(id string, record *tokenRecord) bool) error {
+	var ids []string
+	err := s.iterate(func(id string, record *tokenRecord) error {
+		if shouldDelete(id, record) {
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	errs := wrappers.Errs{}
+	for _, id := range ids {
+		errs.Add(s.tokens.Delete([]byte(id)))
+	}
+	return errs.Err
+}
@@ -0,0 +1,482 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// ChainKey is the metadata key of the alias or ID of the chain a call to
+	// a chain service is routed to
+	ChainKey = "chain"
+
+	baseURL = "/ext"
+
+	// authKey is the metadata key of a call's auth token
+	authKey = "authorization"
+)
+
+var (
+	errNoChain              = errors.New("the chain of the call must be given as \"chain\" metadata")
+	errUnknownChain         = errors.New("unknown chain")
+	errChainNotBootstrapped = errors.New("chain not bootstrapped")
+	errUnknownChainService  = errors.New("unknown chain service")
+	errDuplicateChain       = errors.New("chain service is already registered for chain")
+
+	_ Server = &server{}
+)
+
+// Server serves the gRPC API of the node.
+//
+// Services of the node, such as the info API, are registered once. Services of
+// chains are registered once per chain that serves them, and each call to a
+// chain service is routed to a chain by its [ChainKey] metadata.
+type Server interface {
+	// Registers a service of the node. Must be called before Dispatch.
+	grpc.ServiceRegistrar
+
+	// RegisterChain registers the services of the chain [ctx] served by [vm].
+	// The services must be among the chain services the server was created
+	// with.
+	RegisterChain(ctx *snow.ConsensusContext, vm common.GRPCVM) error
+
+	// Dispatch starts serving calls on [host]:[port]. Blocks until the server
+	// is shut down.
+	Dispatch(host string, port uint16) error
+
+	// Shutdown stops the server. Streams in progress are ended. If the calls
+	// in progress don't finish within the shutdown timeout, they're canceled.
+	Shutdown()
+}
+
+// RateLimiter limits the calls that clients make
+type RateLimiter interface {
+	// Acquire starts a call of [method] at [endpoint] by the client at
+	// [remoteAddr], which may have provided the auth header [authHeader].
+	// Returns a function that must be called once the call finishes, or an
+	// error if the call exceeds the client's limits.
+	Acquire(remoteAddr, authHeader, endpoint, method string) (func(), error)
+}
+
+type server struct {
+	log             logging.Logger
+	authorizer      auth.GRPCAuthorizer
+	rateLimiter     RateLimiter
+	shutdownTimeout time.Duration
+	server          *grpc.Server
+
+	// Closed once the server is shut down, which ends the streams in progress
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	// Service name --> service of chains
+	chainServices map[string]*chainService
+}
+
+// chainService routes the calls of a service to the chains that serve it
+type chainService struct {
+	lock sync.RWMutex
+	// Alias or ID of a chain --> the chain's implementation of the service
+	chains map[string]*chainImpl
+}
+
+type chainImpl struct {
+	ctx  *snow.ConsensusContext
+	impl interface{}
+}
+
+// New returns a new gRPC server. Calls are authorized by [authorizer] and
+// limited by [rateLimiter], unless they're nil. Calls in progress when the
+// server is shut down are given [shutdownTimeout] to finish. [chainServices]
+// are the services that chains may serve. To serve calls over TLS, [opts]
+// should include the server's credentials, so that the authorizer can
+// authenticate clients by their certificates.
+func New(
+	log logging.Logger,
+	authorizer auth.GRPCAuthorizer,
+	rateLimiter RateLimiter,
+	shutdownTimeout time.Duration,
+	chainServices []*grpc.ServiceDesc,
+	opts ...grpc.ServerOption,
+) Server {
+	s := &server{
+		log:             log,
+		authorizer:      authorizer,
+		rateLimiter:     rateLimiter,
+		shutdownTimeout: shutdownTimeout,
+		closing:         make(chan struct{}),
+		chainServices:   make(map[string]*chainService, len(chainServices)),
+	}
+	opts = append(
+		opts,
+		grpc.ChainUnaryInterceptor(s.admitUnary),
+		grpc.ChainStreamInterceptor(s.admitStream),
+	)
+	s.server = grpc.NewServer(opts...)
+
+	for _, desc := range chainServices {
+		service := &chainService{
+			chains: make(map[string]*chainImpl),
+		}
+		s.chainServices[desc.ServiceName] = service
+		s.server.RegisterService(s.routedDesc(service, desc), service)
+	}
+	return s
+}
+
+func (s *server) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	s.server.RegisterService(desc, impl)
+}
+
+func (s *server) RegisterChain(ctx *snow.ConsensusContext, vm common.GRPCVM) error {
+	registrar := &chainRegistrar{
+		server: s,
+		ctx:    ctx,
+	}
+	if err := vm.RegisterGRPCServices(registrar); err != nil {
+		return err
+	}
+	return registrar.err
+}
+
+func (s *server) Dispatch(host string, port uint16) error {
+	listenAddress := fmt.Sprintf("%s:%d", host, port)
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return err
+	}
+	s.log.Info("gRPC API server listening on %q", listener.Addr())
+	return s.server.Serve(listener)
+}
+
+func (s *server) Shutdown() {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+	})
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		s.log.Warn("gRPC API calls didn't finish within %s of shutting down", s.shutdownTimeout)
+		s.server.Stop()
+	}
+}
+
+// chainRegistrar registers the services of a chain
+type chainRegistrar struct {
+	server *server
+	ctx    *snow.ConsensusContext
+	// The first error that occurred while registering a service
+	err error
+}
+
+func (r *chainRegistrar) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	if r.err != nil {
+		return
+	}
+	service, ok := r.server.chainServices[desc.ServiceName]
+	if !ok {
+		r.err = fmt.Errorf("%w: %q", errUnknownChainService, desc.ServiceName)
+		return
+	}
+	r.err = service.register(r.ctx, impl)
+}
+
+// register routes calls to chain [ctx] to [impl]. The chain is identified by
+// its ID and each of its aliases.
+func (s *chainService) register(ctx *snow.ConsensusContext, impl interface{}) error {
+	keys := []string{ctx.ChainID.String()}
+	if aliases, err := ctx.BCLookup.Aliases(ctx.ChainID); err == nil {
+		keys = append(keys, aliases...)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.chains[ctx.ChainID.String()]; ok {
+		return fmt.Errorf("%w %s", errDuplicateChain, ctx.ChainID)
+	}
+	chain := &chainImpl{
+		ctx:  ctx,
+		impl: impl,
+	}
+	for _, key := range keys {
+		s.chains[key] = chain
+	}
+	return nil
+}
+
+// chain returns the chain the call [ctx] is routed to
+func (s *chainService) chain(ctx context.Context) (*chainImpl, error) {
+	key, err := chainKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	chain, ok := s.chains[key]
+	s.lock.RUnlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s: %q", errUnknownChain, key)
+	}
+	if chain.ctx.GetState() != snow.NormalOp {
+		return nil, status.Error(codes.Unavailable, errChainNotBootstrapped.Error())
+	}
+	return chain, nil
+}
+
+// routedDesc returns a description of the service described by [desc] whose
+// handlers call the implementation of the chain each call is routed to.
+//
+// Calls are admitted before they're routed, so that unauthorized clients
+// can't learn which chains exist.
+func (s *server) routedDesc(service *chainService, desc *grpc.ServiceDesc) *grpc.ServiceDesc {
+	routed := &grpc.ServiceDesc{
+		ServiceName: desc.ServiceName,
+		// The implementation is the router, not a server of the service
+		HandlerType: (*interface{})(nil),
+		Methods:     make([]grpc.MethodDesc, len(desc.Methods)),
+		Streams:     make([]grpc.StreamDesc, len(desc.Streams)),
+		Metadata:    desc.Metadata,
+	}
+	for i, method := range desc.Methods {
+		handler := method.Handler
+		fullMethod := fmt.Sprintf("/%s/%s", desc.ServiceName, method.MethodName)
+		routed.Methods[i] = grpc.MethodDesc{
+			MethodName: method.MethodName,
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				release, err := s.admit(ctx, fullMethod)
+				if err != nil {
+					return nil, err
+				}
+				defer release()
+
+				chain, err := service.chain(ctx)
+				if err != nil {
+					return nil, err
+				}
+				// The chain's lock is only held while the implementation is
+				// called, not while the call is intercepted.
+				locked := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+					lockedNext := func(ctx context.Context, req interface{}) (interface{}, error) {
+						chain.ctx.Lock.Lock()
+						defer chain.ctx.Lock.Unlock()
+
+						return next(ctx, req)
+					}
+					if interceptor == nil {
+						return lockedNext(ctx, req)
+					}
+					return interceptor(ctx, req, info, lockedNext)
+				}
+				return handler(chain.impl, ctx, dec, locked)
+			},
+		}
+	}
+	for i, stream := range desc.Streams {
+		handler := stream.Handler
+		fullMethod := fmt.Sprintf("/%s/%s", desc.ServiceName, stream.StreamName)
+		routed.Streams[i] = stream
+		// Streams may be long lived, so they're responsible for their own
+		// locking.
+		routed.Streams[i].Handler = func(_ interface{}, serverStream grpc.ServerStream) error {
+			release, err := s.admit(serverStream.Context(), fullMethod)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			chain, err := service.chain(serverStream.Context())
+			if err != nil {
+				return err
+			}
+			return handler(chain.impl, serverStream)
+		}
+	}
+	return routed
+}
+
+// admitUnary admits the calls of the services of the node. The calls of chain
+// services are admitted by their routed handlers.
+func (s *server) admitUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.isChainMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	release, err := s.admit(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return handler(ctx, req)
+}
+
+// admitStream admits the streams of the services of the node, as admitUnary
+// does. Every stream is ended once the server is shut down.
+func (s *server) admitStream(srv interface{}, serverStream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, cancel := context.WithCancel(serverStream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	serverStream = &closingStream{
+		ServerStream: serverStream,
+		ctx:          ctx,
+	}
+
+	if !s.isChainMethod(info.FullMethod) {
+		release, err := s.admit(ctx, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	return handler(srv, serverStream)
+}
+
+// closingStream is a stream whose context is canceled once the server is shut
+// down
+type closingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *closingStream) Context() context.Context {
+	return s.ctx
+}
+
+// isChainMethod returns true if [fullMethod] is a method of a chain service
+func (s *server) isChainMethod(fullMethod string) bool {
+	serviceName, _, err := splitMethod(fullMethod)
+	if err != nil {
+		return false
+	}
+	_, ok := s.chainServices[serviceName]
+	return ok
+}
+
+// admit returns an error if the call [ctx] of [fullMethod] isn't authorized or
+// exceeds the client's rate limits. Otherwise, returns a function that must be
+// called once the call finishes.
+//
+// Calls are authorized and limited as calls of the HTTP API with the same
+// names. The call of "/info.Info/GetNodeID" is treated as the call of
+// info.getNodeID at /ext/info, and the call of "/avm.AVM/GetTx" to the chain X
+// is treated as the call of avm.getTx at /ext/bc/X.
+func (s *server) admit(ctx context.Context, fullMethod string) (func(), error) {
+	if s.authorizer == nil && s.rateLimiter == nil {
+		return func() {}, nil
+	}
+
+	url, method, err := s.apiMethod(ctx, fullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if s.authorizer != nil {
+		if err := s.authorizer.AuthorizeGRPC(ctx, url, method); err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	if s.rateLimiter == nil {
+		return func() {}, nil
+	}
+
+	var remoteAddr, authHeader string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(authKey); len(values) > 0 {
+		authHeader = values[0]
+	}
+	release, err := s.rateLimiter.Acquire(remoteAddr, authHeader, url, method)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return release, nil
+}
+
+// apiMethod returns the URL and the method of the HTTP API call that the call
+// [ctx] of [fullMethod] corresponds to
+func (s *server) apiMethod(ctx context.Context, fullMethod string) (string, string, error) {
+	serviceName, methodName, err := splitMethod(fullMethod)
+	if err != nil {
+		return "", "", err
+	}
+	namespace := serviceName
+	if i := strings.LastIndex(serviceName, "."); i >= 0 {
+		namespace = serviceName[:i]
+	}
+	method := fmt.Sprintf("%s.%s", namespace, lowercaseFirst(methodName))
+
+	url := fmt.Sprintf("%s/%s", baseURL, namespace)
+	if _, ok := s.chainServices[serviceName]; ok {
+		chain, err := chainKey(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		url = fmt.Sprintf("%s/%s/%s", baseURL, constants.ChainAliasPrefix, chain)
+	}
+	return url, method, nil
+}
+
+// chainKey returns the alias or ID of the chain the call [ctx] is routed to
+func chainKey(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(ChainKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", status.Error(codes.InvalidArgument, errNoChain.Error())
+	}
+	return values[0], nil
+}
+
+// splitMethod returns the service and method names of [fullMethod], which is
+// formatted as "/package.Service/Method"
+func splitMethod(fullMethod string) (string, string, error) {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "", "", status.Errorf(codes.Unimplemented, "malformed method %q", fullMethod)
+	}
+	return serviceName, methodName, nil
+}
+
+// lowercaseFirst returns [name] with its first letter lowercased, which is
+// how the HTTP API names methods
+func lowercaseFirst(name string) string {
+	first, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(first)) + name[size:]
+}
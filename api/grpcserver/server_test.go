@@ -0,0 +1,315 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	avmpb "github.com/ava-labs/avalanchego/proto/pb/avm"
+	indexpb "github.com/ava-labs/avalanchego/proto/pb/index"
+)
+
+var errDenied = errors.New("denied")
+
+type call struct {
+	url    string
+	method string
+}
+
+type testAuthorizer struct {
+	calls []call
+	deny  bool
+}
+
+func (a *testAuthorizer) AuthorizeGRPC(_ context.Context, url, method string) error {
+	a.calls = append(a.calls, call{url: url, method: method})
+	if a.deny {
+		return errDenied
+	}
+	return nil
+}
+
+type testRateLimiter struct {
+	calls []call
+	deny  bool
+}
+
+func (l *testRateLimiter) Acquire(_, _, endpoint, method string) (func(), error) {
+	l.calls = append(l.calls, call{url: endpoint, method: method})
+	if l.deny {
+		return nil, errDenied
+	}
+	return func() {}, nil
+}
+
+type testAVMServer struct {
+	avmpb.UnimplementedAVMServer
+
+	balance uint64
+	// If not nil, calls are signaled on [called] and block until [block] is
+	// closed
+	called chan struct{}
+	block  chan struct{}
+}
+
+func (s *testAVMServer) GetBalance(context.Context, *avmpb.GetBalanceRequest) (*avmpb.GetBalanceResponse, error) {
+	if s.block != nil {
+		s.called <- struct{}{}
+		<-s.block
+	}
+	return &avmpb.GetBalanceResponse{Balance: s.balance}, nil
+}
+
+// testIndexServer streams nothing until the stream is ended
+type testIndexServer struct {
+	indexpb.UnimplementedIndexServer
+
+	subscribed chan struct{}
+}
+
+func (s *testIndexServer) Subscribe(_ *indexpb.SubscribeRequest, stream indexpb.Index_SubscribeServer) error {
+	close(s.subscribed)
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+type testVM struct {
+	desc   *grpc.ServiceDesc
+	server interface{}
+}
+
+func (vm *testVM) RegisterGRPCServices(registrar grpc.ServiceRegistrar) error {
+	registrar.RegisterService(vm.desc, vm.server)
+	return nil
+}
+
+func newTestChain(t *testing.T, alias string) *snow.ConsensusContext {
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	aliaser := ids.NewAliaser()
+	assert.NoError(t, aliaser.Alias(ctx.ChainID, alias))
+	ctx.BCLookup = aliaser
+	ctx.SetState(snow.NormalOp)
+	return ctx
+}
+
+// newTestClient serves [s] in memory and returns a client of the AVM service
+func newTestClient(t *testing.T, s Server) avmpb.AVMClient {
+	return avmpb.NewAVMClient(newTestConn(t, s))
+}
+
+// newTestConn serves [s] in memory and returns a connection to it
+func newTestConn(t *testing.T, s Server) *grpc.ClientConn {
+	listener := bufconn.Listen(units.MiB)
+	go func() {
+		_ = s.(*server).server.Serve(listener)
+	}()
+	t.Cleanup(s.Shutdown)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func getBalance(client avmpb.AVMClient, chain string) (uint64, error) {
+	ctx := context.Background()
+	if chain != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ChainKey, chain)
+	}
+	resp, err := client.GetBalance(ctx, &avmpb.GetBalanceRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Balance, nil
+}
+
+func TestChainRouting(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(logging.NoLog{}, nil, nil, time.Second, []*grpc.ServiceDesc{&avmpb.AVM_ServiceDesc})
+	xChain := newTestChain(t, "X")
+	assert.NoError(s.RegisterChain(xChain, &testVM{
+		desc:   &avmpb.AVM_ServiceDesc,
+		server: &testAVMServer{balance: 1},
+	}))
+	yChain := newTestChain(t, "Y")
+	assert.NoError(s.RegisterChain(yChain, &testVM{
+		desc:   &avmpb.AVM_ServiceDesc,
+		server: &testAVMServer{balance: 2},
+	}))
+	client := newTestClient(t, s)
+
+	balance, err := getBalance(client, "X")
+	assert.NoError(err)
+	assert.EqualValues(1, balance)
+
+	balance, err = getBalance(client, yChain.ChainID.String())
+	assert.NoError(err)
+	assert.EqualValues(2, balance)
+
+	_, err = getBalance(client, "")
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = getBalance(client, "Z")
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	yChain.SetState(snow.Bootstrapping)
+	_, err = getBalance(client, "Y")
+	assert.Equal(codes.Unavailable, status.Code(err))
+}
+
+func TestRegisterChain(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(logging.NoLog{}, nil, nil, time.Second, []*grpc.ServiceDesc{&avmpb.AVM_ServiceDesc})
+	ctx := newTestChain(t, "X")
+	vm := &testVM{
+		desc:   &avmpb.AVM_ServiceDesc,
+		server: &testAVMServer{},
+	}
+	assert.NoError(s.RegisterChain(ctx, vm))
+
+	err := s.RegisterChain(ctx, vm)
+	assert.ErrorIs(err, errDuplicateChain)
+
+	unknownDesc := avmpb.AVM_ServiceDesc
+	unknownDesc.ServiceName = "unknown.Unknown"
+	err = s.RegisterChain(newTestChain(t, "Y"), &testVM{
+		desc:   &unknownDesc,
+		server: &testAVMServer{},
+	})
+	assert.ErrorIs(err, errUnknownChainService)
+}
+
+func TestAuthorize(t *testing.T) {
+	assert := assert.New(t)
+
+	authorizer := &testAuthorizer{}
+	s := New(logging.NoLog{}, authorizer, nil, time.Second, []*grpc.ServiceDesc{&avmpb.AVM_ServiceDesc})
+	assert.NoError(s.RegisterChain(newTestChain(t, "X"), &testVM{
+		desc:   &avmpb.AVM_ServiceDesc,
+		server: &testAVMServer{},
+	}))
+	client := newTestClient(t, s)
+
+	_, err := getBalance(client, "X")
+	assert.NoError(err)
+	assert.Equal([]call{{url: "/ext/bc/X", method: "avm.getBalance"}}, authorizer.calls)
+
+	authorizer.deny = true
+	_, err = getBalance(client, "X")
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthorizeBeforeRouting(t *testing.T) {
+	assert := assert.New(t)
+
+	authorizer := &testAuthorizer{deny: true}
+	s := New(logging.NoLog{}, authorizer, nil, time.Second, []*grpc.ServiceDesc{&avmpb.AVM_ServiceDesc})
+	yChain := newTestChain(t, "Y")
+	yChain.SetState(snow.Bootstrapping)
+	assert.NoError(s.RegisterChain(yChain, &testVM{
+		desc:   &avmpb.AVM_ServiceDesc,
+		server: &testAVMServer{},
+	}))
+	client := newTestClient(t, s)
+
+	// Unauthorized clients shouldn't learn which chains exist.
+	_, err := getBalance(client, "Z")
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+	_, err = getBalance(client, "Y")
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func TestRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	rateLimiter := &testRateLimiter{}
+	s := New(logging.NoLog{}, nil, rateLimiter, time.Second, []*grpc.ServiceDesc{&avmpb.AVM_ServiceDesc})
+	assert.NoError(s.RegisterChain(newTestChain(t, "X"), &testVM{
+		desc:   &avmpb.AVM_ServiceDesc,
+		server: &testAVMServer{},
+	}))
+	client := newTestClient(t, s)
+
+	_, err := getBalance(client, "X")
+	assert.NoError(err)
+	assert.Equal([]call{{url: "/ext/bc/X", method: "avm.getBalance"}}, rateLimiter.calls)
+
+	rateLimiter.deny = true
+	_, err = getBalance(client, "X")
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func TestShutdownEndsStreams(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(logging.NoLog{}, nil, nil, time.Minute, nil)
+	indexServer := &testIndexServer{subscribed: make(chan struct{})}
+	indexpb.RegisterIndexServer(s, indexServer)
+	client := indexpb.NewIndexClient(newTestConn(t, s))
+
+	stream, err := client.Subscribe(context.Background(), &indexpb.SubscribeRequest{})
+	assert.NoError(err)
+	<-indexServer.subscribed
+
+	// Shutting down shouldn't wait for the stream to be closed by the client.
+	s.Shutdown()
+	_, err = stream.Recv()
+	assert.Error(err)
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(logging.NoLog{}, nil, nil, 10*time.Millisecond, []*grpc.ServiceDesc{&avmpb.AVM_ServiceDesc})
+	avmServer := &testAVMServer{
+		called: make(chan struct{}, 1),
+		block:  make(chan struct{}),
+	}
+	defer close(avmServer.block)
+	assert.NoError(s.RegisterChain(newTestChain(t, "X"), &testVM{
+		desc:   &avmpb.AVM_ServiceDesc,
+		server: avmServer,
+	}))
+	client := newTestClient(t, s)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := getBalance(client, "X")
+		errs <- err
+	}()
+	<-avmServer.called
+
+	// Shutting down should cancel the call once the timeout elapses.
+	s.Shutdown()
+	assert.Error(<-errs)
+}
@@ -13,6 +13,8 @@ import (
 var _ Client = &client{}
 
 // Client interface for Avalanche Health API Endpoint
+//
+// The reported checks can be filtered by tag with the WithTags option.
 type Client interface {
 	// Readiness returns if the node has finished initialization
 	Readiness(context.Context, ...rpc.Option) (*APIHealthReply, error)
@@ -25,6 +27,15 @@ type Client interface {
 	AwaitHealthy(ctx context.Context, freq time.Duration, options ...rpc.Option) (bool, error)
 }
 
+// WithTags returns an option that only reports the checks with any of [tags]
+func WithTags(tags ...string) rpc.Option {
+	return func(o *rpc.Options) {
+		for _, tag := range tags {
+			o.QueryParams().Add(tagParam, tag)
+		}
+	}
+}
+
 // Client implementation for Avalanche Health API Endpoint
 type client struct {
 	requester rpc.EndpointRequester
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	healthpb "github.com/ava-labs/avalanchego/proto/pb/health"
+)
+
+var _ healthpb.HealthServer = &grpcServer{}
+
+// grpcServer serves the health API over gRPC
+type grpcServer struct {
+	healthpb.UnsafeHealthServer
+	health Reporter
+}
+
+// NewGRPCServer returns a gRPC server of the health API that reports the
+// results of [reporter]
+func NewGRPCServer(reporter Reporter) healthpb.HealthServer {
+	return &grpcServer{health: reporter}
+}
+
+func (s *grpcServer) Readiness(_ context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	return newHealthResponse(req, s.health.Readiness)
+}
+
+func (s *grpcServer) Health(_ context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	return newHealthResponse(req, s.health.Health)
+}
+
+func (s *grpcServer) Liveness(_ context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	return newHealthResponse(req, s.health.Liveness)
+}
+
+// newHealthResponse returns the results of [reporter] filtered by [req]
+func newHealthResponse(req *healthpb.HealthRequest, reporter func(tags ...string) (map[string]Result, bool)) (*healthpb.HealthResponse, error) {
+	args := APIHealthArgs{
+		Tags:   req.Tags,
+		Chains: req.Chains,
+	}
+	checks, healthy, err := args.report(nil, reporter)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	resp := &healthpb.HealthResponse{
+		Checks:  make(map[string]*healthpb.Result, len(checks)),
+		Healthy: healthy,
+	}
+	for name, result := range checks {
+		details, err := json.Marshal(result.Details)
+		if err != nil {
+			return nil, err
+		}
+		pbResult := &healthpb.Result{
+			Details:            details,
+			Timestamp:          timestamppb.New(result.Timestamp),
+			Duration:           int64(result.Duration),
+			ContiguousFailures: result.ContiguousFailures,
+			Tags:               result.Tags,
+		}
+		if result.Error != nil {
+			pbResult.Error = *result.Error
+		}
+		if result.TimeOfFirstFailure != nil {
+			pbResult.TimeOfFirstFailure = timestamppb.New(*result.TimeOfFirstFailure)
+		}
+		resp.Checks[name] = pbResult
+	}
+	return resp, nil
+}
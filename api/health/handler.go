@@ -8,16 +8,33 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
+const (
+	// tagParam is the query parameter of GET requests that filters the
+	// reported checks by tag, e.g. /ext/health?tag=P
+	tagParam = "tag"
+	// chainParam is the query parameter of GET requests that reports the
+	// checks of a chain, identified by its alias or ID, e.g.
+	// /ext/health/readiness?chain=X
+	chainParam = "chain"
+)
+
+// getError is the response to a GET request that can't be reported
+type getError struct {
+	Error string `json:"error"`
+}
+
 // NewGetAndPostHandler returns a health handler that supports GET and jsonrpc
 // POST requests.
 func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler, error) {
-	newServer := rpc.NewServer()
+	newServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -35,7 +52,7 @@ func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler,
 		getHandler.ServeHTTP(w, r)
 	})
 
-	err := newServer.RegisterService(
+	err = newServer.RegisterService(
 		&Service{
 			log:    log,
 			health: reporter,
@@ -46,13 +63,35 @@ func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler,
 }
 
 // NewGetHandler return a health handler that supports GET requests reporting
-// the result of the provided [reporter].
-func NewGetHandler(reporter func() (map[string]Result, bool)) http.Handler {
+// the result of the provided [reporter]. Only the checks with any of the tags
+// in the [tagParam] query parameters are reported, if any are provided.
+//
+// If any [chainParam] query parameters are provided, only the checks of those
+// chains are reported, and the result is healthy only if every one of those
+// chains has a check and all of their checks pass. This allows a load balancer
+// or a Kubernetes probe to route traffic to a chain as soon as that chain is
+// ready, regardless of the other chains.
+func NewGetHandler(reporter func(tags ...string) (map[string]Result, bool)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Make sure the content type is set before writing the header.
 		w.Header().Set("Content-Type", "application/json")
 
-		checks, healthy := reporter()
+		query := r.URL.Query()
+		var (
+			checks  map[string]Result
+			healthy bool
+		)
+		if chains := query[chainParam]; len(chains) > 0 {
+			checks, healthy = chainResults(reporter, chains)
+		} else {
+			var err error
+			checks, healthy, err = tagResults(reporter, query[tagParam])
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				_ = stdjson.NewEncoder(w).Encode(getError{Error: err.Error()})
+				return
+			}
+		}
 		if !healthy {
 			// If a health check has failed, we should return a 503.
 			w.WriteHeader(http.StatusServiceUnavailable)
@@ -65,3 +104,20 @@ func NewGetHandler(reporter func() (map[string]Result, bool)) http.Handler {
 		})
 	})
 }
+
+// chainResults returns the results of the checks of [chains], as reported by
+// [reporter]. The results are only healthy if every chain has at least one
+// check, so that chains that haven't been created yet aren't reported as
+// healthy.
+func chainResults(reporter func(tags ...string) (map[string]Result, bool), chains []string) (map[string]Result, bool) {
+	checks := make(map[string]Result)
+	healthy := true
+	for _, chain := range chains {
+		chainChecks, chainHealthy := reporter(chain)
+		healthy = healthy && chainHealthy && len(chainChecks) > 0
+		for name, result := range chainChecks {
+			checks[name] = result
+		}
+	}
+	return checks, healthy
+}
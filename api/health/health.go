@@ -25,17 +25,19 @@ type Health interface {
 }
 
 // Registerer defines how to register new components to check the health of.
+// Checks can be tagged to group them, such as by the chain they check.
 type Registerer interface {
-	RegisterReadinessCheck(name string, checker Checker) error
-	RegisterHealthCheck(name string, checker Checker) error
-	RegisterLivenessCheck(name string, checker Checker) error
+	RegisterReadinessCheck(name string, checker Checker, tags ...string) error
+	RegisterHealthCheck(name string, checker Checker, tags ...string) error
+	RegisterLivenessCheck(name string, checker Checker, tags ...string) error
 }
 
-// Reporter returns the current health status.
+// Reporter returns the current health status. If tags are provided, only the
+// checks with any of the tags are reported.
 type Reporter interface {
-	Readiness() (map[string]Result, bool)
-	Health() (map[string]Result, bool)
-	Liveness() (map[string]Result, bool)
+	Readiness(tags ...string) (map[string]Result, bool)
+	Health(tags ...string) (map[string]Result, bool)
+	Liveness(tags ...string) (map[string]Result, bool)
 }
 
 type health struct {
@@ -65,20 +67,20 @@ func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
 	}, err
 }
 
-func (h *health) RegisterReadinessCheck(name string, checker Checker) error {
-	return h.readiness.RegisterMonotonicCheck(name, checker)
+func (h *health) RegisterReadinessCheck(name string, checker Checker, tags ...string) error {
+	return h.readiness.RegisterMonotonicCheck(name, checker, tags...)
 }
 
-func (h *health) RegisterHealthCheck(name string, checker Checker) error {
-	return h.health.RegisterCheck(name, checker)
+func (h *health) RegisterHealthCheck(name string, checker Checker, tags ...string) error {
+	return h.health.RegisterCheck(name, checker, tags...)
 }
 
-func (h *health) RegisterLivenessCheck(name string, checker Checker) error {
-	return h.liveness.RegisterCheck(name, checker)
+func (h *health) RegisterLivenessCheck(name string, checker Checker, tags ...string) error {
+	return h.liveness.RegisterCheck(name, checker, tags...)
 }
 
-func (h *health) Readiness() (map[string]Result, bool) {
-	results, healthy := h.readiness.Results()
+func (h *health) Readiness(tags ...string) (map[string]Result, bool) {
+	results, healthy := h.readiness.Results(tags...)
 	if healthy {
 		return results, healthy
 	}
@@ -91,8 +93,8 @@ func (h *health) Readiness() (map[string]Result, bool) {
 	return results, false
 }
 
-func (h *health) Health() (map[string]Result, bool) {
-	results, healthy := h.health.Results()
+func (h *health) Health(tags ...string) (map[string]Result, bool) {
+	results, healthy := h.health.Results(tags...)
 	if healthy {
 		return results, healthy
 	}
@@ -105,8 +107,8 @@ func (h *health) Health() (map[string]Result, bool) {
 	return results, false
 }
 
-func (h *health) Liveness() (map[string]Result, bool) {
-	results, healthy := h.liveness.Results()
+func (h *health) Liveness(tags ...string) (map[string]Result, bool) {
+	results, healthy := h.liveness.Results(tags...)
 	if healthy {
 		return results, healthy
 	}
@@ -6,16 +6,21 @@ package health
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
 
+	stdjson "encoding/json"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
 const (
@@ -273,3 +278,224 @@ func TestDeadlockRegression(t *testing.T) {
 
 	awaitHealthy(h, true)
 }
+
+func TestTags(t *testing.T) {
+	assert := assert.New(t)
+
+	check := CheckerFunc(func() (interface{}, error) {
+		return "", nil
+	})
+	failingCheck := CheckerFunc(func() (interface{}, error) {
+		return "", errors.New("unhealthy")
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	err = h.RegisterHealthCheck("P", check, "P", "primary")
+	assert.NoError(err)
+	err = h.RegisterHealthCheck("X", failingCheck, "X", "primary")
+	assert.NoError(err)
+	err = h.RegisterHealthCheck("network", check, "network")
+	assert.NoError(err)
+
+	h.Start(checkFreq)
+	defer h.Stop()
+
+	// Only the checks with the requested tags should determine the health.
+	for {
+		results, healthy := h.Health("P", "network")
+		if healthy {
+			assert.Len(results, 2)
+			assert.Contains(results, "P")
+			assert.Contains(results, "network")
+			assert.Equal([]string{"P", "primary"}, results["P"].Tags)
+			break
+		}
+		time.Sleep(awaitFreq)
+	}
+
+	results, healthy := h.Health("primary")
+	assert.Len(results, 2)
+	assert.False(healthy)
+
+	results, healthy = h.Health("unknown")
+	assert.Empty(results)
+	assert.True(healthy)
+
+	results, healthy = h.Health()
+	assert.Len(results, 3)
+	assert.False(healthy)
+
+	// GET requests should be filtered by the tag query parameters.
+	handler := NewGetHandler(h.Health)
+	req := httptest.NewRequest(http.MethodGet, "/ext/health?tag=P&tag=network", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(http.StatusOK, rr.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/ext/health?tag=X", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(http.StatusServiceUnavailable, rr.Code)
+
+	// Unknown tags shouldn't be reported as healthy.
+	req = httptest.NewRequest(http.MethodGet, "/ext/health?tag=unknown", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(http.StatusNotFound, rr.Code)
+
+	// JSON-RPC requests should be filtered by the tags in the arguments and in
+	// the query parameters, as set by the WithTags option.
+	service := &Service{
+		log:    logging.NoLog{},
+		health: h,
+	}
+	req = httptest.NewRequest(http.MethodPost, "/ext/health", nil)
+	req.URL.RawQuery = rpc.NewOptions([]rpc.Option{WithTags("network")}).QueryParams().Encode()
+	reply := APIHealthReply{}
+	assert.NoError(service.Health(req, &APIHealthArgs{Tags: []string{"P"}}, &reply))
+	assert.Len(reply.Checks, 2)
+	assert.True(reply.Healthy)
+
+	err = service.Health(httptest.NewRequest(http.MethodPost, "/ext/health", nil), &APIHealthArgs{Tags: []string{"unknown"}}, &reply)
+	assert.ErrorIs(err, errUnknownTags)
+}
+
+func TestChainReadiness(t *testing.T) {
+	assert := assert.New(t)
+
+	check := CheckerFunc(func() (interface{}, error) {
+		return "", nil
+	})
+	failingCheck := CheckerFunc(func() (interface{}, error) {
+		return "", errors.New("not bootstrapped")
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	err = h.RegisterReadinessCheck("bootstrapped-X", check, "X", "xChainID", "primary")
+	assert.NoError(err)
+	err = h.RegisterReadinessCheck("bootstrapped-subnet", failingCheck, "subnet", "subnetChainID", "subnetID")
+	assert.NoError(err)
+
+	h.Start(checkFreq)
+	defer h.Stop()
+
+	for {
+		if _, ready := h.Readiness("X"); ready {
+			break
+		}
+		time.Sleep(awaitFreq)
+	}
+
+	tests := []struct {
+		query        string
+		expectedCode int
+		expectedLen  int
+	}{
+		{
+			query:        "chain=X",
+			expectedCode: http.StatusOK,
+			expectedLen:  1,
+		},
+		{
+			query:        "chain=xChainID",
+			expectedCode: http.StatusOK,
+			expectedLen:  1,
+		},
+		{
+			query:        "chain=subnet",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedLen:  1,
+		},
+		{
+			query:        "chain=X&chain=subnet",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedLen:  2,
+		},
+		{
+			// Chains without checks, such as chains that haven't been created
+			// yet, shouldn't be reported as ready.
+			query:        "chain=unknown",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedLen:  0,
+		},
+		{
+			query:        "",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedLen:  2,
+		},
+	}
+	handler := NewGetHandler(h.Readiness)
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/ext/health/readiness?"+test.query, nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(test.expectedCode, rr.Code, test.query)
+
+		reply := APIHealthReply{}
+		assert.NoError(stdjson.NewDecoder(rr.Body).Decode(&reply))
+		assert.Len(reply.Checks, test.expectedLen, test.query)
+		assert.Equal(test.expectedCode == http.StatusOK, reply.Healthy, test.query)
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		shouldCheckErr bool
+		checkErr       = errors.New("unhealthy")
+	)
+	check := CheckerFunc(func() (interface{}, error) {
+		if shouldCheckErr {
+			return nil, checkErr
+		}
+		return nil, nil
+	})
+
+	w, err := newWorker("health", prometheus.NewRegistry())
+	assert.NoError(err)
+	err = w.RegisterCheck("check", check)
+	assert.NoError(err)
+
+	results, _ := w.Results()
+	assert.Empty(results["check"].Transitions)
+
+	// The first run is a transition, but following runs with the same outcome
+	// aren't.
+	w.runChecks()
+	w.runChecks()
+	results, _ = w.Results()
+	transitions := results["check"].Transitions
+	assert.Len(transitions, 1)
+	assert.Nil(transitions[0].Error)
+	assert.True(results["check"].Timestamp.After(transitions[0].Timestamp))
+
+	shouldCheckErr = true
+	w.runChecks()
+	results, _ = w.Results()
+	transitions = results["check"].Transitions
+	assert.Len(transitions, 2)
+	assert.Equal(checkErr.Error(), *transitions[1].Error)
+	assert.Equal(results["check"].Timestamp, transitions[1].Timestamp)
+
+	// Only the last transitions should be kept.
+	for i := 0; i < 2*maxTransitions; i++ {
+		shouldCheckErr = !shouldCheckErr
+		w.runChecks()
+	}
+	results, _ = w.Results()
+	transitions = results["check"].Transitions
+	assert.Len(transitions, maxTransitions)
+	// The last run failed, so the transitions should alternate ending with a
+	// failure.
+	for i, transition := range transitions {
+		assert.Equal(i%2 == 1, transition.Error != nil)
+		if i > 0 {
+			assert.False(transition.Timestamp.Before(transitions[i-1].Timestamp))
+		}
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"time"
 )
 
+// maxTransitions is the number of transitions of a HealthCheck that are
+// remembered.
+const maxTransitions = 16
+
 // notYetRunResult is the result that is returned when a HealthCheck hasn't been
 // run yet.
 var notYetRunResult Result
@@ -37,4 +41,42 @@ type Result struct {
 
 	// TimeOfFirstFailure of the HealthCheck,
 	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+
+	// Tags the HealthCheck was registered with.
+	Tags []string `json:"tags,omitempty"`
+
+	// Transitions of the HealthCheck between passing and failing, oldest
+	// first. Only the last [maxTransitions] transitions are kept.
+	Transitions []Transition `json:"transitions,omitempty"`
+}
+
+// Transition of a HealthCheck between passing and failing. The first run of a
+// HealthCheck is also a transition.
+type Transition struct {
+	// Timestamp of the HealthCheck that transitioned.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Error is the string representation of the error returned by the
+	// HealthCheck, if it started failing. The value is nil if the check
+	// started passing.
+	Error *string `json:"error,omitempty"`
+
+	// Duration is the amount of time the HealthCheck that transitioned took to
+	// evaluate.
+	Duration time.Duration `json:"duration"`
+}
+
+// hasTag returns true if [r] has any of [tags], or if [tags] is empty.
+func (r *Result) hasTag(tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		for _, resultTag := range r.Tags {
+			if tag == resultTag {
+				return true
+			}
+		}
+	}
+	return false
 }
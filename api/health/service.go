@@ -4,16 +4,54 @@
 package health
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
+var errUnknownTags = errors.New("no checks have any of the tags")
+
 type Service struct {
 	log    logging.Logger
 	health Reporter
 }
 
+// APIHealthArgs are the arguments for Readiness, Health and Liveness
+type APIHealthArgs struct {
+	// If not empty, only the checks with any of [Tags] are reported.
+	Tags []string `json:"tags"`
+	// If not empty, only the checks of [Chains] are reported, and the result
+	// is only healthy if every one of [Chains] has a check. [Tags] is ignored.
+	Chains []string `json:"chains"`
+}
+
+// report returns the results of [reporter] filtered by [args] and, if [r]
+// isn't nil, by the tags in the [tagParam] query parameters of [r].
+func (args *APIHealthArgs) report(r *http.Request, reporter func(tags ...string) (map[string]Result, bool)) (map[string]Result, bool, error) {
+	if len(args.Chains) > 0 {
+		checks, healthy := chainResults(reporter, args.Chains)
+		return checks, healthy, nil
+	}
+	tags := args.Tags
+	if r != nil {
+		tags = append(tags[:len(tags):len(tags)], r.URL.Query()[tagParam]...)
+	}
+	return tagResults(reporter, tags)
+}
+
+// tagResults returns the results of the checks with any of [tags], as reported
+// by [reporter]. Returns an error if [tags] isn't empty and no check has any of
+// them, so that a mistyped tag isn't reported as healthy.
+func tagResults(reporter func(tags ...string) (map[string]Result, bool), tags []string) (map[string]Result, bool, error) {
+	checks, healthy := reporter(tags...)
+	if len(tags) > 0 && len(checks) == 0 {
+		return nil, false, fmt.Errorf("%w: %q", errUnknownTags, tags)
+	}
+	return checks, healthy, nil
+}
+
 // APIHealthReply is the response for Health
 type APIHealthReply struct {
 	Checks  map[string]Result `json:"checks"`
@@ -21,22 +59,25 @@ type APIHealthReply struct {
 }
 
 // Readiness returns if the node has finished initialization
-func (s *Service) Readiness(_ *http.Request, _ *struct{}, reply *APIHealthReply) error {
+func (s *Service) Readiness(r *http.Request, args *APIHealthArgs, reply *APIHealthReply) error {
 	s.log.Debug("Health.readiness called")
-	reply.Checks, reply.Healthy = s.health.Readiness()
-	return nil
+	var err error
+	reply.Checks, reply.Healthy, err = args.report(r, s.health.Readiness)
+	return err
 }
 
 // Health returns a summation of the health of the node
-func (s *Service) Health(_ *http.Request, _ *struct{}, reply *APIHealthReply) error {
+func (s *Service) Health(r *http.Request, args *APIHealthArgs, reply *APIHealthReply) error {
 	s.log.Debug("Health.health called")
-	reply.Checks, reply.Healthy = s.health.Health()
-	return nil
+	var err error
+	reply.Checks, reply.Healthy, err = args.report(r, s.health.Health)
+	return err
 }
 
 // Liveness returns if the node is in need of a restart
-func (s *Service) Liveness(_ *http.Request, _ *struct{}, reply *APIHealthReply) error {
+func (s *Service) Liveness(r *http.Request, args *APIHealthArgs, reply *APIHealthReply) error {
 	s.log.Debug("Health.liveness called")
-	reply.Checks, reply.Healthy = s.health.Liveness()
-	return nil
+	var err error
+	reply.Checks, reply.Healthy, err = args.report(r, s.health.Liveness)
+	return err
 }
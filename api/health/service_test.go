@@ -37,7 +37,7 @@ func TestServiceResponses(t *testing.T) {
 
 	{
 		reply := APIHealthReply{}
-		err = s.Readiness(nil, nil, &reply)
+		err = s.Readiness(nil, &APIHealthArgs{}, &reply)
 		assert.NoError(err)
 
 		assert.Len(reply.Checks, 1)
@@ -48,7 +48,7 @@ func TestServiceResponses(t *testing.T) {
 
 	{
 		reply := APIHealthReply{}
-		err = s.Health(nil, nil, &reply)
+		err = s.Health(nil, &APIHealthArgs{}, &reply)
 		assert.NoError(err)
 
 		assert.Len(reply.Checks, 1)
@@ -59,7 +59,7 @@ func TestServiceResponses(t *testing.T) {
 
 	{
 		reply := APIHealthReply{}
-		err = s.Liveness(nil, nil, &reply)
+		err = s.Liveness(nil, &APIHealthArgs{}, &reply)
 		assert.NoError(err)
 
 		assert.Len(reply.Checks, 1)
@@ -77,7 +77,7 @@ func TestServiceResponses(t *testing.T) {
 
 	{
 		reply := APIHealthReply{}
-		err = s.Readiness(nil, nil, &reply)
+		err = s.Readiness(nil, &APIHealthArgs{}, &reply)
 		assert.NoError(err)
 
 		result := reply.Checks["check"]
@@ -89,7 +89,7 @@ func TestServiceResponses(t *testing.T) {
 
 	{
 		reply := APIHealthReply{}
-		err = s.Health(nil, nil, &reply)
+		err = s.Health(nil, &APIHealthArgs{}, &reply)
 		assert.NoError(err)
 
 		result := reply.Checks["check"]
@@ -101,7 +101,7 @@ func TestServiceResponses(t *testing.T) {
 
 	{
 		reply := APIHealthReply{}
-		err = s.Liveness(nil, nil, &reply)
+		err = s.Liveness(nil, &APIHealthArgs{}, &reply)
 		assert.NoError(err)
 
 		result := reply.Checks["check"]
@@ -39,7 +39,7 @@ func newWorker(namespace string, registerer prometheus.Registerer) (*worker, err
 	}, err
 }
 
-func (w *worker) RegisterCheck(name string, checker Checker) error {
+func (w *worker) RegisterCheck(name string, checker Checker, tags ...string) error {
 	w.checksLock.Lock()
 	defer w.checksLock.Unlock()
 
@@ -51,14 +51,16 @@ func (w *worker) RegisterCheck(name string, checker Checker) error {
 	defer w.resultsLock.Unlock()
 
 	w.checks[name] = checker
-	w.results[name] = notYetRunResult
+	result := notYetRunResult
+	result.Tags = tags
+	w.results[name] = result
 
 	// Whenever a new check is added - it is failing
 	w.metrics.failingChecks.Inc()
 	return nil
 }
 
-func (w *worker) RegisterMonotonicCheck(name string, checker Checker) error {
+func (w *worker) RegisterMonotonicCheck(name string, checker Checker, tags ...string) error {
 	var result utils.AtomicInterface
 	return w.RegisterCheck(name, CheckerFunc(func() (interface{}, error) {
 		details := result.GetValue()
@@ -71,16 +73,22 @@ func (w *worker) RegisterMonotonicCheck(name string, checker Checker) error {
 			result.SetValue(details)
 		}
 		return details, err
-	}))
+	}), tags...)
 }
 
-func (w *worker) Results() (map[string]Result, bool) {
+// Results returns the results of the checks with any of [tags], and whether
+// they're all passing. If [tags] is empty, the results of all the checks are
+// returned.
+func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 	w.resultsLock.RLock()
 	defer w.resultsLock.RUnlock()
 
 	results := make(map[string]Result, len(w.results))
 	healthy := true
 	for name, result := range w.results {
+		if !result.hasTag(tags) {
+			continue
+		}
 		results[name] = result
 		healthy = healthy && result.Error == nil
 	}
@@ -152,6 +160,29 @@ func (w *worker) runCheck(wg *sync.WaitGroup, name string, check Checker) {
 	w.resultsLock.Lock()
 	defer w.resultsLock.Unlock()
 	prevResult := w.results[name]
+	result.Tags = prevResult.Tags
+	result.Transitions = prevResult.Transitions
+	// The first run of the check is always a transition, as the check hasn't
+	// passed or failed before.
+	if prevResult.Timestamp.IsZero() || (err == nil) != (prevResult.Error == nil) {
+		transition := Transition{
+			Timestamp: end,
+			Duration:  result.Duration,
+		}
+		if err != nil {
+			errString := err.Error()
+			transition.Error = &errString
+		}
+		// The transitions are copied, rather than appended to, as the previous
+		// transitions may have been returned by [Results].
+		numKept := len(prevResult.Transitions)
+		if numKept >= maxTransitions {
+			numKept = maxTransitions - 1
+		}
+		transitions := make([]Transition, 0, numKept+1)
+		transitions = append(transitions, prevResult.Transitions[len(prevResult.Transitions)-numKept:]...)
+		result.Transitions = append(transitions, transition)
+	}
 	if err != nil {
 		errString := err.Error()
 		result.Error = &errString
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms"
+
+	infopb "github.com/ava-labs/avalanchego/proto/pb/info"
+)
+
+var _ infopb.InfoServer = &grpcServer{}
+
+// grpcServer serves the read methods of the info API over gRPC
+type grpcServer struct {
+	infopb.UnsafeInfoServer
+	info *Info
+}
+
+// NewGRPCServer returns a gRPC server of the info API
+func NewGRPCServer(
+	parameters Parameters,
+	log logging.Logger,
+	chainManager chains.Manager,
+	vmManager vms.Manager,
+) infopb.InfoServer {
+	return &grpcServer{
+		info: &Info{
+			Parameters:   parameters,
+			log:          log,
+			chainManager: chainManager,
+			vmManager:    vmManager,
+		},
+	}
+}
+
+func (s *grpcServer) GetNodeID(context.Context, *emptypb.Empty) (*infopb.GetNodeIDResponse, error) {
+	reply := GetNodeIDReply{}
+	if err := s.info.GetNodeID(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetNodeIDResponse{
+		NodeId: reply.NodeID.Bytes(),
+	}, nil
+}
+
+func (s *grpcServer) GetNodeVersion(context.Context, *emptypb.Empty) (*infopb.GetNodeVersionResponse, error) {
+	reply := GetNodeVersionReply{}
+	if err := s.info.GetNodeVersion(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetNodeVersionResponse{
+		Version:         reply.Version,
+		DatabaseVersion: reply.DatabaseVersion,
+		VmVersions:      reply.VMVersions,
+	}, nil
+}
+
+func (s *grpcServer) GetNetworkID(context.Context, *emptypb.Empty) (*infopb.GetNetworkIDResponse, error) {
+	return &infopb.GetNetworkIDResponse{
+		NetworkId: s.info.NetworkID,
+	}, nil
+}
+
+func (s *grpcServer) GetBlockchainID(_ context.Context, req *infopb.GetBlockchainIDRequest) (*infopb.GetBlockchainIDResponse, error) {
+	reply := GetBlockchainIDReply{}
+	if err := s.info.GetBlockchainID(nil, &GetBlockchainIDArgs{Alias: req.Alias}, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.GetBlockchainIDResponse{
+		BlockchainId: reply.BlockchainID[:],
+	}, nil
+}
+
+func (s *grpcServer) IsBootstrapped(_ context.Context, req *infopb.IsBootstrappedRequest) (*infopb.IsBootstrappedResponse, error) {
+	reply := IsBootstrappedResponse{}
+	if err := s.info.IsBootstrapped(nil, &IsBootstrappedArgs{Chain: req.Chain}, &reply); err != nil {
+		return nil, err
+	}
+	return &infopb.IsBootstrappedResponse{
+		IsBootstrapped: reply.IsBootstrapped,
+	}, nil
+}
@@ -8,8 +8,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
@@ -64,7 +63,10 @@ func NewService(
 	validators validators.Set,
 	benchlist benchlist.Manager,
 ) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -7,9 +7,8 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
@@ -37,7 +36,10 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer serv
 		ipcs: ipcs,
 	}
 
-	newServer := rpc.NewServer()
+	newServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
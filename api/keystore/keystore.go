@@ -9,8 +9,7 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/encdb"
@@ -120,7 +119,10 @@ func New(log logging.Logger, dbManager manager.Manager) Keystore {
 }
 
 func (ks *keystore) CreateHandler() (http.Handler, error) {
-	newServer := rpc.NewServer()
+	newServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
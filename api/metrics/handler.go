@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Query parameter of the namespaces of the metrics to serve
+const namespaceParam = "namespace"
+
+// NewHandler returns a handler that serves the metrics of [gatherer]. If the
+// request has namespace query parameters, such as ?namespace=avalanche_X, only
+// the metrics of the matching namespaces are served.
+func NewHandler(gatherer MultiGatherer) http.Handler {
+	all := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespaces, ok := r.URL.Query()[namespaceParam]
+		if !ok {
+			all.ServeHTTP(w, r)
+			return
+		}
+		filtered := prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+			return gatherer.GatherNamespaces(namespaces...)
+		})
+		promhttp.HandlerFor(filtered, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	})
+}
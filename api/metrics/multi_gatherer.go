@@ -6,16 +6,25 @@ package metrics
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
 var (
 	errDuplicatedPrefix = errors.New("duplicated prefix")
+	errInvalidLabelName = errors.New("invalid label name")
+	errDuplicatedLabel  = errors.New("metric already has label")
+	errConflictingTypes = errors.New("metrics with the same name have different types")
+
+	labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 
 	_ MultiGatherer = &multiGatherer{}
 )
@@ -28,44 +37,119 @@ type MultiGatherer interface {
 	// Register adds the outputs of [gatherer] to the results of future calls to
 	// Gather with the provided [namespace] added to the metrics.
 	Register(namespace string, gatherer prometheus.Gatherer) error
+
+	// RegisterWithLabels adds the outputs of [gatherer] to the results of
+	// future calls to Gather with the provided [namespace] added to the
+	// metrics and [labels] added to each of their samples. Gatherers
+	// registered with the same namespace but different labels are merged into
+	// the same metrics.
+	RegisterWithLabels(namespace string, labels prometheus.Labels, gatherer prometheus.Gatherer) error
+
+	// GatherNamespaces returns the metrics of the gatherers registered with
+	// one of [namespaces], or with a namespace nested in one of them. For
+	// example, "avalanche_X" matches "avalanche_X" and "avalanche_X_vm", but
+	// not "avalanche_XY".
+	GatherNamespaces(namespaces ...string) ([]*dto.MetricFamily, error)
+}
+
+type labeledGatherer struct {
+	namespace string
+	// Label pairs added to the samples of the gatherer, sorted by name
+	labels   []*dto.LabelPair
+	gatherer prometheus.Gatherer
 }
 
 type multiGatherer struct {
-	lock      sync.RWMutex
-	gatherers map[string]prometheus.Gatherer
+	log  logging.Logger
+	lock sync.RWMutex
+	// Namespace and labels of a gatherer --> the gatherer
+	gatherers map[string]*labeledGatherer
 }
 
-func NewMultiGatherer() MultiGatherer {
+// NewMultiGatherer returns a MultiGatherer. Metrics that can't be merged with
+// the metrics of the other gatherers are dropped from the results and logged to
+// [log].
+func NewMultiGatherer(log logging.Logger) MultiGatherer {
 	return &multiGatherer{
-		gatherers: make(map[string]prometheus.Gatherer),
+		log:       log,
+		gatherers: make(map[string]*labeledGatherer),
 	}
 }
 
 func (g *multiGatherer) Gather() ([]*dto.MetricFamily, error) {
+	return g.gather(func(string) bool { return true })
+}
+
+func (g *multiGatherer) GatherNamespaces(namespaces ...string) ([]*dto.MetricFamily, error) {
+	return g.gather(func(namespace string) bool {
+		for _, filter := range namespaces {
+			if namespace == filter || strings.HasPrefix(namespace, filter+"_") {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// gather returns the metrics of the gatherers whose namespace is [included].
+// Metrics with the same name are merged into one family. A metric that can't be
+// merged is skipped, so that it doesn't fail the whole scrape.
+func (g *multiGatherer) gather(included func(namespace string) bool) ([]*dto.MetricFamily, error) {
 	g.lock.RLock()
 	defer g.lock.RUnlock()
 
-	var results []*dto.MetricFamily
-	for namespace, gatherer := range g.gatherers {
-		metrics, err := gatherer.Gather()
+	// Gather in a deterministic order so that the same metrics are skipped on
+	// every scrape
+	keys := make([]string, 0, len(g.gatherers))
+	for key := range g.gatherers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	families := make(map[string]*dto.MetricFamily)
+	for _, key := range keys {
+		lg := g.gatherers[key]
+		if !included(lg.namespace) {
+			continue
+		}
+		metrics, err := lg.gatherer.Gather()
 		if err != nil {
 			return nil, err
 		}
 		for _, metric := range metrics {
 			var name string
 			if metric.Name != nil {
-				if len(namespace) > 0 {
-					name = fmt.Sprintf("%s_%s", namespace, *metric.Name)
+				if len(lg.namespace) > 0 {
+					name = fmt.Sprintf("%s_%s", lg.namespace, *metric.Name)
 				} else {
 					name = *metric.Name
 				}
 			} else {
-				name = namespace
+				name = lg.namespace
 			}
 			metric.Name = &name
-			results = append(results, metric)
+			if err := addLabels(metric, lg.labels); err != nil {
+				g.log.Warn("skipping metrics of %s: %s", key, err)
+				continue
+			}
+
+			family, exists := families[name]
+			if !exists {
+				families[name] = metric
+				continue
+			}
+			if family.GetType() != metric.GetType() {
+				g.log.Warn("skipping metrics of %s: %s", key, fmt.Errorf("%w: %q", errConflictingTypes, name))
+				continue
+			}
+			family.Metric = append(family.Metric, metric.Metric...)
 		}
 	}
+
+	results := make([]*dto.MetricFamily, 0, len(families))
+	for _, family := range families {
+		results = append(results, family)
+	}
 	// Because we overwrite every metric's name, we are guaranteed that there
 	// are no metrics with nil names.
 	sortMetrics(results)
@@ -73,14 +157,69 @@ func (g *multiGatherer) Gather() ([]*dto.MetricFamily, error) {
 }
 
 func (g *multiGatherer) Register(namespace string, gatherer prometheus.Gatherer) error {
+	return g.RegisterWithLabels(namespace, nil, gatherer)
+}
+
+func (g *multiGatherer) RegisterWithLabels(namespace string, labels prometheus.Labels, gatherer prometheus.Gatherer) error {
+	labelPairs := make([]*dto.LabelPair, 0, len(labels))
+	for name, value := range labels {
+		if !labelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("%w: %q", errInvalidLabelName, name)
+		}
+		name, value := name, value
+		labelPairs = append(labelPairs, &dto.LabelPair{
+			Name:  &name,
+			Value: &value,
+		})
+	}
+	sortLabels(labelPairs)
+	key := gathererKey(namespace, labelPairs)
+
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
-	if _, exists := g.gatherers[namespace]; exists {
+	if _, exists := g.gatherers[key]; exists {
 		return errDuplicatedPrefix
 	}
 
-	g.gatherers[namespace] = gatherer
+	g.gatherers[key] = &labeledGatherer{
+		namespace: namespace,
+		labels:    labelPairs,
+		gatherer:  gatherer,
+	}
+	return nil
+}
+
+// gathererKey returns the key of the gatherer registered with [namespace] and
+// [labels]
+func gathererKey(namespace string, labels []*dto.LabelPair) string {
+	var sb strings.Builder
+	sb.WriteString(namespace)
+	for _, label := range labels {
+		sb.WriteString(fmt.Sprintf("{%s=%q}", label.GetName(), label.GetValue()))
+	}
+	return sb.String()
+}
+
+// addLabels adds [labels] to each sample of [metric]. If a sample already has
+// one of [labels], [metric] is left unchanged.
+func addLabels(metric *dto.MetricFamily, labels []*dto.LabelPair) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	for _, sample := range metric.Metric {
+		for _, label := range labels {
+			for _, existing := range sample.Label {
+				if existing.GetName() == label.GetName() {
+					return fmt.Errorf("%w %q: %q", errDuplicatedLabel, label.GetName(), metric.GetName())
+				}
+			}
+		}
+	}
+	for _, sample := range metric.Metric {
+		sample.Label = append(sample.Label, labels...)
+		sortLabels(sample.Label)
+	}
 	return nil
 }
 
@@ -91,3 +230,9 @@ func (m sortMetricsData) Len() int           { return len(m) }
 func (m sortMetricsData) Swap(i, j int)      { m[j], m[i] = m[i], m[j] }
 
 func sortMetrics(m []*dto.MetricFamily) { sort.Sort(sortMetricsData(m)) }
+
+func sortLabels(labels []*dto.LabelPair) {
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].GetName() < labels[j].GetName()
+	})
+}
@@ -7,15 +7,19 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/assert"
 
 	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
 func TestMultiGathererEmptyGather(t *testing.T) {
 	assert := assert.New(t)
 
-	g := NewMultiGatherer()
+	g := NewMultiGatherer(logging.NoLog{})
 
 	mfs, err := g.Gather()
 	assert.NoError(err)
@@ -25,7 +29,7 @@ func TestMultiGathererEmptyGather(t *testing.T) {
 func TestMultiGathererDuplicatedPrefix(t *testing.T) {
 	assert := assert.New(t)
 
-	g := NewMultiGatherer()
+	g := NewMultiGatherer(logging.NoLog{})
 	og := NewOptionalGatherer()
 
 	err := g.Register("", og)
@@ -41,7 +45,7 @@ func TestMultiGathererDuplicatedPrefix(t *testing.T) {
 func TestMultiGathererAddedError(t *testing.T) {
 	assert := assert.New(t)
 
-	g := NewMultiGatherer()
+	g := NewMultiGatherer(logging.NoLog{})
 
 	expected := errors.New(":(")
 	tg := &testGatherer{
@@ -59,7 +63,7 @@ func TestMultiGathererAddedError(t *testing.T) {
 func TestMultiGathererNoAddedPrefix(t *testing.T) {
 	assert := assert.New(t)
 
-	g := NewMultiGatherer()
+	g := NewMultiGatherer(logging.NoLog{})
 
 	tg := &testGatherer{
 		mfs: []*dto.MetricFamily{{
@@ -79,7 +83,7 @@ func TestMultiGathererNoAddedPrefix(t *testing.T) {
 func TestMultiGathererAddedPrefix(t *testing.T) {
 	assert := assert.New(t)
 
-	g := NewMultiGatherer()
+	g := NewMultiGatherer(logging.NoLog{})
 
 	tg := &testGatherer{
 		mfs: []*dto.MetricFamily{{
@@ -99,7 +103,7 @@ func TestMultiGathererAddedPrefix(t *testing.T) {
 func TestMultiGathererJustPrefix(t *testing.T) {
 	assert := assert.New(t)
 
-	g := NewMultiGatherer()
+	g := NewMultiGatherer(logging.NoLog{})
 
 	tg := &testGatherer{
 		mfs: []*dto.MetricFamily{{}},
@@ -117,7 +121,7 @@ func TestMultiGathererJustPrefix(t *testing.T) {
 func TestMultiGathererSorted(t *testing.T) {
 	assert := assert.New(t)
 
-	g := NewMultiGatherer()
+	g := NewMultiGatherer(logging.NoLog{})
 
 	name0 := "a"
 	name1 := "z"
@@ -141,3 +145,132 @@ func TestMultiGathererSorted(t *testing.T) {
 	assert.Equal(&name0, mfs[0].Name)
 	assert.Equal(&name1, mfs[1].Name)
 }
+
+func TestMultiGathererRegisterWithLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewMultiGatherer(logging.NoLog{})
+
+	gaugeType := dto.MetricType_GAUGE
+	newGatherer := func() *testGatherer {
+		return &testGatherer{
+			mfs: []*dto.MetricFamily{{
+				Name:   &world,
+				Type:   &gaugeType,
+				Metric: []*dto.Metric{{}},
+			}},
+		}
+	}
+
+	err := g.RegisterWithLabels(hello, prometheus.Labels{"chain": "X", "subnet": "P"}, newGatherer())
+	assert.NoError(err)
+
+	err = g.RegisterWithLabels(hello, prometheus.Labels{"chain": "Y", "subnet": "P"}, newGatherer())
+	assert.NoError(err)
+
+	err = g.RegisterWithLabels(hello, prometheus.Labels{"chain": "Y", "subnet": "P"}, newGatherer())
+	assert.Equal(errDuplicatedPrefix, err)
+
+	err = g.RegisterWithLabels(hello, prometheus.Labels{"not-a-label": "Y"}, newGatherer())
+	assert.ErrorIs(err, errInvalidLabelName)
+
+	mfs, err := g.Gather()
+	assert.NoError(err)
+	assert.Len(mfs, 1)
+	assert.Equal(&helloWorld, mfs[0].Name)
+
+	chains := []string(nil)
+	for _, metric := range mfs[0].Metric {
+		assert.Len(metric.Label, 2)
+		assert.Equal("chain", metric.Label[0].GetName())
+		assert.Equal("subnet", metric.Label[1].GetName())
+		assert.Equal("P", metric.Label[1].GetValue())
+		chains = append(chains, metric.Label[0].GetValue())
+	}
+	assert.ElementsMatch([]string{"X", "Y"}, chains)
+}
+
+func TestMultiGathererDuplicatedLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewMultiGatherer(logging.NoLog{})
+
+	label := "chain"
+	tg := &testGatherer{
+		mfs: []*dto.MetricFamily{{
+			Name: &world,
+			Metric: []*dto.Metric{{
+				Label: []*dto.LabelPair{{Name: &label, Value: &label}},
+			}},
+		}},
+	}
+
+	err := g.RegisterWithLabels(hello, prometheus.Labels{label: "X"}, tg)
+	assert.NoError(err)
+	err = g.Register(hello, &testGatherer{
+		mfs: []*dto.MetricFamily{{Name: &hello}},
+	})
+	assert.NoError(err)
+
+	// The metric that already has the label is skipped
+	mfs, err := g.Gather()
+	assert.NoError(err)
+	assert.Len(mfs, 1)
+	assert.Equal("hello_hello", mfs[0].GetName())
+
+	err = addLabels(tg.mfs[0], []*dto.LabelPair{{Name: &label, Value: &label}})
+	assert.ErrorIs(err, errDuplicatedLabel)
+	assert.Len(tg.mfs[0].Metric[0].Label, 1)
+}
+
+func TestMultiGathererConflictingTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewMultiGatherer(logging.NoLog{})
+
+	gaugeType := dto.MetricType_GAUGE
+	counterType := dto.MetricType_COUNTER
+	err := g.RegisterWithLabels(hello, prometheus.Labels{"chain": "X"}, &testGatherer{
+		mfs: []*dto.MetricFamily{{Name: &world, Type: &gaugeType}},
+	})
+	assert.NoError(err)
+	err = g.RegisterWithLabels(hello, prometheus.Labels{"chain": "Y"}, &testGatherer{
+		mfs: []*dto.MetricFamily{{Name: &world, Type: &counterType}},
+	})
+	assert.NoError(err)
+
+	// The metrics of the gatherer gathered last are skipped
+	mfs, err := g.Gather()
+	assert.NoError(err)
+	assert.Len(mfs, 1)
+	assert.Equal(helloWorld, mfs[0].GetName())
+	assert.Equal(gaugeType, mfs[0].GetType())
+}
+
+func TestMultiGathererGatherNamespaces(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewMultiGatherer(logging.NoLog{})
+
+	for _, namespace := range []string{"avalanche", "avalanche_X", "avalanche_X_vm", "avalanche_XY"} {
+		err := g.Register(namespace, &testGatherer{
+			mfs: []*dto.MetricFamily{{}},
+		})
+		assert.NoError(err)
+	}
+
+	mfs, err := g.GatherNamespaces("avalanche_X")
+	assert.NoError(err)
+	assert.Len(mfs, 2)
+	assert.Equal("avalanche_X", mfs[0].GetName())
+	assert.Equal("avalanche_X_vm", mfs[1].GetName())
+
+	mfs, err = g.GatherNamespaces("avalanche_XY", "unknown")
+	assert.NoError(err)
+	assert.Len(mfs, 1)
+	assert.Equal("avalanche_XY", mfs[0].GetName())
+
+	mfs, err = g.GatherNamespaces()
+	assert.NoError(err)
+	assert.Empty(mfs)
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// Version of the OpenRPC specification the documents conform to
+const Version = "1.2.6"
+
+var (
+	errNoMethods = errors.New("service has no methods")
+
+	requestType = reflect.TypeOf(http.Request{})
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Document is an OpenRPC document that describes the methods of JSON-RPC
+// services
+type Document struct {
+	OpenRPC    string     `json:"openrpc"`
+	Info       Info       `json:"info"`
+	Methods    []Method   `json:"methods"`
+	Components Components `json:"components"`
+}
+
+// Info describes the API of a document
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Method describes a JSON-RPC method
+type Method struct {
+	Name string `json:"name"`
+	// The parameters of the method are the properties of the params object of
+	// the request
+	ParamStructure string              `json:"paramStructure"`
+	Params         []ContentDescriptor `json:"params"`
+	Result         ContentDescriptor   `json:"result"`
+}
+
+// ContentDescriptor describes a parameter or the result of a method
+type ContentDescriptor struct {
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+// Components of a document that are referenced by its schemas
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// generator builds the document of a set of services
+type generator struct {
+	services  []string
+	methods   []Method
+	reflector *reflector
+}
+
+func newGenerator() *generator {
+	return &generator{
+		reflector: newReflector(),
+	}
+}
+
+// addService adds the methods of [receiver], as they're served by a gorilla
+// RPC server that registered [receiver] as the service [name], to the
+// document.
+func (g *generator) addService(receiver interface{}, name string) error {
+	receiverType := reflect.TypeOf(receiver)
+	methods := []Method(nil)
+	for i := 0; i < receiverType.NumMethod(); i++ {
+		method := receiverType.Method(i)
+		argsType, replyType, ok := serviceMethodTypes(method)
+		if !ok {
+			continue
+		}
+		methods = append(methods, Method{
+			Name:           fmt.Sprintf("%s.%s", name, lowercaseFirst(method.Name)),
+			ParamStructure: "by-name",
+			Params:         g.params(argsType),
+			Result: ContentDescriptor{
+				Name:   "result",
+				Schema: g.reflector.schema(replyType),
+			},
+		})
+	}
+	if len(methods) == 0 {
+		return fmt.Errorf("%w: %q", errNoMethods, name)
+	}
+	g.services = append(g.services, name)
+	g.methods = append(g.methods, methods...)
+	return nil
+}
+
+// params returns the parameters of a method whose arguments are [argsType]
+func (g *generator) params(argsType reflect.Type) []ContentDescriptor {
+	for argsType.Kind() == reflect.Ptr {
+		argsType = argsType.Elem()
+	}
+	if argsType.Kind() != reflect.Struct {
+		return []ContentDescriptor{{
+			Name:   "params",
+			Schema: g.reflector.schema(argsType),
+		}}
+	}
+
+	fields := g.reflector.fields(argsType)
+	params := make([]ContentDescriptor, len(fields))
+	for i, field := range fields {
+		params[i] = ContentDescriptor{
+			Name:   field.name,
+			Schema: field.schema,
+		}
+	}
+	return params
+}
+
+// document returns the document of the added services
+func (g *generator) document() *Document {
+	services := make([]string, len(g.services))
+	copy(services, g.services)
+	sort.Strings(services)
+
+	methods := make([]Method, len(g.methods))
+	copy(methods, g.methods)
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	schemas := make(map[string]*Schema, len(g.reflector.components))
+	for name, schema := range g.reflector.components {
+		schemas[name] = schema
+	}
+	return &Document{
+		OpenRPC: Version,
+		Info: Info{
+			Title:   strings.Join(services, ", "),
+			Version: version.Current.String(),
+		},
+		Methods: methods,
+		Components: Components{
+			Schemas: schemas,
+		},
+	}
+}
+
+// serviceMethodTypes returns the types of the arguments and reply of
+// [method], if it's served by gorilla RPC servers. Such methods have the
+// signature:
+//
+//	func (*Receiver) Method(*http.Request, *Args, *Reply) error
+func serviceMethodTypes(method reflect.Method) (reflect.Type, reflect.Type, bool) {
+	methodType := method.Type
+	if method.PkgPath != "" || methodType.NumIn() != 4 || methodType.NumOut() != 1 {
+		return nil, nil, false
+	}
+	if req := methodType.In(1); req.Kind() != reflect.Ptr || req.Elem() != requestType {
+		return nil, nil, false
+	}
+	argsType, replyType := methodType.In(2), methodType.In(3)
+	if argsType.Kind() != reflect.Ptr || replyType.Kind() != reflect.Ptr || methodType.Out(0) != errorType {
+		return nil, nil, false
+	}
+	return argsType.Elem(), replyType.Elem(), true
+}
+
+// lowercaseFirst returns [name] with its first letter lowercased, which is
+// how the JSON codec expects methods to be called
+func lowercaseFirst(name string) string {
+	first, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(first)) + name[size:]
+}
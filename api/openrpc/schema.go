@@ -0,0 +1,232 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const schemasRef = "#/components/schemas/"
+
+var (
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+
+	// invalidNameChars are the characters that can't be in the name of a
+	// component of an OpenRPC document
+	invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]`)
+)
+
+// Schema is a JSON Schema that describes the JSON encoding of a Go type
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// reflector builds the schemas of Go types. The schemas of named struct types
+// are defined once, as components, and referenced by the schemas that use
+// them, which also allows recursive types to be described.
+type reflector struct {
+	// Name of the component of each named struct type
+	names map[reflect.Type]string
+	// Type of each component name, to detect name collisions
+	types      map[string]reflect.Type
+	components map[string]*Schema
+}
+
+func newReflector() *reflector {
+	return &reflector{
+		names:      make(map[reflect.Type]string),
+		types:      make(map[string]reflect.Type),
+		components: make(map[string]*Schema),
+	}
+}
+
+// schema returns the schema of the JSON encoding of [t]
+func (r *reflector) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType):
+		return marshalerSchema(t)
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		minimum := 0
+		return &Schema{Type: "integer", Minimum: &minimum}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		// Byte slices are encoded as base64 strings
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PtrTo(t.Elem()).Implements(marshalerType) {
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: schemasRef + r.component(t)}
+	default:
+		// Interfaces can hold any value. Channels and functions can't be
+		// encoded, so they aren't restricted either.
+		return &Schema{}
+	}
+}
+
+// component returns the name of the component that defines the named struct
+// type [t], defining it if it hasn't been defined yet
+func (r *reflector) component(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+
+	pkgPath := strings.Split(t.PkgPath(), "/")
+	baseName := invalidNameChars.ReplaceAllString(fmt.Sprintf("%s.%s", pkgPath[len(pkgPath)-1], t.Name()), "_")
+	name := baseName
+	for i := 1; r.types[name] != nil; i++ {
+		name = fmt.Sprintf("%s-%d", baseName, i)
+	}
+	r.names[t] = name
+	r.types[name] = t
+
+	// The name is registered before the schema is built so that recursive
+	// references to [t] refer to the component.
+	r.components[name] = r.structSchema(t)
+	return name
+}
+
+// structSchema returns the schema of the struct type [t]
+func (r *reflector) structSchema(t reflect.Type) *Schema {
+	fields := r.fields(t)
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema, len(fields)),
+	}
+	for _, field := range fields {
+		schema.Properties[field.name] = field.schema
+	}
+	return schema
+}
+
+type field struct {
+	name   string
+	schema *Schema
+}
+
+// fields returns the fields of the JSON encoding of the struct type [t], in
+// the order they're encoded. As with encoding/json, the fields of embedded
+// structs are promoted unless they're shadowed by a field of [t].
+func (r *reflector) fields(t reflect.Type) []field {
+	var (
+		fields   []field
+		names    = make(map[string]bool)
+		embedded []reflect.Type
+	)
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag := structField.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fieldType := structField.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if structField.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded = append(embedded, fieldType)
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+
+		schema := r.schema(structField.Type)
+		if strings.Contains(opts, "string") {
+			schema = &Schema{Type: "string"}
+		}
+		fields = append(fields, field{
+			name:   name,
+			schema: schema,
+		})
+		names[name] = true
+	}
+	for _, embeddedType := range embedded {
+		for _, field := range r.fields(embeddedType) {
+			if names[field.name] {
+				continue
+			}
+			fields = append(fields, field)
+			names[field.name] = true
+		}
+	}
+	return fields
+}
+
+// marshalerSchema returns the schema of a type that implements its own JSON
+// encoding. The schema is inferred from the encoding of the zero value of the
+// type.
+func marshalerSchema(t reflect.Type) (schema *Schema) {
+	// A zero value may not be encodable, in which case the encoding isn't
+	// restricted.
+	defer func() {
+		if recover() != nil {
+			schema = &Schema{}
+		}
+	}()
+
+	encoded, err := json.Marshal(reflect.New(t).Interface())
+	if err != nil || len(encoded) == 0 {
+		return &Schema{}
+	}
+	switch encoded[0] {
+	case '"':
+		return &Schema{Type: "string"}
+	case '[':
+		return &Schema{Type: "array", Items: &Schema{}}
+	case '{':
+		return &Schema{Type: "object"}
+	case 't', 'f':
+		return &Schema{Type: "boolean"}
+	case 'n':
+		return &Schema{}
+	default:
+		return &Schema{Type: "number"}
+	}
+}
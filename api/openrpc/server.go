@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/rpc/v2"
+)
+
+// discoveryService is the name of the service that serves the document of a
+// server. Its method is called as "rpc.discover".
+const discoveryService = "rpc"
+
+// Server is a gorilla RPC server that describes the services registered with
+// it in an OpenRPC document, which is served by the rpc.discover method.
+type Server struct {
+	*rpc.Server
+
+	lock      sync.RWMutex
+	generator *generator
+}
+
+// NewServer returns a new server that serves the rpc.discover method
+func NewServer() (*Server, error) {
+	s := &Server{
+		Server:    rpc.NewServer(),
+		generator: newGenerator(),
+	}
+	return s, s.Server.RegisterService(&Discovery{server: s}, discoveryService)
+}
+
+// RegisterService registers [receiver] as the service [name], and adds its
+// methods to the document of the server.
+func (s *Server) RegisterService(receiver interface{}, name string) error {
+	if err := s.Server.RegisterService(receiver, name); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.generator.addService(receiver, name)
+}
+
+// Document returns the document of the services registered with the server
+func (s *Server) Document() *Document {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.generator.document()
+}
+
+// Discovery serves the document of a server
+type Discovery struct {
+	server *Server
+}
+
+// Discover returns the OpenRPC document of the services of the server
+func (d *Discovery) Discover(_ *http.Request, _ *struct{}, reply *Document) error {
+	*reply = *d.server.Document()
+	return nil
+}
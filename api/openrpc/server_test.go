@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	stdjson "encoding/json"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+type Embedded struct {
+	Address  string `json:"address"`
+	Shadowed string `json:"name"`
+}
+
+type Node struct {
+	Children []*Node `json:"children"`
+}
+
+type TestArgs struct {
+	Embedded
+
+	Name     string         `json:"name"`
+	ID       ids.ID         `json:"id"`
+	Amount   json.Uint64    `json:"amount"`
+	Bytes    []byte         `json:"bytes"`
+	Time     time.Time      `json:"time"`
+	Values   map[string]int `json:"values"`
+	Tree     Node           `json:"tree"`
+	Quoted   int            `json:"quoted,string"`
+	Untagged bool
+	Skipped  string `json:"-"`
+}
+
+type TestReply struct {
+	Tree *Node `json:"tree"`
+}
+
+type testService struct{}
+
+func (*testService) Call(_ *http.Request, _ *TestArgs, _ *TestReply) error { return nil }
+
+func (*testService) NoArgs(_ *http.Request, _ *struct{}, _ *TestReply) error { return nil }
+
+// NotAMethod isn't served as it doesn't have the signature of a service method
+func (*testService) NotAMethod() {}
+
+func TestDiscover(t *testing.T) {
+	assert := assert.New(t)
+
+	server, err := NewServer()
+	assert.NoError(err)
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	assert.NoError(server.RegisterService(&testService{}, "test"))
+
+	body := []byte(`{"jsonrpc":"2.0","method":"rpc.discover","params":{},"id":1}`)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	assert.Equal(http.StatusOK, rr.Code)
+
+	reply := struct {
+		Result Document `json:"result"`
+	}{}
+	assert.NoError(stdjson.Unmarshal(rr.Body.Bytes(), &reply))
+	doc := reply.Result
+
+	assert.Equal(Version, doc.OpenRPC)
+	assert.Equal("test", doc.Info.Title)
+	assert.Len(doc.Methods, 2)
+
+	call := doc.Methods[0]
+	assert.Equal("test.call", call.Name)
+	assert.Equal("by-name", call.ParamStructure)
+	params := make(map[string]*Schema, len(call.Params))
+	for _, param := range call.Params {
+		params[param.Name] = param.Schema
+	}
+	assert.Len(params, 10)
+	// The embedded field is shadowed by the outer field.
+	assert.Equal("string", params["name"].Type)
+	assert.Equal("string", params["address"].Type)
+	assert.Equal("string", params["id"].Type)
+	assert.Equal("string", params["amount"].Type)
+	assert.Equal("base64", params["bytes"].ContentEncoding)
+	assert.Equal("date-time", params["time"].Format)
+	assert.Equal("integer", params["values"].AdditionalProperties.Type)
+	assert.Equal("string", params["quoted"].Type)
+	assert.Equal("boolean", params["Untagged"].Type)
+	assert.Equal(schemasRef+"openrpc.Node", params["tree"].Ref)
+	assert.Equal(schemasRef+"openrpc.TestReply", call.Result.Schema.Ref)
+
+	noArgs := doc.Methods[1]
+	assert.Equal("test.noArgs", noArgs.Name)
+	assert.Empty(noArgs.Params)
+
+	// Recursive types should refer to their own component.
+	node := doc.Components.Schemas["openrpc.Node"]
+	assert.Equal(schemasRef+"openrpc.Node", node.Properties["children"].Items.Ref)
+	assert.Contains(doc.Components.Schemas, "openrpc.TestReply")
+}
+
+func TestRegisterServiceWithoutMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	server, err := NewServer()
+	assert.NoError(err)
+	err = server.RegisterService(&Embedded{}, "embedded")
+	assert.Error(err)
+}
@@ -247,23 +247,23 @@ func (mr *MockServerMockRecorder) Dispatch() *gomock.Call {
 }
 
 // DispatchTLS mocks base method.
-func (m *MockServer) DispatchTLS(certBytes, keyBytes []byte) error {
+func (m *MockServer) DispatchTLS(certBytes, keyBytes []byte, clientAuth ClientAuthConfig) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DispatchTLS", certBytes, keyBytes)
+	ret := m.ctrl.Call(m, "DispatchTLS", certBytes, keyBytes, clientAuth)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DispatchTLS indicates an expected call of DispatchTLS.
-func (mr *MockServerMockRecorder) DispatchTLS(certBytes, keyBytes interface{}) *gomock.Call {
+func (mr *MockServerMockRecorder) DispatchTLS(certBytes, keyBytes, clientAuth interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTLS", reflect.TypeOf((*MockServer)(nil).DispatchTLS), certBytes, keyBytes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTLS", reflect.TypeOf((*MockServer)(nil).DispatchTLS), certBytes, keyBytes, clientAuth)
 }
 
 // Initialize mocks base method.
-func (m *MockServer) Initialize(log logging.Logger, factory logging.Factory, host string, port uint16, allowedOrigins []string, shutdownTimeout time.Duration, nodeID ids.NodeID, wrappers ...Wrapper) {
+func (m *MockServer) Initialize(log logging.Logger, factory logging.Factory, host string, port uint16, allowedOrigins []string, shutdownTimeout time.Duration, nodeID ids.NodeID, rateLimiter *RateLimiter, maxRequestSize int64, maxBatchSize int, wrappers ...Wrapper) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID}
+	varargs := []interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID, rateLimiter, maxRequestSize, maxBatchSize}
 	for _, a := range wrappers {
 		varargs = append(varargs, a)
 	}
@@ -271,9 +271,9 @@ func (m *MockServer) Initialize(log logging.Logger, factory logging.Factory, hos
 }
 
 // Initialize indicates an expected call of Initialize.
-func (mr *MockServerMockRecorder) Initialize(log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID interface{}, wrappers ...interface{}) *gomock.Call {
+func (mr *MockServerMockRecorder) Initialize(log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID, rateLimiter, maxRequestSize, maxBatchSize interface{}, wrappers ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID}, wrappers...)
+	varargs := append([]interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID, rateLimiter, maxRequestSize, maxBatchSize}, wrappers...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initialize", reflect.TypeOf((*MockServer)(nil).Initialize), varargs...)
 }
 
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NewOriginChecker returns a function that reports whether a request may be
+// made from its Origin. Origins are matched the same way the CORS handler of
+// the API server matches them: "*" allows every origin, and an origin may
+// contain one "*" wildcard, such as "https://*.avax.network". Requests without
+// an Origin header, which aren't made by browsers, are allowed.
+func NewOriginChecker(allowedOrigins []string) func(*http.Request) bool {
+	var (
+		allowAll  = len(allowedOrigins) == 0
+		exact     = make(map[string]struct{})
+		wildcards [][2]string
+	)
+	for _, origin := range allowedOrigins {
+		origin = strings.ToLower(origin)
+		switch i := strings.IndexByte(origin, '*'); {
+		case origin == "*":
+			allowAll = true
+		case i >= 0:
+			wildcards = append(wildcards, [2]string{origin[:i], origin[i+1:]})
+		default:
+			exact[origin] = struct{}{}
+		}
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if allowAll || origin == "" {
+			return true
+		}
+		origin = strings.ToLower(origin)
+		if _, ok := exact[origin]; ok {
+			return true
+		}
+		for _, w := range wildcards {
+			prefix, suffix := w[0], w[1]
+			if len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+		return false
+	}
+}
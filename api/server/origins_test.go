@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginChecker(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		allowed        bool
+	}{
+		{
+			name:           "no origin",
+			allowedOrigins: []string{"https://example.com"},
+			allowed:        true,
+		},
+		{
+			name:           "all origins",
+			allowedOrigins: []string{"*"},
+			origin:         "https://example.com",
+			allowed:        true,
+		},
+		{
+			name:    "no allowed origins",
+			origin:  "https://example.com",
+			allowed: true,
+		},
+		{
+			name:           "exact match",
+			allowedOrigins: []string{"https://Example.com"},
+			origin:         "https://example.COM",
+			allowed:        true,
+		},
+		{
+			name:           "wildcard match",
+			allowedOrigins: []string{"https://*.avax.network"},
+			origin:         "https://api.avax.network",
+			allowed:        true,
+		},
+		{
+			name:           "wildcard mismatch",
+			allowedOrigins: []string{"https://*.avax.network"},
+			origin:         "https://avax.network.evil.com",
+			allowed:        false,
+		},
+		{
+			name:           "mismatch",
+			allowedOrigins: []string{"https://example.com"},
+			origin:         "https://evil.com",
+			allowed:        false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.origin != "" {
+				r.Header.Set("Origin", test.origin)
+			}
+			assert.Equal(t, test.allowed, NewOriginChecker(test.allowedOrigins)(r))
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/cache"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	// defaultMaxRateLimitedClients is the default number of clients whose
+	// limits are tracked at once.
+	defaultMaxRateLimitedClients = 64 * 1024
+
+	defaultLimitName = "default"
+
+	authHeaderKey   = "Authorization"
+	authHeaderStart = "Bearer "
+
+	rateLimitedMsg        = "API call rejected because the client exceeded its rate limit"
+	concurrencyLimitedMsg = "API call rejected because the client has too many API calls in progress"
+)
+
+var (
+	errRateLimited        = errors.New(rateLimitedMsg)
+	errConcurrencyLimited = errors.New(concurrencyLimitedMsg)
+)
+
+// RateLimit limits the API calls a client makes. A limit of 0 isn't enforced.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which a client may make API calls.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Burst is the number of API calls a client may make at once before being
+	// limited to [RequestsPerSecond]. Defaults to [RequestsPerSecond], rounded
+	// up.
+	Burst int `json:"burst"`
+	// MaxConcurrent is the number of API calls a client may have in progress.
+	MaxConcurrent int `json:"maxConcurrent"`
+}
+
+func (l RateLimit) isZero() bool {
+	return l.RequestsPerSecond <= 0 && l.MaxConcurrent <= 0
+}
+
+func (l RateLimit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Ceil(l.RequestsPerSecond))
+}
+
+// RateLimitConfig configures the limits enforced on the API calls made by
+// each client. Clients are identified by their IP, or by their auth token if
+// [LimitByToken] is set.
+type RateLimitConfig struct {
+	// RateLimit is shared by all the API calls a client makes that don't have
+	// an override.
+	RateLimit
+	// Endpoints overrides the limit of the calls to an endpoint, such as
+	// "/ext/bc/X" or "/ext/info".
+	Endpoints map[string]RateLimit `json:"endpoints"`
+	// Methods overrides the limit of the calls to a JSON-RPC method, such as
+	// "avm.getUTXOs". Takes precedence over [Endpoints].
+	Methods map[string]RateLimit `json:"methods"`
+	// MaxClients is the number of clients whose limits are tracked at once.
+	// If more clients make calls, the limits of the least recently seen
+	// clients are reset.
+	MaxClients int `json:"maxClients"`
+
+	// LimitByToken identifies clients by their auth token, if they provided
+	// one. Should only be set if auth tokens are verified.
+	LimitByToken bool `json:"-"`
+}
+
+// clientLimit is the key of a client's state for a limit.
+type clientLimit struct {
+	client string
+	limit  string
+}
+
+type clientLimiter struct {
+	// accessed atomically
+	inProgress int64
+
+	limiter *rate.Limiter
+}
+
+// RateLimiter enforces per-client limits on the calls made to the API
+type RateLimiter struct {
+	config RateLimitConfig
+
+	// lock ensures that concurrent calls from a new client share its state
+	lock    sync.Mutex
+	clients cache.TypedCacher[clientLimit, *clientLimiter]
+
+	rejected *prometheus.CounterVec
+}
+
+// NewRateLimiter returns a rate limiter that enforces [config] and registers
+// its metrics with [registerer].
+func NewRateLimiter(
+	config RateLimitConfig,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*RateLimiter, error) {
+	maxClients := config.MaxClients
+	if maxClients <= 0 {
+		maxClients = defaultMaxRateLimitedClients
+	}
+	l := &RateLimiter{
+		config: config,
+		clients: &cache.SizedLRU[clientLimit, *clientLimiter]{
+			MaxSize: maxClients,
+		},
+		rejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rejected",
+				Help:      "Number of API calls rejected for exceeding a limit",
+			},
+			[]string{"reason", "limit"},
+		),
+	}
+	return l, registerer.Register(l.rejected)
+}
+
+// rateLimitMiddleware wraps a handler by rejecting calls from clients that
+// exceed their limits. [endpoints] are the URLs the handler is served at.
+func rateLimitMiddleware(handler http.Handler, limiter *RateLimiter, endpoints ...string) http.Handler {
+	if limiter == nil {
+		return handler
+	}
+
+	// Find the endpoint override, if any, once rather than on every call.
+	endpointLimit, endpointLimitName := limiter.endpointLimit(endpoints...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			call      *rpcCall
+			limit     = endpointLimit
+			limitName = endpointLimitName
+		)
+		if len(limiter.config.Methods) > 0 {
+			call = readCall(r)
+			limit, limitName = limiter.methodLimit(call.Method, limit, limitName)
+		}
+
+		client := limiter.client(r.RemoteAddr, r.Header.Get(authHeaderKey))
+		release, err := limiter.acquire(client, limit, limitName)
+		if err != nil {
+			if call == nil {
+				call = readCall(r)
+			}
+			writeLimitError(w, call.ID, err.Error())
+			return
+		}
+		defer release()
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Acquire starts a call of [method] at [endpoint] by the client at
+// [remoteAddr], which may have provided the Authorization header [authHeader].
+// Returns a function that must be called once the call finishes, or an error
+// if the call exceeds the client's limits.
+//
+// This allows limiting the calls made over other protocols than HTTP, such as
+// gRPC, with the same limits.
+func (l *RateLimiter) Acquire(remoteAddr, authHeader, endpoint, method string) (func(), error) {
+	limit, limitName := l.endpointLimit(endpoint)
+	limit, limitName = l.methodLimit(method, limit, limitName)
+	return l.acquire(l.client(remoteAddr, authHeader), limit, limitName)
+}
+
+// endpointLimit returns the limit of the calls to [endpoints] and its name
+func (l *RateLimiter) endpointLimit(endpoints ...string) (RateLimit, string) {
+	for _, endpoint := range endpoints {
+		if limit, ok := l.config.Endpoints[endpoint]; ok {
+			return limit, endpoint
+		}
+	}
+	return l.config.RateLimit, defaultLimitName
+}
+
+// methodLimit returns the limit of the calls of [method] and its name. If
+// [method] doesn't have an override, [limit] is returned.
+func (l *RateLimiter) methodLimit(method string, limit RateLimit, limitName string) (RateLimit, string) {
+	if methodLimit, ok := l.config.Methods[method]; ok {
+		return methodLimit, method
+	}
+	return limit, limitName
+}
+
+// acquire starts a call by [client] that's subject to [limit]. Returns a
+// function that must be called once the call finishes, or an error if the call
+// exceeds [limit].
+func (l *RateLimiter) acquire(client string, limit RateLimit, limitName string) (func(), error) {
+	if limit.isZero() {
+		return func() {}, nil
+	}
+
+	// The concurrency limit is checked first, so that calls rejected for it
+	// don't use up the client's rate limit.
+	limiter := l.clientLimiter(client, limit, limitName)
+	inProgress := atomic.AddInt64(&limiter.inProgress, 1)
+	release := func() {
+		atomic.AddInt64(&limiter.inProgress, -1)
+	}
+	if limit.MaxConcurrent > 0 && inProgress > int64(limit.MaxConcurrent) {
+		release()
+		l.rejected.WithLabelValues("concurrency", limitName).Inc()
+		return nil, errConcurrencyLimited
+	}
+	if limiter.limiter != nil && !limiter.limiter.Allow() {
+		release()
+		l.rejected.WithLabelValues("rate", limitName).Inc()
+		return nil, errRateLimited
+	}
+	return release, nil
+}
+
+// clientLimiter returns the state of [limit] for [client]
+func (l *RateLimiter) clientLimiter(client string, limit RateLimit, limitName string) *clientLimiter {
+	key := clientLimit{
+		client: client,
+		limit:  limitName,
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	c, ok := l.clients.Get(key)
+	if !ok {
+		c = &clientLimiter{}
+		if limit.RequestsPerSecond > 0 {
+			c.limiter = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.burst())
+		}
+		l.clients.Put(key, c)
+	}
+	return c
+}
+
+// client returns the identity of the client at [remoteAddr] that provided the
+// Authorization header [authHeader]
+func (l *RateLimiter) client(remoteAddr, authHeader string) string {
+	if l.config.LimitByToken && strings.HasPrefix(authHeader, authHeaderStart) {
+		return "token:" + strings.TrimPrefix(authHeader, authHeaderStart)
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "ip:" + host
+}
+
+// rpcCall is the part of a JSON-RPC request that the rate limiter uses
+type rpcCall struct {
+	Method string          `json:"method"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// readCall returns the JSON-RPC call made by [r]. The fields of the call are
+// empty if [r] isn't a JSON-RPC request. The body of [r] isn't consumed.
+func readCall(r *http.Request) *rpcCall {
+	call := &rpcCall{}
+	if r.Method != http.MethodPost {
+		return call
+	}
+
+	body, err := cjson.RequestBody(r)
+	if err != nil {
+		return call
+	}
+
+	_ = json.Unmarshal(body, call)
+	return call
+}
+
+// limitError is the JSON-RPC response to a call that was rejected for
+// exceeding a limit
+type limitError struct {
+	Version string          `json:"jsonrpc"`
+	Error   *json2.Error    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// writeLimitError responds to the call [id] with a JSON-RPC error
+func writeLimitError(w http.ResponseWriter, id json.RawMessage, message string) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusTooManyRequests)
+	// Doesn't matter if there's an error while writing. They'll get the
+	// StatusTooManyRequests code.
+	_ = json.NewEncoder(w).Encode(limitError{
+		Version: json2.Version,
+		Error: &json2.Error{
+			Code:    json2.E_SERVER,
+			Message: message,
+		},
+		ID: id,
+	})
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRateLimiter(t *testing.T, config RateLimitConfig) *RateLimiter {
+	limiter, err := NewRateLimiter(config, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return limiter
+}
+
+// call calls [handler] from [remoteAddr] and returns the response code
+func call(handler http.Handler, remoteAddr, body string, header http.Header) int {
+	r := httptest.NewRequest(http.MethodPost, "/ext/info", strings.NewReader(body))
+	r.RemoteAddr = remoteAddr
+	for key, values := range header {
+		r.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestRateLimitPerClient(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newTestRateLimiter(t, RateLimitConfig{
+		RateLimit: RateLimit{
+			RequestsPerSecond: 0.001,
+			Burst:             2,
+		},
+	})
+	h := rateLimitMiddleware(&testHandler{}, limiter, "/ext/info")
+
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", "", nil))
+	// Calls from other ports of the same IP should share the limit.
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:2", "", nil))
+	assert.Equal(http.StatusTooManyRequests, call(h, "1.2.3.4:1", "", nil))
+	// Other clients shouldn't be limited.
+	assert.Equal(http.StatusOK, call(h, "5.6.7.8:1", "", nil))
+
+	// Tokens shouldn't identify clients unless they're verified.
+	header := http.Header{authHeaderKey: []string{authHeaderStart + "token"}}
+	assert.Equal(http.StatusTooManyRequests, call(h, "1.2.3.4:1", "", header))
+	assert.EqualValues(2, testutil.ToFloat64(limiter.rejected.WithLabelValues("rate", defaultLimitName)))
+}
+
+func TestRateLimitByToken(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newTestRateLimiter(t, RateLimitConfig{
+		RateLimit: RateLimit{
+			RequestsPerSecond: 0.001,
+			Burst:             1,
+		},
+		LimitByToken: true,
+	})
+	h := rateLimitMiddleware(&testHandler{}, limiter, "/ext/info")
+
+	token1 := http.Header{authHeaderKey: []string{authHeaderStart + "token1"}}
+	token2 := http.Header{authHeaderKey: []string{authHeaderStart + "token2"}}
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", "", token1))
+	assert.Equal(http.StatusTooManyRequests, call(h, "5.6.7.8:1", "", token1))
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", "", token2))
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", "", nil))
+}
+
+func TestRateLimitOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newTestRateLimiter(t, RateLimitConfig{
+		RateLimit: RateLimit{
+			RequestsPerSecond: 0.001,
+			Burst:             1,
+		},
+		Endpoints: map[string]RateLimit{
+			"/ext/bc/X": {},
+		},
+		Methods: map[string]RateLimit{
+			"avm.getUTXOs": {
+				RequestsPerSecond: 0.001,
+				Burst:             1,
+			},
+		},
+	})
+
+	// The chain's endpoint should be unlimited, whichever alias configured it,
+	// except for the overridden method.
+	handler := &bodyHandler{}
+	h := rateLimitMiddleware(handler, limiter, "/ext/bc/chainID", "/ext/bc/X")
+	getUTXOs := `{"jsonrpc":"2.0","id":1,"method":"avm.getUTXOs","params":{}}`
+	getBalance := `{"jsonrpc":"2.0","id":1,"method":"avm.getBalance","params":{}}`
+	for i := 0; i < 3; i++ {
+		assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", getBalance, nil))
+	}
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", getUTXOs, nil))
+	// The handler should still be able to read the body.
+	assert.Equal(getUTXOs, handler.body)
+	assert.Equal(http.StatusTooManyRequests, call(h, "1.2.3.4:1", getUTXOs, nil))
+	assert.EqualValues(1, testutil.ToFloat64(limiter.rejected.WithLabelValues("rate", "avm.getUTXOs")))
+
+	// Other endpoints should use the default limit, which isn't affected by
+	// the calls to the overridden endpoint and method.
+	h = rateLimitMiddleware(&testHandler{}, limiter, "/ext/info")
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", getBalance, nil))
+	assert.Equal(http.StatusTooManyRequests, call(h, "1.2.3.4:1", "", nil))
+	assert.Equal(http.StatusTooManyRequests, call(h, "1.2.3.4:1", getUTXOs, nil))
+}
+
+func TestRateLimitConcurrency(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newTestRateLimiter(t, RateLimitConfig{
+		RateLimit: RateLimit{
+			MaxConcurrent: 1,
+		},
+	})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	h := rateLimitMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		started <- struct{}{}
+		<-release
+	}), limiter, "/ext/info")
+
+	done := make(chan int)
+	go func() {
+		done <- call(h, "1.2.3.4:1", "", nil)
+	}()
+	<-started
+
+	assert.Equal(http.StatusTooManyRequests, call(h, "1.2.3.4:1", "", nil))
+	assert.EqualValues(1, testutil.ToFloat64(limiter.rejected.WithLabelValues("concurrency", defaultLimitName)))
+
+	close(release)
+	assert.Equal(http.StatusOK, <-done)
+
+	// Once the call finishes, another call can be made.
+	go func() { <-started }()
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", "", nil))
+}
+
+type bodyHandler struct{ body string }
+
+func (b *bodyHandler) ServeHTTP(_ http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	b.body = string(body)
+}
+
+func TestRateLimitJSONRPCError(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newTestRateLimiter(t, RateLimitConfig{
+		RateLimit: RateLimit{
+			RequestsPerSecond: 0.001,
+			Burst:             1,
+		},
+	})
+	h := rateLimitMiddleware(&testHandler{}, limiter, "/ext/info")
+
+	body := `{"jsonrpc":"2.0","id":7,"method":"info.getNodeID","params":{}}`
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", body, nil))
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/info", strings.NewReader(body))
+	r.RemoteAddr = "1.2.3.4:1"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+
+	response := struct {
+		Version string          `json:"jsonrpc"`
+		Error   *json2.Error    `json:"error"`
+		ID      json.RawMessage `json:"id"`
+	}{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(json2.Version, response.Version)
+	assert.Equal(json2.E_SERVER, response.Error.Code)
+	assert.Equal(rateLimitedMsg, response.Error.Message)
+	assert.Equal(json.RawMessage("7"), response.ID)
+}
+
+func TestRateLimitConcurrencyDoesNotUseRate(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newTestRateLimiter(t, RateLimitConfig{
+		RateLimit: RateLimit{
+			RequestsPerSecond: 0.001,
+			Burst:             2,
+			MaxConcurrent:     1,
+		},
+	})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	h := rateLimitMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		started <- struct{}{}
+		<-release
+	}), limiter, "/ext/info")
+
+	done := make(chan int)
+	go func() {
+		done <- call(h, "1.2.3.4:1", "", nil)
+	}()
+	<-started
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(http.StatusTooManyRequests, call(h, "1.2.3.4:1", "", nil))
+	}
+	close(release)
+	assert.Equal(http.StatusOK, <-done)
+
+	// The calls rejected for concurrency shouldn't have used the second token.
+	go func() { <-started }()
+	assert.Equal(http.StatusOK, call(h, "1.2.3.4:1", "", nil))
+	assert.EqualValues(0, testutil.ToFloat64(limiter.rejected.WithLabelValues("rate", defaultLimitName)))
+}
+
+func TestRateLimiterAcquire(t *testing.T) {
+	assert := assert.New(t)
+
+	limiter := newTestRateLimiter(t, RateLimitConfig{
+		Endpoints: map[string]RateLimit{
+			"/ext/bc/X": {MaxConcurrent: 1},
+		},
+		Methods: map[string]RateLimit{
+			"avm.getUTXOs": {
+				RequestsPerSecond: 0.001,
+				Burst:             1,
+			},
+		},
+	})
+
+	// Calls made over other protocols should share the limits of the HTTP
+	// API.
+	release, err := limiter.Acquire("1.2.3.4:1", "", "/ext/bc/X", "avm.getBalance")
+	assert.NoError(err)
+	_, err = limiter.Acquire("1.2.3.4:2", "", "/ext/bc/X", "avm.getTx")
+	assert.ErrorIs(err, errConcurrencyLimited)
+	release()
+	release, err = limiter.Acquire("1.2.3.4:1", "", "/ext/bc/X", "avm.getTx")
+	assert.NoError(err)
+	release()
+
+	release, err = limiter.Acquire("1.2.3.4:1", "", "/ext/bc/X", "avm.getUTXOs")
+	assert.NoError(err)
+	release()
+	_, err = limiter.Acquire("1.2.3.4:1", "", "/ext/bc/X", "avm.getUTXOs")
+	assert.ErrorIs(err, errRateLimited)
+
+	// Endpoints without limits shouldn't be limited.
+	for i := 0; i < 2; i++ {
+		_, err = limiter.Acquire("1.2.3.4:1", "", "/ext/info", "info.peers")
+		assert.NoError(err)
+	}
+}
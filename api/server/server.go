@@ -6,6 +6,7 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -24,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -34,6 +36,7 @@ const (
 
 var (
 	errUnknownLockOption = errors.New("invalid lock options")
+	errInvalidClientCAs  = errors.New("couldn't parse any client CA certificates")
 
 	_ PathAdder = readPathAdder{}
 	_ Server    = &server{}
@@ -69,11 +72,16 @@ type Server interface {
 		allowedOrigins []string,
 		shutdownTimeout time.Duration,
 		nodeID ids.NodeID,
+		rateLimiter *RateLimiter,
+		maxRequestSize int64,
+		maxBatchSize int,
 		wrappers ...Wrapper)
 	// Dispatch starts the API server
 	Dispatch() error
-	// DispatchTLS starts the API server with the provided TLS certificate
-	DispatchTLS(certBytes, keyBytes []byte) error
+	// DispatchTLS starts the API server with the provided TLS certificate. If
+	// [clientAuth] specifies CA certificates, clients are authenticated by
+	// their TLS certificates.
+	DispatchTLS(certBytes, keyBytes []byte, clientAuth ClientAuthConfig) error
 	// RegisterChain registers the API endpoints associated with this chain. That is,
 	// add <route, handler> pairs to server so that API calls can be made to the VM.
 	// This method runs in a goroutine to avoid a deadlock in the event that the caller
@@ -92,6 +100,50 @@ type Server interface {
 	Shutdown() error
 }
 
+// ClientAuthConfig configures the authentication of API clients by their TLS
+// certificates
+type ClientAuthConfig struct {
+	// PEM encoded certificates of the CAs that must have issued the client
+	// certificates. If empty, clients aren't authenticated.
+	CACerts []byte
+	// If true, clients must present a certificate. Otherwise, a client may
+	// connect without one, but a certificate it does present must be valid.
+	Required bool
+}
+
+// configure sets up [config] to authenticate clients
+func (c ClientAuthConfig) configure(config *tls.Config) error {
+	if len(c.CACerts) == 0 {
+		return nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(c.CACerts) {
+		return errInvalidClientCAs
+	}
+	config.ClientCAs = pool
+	if c.Required {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return nil
+}
+
+// NewTLSConfig returns the TLS configuration of an API server that serves
+// [certBytes] and [keyBytes] and authenticates its clients as configured by
+// [clientAuth]
+func NewTLSConfig(certBytes, keyBytes []byte, clientAuth ClientAuthConfig) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	return config, clientAuth.configure(config)
+}
+
 type server struct {
 	// log this server writes to
 	log logging.Logger
@@ -105,6 +157,11 @@ type server struct {
 
 	shutdownTimeout time.Duration
 
+	// Limits the calls each client makes, if not nil
+	rateLimiter *RateLimiter
+	// Maximum number of requests in a JSON-RPC batch
+	maxBatchSize int
+
 	// Maps endpoints to handlers
 	router *router
 
@@ -124,6 +181,9 @@ func (s *server) Initialize(
 	allowedOrigins []string,
 	shutdownTimeout time.Duration,
 	nodeID ids.NodeID,
+	rateLimiter *RateLimiter,
+	maxRequestSize int64,
+	maxBatchSize int,
 	wrappers ...Wrapper,
 ) {
 	s.log = log
@@ -131,6 +191,8 @@ func (s *server) Initialize(
 	s.listenHost = host
 	s.listenPort = port
 	s.shutdownTimeout = shutdownTimeout
+	s.rateLimiter = rateLimiter
+	s.maxBatchSize = maxBatchSize
 	s.router = newRouter()
 
 	s.log.Info("API created with allowed origins: %v", allowedOrigins)
@@ -151,6 +213,9 @@ func (s *server) Initialize(
 	for _, wrapper := range wrappers {
 		s.handler = wrapper.WrapHandler(s.handler)
 	}
+	// The body of each request is read once, before any of the middlewares,
+	// and shared between them.
+	s.handler = json.NewBodyHandler(s.handler, maxRequestSize)
 }
 
 func (s *server) Dispatch() error {
@@ -174,16 +239,12 @@ func (s *server) Dispatch() error {
 	return s.srv.Serve(listener)
 }
 
-func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
+func (s *server) DispatchTLS(certBytes, keyBytes []byte, clientAuth ClientAuthConfig) error {
 	listenAddress := fmt.Sprintf("%s:%d", s.listenHost, s.listenPort)
-	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	config, err := NewTLSConfig(certBytes, keyBytes, clientAuth)
 	if err != nil {
 		return err
 	}
-	config := &tls.Config{
-		MinVersion:   tls.VersionTLS12,
-		Certificates: []tls.Certificate{cert},
-	}
 
 	listener, err := tls.Listen("tcp", listenAddress, config)
 	if err != nil {
@@ -253,6 +314,19 @@ func (s *server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.ConsensusC
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
+	// Apply middleware to reject calls from clients that exceed their limits.
+	// The limits of the chain's endpoint may be configured by any of its
+	// aliases.
+	endpoints := []string{url + endpoint}
+	if aliases, err := ctx.BCLookup.Aliases(ctx.ChainID); err == nil {
+		for _, alias := range aliases {
+			endpoints = append(endpoints, fmt.Sprintf("%s/%s/%s%s", baseURL, constants.ChainAliasPrefix, alias, endpoint))
+		}
+	}
+	h = rateLimitMiddleware(h, s.rateLimiter, endpoints...)
+	// Apply middleware to split JSON-RPC batches into calls that are each
+	// limited and locked separately
+	h = json.NewBatchHandler(h, s.maxBatchSize)
 	return s.router.AddRouter(url, endpoint, h)
 }
 
@@ -274,6 +348,11 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 	if err != nil {
 		return err
 	}
+	// Apply middleware to reject calls from clients that exceed their limits
+	h = rateLimitMiddleware(h, s.rateLimiter, url+endpoint)
+	// Apply middleware to split JSON-RPC batches into calls that are each
+	// limited and locked separately
+	h = json.NewBatchHandler(h, s.maxBatchSize)
 	return s.router.AddRouter(url, endpoint, h)
 }
 
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func TestClientAuthConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	caCert, _, err := staking.NewCertAndKeyBytes()
+	assert.NoError(err)
+
+	// Without CA certificates, clients aren't authenticated.
+	config := &tls.Config{}
+	assert.NoError(ClientAuthConfig{Required: true}.configure(config))
+	assert.Equal(tls.NoClientCert, config.ClientAuth)
+	assert.Nil(config.ClientCAs)
+
+	config = &tls.Config{}
+	assert.NoError(ClientAuthConfig{CACerts: caCert}.configure(config))
+	assert.Equal(tls.VerifyClientCertIfGiven, config.ClientAuth)
+	assert.NotNil(config.ClientCAs)
+
+	config = &tls.Config{}
+	assert.NoError(ClientAuthConfig{CACerts: caCert, Required: true}.configure(config))
+	assert.Equal(tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	err = ClientAuthConfig{CACerts: []byte("not a certificate")}.configure(&tls.Config{})
+	assert.ErrorIs(err, errInvalidClientCAs)
+}
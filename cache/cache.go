@@ -38,3 +38,21 @@ type Deduplicator interface {
 	// Flush removes all entries from the cache
 	Flush()
 }
+
+// TypedCacher acts as a best effort key value store with typed keys and
+// values.
+type TypedCacher[K comparable, V any] interface {
+	// Put inserts an element into the cache. If space is required, elements
+	// will be evicted.
+	Put(key K, value V)
+
+	// Get returns the entry in the cache with the key specified, if no value
+	// exists, false is returned.
+	Get(key K) (V, bool)
+
+	// Evict removes the specified entry from the cache
+	Evict(key K)
+
+	// Flush removes all entries from the cache
+	Flush()
+}
@@ -60,3 +60,50 @@ func BenchmarkLRUCachePutLarge(b *testing.B) {
 		b.StartTimer()
 	}
 }
+
+// benchmarkParallelGet measures the throughput of [cache] under parallel
+// readers of [size] keys, where every [writeEvery]th operation of each reader
+// is a Put rather than a Get. If [writeEvery] is 0, only Gets are performed.
+func benchmarkParallelGet(b *testing.B, cache Cacher, size int, writeEvery int) {
+	keys := make([]ids.ID, size)
+	for i := range keys {
+		if _, err := rand.Read(keys[i][:]); err != nil {
+			b.Fatal(err)
+		}
+		cache.Put(keys[i], i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := keys[i%size]
+			if writeEvery > 0 && i%writeEvery == 0 {
+				cache.Put(key, i)
+			} else {
+				cache.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkLRUCacheParallelGet(b *testing.B) {
+	size := 10000
+	benchmarkParallelGet(b, &LRU{Size: size}, size, 0)
+}
+
+func BenchmarkShardedLRUCacheParallelGet(b *testing.B) {
+	size := 10000
+	benchmarkParallelGet(b, NewShardedLRU(size, DefaultShards, nil), size, 0)
+}
+
+func BenchmarkLRUCacheParallelGetPut(b *testing.B) {
+	size := 10000
+	benchmarkParallelGet(b, &LRU{Size: size}, size, 10)
+}
+
+func BenchmarkShardedLRUCacheParallelGetPut(b *testing.B) {
+	size := 10000
+	benchmarkParallelGet(b, NewShardedLRU(size, DefaultShards, nil), size, 10)
+}
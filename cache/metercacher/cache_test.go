@@ -8,7 +8,10 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
 )
 
 func TestInterface(t *testing.T) {
@@ -22,3 +25,35 @@ func TestInterface(t *testing.T) {
 		test.Func(t, c)
 	}
 }
+
+func TestTyped(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	c, err := NewTyped[ids.ID, int]("", registry, &cache.SizedLRU[ids.ID, int]{MaxSize: 1})
+	assert.NoError(err)
+
+	id1 := ids.ID{1}
+	_, found := c.Get(id1)
+	assert.False(found)
+
+	c.Put(id1, 1)
+	val, found := c.Get(id1)
+	assert.True(found)
+	assert.Equal(1, val)
+
+	families, err := registry.Gather()
+	assert.NoError(err)
+	counts := make(map[string]float64)
+	for _, family := range families {
+		if counter := family.GetMetric()[0].Counter; counter != nil {
+			counts[family.GetName()] = counter.GetValue()
+		}
+	}
+	assert.Equal(map[string]float64{
+		"get_count": 2,
+		"put_count": 1,
+		"hit":       1,
+		"miss":      1,
+	}, counts)
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metercacher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ cache.TypedCacher[struct{}, struct{}] = &TypedCache[struct{}, struct{}]{}
+
+// TypedCache reports the same metrics as Cache for a typed cache.
+type TypedCache[K comparable, V any] struct {
+	metrics
+	cache.TypedCacher[K, V]
+
+	clock mockable.Clock
+}
+
+func NewTyped[K comparable, V any](
+	namespace string,
+	registerer prometheus.Registerer,
+	cache cache.TypedCacher[K, V],
+) (cache.TypedCacher[K, V], error) {
+	meterCache := &TypedCache[K, V]{TypedCacher: cache}
+	return meterCache, meterCache.metrics.Initialize(namespace, registerer)
+}
+
+func (c *TypedCache[K, V]) Put(key K, value V) {
+	start := c.clock.Time()
+	c.TypedCacher.Put(key, value)
+	end := c.clock.Time()
+	c.put.Observe(float64(end.Sub(start)))
+}
+
+func (c *TypedCache[K, V]) Get(key K) (V, bool) {
+	start := c.clock.Time()
+	value, has := c.TypedCacher.Get(key)
+	end := c.clock.Time()
+	c.get.Observe(float64(end.Sub(start)))
+	if has {
+		c.hit.Inc()
+	} else {
+		c.miss.Inc()
+	}
+
+	return value, has
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// DefaultShards is the number of shards a ShardedLRU uses if none is
+// specified.
+const DefaultShards = 16
+
+var _ Cacher = &ShardedLRU{}
+
+// ShardedLRU is a key value store with bounded size that splits its entries
+// across independently locked LRU caches, so that concurrent accesses to
+// different keys rarely contend on the same lock.
+//
+// Each shard evicts its least recently used entry when it is full, so an entry
+// may be evicted before the least recently used entry of the whole cache.
+type ShardedLRU struct {
+	shards []LRU
+	hasher Hasher
+}
+
+// Hasher returns the hash of [key], which is used to pick the shard that
+// [key] is cached in.
+type Hasher func(key interface{}) uint64
+
+// NewShardedLRU returns a cache of at most [size] entries split across
+// [numShards] shards. If [numShards] isn't positive, DefaultShards is used.
+// There are never more shards than entries.
+//
+// If [hasher] is nil, DefaultHasher is used.
+func NewShardedLRU(size, numShards int, hasher Hasher) *ShardedLRU {
+	if numShards <= 0 {
+		numShards = DefaultShards
+	}
+	if size < 1 {
+		size = 1
+	}
+	if numShards > size {
+		numShards = size
+	}
+	if hasher == nil {
+		hasher = DefaultHasher
+	}
+	c := &ShardedLRU{
+		shards: make([]LRU, numShards),
+		hasher: hasher,
+	}
+	// Split [size] exactly, so that the shards hold at most [size] entries in
+	// total.
+	shardSize, remainder := size/numShards, size%numShards
+	for i := range c.shards {
+		c.shards[i].Size = shardSize
+		if i < remainder {
+			c.shards[i].Size++
+		}
+	}
+	return c
+}
+
+func (c *ShardedLRU) Put(key, value interface{}) {
+	c.shard(key).Put(key, value)
+}
+
+func (c *ShardedLRU) Get(key interface{}) (interface{}, bool) {
+	return c.shard(key).Get(key)
+}
+
+func (c *ShardedLRU) Evict(key interface{}) {
+	c.shard(key).Evict(key)
+}
+
+func (c *ShardedLRU) Flush() {
+	for i := range c.shards {
+		c.shards[i].Flush()
+	}
+}
+
+func (c *ShardedLRU) shard(key interface{}) *LRU {
+	if len(c.shards) == 1 {
+		return &c.shards[0]
+	}
+	return &c.shards[c.hasher(key)%uint64(len(c.shards))]
+}
+
+// DefaultHasher hashes keys of the common key types: ids.ID, ids.ShortID,
+// ids.NodeID, string, uint64 and int. Keys of any other type are hashed by
+// their String method if they implement fmt.Stringer, or by their default
+// format otherwise. This is much slower, so a ShardedLRU with such keys should
+// be given its own Hasher.
+//
+// IDs are usually hashes themselves, so their words are folded together
+// rather than rehashed.
+func DefaultHasher(key interface{}) uint64 {
+	switch k := key.(type) {
+	case ids.ID:
+		return hashUint64(
+			binary.LittleEndian.Uint64(k[:8]) ^
+				binary.LittleEndian.Uint64(k[8:16]) ^
+				binary.LittleEndian.Uint64(k[16:24]) ^
+				binary.LittleEndian.Uint64(k[24:]),
+		)
+	case ids.ShortID:
+		return hashShortID(k)
+	case ids.NodeID:
+		return hashShortID(ids.ShortID(k))
+	case string:
+		return hashString(k)
+	case uint64:
+		return hashUint64(k)
+	case int:
+		return hashUint64(uint64(k))
+	case fmt.Stringer:
+		return hashString(k.String())
+	default:
+		return hashString(fmt.Sprintf("%v", key))
+	}
+}
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+func hashShortID(id ids.ShortID) uint64 {
+	return hashUint64(
+		binary.LittleEndian.Uint64(id[:8]) ^
+			binary.LittleEndian.Uint64(id[8:16]) ^
+			uint64(binary.LittleEndian.Uint32(id[16:])),
+	)
+}
+
+// hashString returns the FNV-1a hash of [s]. This is equivalent to using
+// hash/fnv, without the allocation.
+func hashString(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// hashUint64 spreads sequential values of [v] across the high bits of the
+// result using Fibonacci hashing.
+func hashUint64(v uint64) uint64 {
+	return (v * 0x9E3779B97F4A7C15) >> 32
+}
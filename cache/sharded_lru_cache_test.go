@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestShardedLRU(t *testing.T) {
+	// With a single shard, the cache must behave exactly like an LRU.
+	for _, test := range CacherTests {
+		test.Func(t, NewShardedLRU(test.Size, 1, nil))
+	}
+}
+
+func TestShardedLRUSize(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := NewShardedLRU(100, 4, nil)
+	for i := 0; i < 1000; i++ {
+		cache.Put(ids.Empty.Prefix(uint64(i)), i)
+	}
+
+	numCached := 0
+	for i := 0; i < 1000; i++ {
+		if val, found := cache.Get(ids.Empty.Prefix(uint64(i))); found {
+			assert.Equal(i, val)
+			numCached++
+		}
+	}
+	assert.LessOrEqual(numCached, 100)
+	// Every shard should be full.
+	for i := range cache.shards {
+		assert.Equal(25, cache.shards[i].entryList.Len())
+	}
+
+	cache.Flush()
+	for i := 0; i < 1000; i++ {
+		_, found := cache.Get(ids.Empty.Prefix(uint64(i)))
+		assert.False(found)
+	}
+}
+
+func TestShardedLRUSplitsSizeExactly(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, test := range []struct {
+		size, numShards, expectedSize, expectedShards int
+	}{
+		{size: 100, numShards: 16, expectedSize: 100, expectedShards: 16},
+		{size: 17, numShards: 4, expectedSize: 17, expectedShards: 4},
+		{size: 3, numShards: 16, expectedSize: 3, expectedShards: 3},
+		{size: 0, numShards: 4, expectedSize: 1, expectedShards: 1},
+	} {
+		cache := NewShardedLRU(test.size, test.numShards, nil)
+		assert.Len(cache.shards, test.expectedShards)
+
+		totalSize := 0
+		for i := range cache.shards {
+			assert.Positive(cache.shards[i].Size)
+			totalSize += cache.shards[i].Size
+		}
+		assert.Equal(test.expectedSize, totalSize)
+	}
+}
+
+type stringerKey struct{}
+
+func (stringerKey) String() string { return "key" }
+
+func TestShardedLRUHasher(t *testing.T) {
+	assert := assert.New(t)
+
+	type key struct{ a, b int }
+
+	// Keys of other types are hashed by their default format.
+	cache := NewShardedLRU(10, 2, nil)
+	cache.Put(key{1, 2}, 1)
+	val, found := cache.Get(key{1, 2})
+	assert.True(found)
+	assert.Equal(1, val)
+	assert.Equal(DefaultHasher(key{1, 2}), DefaultHasher(key{1, 2}))
+	assert.NotEqual(DefaultHasher(key{1, 2}), DefaultHasher(key{2, 1}))
+
+	// Keys that implement fmt.Stringer are hashed by their String method.
+	assert.Equal(hashString("key"), DefaultHasher(stringerKey{}))
+
+	cache = NewShardedLRU(10, 2, func(k interface{}) uint64 {
+		return uint64(k.(key).a)
+	})
+	cache.Put(key{1, 2}, 1)
+	val, found = cache.Get(key{1, 2})
+	assert.True(found)
+	assert.Equal(1, val)
+	assert.Equal(1, cache.shards[1].entryList.Len())
+}
+
+func TestShardedLRUEvict(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := NewShardedLRU(100, 0, nil)
+	assert.Len(cache.shards, DefaultShards)
+
+	id := ids.GenerateTestID()
+	cache.Put(id, 1)
+	val, found := cache.Get(id)
+	assert.True(found)
+	assert.Equal(1, val)
+
+	cache.Evict(id)
+	_, found = cache.Get(id)
+	assert.False(found)
+}
+
+func TestShardedLRUConcurrentAccess(t *testing.T) {
+	cache := NewShardedLRU(64, 4, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				key := uint64((i + j) % 128)
+				cache.Put(key, j)
+				cache.Get(key)
+				if j%10 == 0 {
+					cache.Evict(key)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ TypedCacher[struct{}, struct{}] = &SizedLRU[struct{}, struct{}]{}
+
+type sizedEntry[K comparable, V any] struct {
+	key   K
+	value V
+	size  int
+	// expiry is the time after which the entry is no longer returned, or the
+	// zero time if the entry doesn't expire.
+	expiry time.Time
+}
+
+// SizedLRU is a key value store bounded by the total size of its entries. If
+// the size is attempted to be exceeded, then the least recently used entries
+// are removed from the cache until the insertion fits.
+type SizedLRU[K comparable, V any] struct {
+	lock        sync.Mutex
+	entryMap    map[K]*list.Element
+	entryList   *list.List
+	currentSize int
+
+	// MaxSize is the maximum total size of the entries in the cache.
+	MaxSize int
+	// Size returns the size of an entry. If nil, every entry has a size of 1,
+	// so [MaxSize] bounds the number of entries in the cache. Sizes less than
+	// 1 are treated as 1.
+	Size func(K, V) int
+	// TTL is how long an entry is returned for after it was inserted. If 0,
+	// entries don't expire.
+	TTL time.Duration
+
+	clock mockable.Clock
+}
+
+func (c *SizedLRU[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *SizedLRU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *SizedLRU[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *SizedLRU[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+// Len returns the number of entries in the cache.
+func (c *SizedLRU[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.init()
+	return c.entryList.Len()
+}
+
+// CurrentSize returns the total size of the entries in the cache.
+func (c *SizedLRU[K, V]) CurrentSize() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.currentSize
+}
+
+func (c *SizedLRU[K, V]) init() {
+	if c.entryMap == nil {
+		c.entryMap = make(map[K]*list.Element, minCacheSize)
+	}
+	if c.entryList == nil {
+		c.entryList = list.New()
+	}
+	if c.MaxSize <= 0 {
+		c.MaxSize = 1
+	}
+}
+
+func (c *SizedLRU[K, V]) resize() {
+	for c.currentSize > c.MaxSize {
+		c.remove(c.entryList.Front())
+	}
+}
+
+func (c *SizedLRU[K, V]) put(key K, value V) {
+	c.init()
+	c.resize()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+
+	size := 1
+	if c.Size != nil {
+		// Every entry takes up some space, and a non-positive size would
+		// otherwise allow the cache to grow without bound.
+		size = c.Size(key, value)
+		if size < 1 {
+			size = 1
+		}
+	}
+	if size > c.MaxSize {
+		// The entry can never fit in the cache.
+		return
+	}
+	for c.currentSize+size > c.MaxSize {
+		c.remove(c.entryList.Front())
+	}
+
+	entry := &sizedEntry[K, V]{
+		key:   key,
+		value: value,
+		size:  size,
+	}
+	if c.TTL > 0 {
+		entry.expiry = c.clock.Time().Add(c.TTL)
+	}
+	c.entryMap[key] = c.entryList.PushBack(entry)
+	c.currentSize += size
+}
+
+func (c *SizedLRU[K, V]) get(key K) (V, bool) {
+	c.init()
+	c.resize()
+
+	e, ok := c.entryMap[key]
+	if !ok {
+		return *new(V), false
+	}
+	entry := e.Value.(*sizedEntry[K, V])
+	if !entry.expiry.IsZero() && !c.clock.Time().Before(entry.expiry) {
+		c.remove(e)
+		return *new(V), false
+	}
+
+	c.entryList.MoveToBack(e)
+	return entry.value, true
+}
+
+func (c *SizedLRU[K, V]) evict(key K) {
+	c.init()
+	c.resize()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *SizedLRU[K, V]) flush() {
+	c.init()
+
+	c.entryMap = make(map[K]*list.Element, minCacheSize)
+	c.entryList = list.New()
+	c.currentSize = 0
+}
+
+// remove removes [e] from the cache. Assumes [e] is in the cache.
+func (c *SizedLRU[K, V]) remove(e *list.Element) {
+	entry := c.entryList.Remove(e).(*sizedEntry[K, V])
+	delete(c.entryMap, entry.key)
+	c.currentSize -= entry.size
+}
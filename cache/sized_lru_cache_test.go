@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestSizedLRU(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := &SizedLRU[ids.ID, int]{MaxSize: 1}
+
+	id1 := ids.ID{1}
+	_, found := cache.Get(id1)
+	assert.False(found)
+
+	cache.Put(id1, 1)
+	val, found := cache.Get(id1)
+	assert.True(found)
+	assert.Equal(1, val)
+
+	cache.Put(id1, 2)
+	val, found = cache.Get(id1)
+	assert.True(found)
+	assert.Equal(2, val)
+	assert.Equal(1, cache.Len())
+
+	id2 := ids.ID{2}
+	cache.Put(id2, 3)
+	_, found = cache.Get(id1)
+	assert.False(found)
+	val, found = cache.Get(id2)
+	assert.True(found)
+	assert.Equal(3, val)
+
+	cache.Evict(id2)
+	_, found = cache.Get(id2)
+	assert.False(found)
+	assert.Zero(cache.Len())
+	assert.Zero(cache.CurrentSize())
+}
+
+func TestSizedLRUEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := &SizedLRU[int, []byte]{
+		MaxSize: 10,
+		Size: func(_ int, value []byte) int {
+			return len(value)
+		},
+	}
+
+	cache.Put(1, make([]byte, 4))
+	cache.Put(2, make([]byte, 4))
+	assert.Equal(8, cache.CurrentSize())
+
+	// Touch 1 so that 2 is the least recently used entry.
+	_, found := cache.Get(1)
+	assert.True(found)
+
+	cache.Put(3, make([]byte, 4))
+	assert.Equal(8, cache.CurrentSize())
+	_, found = cache.Get(2)
+	assert.False(found)
+	_, found = cache.Get(1)
+	assert.True(found)
+	_, found = cache.Get(3)
+	assert.True(found)
+
+	// Replacing an entry should account for its new size.
+	cache.Put(3, make([]byte, 6))
+	assert.Equal(10, cache.CurrentSize())
+	assert.Equal(2, cache.Len())
+
+	// An entry larger than the cache should be dropped without evicting
+	// anything.
+	cache.Put(4, make([]byte, 11))
+	_, found = cache.Get(4)
+	assert.False(found)
+	assert.Equal(2, cache.Len())
+
+	// Putting a larger value for an existing key that doesn't fit should
+	// drop the old value.
+	cache.Put(1, make([]byte, 11))
+	_, found = cache.Get(1)
+	assert.False(found)
+	assert.Equal(6, cache.CurrentSize())
+
+	cache.Put(5, make([]byte, 10))
+	assert.Equal(1, cache.Len())
+	assert.Equal(10, cache.CurrentSize())
+
+	cache.Flush()
+	assert.Zero(cache.Len())
+	assert.Zero(cache.CurrentSize())
+}
+
+func TestSizedLRUResize(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := &SizedLRU[int, int]{MaxSize: 2}
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+
+	cache.MaxSize = 1
+
+	_, found := cache.Get(1)
+	assert.False(found)
+	val, found := cache.Get(2)
+	assert.True(found)
+	assert.Equal(2, val)
+}
+
+func TestSizedLRUNonPositiveSize(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := &SizedLRU[int, int]{
+		MaxSize: 2,
+		Size: func(_ int, value int) int {
+			return value
+		},
+	}
+	cache.Put(1, -5)
+	cache.Put(2, 0)
+	assert.Equal(2, cache.CurrentSize())
+
+	// Entries with a non-positive size still count towards the maximum size.
+	cache.Put(3, -1)
+	assert.Equal(2, cache.Len())
+	assert.Equal(2, cache.CurrentSize())
+	_, found := cache.Get(1)
+	assert.False(found)
+}
+
+func TestSizedLRUTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := &SizedLRU[int, int]{
+		MaxSize: 2,
+		TTL:     time.Minute,
+	}
+	now := time.Unix(1000, 0)
+	cache.clock.Set(now)
+
+	cache.Put(1, 1)
+	cache.clock.Set(now.Add(30 * time.Second))
+	cache.Put(2, 2)
+
+	// Getting an entry shouldn't extend its lifetime.
+	cache.clock.Set(now.Add(59 * time.Second))
+	_, found := cache.Get(1)
+	assert.True(found)
+
+	cache.clock.Set(now.Add(time.Minute))
+	_, found = cache.Get(1)
+	assert.False(found)
+	assert.Equal(1, cache.Len())
+	_, found = cache.Get(2)
+	assert.True(found)
+
+	cache.clock.Set(now.Add(90 * time.Second))
+	_, found = cache.Get(2)
+	assert.False(found)
+	assert.Zero(cache.CurrentSize())
+}
@@ -13,11 +13,13 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/api/grpcserver"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
@@ -57,13 +59,23 @@ import (
 	snowgetter "github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
 )
 
-const defaultChannelSize = 1
+const (
+	defaultChannelSize = 1
+
+	// Namespace of the metrics of chains, if they're labeled by chain rather
+	// than namespaced by alias
+	chainMetricsNamespace = "chain"
+	// Labels of the metrics of chains, if they're labeled by chain
+	chainLabel  = "chain"
+	subnetLabel = "subnet"
+)
 
 var (
-	errUnknownChainID   = errors.New("unknown chain ID")
-	errUnknownVMType    = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
-	errCreatePlatformVM = errors.New("attempted to create a chain running the PlatformVM")
-	errNotBootstrapped  = errors.New("chains not bootstrapped")
+	errUnknownChainID       = errors.New("unknown chain ID")
+	errUnknownVMType        = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
+	errCreatePlatformVM     = errors.New("attempted to create a chain running the PlatformVM")
+	errNotBootstrapped      = errors.New("chains not bootstrapped")
+	errChainNotBootstrapped = errors.New("chain not bootstrapped")
 
 	_ Manager = &manager{}
 )
@@ -153,6 +165,7 @@ type ManagerConfig struct {
 	NodeID                      ids.NodeID         // The ID of this node
 	NetworkID                   uint32             // ID of the network this node is connected to
 	Server                      server.Server      // Handles HTTP API calls
+	GRPCServer                  grpcserver.Server  // Handles gRPC API calls, if not nil
 	Keystore                    keystore.Keystore
 	AtomicMemory                *atomic.Memory
 	AVAXAssetID                 ids.ID
@@ -169,6 +182,9 @@ type ManagerConfig struct {
 	ShutdownNodeFunc func(exitCode int)
 	MeterVMEnabled   bool // Should each VM be wrapped with a MeterVM
 	Metrics          metrics.MultiGatherer
+	// If true, the metrics of each chain are labeled with the IDs of the chain
+	// and its subnet. Otherwise, they're namespaced by the chain's alias.
+	MetricsChainLabels bool
 
 	ConsensusGossipFrequency time.Duration
 
@@ -299,7 +315,7 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 		healthCheckErr := fmt.Errorf("failed to create chain on whitelisted subnet: %s", chainParams.SubnetID)
 		if err := m.Health.RegisterHealthCheck(chainAlias, health.CheckerFunc(func() (interface{}, error) {
 			return nil, healthCheckErr
-		})); err != nil {
+		}), chainAlias, chainParams.ID.String(), chainParams.SubnetID.String()); err != nil {
 			m.Log.Error("failed to register health check for chain %q on whitelisted subnet %s", chainAlias, chainParams.SubnetID)
 		}
 		return
@@ -320,6 +336,13 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 	// handler is started.
 	m.ManagerConfig.Router.AddChain(chain.Handler)
 
+	// Register the readiness check of this chain, so that traffic can be
+	// routed to this chain as soon as it has bootstrapped, regardless of the
+	// other chains.
+	if err := m.registerChainReadinessCheck(chainParams.ID, chainParams.SubnetID, chain.Handler.Context()); err != nil {
+		chain.Handler.StopWithError(err)
+	}
+
 	// Register bootstrapped health checks after P chain has been added to
 	// chains.
 	//
@@ -355,15 +378,24 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, fmt.Errorf("error while creating chain's log %w", err)
 	}
 
-	consensusMetrics := prometheus.NewRegistry()
 	chainNamespace := fmt.Sprintf("%s_%s", constants.PlatformName, primaryAlias)
-	if err := m.Metrics.Register(chainNamespace, consensusMetrics); err != nil {
+	var chainLabels prometheus.Labels
+	if m.MetricsChainLabels {
+		chainNamespace = fmt.Sprintf("%s_%s", constants.PlatformName, chainMetricsNamespace)
+		chainLabels = prometheus.Labels{
+			chainLabel:  chainParams.ID.String(),
+			subnetLabel: chainParams.SubnetID.String(),
+		}
+	}
+
+	consensusMetrics := prometheus.NewRegistry()
+	if err := m.Metrics.RegisterWithLabels(chainNamespace, chainLabels, consensusMetrics); err != nil {
 		return nil, fmt.Errorf("error while registering chain's metrics %w", err)
 	}
 
 	vmMetrics := metrics.NewOptionalGatherer()
 	vmNamespace := fmt.Sprintf("%s_vm", chainNamespace)
-	if err := m.Metrics.Register(vmNamespace, vmMetrics); err != nil {
+	if err := m.Metrics.RegisterWithLabels(vmNamespace, chainLabels, vmMetrics); err != nil {
 		return nil, fmt.Errorf("error while registering vm's metrics %w", err)
 	}
 
@@ -505,6 +537,14 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, err
 	}
 
+	// Serve the gRPC API of the chain. The VM is checked before it's wrapped,
+	// as the wrappers don't expose its gRPC services.
+	if grpcVM, ok := vm.(common.GRPCVM); ok && m.GRPCServer != nil {
+		if err := m.GRPCServer.RegisterChain(ctx, grpcVM); err != nil {
+			m.Log.Error("failed to register the gRPC API of chain %s: %s", chainParams.ID, err)
+		}
+	}
+
 	return chain, nil
 }
 
@@ -519,6 +559,16 @@ func (m *manager) unblockChains() {
 	}
 }
 
+// registerDBPrefix registers the chain's database so that its estimated disk
+// usage is reported by the node's database metrics. The keys of a whole chain
+// are too many to count, so they are never counted.
+func (m *manager) registerDBPrefix(ctx *snow.ConsensusContext, db database.Database) {
+	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
+	if err := database.RegisterPrefix(db, chainAlias, nil, false); err != nil {
+		m.Log.Warn("couldn't register database prefix for chain %s: %s", chainAlias, err)
+	}
+}
+
 // Create a DAG-based blockchain that uses Avalanche
 func (m *manager) createAvalancheChain(
 	ctx *snow.ConsensusContext,
@@ -542,6 +592,7 @@ func (m *manager) createAvalancheChain(
 	vmDBManager := prefixDBManager.NewPrefixDBManager([]byte("vm"))
 
 	db := prefixDBManager.Current()
+	m.registerDBPrefix(ctx, db.Database)
 	vertexDB := prefixdb.New([]byte("vertex"), db.Database)
 	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db.Database)
 	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db.Database)
@@ -700,7 +751,9 @@ func (m *manager) createAvalancheChain(
 	// Register health check for this chain
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 
-	if err := m.Health.RegisterHealthCheck(chainAlias, handler); err != nil {
+	// The check is tagged with the chain and its subnet, so that the health of
+	// a chain or a subnet can be reported on its own.
+	if err := m.Health.RegisterHealthCheck(chainAlias, handler, chainAlias, ctx.ChainID.String(), ctx.SubnetID.String()); err != nil {
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
@@ -734,6 +787,7 @@ func (m *manager) createSnowmanChain(
 	vmDBManager := prefixDBManager.NewPrefixDBManager([]byte("vm"))
 
 	db := prefixDBManager.Current()
+	m.registerDBPrefix(ctx, db.Database)
 	bootstrappingDB := prefixdb.New([]byte("bs"), db.Database)
 
 	blocked, err := queue.NewWithMissing(bootstrappingDB, "block", ctx.Registerer)
@@ -914,7 +968,9 @@ func (m *manager) createSnowmanChain(
 	// Register health checks
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 
-	if err := m.Health.RegisterHealthCheck(chainAlias, handler); err != nil {
+	// The check is tagged with the chain and its subnet, so that the health of
+	// a chain or a subnet can be reported on its own.
+	if err := m.Health.RegisterHealthCheck(chainAlias, handler, chainAlias, ctx.ChainID.String(), ctx.SubnetID.String()); err != nil {
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
@@ -983,6 +1039,25 @@ func (m *manager) registerBootstrappedHealthChecks() error {
 	return nil
 }
 
+// registerChainReadinessCheck registers a readiness check that passes once the
+// chain [chainID] has bootstrapped. The check is tagged with the alias and ID
+// of the chain and the ID of its subnet.
+func (m *manager) registerChainReadinessCheck(chainID, subnetID ids.ID, ctx *snow.ConsensusContext) error {
+	chainAlias := m.PrimaryAliasOrDefault(chainID)
+	check := health.CheckerFunc(func() (interface{}, error) {
+		state := ctx.GetState()
+		if state != snow.NormalOp {
+			return state.String(), errChainNotBootstrapped
+		}
+		return state.String(), nil
+	})
+	name := fmt.Sprintf("bootstrapped-%s", chainAlias)
+	if err := m.Health.RegisterReadinessCheck(name, check, chainAlias, chainID.String(), subnetID.String()); err != nil {
+		return fmt.Errorf("couldn't register readiness check for chain %s: %w", chainAlias, err)
+	}
+	return nil
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
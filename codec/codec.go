@@ -10,3 +10,13 @@ type Codec interface {
 	MarshalInto(interface{}, *wrappers.Packer) error
 	Unmarshal([]byte, interface{}) error
 }
+
+// VersionedCodec marshals and unmarshals values as of a version of their
+// schema, so that a single codec can be registered for multiple versions.
+// MarshalInto and Unmarshal use the latest version of the schema.
+type VersionedCodec interface {
+	Codec
+
+	MarshalVersionInto(version uint16, source interface{}, p *wrappers.Packer) error
+	UnmarshalVersion(version uint16, source []byte, destination interface{}) error
+}
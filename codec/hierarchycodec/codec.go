@@ -19,16 +19,17 @@ const (
 )
 
 var (
-	_ Codec              = &hierarchyCodec{}
-	_ codec.Codec        = &hierarchyCodec{}
-	_ codec.Registry     = &hierarchyCodec{}
-	_ codec.GeneralCodec = &hierarchyCodec{}
+	_ Codec                = &hierarchyCodec{}
+	_ codec.Codec          = &hierarchyCodec{}
+	_ codec.VersionedCodec = &hierarchyCodec{}
+	_ codec.Registry       = &hierarchyCodec{}
+	_ codec.GeneralCodec   = &hierarchyCodec{}
 )
 
 // Codec marshals and unmarshals
 type Codec interface {
 	codec.Registry
-	codec.Codec
+	codec.VersionedCodec
 	SkipRegistrations(int)
 	NextGroup()
 }
@@ -40,7 +41,7 @@ type typeID struct {
 
 // Codec handles marshaling and unmarshaling of structs
 type hierarchyCodec struct {
-	codec.Codec
+	codec.VersionedCodec
 
 	lock           sync.RWMutex
 	currentGroupID uint16
@@ -57,7 +58,7 @@ func New(tagNames []string, maxSliceLen uint32) Codec {
 		typeIDToType:   map[typeID]reflect.Type{},
 		typeToTypeID:   map[reflect.Type]typeID{},
 	}
-	hCodec.Codec = reflectcodec.New(hCodec, tagNames, maxSliceLen)
+	hCodec.VersionedCodec = reflectcodec.New(hCodec, tagNames, maxSliceLen)
 	return hCodec
 }
 
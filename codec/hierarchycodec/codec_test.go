@@ -22,3 +22,10 @@ func TestMultipleTags(t *testing.T) {
 		test(c, t)
 	}
 }
+
+func TestVersions(t *testing.T) {
+	for _, test := range codec.VersionTests {
+		c := NewDefault()
+		test(c, t)
+	}
+}
@@ -6,6 +6,7 @@ package linearcodec
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -19,22 +20,29 @@ const (
 )
 
 var (
-	_ Codec              = &linearCodec{}
-	_ codec.Codec        = &linearCodec{}
-	_ codec.Registry     = &linearCodec{}
-	_ codec.GeneralCodec = &linearCodec{}
+	_ Codec                = &linearCodec{}
+	_ codec.Codec          = &linearCodec{}
+	_ codec.VersionedCodec = &linearCodec{}
+	_ codec.Registry       = &linearCodec{}
+	_ codec.GeneralCodec   = &linearCodec{}
+	_ codec.Describer      = &linearCodec{}
 )
 
 // Codec marshals and unmarshals
 type Codec interface {
 	codec.Registry
-	codec.Codec
+	codec.VersionedCodec
+	codec.Describer
 	SkipRegistrations(int)
 }
 
 // Codec handles marshaling and unmarshaling of structs
 type linearCodec struct {
-	codec.Codec
+	codec.VersionedCodec
+
+	// used to describe the schema of the registered types
+	fielder     reflectcodec.StructFielder
+	maxSliceLen uint32
 
 	lock         sync.RWMutex
 	nextTypeID   uint32
@@ -49,8 +57,10 @@ func New(tagNames []string, maxSliceLen uint32) Codec {
 		nextTypeID:   0,
 		typeIDToType: map[uint32]reflect.Type{},
 		typeToTypeID: map[reflect.Type]uint32{},
+		fielder:      reflectcodec.NewStructFielder(tagNames, maxSliceLen),
+		maxSliceLen:  maxSliceLen,
 	}
-	hCodec.Codec = reflectcodec.New(hCodec, tagNames, maxSliceLen)
+	hCodec.VersionedCodec = reflectcodec.New(hCodec, tagNames, maxSliceLen)
 	return hCodec
 }
 
@@ -117,3 +127,26 @@ func (c *linearCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type) (
 	}
 	return reflect.New(implementingType).Elem(), nil // instance of the proper type
 }
+
+// Schema returns the wire format of the registered types, as of [version], in
+// order of type ID
+func (c *linearCodec) Schema(version uint16) (*codec.Schema, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	typeIDs := make([]uint32, 0, len(c.typeIDToType))
+	for typeID := range c.typeIDToType {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Slice(typeIDs, func(i, j int) bool {
+		return typeIDs[i] < typeIDs[j]
+	})
+
+	builder := reflectcodec.NewSchemaBuilder(c.fielder, c.maxSliceLen, version)
+	for _, typeID := range typeIDs {
+		if err := builder.AddType(typeID, c.typeIDToType[typeID]); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Schema(), nil
+}
@@ -6,7 +6,10 @@ package linearcodec
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
 )
 
 func TestVectors(t *testing.T) {
@@ -22,3 +25,105 @@ func TestMultipleTags(t *testing.T) {
 		test(c, t)
 	}
 }
+
+func TestVersions(t *testing.T) {
+	for _, test := range codec.VersionTests {
+		c := NewDefault()
+		test(c, t)
+	}
+}
+
+type schemaStruct struct {
+	Fixed   [4]byte              `serialize:"true"`
+	Bytes   []byte               `serialize:"true" len:"8"`
+	Nums    []uint16             `serialize:"true"`
+	Pairs   [2]codec.Foo         `serialize:"true"`
+	Nested  *codec.MyInnerStruct `serialize:"true"`
+	Ignored int                  `serialize:"false"`
+}
+
+func TestSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New([]string{reflectcodec.DefaultTagName}, 16)
+	assert.NoError(c.RegisterType(&codec.MyInnerStruct{}))
+	c.SkipRegistrations(1)
+	assert.NoError(c.RegisterType(&codec.VersionedStructV1{}))
+	assert.NoError(c.RegisterType(schemaStruct{}))
+
+	manager := codec.NewDefaultManager()
+	assert.NoError(manager.RegisterCodec(1, c))
+	assert.NoError(manager.RegisterCodec(0, c))
+
+	schemas, err := manager.Schema()
+	assert.NoError(err)
+	assert.Len(schemas, 2)
+
+	const (
+		innerName     = "github.com/ava-labs/avalanchego/codec.MyInnerStruct"
+		versionedName = "github.com/ava-labs/avalanchego/codec.VersionedStructV1"
+		fooName       = "github.com/ava-labs/avalanchego/codec.Foo"
+		schemaName    = "github.com/ava-labs/avalanchego/codec/linearcodec.schemaStruct"
+	)
+	stringType := &codec.WireType{Kind: codec.KindString}
+	innerType := &codec.WireType{Kind: codec.KindStruct, Name: innerName}
+	fooType := &codec.WireType{Kind: codec.KindInterface, Name: fooName}
+	expectedTypes := []codec.RegisteredType{
+		{
+			ID:   0,
+			Name: "*codec.MyInnerStruct",
+			Type: innerType,
+		},
+		{
+			ID:   2,
+			Name: "*codec.VersionedStructV1",
+			Type: &codec.WireType{Kind: codec.KindStruct, Name: versionedName},
+		},
+		{
+			ID:   3,
+			Name: "linearcodec.schemaStruct",
+			Type: &codec.WireType{Kind: codec.KindStruct, Name: schemaName},
+		},
+	}
+	innerFields := []codec.Field{{Name: "Str", Type: stringType}}
+	schemaFields := []codec.Field{
+		{Name: "Fixed", Type: &codec.WireType{Kind: codec.KindFixedBytes, Length: 4}},
+		{Name: "Bytes", Type: &codec.WireType{Kind: codec.KindBytes, MaxLength: 8}},
+		{Name: "Nums", Type: &codec.WireType{
+			Kind:      codec.KindSlice,
+			MaxLength: 16,
+			Elem:      &codec.WireType{Kind: codec.KindUint16},
+		}},
+		{Name: "Pairs", Type: &codec.WireType{
+			Kind:   codec.KindArray,
+			Length: 2,
+			Elem:   fooType,
+		}},
+		{Name: "Nested", Type: innerType},
+	}
+
+	assert.EqualValues(0, schemas[0].Version)
+	assert.Equal(&codec.Schema{
+		Types: expectedTypes,
+		Structs: map[string][]codec.Field{
+			innerName:     innerFields,
+			versionedName: {{Name: "Str", Type: stringType}},
+			schemaName:    schemaFields,
+		},
+		Interfaces: map[string][]uint32{
+			fooName: {0, 2},
+		},
+	}, schemas[0].Schema)
+
+	// Version 1 should include the fields added in version 1.
+	assert.EqualValues(1, schemas[1].Version)
+	assert.Equal(map[string][]codec.Field{
+		innerName: innerFields,
+		versionedName: {
+			{Name: "Str", Type: stringType},
+			{Name: "Added", Type: &codec.WireType{Kind: codec.KindUint32}, Since: 1},
+			{Name: "Inner", Type: innerType, Since: 1},
+		},
+		schemaName: schemaFields,
+	}, schemas[1].Schema.Structs)
+}
@@ -6,6 +6,7 @@ package codec
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -35,7 +36,9 @@ var _ Manager = &manager{}
 
 // Manager describes the functionality for managing codec versions.
 type Manager interface {
-	// Associate the given codec with the given version ID
+	// Associate the given codec with the given version ID. If the codec is a
+	// VersionedCodec, it is passed the version of the values it (un)marshals,
+	// so the same codec may be registered for multiple versions.
 	RegisterCodec(version uint16, codec Codec) error
 
 	// Define the maximum size, in bytes, of something serialized/deserialized
@@ -50,6 +53,10 @@ type Manager interface {
 	// be a pointer or an interface. Returns the version of the codec that
 	// produces the given bytes.
 	Unmarshal(source []byte, destination interface{}) (version uint16, err error)
+
+	// Schema returns the wire format of each version whose codec is a
+	// Describer, in order of version.
+	Schema() ([]VersionSchema, error)
 }
 
 // NewManager returns a new codec manager.
@@ -111,6 +118,9 @@ func (m *manager) Marshal(version uint16, value interface{}) ([]byte, error) {
 	if p.Errored() {
 		return nil, errCantPackVersion // Should never happen
 	}
+	if vc, ok := c.(VersionedCodec); ok {
+		return p.Bytes, vc.MarshalVersionInto(version, value, &p)
+	}
 	return p.Bytes, c.MarshalInto(value, &p)
 }
 
@@ -142,5 +152,33 @@ func (m *manager) Unmarshal(bytes []byte, dest interface{}) (uint16, error) {
 	if !exists {
 		return version, errUnknownVersion
 	}
+	if vc, ok := c.(VersionedCodec); ok {
+		return version, vc.UnmarshalVersion(version, p.Bytes[p.Offset:], dest)
+	}
 	return version, c.Unmarshal(p.Bytes[p.Offset:], dest)
 }
+
+func (m *manager) Schema() ([]VersionSchema, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	schemas := make([]VersionSchema, 0, len(m.codecs))
+	for version, c := range m.codecs {
+		describer, ok := c.(Describer)
+		if !ok {
+			continue
+		}
+		schema, err := describer.Schema(version)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't describe codec version %d: %w", version, err)
+		}
+		schemas = append(schemas, VersionSchema{
+			Version: version,
+			Schema:  schema,
+		})
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Version < schemas[j].Version
+	})
+	return schemas, nil
+}
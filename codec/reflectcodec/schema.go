@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reflectcodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/codec"
+)
+
+// SchemaBuilder describes the wire format of the types registered with a codec
+// returned by New.
+type SchemaBuilder struct {
+	fielder     StructFielder
+	maxSliceLen uint32
+	version     uint16
+
+	types      []codec.RegisteredType
+	registered []reflect.Type
+	structs    map[string][]codec.Field
+	interfaces map[string]reflect.Type
+}
+
+// NewSchemaBuilder returns a builder for the schema, as of [version], of a
+// codec that finds serialized fields with [fielder] and limits slices to
+// [maxSliceLen] elements by default.
+func NewSchemaBuilder(fielder StructFielder, maxSliceLen uint32, version uint16) *SchemaBuilder {
+	return &SchemaBuilder{
+		fielder:     fielder,
+		maxSliceLen: maxSliceLen,
+		version:     version,
+		structs:     make(map[string][]codec.Field),
+		interfaces:  make(map[string]reflect.Type),
+	}
+}
+
+// AddType adds [t], which was registered with [typeID], to the schema. Types
+// should be added in order of type ID.
+func (b *SchemaBuilder) AddType(typeID uint32, t reflect.Type) error {
+	wireType, err := b.describe(t, b.maxSliceLen)
+	if err != nil {
+		return fmt.Errorf("couldn't describe type %s: %w", t, err)
+	}
+	b.types = append(b.types, codec.RegisteredType{
+		ID:   typeID,
+		Name: t.String(),
+		Type: wireType,
+	})
+	b.registered = append(b.registered, t)
+	return nil
+}
+
+// Schema returns the schema of the added types
+func (b *SchemaBuilder) Schema() *codec.Schema {
+	interfaces := make(map[string][]uint32, len(b.interfaces))
+	for name, intf := range b.interfaces {
+		typeIDs := []uint32{}
+		for i, t := range b.registered {
+			if t.Implements(intf) {
+				typeIDs = append(typeIDs, b.types[i].ID)
+			}
+		}
+		interfaces[name] = typeIDs
+	}
+	return &codec.Schema{
+		Types:      b.types,
+		Structs:    b.structs,
+		Interfaces: interfaces,
+	}
+}
+
+// describe returns the wire format of [t], mirroring how genericCodec marshals
+// values of type [t].
+func (b *SchemaBuilder) describe(t reflect.Type, maxSliceLen uint32) (*codec.WireType, error) {
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Bool, reflect.String:
+		return &codec.WireType{Kind: t.Kind().String()}, nil
+	case reflect.Ptr:
+		return b.describe(t.Elem(), b.maxSliceLen)
+	case reflect.Interface:
+		name := typeName(t)
+		b.interfaces[name] = t
+		return &codec.WireType{
+			Kind: codec.KindInterface,
+			Name: name,
+		}, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &codec.WireType{
+				Kind:      codec.KindBytes,
+				MaxLength: maxSliceLen,
+			}, nil
+		}
+		elem, err := b.describe(t.Elem(), b.maxSliceLen)
+		if err != nil {
+			return nil, err
+		}
+		return &codec.WireType{
+			Kind:      codec.KindSlice,
+			MaxLength: maxSliceLen,
+			Elem:      elem,
+		}, nil
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &codec.WireType{
+				Kind:   codec.KindFixedBytes,
+				Length: t.Len(),
+			}, nil
+		}
+		elem, err := b.describe(t.Elem(), b.maxSliceLen)
+		if err != nil {
+			return nil, err
+		}
+		return &codec.WireType{
+			Kind:   codec.KindArray,
+			Length: t.Len(),
+			Elem:   elem,
+		}, nil
+	case reflect.Struct:
+		name := typeName(t)
+		wireType := &codec.WireType{
+			Kind: codec.KindStruct,
+			Name: name,
+		}
+		if _, described := b.structs[name]; described {
+			return wireType, nil
+		}
+		// Mark the struct as described before describing its fields, in case
+		// it's recursive.
+		b.structs[name] = nil
+
+		serializedFields, err := b.fielder.GetSerializedFields(t)
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]codec.Field, 0, len(serializedFields))
+		for _, fieldDesc := range serializedFields {
+			if fieldDesc.Since > b.version {
+				continue
+			}
+			field := t.Field(fieldDesc.Index)
+			fieldType, err := b.describe(field.Type, fieldDesc.MaxSliceLen)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't describe field %s.%s: %w", t, field.Name, err)
+			}
+			fields = append(fields, codec.Field{
+				Name:  field.Name,
+				Type:  fieldType,
+				Since: fieldDesc.Since,
+			})
+		}
+		b.structs[name] = fields
+		return wireType, nil
+	default:
+		return nil, fmt.Errorf("can't describe unknown kind %s", t.Kind())
+	}
+}
+
+// typeName returns the name of [t] qualified by its package path, so that
+// types with the same name in different packages are distinguished.
+func typeName(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
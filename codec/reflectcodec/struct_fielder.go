@@ -14,6 +14,10 @@ const (
 	// SliceLenTagName that specifies the length of a slice.
 	SliceLenTagName = "len"
 
+	// SinceTagName that specifies the first codec version a field is
+	// serialized in. Fields without the tag are serialized in every version.
+	SinceTagName = "since"
+
 	// TagValue is the value the tag must have to be serialized.
 	TagValue = "true"
 )
@@ -23,6 +27,8 @@ var _ StructFielder = &structFielder{}
 type FieldDesc struct {
 	Index       int
 	MaxSliceLen uint32
+	// Since is the first codec version the field is serialized in.
+	Since uint16
 }
 
 // StructFielder handles discovery of serializable fields in a struct.
@@ -31,7 +37,7 @@ type StructFielder interface {
 	// a struct type. Additionally, returns the custom maximum length slice that
 	// may be serialized into the field, if any.
 	// Returns an error if a field has tag "[tagName]: [TagValue]" but the field
-	// is un-exported.
+	// is un-exported, or if the field's "[SinceTagName]" tag isn't a version.
 	// GetSerializedField(Foo) --> [1,5,8] means Foo.Field(1), Foo.Field(5),
 	// Foo.Field(8) are to be serialized/deserialized.
 	GetSerializedFields(t reflect.Type) ([]FieldDesc, error)
@@ -99,9 +105,18 @@ func (s *structFielder) GetSerializedFields(t reflect.Type) ([]FieldDesc, error)
 		if newLen, err := strconv.ParseUint(sliceLenField, 10, 31); err == nil {
 			maxSliceLen = uint32(newLen)
 		}
+		var since uint16
+		if sinceField, ok := field.Tag.Lookup(SinceTagName); ok {
+			sinceVersion, err := strconv.ParseUint(sinceField, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s tag on field %s: %w", SinceTagName, field.Name, err)
+			}
+			since = uint16(sinceVersion)
+		}
 		serializedFields = append(serializedFields, FieldDesc{
 			Index:       i,
 			MaxSliceLen: maxSliceLen,
+			Since:       since,
 		})
 	}
 	s.serializedFieldIndices[t] = serializedFields // cache result
@@ -16,6 +16,10 @@ import (
 const (
 	// DefaultTagName that enables serialization.
 	DefaultTagName = "serialize"
+
+	// latestVersion is the version values are (un)marshaled as when no
+	// version is specified, which includes every field.
+	latestVersion = math.MaxUint16
 )
 
 var (
@@ -23,6 +27,7 @@ var (
 	errUnmarshalNil = errors.New("can't unmarshal nil")
 	errNeedPointer  = errors.New("argument to unmarshal must be a pointer")
 	errExtraSpace   = errors.New("trailing buffer space")
+	errFieldVersion = errors.New("field isn't serialized in version")
 )
 
 var _ codec.Codec = &genericCodec{}
@@ -56,6 +61,9 @@ type TypeCodec interface {
 // 5) To unmarshal an interface,  you must call codec.RegisterType([instance of the type that fulfills the interface]).
 // 6) Serialized fields must be exported
 // 7) nil slices are marshaled as empty slices
+// 8) To add a field to a struct in a new codec version, add the tag
+//    `since:"{version}"` to it. The field is zero when unmarshaling earlier
+//    versions, and marshaling an earlier version fails if the field isn't zero.
 type genericCodec struct {
 	typer       TypeCodec
 	maxSliceLen uint32
@@ -63,7 +71,7 @@ type genericCodec struct {
 }
 
 // New returns a new, concurrency-safe codec
-func New(typer TypeCodec, tagNames []string, maxSliceLen uint32) codec.Codec {
+func New(typer TypeCodec, tagNames []string, maxSliceLen uint32) codec.VersionedCodec {
 	return &genericCodec{
 		typer:       typer,
 		maxSliceLen: maxSliceLen,
@@ -77,13 +85,23 @@ func (c *genericCodec) MarshalInto(value interface{}, p *wrappers.Packer) error
 		return errMarshalNil // can't marshal nil
 	}
 
-	return c.marshal(reflect.ValueOf(value), p, c.maxSliceLen)
+	return c.marshal(latestVersion, reflect.ValueOf(value), p, c.maxSliceLen)
 }
 
-// marshal writes the byte representation of [value] to [p]
+// MarshalVersionInto marshals [value] as of [version]. To marshal an
+// interface, [value] must be a pointer to the interface
+func (c *genericCodec) MarshalVersionInto(version uint16, value interface{}, p *wrappers.Packer) error {
+	if value == nil {
+		return errMarshalNil // can't marshal nil
+	}
+
+	return c.marshal(version, reflect.ValueOf(value), p, c.maxSliceLen)
+}
+
+// marshal writes the byte representation of [value] as of [version] to [p]
 // [value]'s underlying value must not be a nil pointer or interface
 // c.lock should be held for the duration of this function
-func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSliceLen uint32) error {
+func (c *genericCodec) marshal(version uint16, value reflect.Value, p *wrappers.Packer, maxSliceLen uint32) error {
 	valueKind := value.Kind()
 	switch valueKind {
 	case reflect.Interface, reflect.Ptr, reflect.Invalid:
@@ -124,14 +142,14 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 		p.PackBool(value.Bool())
 		return p.Err
 	case reflect.Uintptr, reflect.Ptr:
-		return c.marshal(value.Elem(), p, c.maxSliceLen)
+		return c.marshal(version, value.Elem(), p, c.maxSliceLen)
 	case reflect.Interface:
 		underlyingValue := value.Interface()
 		underlyingType := reflect.TypeOf(underlyingValue)
 		if err := c.typer.PackPrefix(p, underlyingType); err != nil {
 			return err
 		}
-		if err := c.marshal(value.Elem(), p, c.maxSliceLen); err != nil {
+		if err := c.marshal(version, value.Elem(), p, c.maxSliceLen); err != nil {
 			return err
 		}
 		return p.Err
@@ -153,7 +171,7 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 			return p.Err
 		}
 		for i := 0; i < numElts; i++ { // Process each element in the slice
-			if err := c.marshal(value.Index(i), p, c.maxSliceLen); err != nil {
+			if err := c.marshal(version, value.Index(i), p, c.maxSliceLen); err != nil {
 				return err
 			}
 		}
@@ -169,7 +187,7 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 			return fmt.Errorf("array length, %d, exceeds maximum length, %d", numElts, c.maxSliceLen)
 		}
 		for i := 0; i < numElts; i++ { // Process each element in the array
-			if err := c.marshal(value.Index(i), p, c.maxSliceLen); err != nil {
+			if err := c.marshal(version, value.Index(i), p, c.maxSliceLen); err != nil {
 				return err
 			}
 		}
@@ -180,7 +198,22 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 			return err
 		}
 		for _, fieldDesc := range serializedFields { // Go through all fields of this struct that are serialized
-			if err := c.marshal(value.Field(fieldDesc.Index), p, fieldDesc.MaxSliceLen); err != nil { // Serialize the field and write to byte array
+			field := value.Field(fieldDesc.Index)
+			if fieldDesc.Since > version {
+				// The field didn't exist in [version], so it can only be
+				// omitted if doing so doesn't lose information.
+				if !field.IsZero() {
+					return fmt.Errorf("%w %d: %s.%s was added in version %d",
+						errFieldVersion,
+						version,
+						value.Type(),
+						value.Type().Field(fieldDesc.Index).Name,
+						fieldDesc.Since,
+					)
+				}
+				continue
+			}
+			if err := c.marshal(version, field, p, fieldDesc.MaxSliceLen); err != nil { // Serialize the field and write to byte array
 				return err
 			}
 		}
@@ -193,6 +226,12 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 // Unmarshal unmarshals [bytes] into [dest], where
 // [dest] must be a pointer or interface
 func (c *genericCodec) Unmarshal(bytes []byte, dest interface{}) error {
+	return c.UnmarshalVersion(latestVersion, bytes, dest)
+}
+
+// UnmarshalVersion unmarshals [bytes], which were marshaled as of [version],
+// into [dest], where [dest] must be a pointer or interface
+func (c *genericCodec) UnmarshalVersion(version uint16, bytes []byte, dest interface{}) error {
 	if dest == nil {
 		return errUnmarshalNil
 	}
@@ -204,7 +243,7 @@ func (c *genericCodec) Unmarshal(bytes []byte, dest interface{}) error {
 	if destPtr.Kind() != reflect.Ptr {
 		return errNeedPointer
 	}
-	if err := c.unmarshal(&p, destPtr.Elem(), c.maxSliceLen); err != nil {
+	if err := c.unmarshal(version, &p, destPtr.Elem(), c.maxSliceLen); err != nil {
 		return err
 	}
 	if p.Offset != len(bytes) {
@@ -213,9 +252,10 @@ func (c *genericCodec) Unmarshal(bytes []byte, dest interface{}) error {
 	return nil
 }
 
-// Unmarshal from p.Bytes into [value]. [value] must be addressable.
+// Unmarshal from p.Bytes, as of [version], into [value]. [value] must be
+// addressable.
 // c.lock should be held for the duration of this function
-func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSliceLen uint32) error {
+func (c *genericCodec) unmarshal(version uint16, p *wrappers.Packer, value reflect.Value, maxSliceLen uint32) error {
 	switch value.Kind() {
 	case reflect.Uint8:
 		value.SetUint(uint64(p.UnpackByte()))
@@ -298,7 +338,7 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		value.Set(reflect.MakeSlice(value.Type(), numElts, numElts))
 		// Unmarshal each element into the appropriate index of the slice
 		for i := 0; i < numElts; i++ {
-			if err := c.unmarshal(p, value.Index(i), c.maxSliceLen); err != nil {
+			if err := c.unmarshal(version, p, value.Index(i), c.maxSliceLen); err != nil {
 				return fmt.Errorf("couldn't unmarshal slice element: %w", err)
 			}
 		}
@@ -316,7 +356,7 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 			return nil
 		}
 		for i := 0; i < numElts; i++ {
-			if err := c.unmarshal(p, value.Index(i), c.maxSliceLen); err != nil {
+			if err := c.unmarshal(version, p, value.Index(i), c.maxSliceLen); err != nil {
 				return fmt.Errorf("couldn't unmarshal array element: %w", err)
 			}
 		}
@@ -333,7 +373,7 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 			return err
 		}
 		// Unmarshal into the struct
-		if err := c.unmarshal(p, intfImplementor, c.maxSliceLen); err != nil {
+		if err := c.unmarshal(version, p, intfImplementor, c.maxSliceLen); err != nil {
 			return fmt.Errorf("couldn't unmarshal interface: %w", err)
 		}
 		// And assign the filled struct to the value
@@ -347,7 +387,14 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		}
 		// Go through the fields and umarshal into them
 		for _, fieldDesc := range serializedFieldIndices {
-			if err := c.unmarshal(p, value.Field(fieldDesc.Index), fieldDesc.MaxSliceLen); err != nil {
+			field := value.Field(fieldDesc.Index)
+			if fieldDesc.Since > version {
+				// The field didn't exist in [version], so it is zeroed in
+				// case [value] was already populated.
+				field.Set(reflect.Zero(field.Type()))
+				continue
+			}
+			if err := c.unmarshal(version, p, field, fieldDesc.MaxSliceLen); err != nil {
 				return fmt.Errorf("couldn't unmarshal struct: %w", err)
 			}
 		}
@@ -358,7 +405,7 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		// Create a new pointer to a new value of the underlying type
 		v := reflect.New(t)
 		// Fill the value
-		if err := c.unmarshal(p, v.Elem(), c.maxSliceLen); err != nil {
+		if err := c.unmarshal(version, p, v.Elem(), c.maxSliceLen); err != nil {
 			return fmt.Errorf("couldn't unmarshal pointer: %w", err)
 		}
 		// Assign to the top-level struct's member
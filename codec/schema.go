@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+// Kinds of values in a codec's wire format. Integers are big endian. Strings
+// are prefixed by their length as a uint16. Bytes and slices are prefixed by
+// their length as a uint32. Fixed bytes and arrays have no length prefix.
+// Structs are their fields in order. Interfaces are prefixed by the uint32 type
+// ID of their concrete type.
+const (
+	KindUint8      = "uint8"
+	KindUint16     = "uint16"
+	KindUint32     = "uint32"
+	KindUint64     = "uint64"
+	KindInt8       = "int8"
+	KindInt16      = "int16"
+	KindInt32      = "int32"
+	KindInt64      = "int64"
+	KindBool       = "bool"
+	KindString     = "string"
+	KindBytes      = "bytes"
+	KindFixedBytes = "fixedBytes"
+	KindSlice      = "slice"
+	KindArray      = "array"
+	KindStruct     = "struct"
+	KindInterface  = "interface"
+)
+
+// Describer is a codec that can describe its wire format
+type Describer interface {
+	// Schema returns the wire format of the types registered with the codec,
+	// as of [version].
+	Schema(version uint16) (*Schema, error)
+}
+
+// VersionSchema is the wire format of a codec version
+type VersionSchema struct {
+	Version uint16  `json:"version"`
+	Schema  *Schema `json:"schema"`
+}
+
+// Schema describes the wire format of the types registered with a codec
+type Schema struct {
+	// Types are the registered types, in order of type ID.
+	Types []RegisteredType `json:"types"`
+	// Structs maps the name of every struct referenced by the schema to its
+	// serialized fields, in order.
+	Structs map[string][]Field `json:"structs"`
+	// Interfaces maps the name of every interface referenced by the schema to
+	// the IDs of the registered types that implement it.
+	Interfaces map[string][]uint32 `json:"interfaces"`
+}
+
+// RegisteredType is a type that may be (un)marshaled into an interface
+type RegisteredType struct {
+	ID uint32 `json:"id"`
+	// Name is the Go type that was registered.
+	Name string    `json:"name"`
+	Type *WireType `json:"type"`
+}
+
+// Field is a serialized field of a struct
+type Field struct {
+	Name string    `json:"name"`
+	Type *WireType `json:"type"`
+	// Since is the first codec version the field is serialized in.
+	Since uint16 `json:"since,omitempty"`
+}
+
+// WireType describes how a value is serialized
+type WireType struct {
+	Kind string `json:"kind"`
+	// Name is the name of the struct or interface, for those kinds.
+	Name string `json:"name,omitempty"`
+	// Length is the number of elements of fixed bytes and arrays.
+	Length int `json:"length,omitempty"`
+	// MaxLength is the maximum number of elements of bytes and slices.
+	MaxLength uint32 `json:"maxLength,omitempty"`
+	// Elem is the type of the elements of slices and arrays.
+	Elem *WireType `json:"elem,omitempty"`
+}
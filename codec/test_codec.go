@@ -45,6 +45,14 @@ var MultipleTagsTests = []func(c GeneralCodec, t testing.TB){
 	TestMultipleTags,
 }
 
+// VersionTests are the tests of codecs that support the since tag
+var VersionTests = []func(c GeneralCodec, t testing.TB){
+	TestSinceField,
+	TestSinceFieldInInterface,
+	TestSinceFieldNotZero,
+	TestInvalidSinceTag,
+}
+
 // The below structs and interfaces exist
 // for the sake of testing
 
@@ -1049,3 +1057,143 @@ func TestMultipleTags(codec GeneralCodec, t testing.TB) {
 		assert.True(t, len(output.NoTags) == 0)
 	}
 }
+
+type VersionedStructV0 struct {
+	Str string `serialize:"true"`
+}
+
+type VersionedStructV1 struct {
+	Str      string         `serialize:"true"`
+	Added    uint32         `serialize:"true" since:"1"`
+	Inner    *MyInnerStruct `serialize:"true" since:"1"`
+	AddedTwo []string       `serialize:"true" since:"2"`
+}
+
+func (*VersionedStructV1) Foo() int {
+	return 4
+}
+
+// newVersionedManager returns a manager with [codec] registered for versions
+// 0, 1 and 2.
+func newVersionedManager(codec GeneralCodec, t testing.TB) Manager {
+	manager := NewDefaultManager()
+	for version := uint16(0); version <= 2; version++ {
+		if err := manager.RegisterCodec(version, codec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return manager
+}
+
+// Test that fields tagged with since are only serialized in later versions
+func TestSinceField(codec GeneralCodec, t testing.TB) {
+	assert := assert.New(t)
+	manager := newVersionedManager(codec, t)
+
+	// Bytes of the old struct should unmarshal with the new fields left as
+	// zero.
+	oldBytes, err := manager.Marshal(0, &VersionedStructV0{Str: "old"})
+	assert.NoError(err)
+
+	newStruct := VersionedStructV1{}
+	version, err := manager.Unmarshal(oldBytes, &newStruct)
+	assert.NoError(err)
+	assert.EqualValues(0, version)
+	assert.Equal(VersionedStructV1{Str: "old"}, newStruct)
+
+	// The new fields should be zeroed even if they were already set.
+	populatedStruct := VersionedStructV1{
+		Str:      "populated",
+		Added:    1,
+		Inner:    &MyInnerStruct{Str: "inner"},
+		AddedTwo: []string{"value"},
+	}
+	_, err = manager.Unmarshal(oldBytes, &populatedStruct)
+	assert.NoError(err)
+	assert.Equal(VersionedStructV1{Str: "old"}, populatedStruct)
+
+	// Marshaling the new struct as of version 0 should produce the old bytes.
+	newBytes, err := manager.Marshal(0, &newStruct)
+	assert.NoError(err)
+	assert.Equal(oldBytes, newBytes)
+
+	// Later versions should include the fields added by then.
+	newStruct = VersionedStructV1{
+		Str:   "new",
+		Added: 5,
+		Inner: &MyInnerStruct{Str: "inner"},
+	}
+	v1Bytes, err := manager.Marshal(1, &newStruct)
+	assert.NoError(err)
+	v2Bytes, err := manager.Marshal(2, &newStruct)
+	assert.NoError(err)
+	// The version 2 bytes include an empty AddedTwo slice.
+	assert.Equal(len(v1Bytes)+wrappers.IntLen, len(v2Bytes))
+
+	for _, b := range [][]byte{v1Bytes, v2Bytes} {
+		unmarshaled := VersionedStructV1{}
+		_, err := manager.Unmarshal(b, &unmarshaled)
+		assert.NoError(err)
+		assert.Equal(newStruct.Str, unmarshaled.Str)
+		assert.Equal(newStruct.Added, unmarshaled.Added)
+		assert.Equal(newStruct.Inner, unmarshaled.Inner)
+		assert.Empty(unmarshaled.AddedTwo)
+	}
+
+	// Marshaling without a version should include every field.
+	p := wrappers.Packer{MaxSize: 1024}
+	assert.NoError(codec.MarshalInto(&newStruct, &p))
+	assert.Equal(v2Bytes[wrappers.ShortLen:], p.Bytes)
+}
+
+// Test that the version is applied to the values of interfaces
+func TestSinceFieldInInterface(codec GeneralCodec, t testing.TB) {
+	assert := assert.New(t)
+	assert.NoError(codec.RegisterType(&VersionedStructV1{}))
+	manager := newVersionedManager(codec, t)
+
+	var foo Foo = &VersionedStructV1{Str: "str"}
+	v0Bytes, err := manager.Marshal(0, &foo)
+	assert.NoError(err)
+
+	var unmarshaled Foo
+	_, err = manager.Unmarshal(v0Bytes, &unmarshaled)
+	assert.NoError(err)
+	assert.Equal(foo, unmarshaled)
+
+	foo = &VersionedStructV1{
+		Str:   "str",
+		Added: 1,
+		Inner: &MyInnerStruct{},
+	}
+	v1Bytes, err := manager.Marshal(1, &foo)
+	assert.NoError(err)
+	assert.Greater(len(v1Bytes), len(v0Bytes))
+
+	_, err = manager.Unmarshal(v1Bytes, &unmarshaled)
+	assert.NoError(err)
+	assert.Equal(foo, unmarshaled)
+}
+
+// Test that marshaling an older version fails rather than dropping a field
+// that isn't zero
+func TestSinceFieldNotZero(codec GeneralCodec, t testing.TB) {
+	manager := newVersionedManager(codec, t)
+
+	_, err := manager.Marshal(1, &VersionedStructV1{
+		AddedTwo: []string{"value"},
+	})
+	assert.Error(t, err)
+}
+
+type InvalidSinceStruct struct {
+	Field uint32 `serialize:"true" since:"latest"`
+}
+
+// Test that an invalid since tag is reported
+func TestInvalidSinceTag(codec GeneralCodec, t testing.TB) {
+	manager := newVersionedManager(codec, t)
+
+	_, err := manager.Marshal(0, &InvalidSinceStruct{})
+	assert.Error(t, err)
+}
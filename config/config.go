@@ -18,14 +18,18 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/app/runner"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
@@ -35,6 +39,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -62,6 +67,8 @@ var (
 	errInvalidStakerWeights          = errors.New("staking weights must be positive")
 	errStakingDisableOnPublicNetwork = errors.New("staking disabled on public network")
 	errAuthPasswordTooWeak           = errors.New("API auth password is not strong enough")
+	errClientCAsWithoutTLS           = errors.New("client CA certificates require the HTTP server to use TLS")
+	errClientAuthWithoutClientCAs    = errors.New("client certificates can't be required or mapped to permissions without client CA certificates")
 	errInvalidUptimeRequirement      = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax     = errors.New("minimum validator stake can't be greater than maximum validator stake")
 	errInvalidDelegationFee          = errors.New("delegation fee must be in the range [0, 1,000,000]")
@@ -72,6 +79,8 @@ var (
 	errCannotWhitelistPrimaryNetwork = errors.New("cannot whitelist primary network")
 	errStakingKeyContentUnset        = fmt.Errorf("%s key not set but %s set", StakingKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset       = fmt.Errorf("%s key set but %s not set", StakingKeyContentKey, StakingCertContentKey)
+	errInvalidMaxRequestSize         = fmt.Errorf("%s must be positive", HTTPMaxRequestSizeKey)
+	errInvalidMaxBatchSize           = fmt.Errorf("%s can't be negative", HTTPMaxBatchSizeKey)
 )
 
 func GetRunnerConfig(v *viper.Viper) (runner.Config, error) {
@@ -255,16 +264,117 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		HTTPSKey:          httpsKey,
 		HTTPSCert:         httpsCert,
 		APIAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
+		MaxRequestSize:    v.GetInt64(HTTPMaxRequestSizeKey),
+		MaxBatchSize:      v.GetInt(HTTPMaxBatchSizeKey),
+		GRPCAPIEnabled:    v.GetBool(GRPCAPIEnabledKey),
+		GRPCPort:          uint16(v.GetUint(GRPCPortKey)),
 
 		ShutdownTimeout: v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:    v.GetDuration(HTTPShutdownWaitKey),
 	}
+	if config.MaxRequestSize <= 0 {
+		return node.HTTPConfig{}, errInvalidMaxRequestSize
+	}
+	if config.MaxBatchSize < 0 {
+		return node.HTTPConfig{}, errInvalidMaxBatchSize
+	}
 
 	config.APIAuthConfig, err = getAPIAuthConfig(v)
 	if err != nil {
 		return node.HTTPConfig{}, err
 	}
 	config.IPCConfig = getIPCConfig(v)
+	config.RateLimitConfig, err = getRateLimitConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	if err := getClientAuthConfig(v, &config); err != nil {
+		return node.HTTPConfig{}, err
+	}
+	return config, nil
+}
+
+// getClientAuthConfig sets the configuration of the authentication of HTTPs
+// clients by their TLS certificates
+func getClientAuthConfig(v *viper.Viper, config *node.HTTPConfig) error {
+	var err error
+	switch {
+	case v.IsSet(HTTPSClientCAContentKey):
+		rawContent := v.GetString(HTTPSClientCAContentKey)
+		config.HTTPSClientCAs, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPSClientCAFileKey):
+		path := GetExpandedArg(v, HTTPSClientCAFileKey)
+		config.HTTPSClientCAs, err = os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+	}
+	config.HTTPSClientCertRequired = v.GetBool(HTTPSClientCertRequiredKey)
+
+	var permissionsBytes []byte
+	switch {
+	case v.IsSet(HTTPSClientPermissionsContentKey):
+		rawContent := v.GetString(HTTPSClientPermissionsContentKey)
+		permissionsBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPSClientPermissionsFileKey):
+		path := GetExpandedArg(v, HTTPSClientPermissionsFileKey)
+		permissionsBytes, err = os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+	}
+	if len(permissionsBytes) > 0 {
+		if err := json.Unmarshal(permissionsBytes, &config.ClientCertificateConfig); err != nil {
+			return fmt.Errorf("couldn't parse client certificate permissions: %w", err)
+		}
+		// Verify the permissions now, rather than when the API server starts.
+		if _, err := auth.NewCertificateAuthorizer(config.ClientCertificateConfig, nil); err != nil {
+			return fmt.Errorf("invalid client certificate permissions: %w", err)
+		}
+	}
+
+	hasClientAuth := config.HTTPSClientCertRequired || len(config.ClientCertificateConfig.Certificates) > 0
+	switch {
+	case len(config.HTTPSClientCAs) > 0 && !config.HTTPSEnabled:
+		return errClientCAsWithoutTLS
+	case len(config.HTTPSClientCAs) == 0 && hasClientAuth:
+		return errClientAuthWithoutClientCAs
+	}
+	return nil
+}
+
+func getRateLimitConfig(v *viper.Viper) (server.RateLimitConfig, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	switch {
+	case v.IsSet(HTTPRateLimitConfigContentKey):
+		rawContent := v.GetString(HTTPRateLimitConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return server.RateLimitConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPRateLimitConfigFileKey):
+		path := GetExpandedArg(v, HTTPRateLimitConfigFileKey)
+		configBytes, err = os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return server.RateLimitConfig{}, err
+		}
+	default:
+		return server.RateLimitConfig{}, nil
+	}
+
+	config := server.RateLimitConfig{}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return server.RateLimitConfig{}, fmt.Errorf("couldn't parse API rate limit config: %w", err)
+	}
 	return config, nil
 }
 
@@ -360,10 +470,18 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 				},
 			},
 
-			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
-				AtLargeAllocSize:    v.GetUint64(OutboundThrottlerAtLargeAllocSizeKey),
-				VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
-				NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
+			OutboundMsgThrottlerConfig: throttling.OutboundMsgThrottlerConfig{
+				MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
+					AtLargeAllocSize:    v.GetUint64(OutboundThrottlerAtLargeAllocSizeKey),
+					VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
+					NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
+				},
+				NodeMaxClassBytes: map[message.Class]uint64{
+					message.ConsensusClass: v.GetUint64(OutboundThrottlerNodeMaxConsensusBytesKey),
+					message.BootstrapClass: v.GetUint64(OutboundThrottlerNodeMaxBootstrapBytesKey),
+					message.AppClass:       v.GetUint64(OutboundThrottlerNodeMaxAppBytesKey),
+					message.GossipClass:    v.GetUint64(OutboundThrottlerNodeMaxGossipBytesKey),
+				},
 			},
 		},
 
@@ -399,8 +517,20 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 			InitialReconnectDelay: v.GetDuration(NetworkInitialReconnectDelayKey),
 		},
 
+		ReputationConfig: reputation.Config{
+			BanThreshold:  v.GetFloat64(NetworkReputationBanThresholdKey),
+			BanDuration:   v.GetDuration(NetworkReputationBanDurationKey),
+			ScoreHalflife: v.GetDuration(NetworkReputationScoreHalflifeKey),
+			MaxScores:     v.GetInt(NetworkReputationMaxScoresKey),
+			SyncFrequency: v.GetDuration(NetworkReputationSyncFrequencyKey),
+
+			ToleratedViolations:       v.GetInt(NetworkReputationToleratedViolationsKey),
+			ToleratedViolationsWindow: v.GetDuration(NetworkReputationToleratedViolationsWindowKey),
+		},
+
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionEnabled:           v.GetBool(NetworkCompressionEnabledKey),
+		ProtoCodecEnabled:            v.GetBool(NetworkProtoCodecEnabledKey),
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              v.GetBool(NetworkAllowPrivateIPsKey),
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -411,7 +541,68 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
 	}
 
+	compressionType, err := compression.TypeFromString(v.GetString(NetworkCompressionTypeKey))
+	if err != nil {
+		return network.Config{}, err
+	}
+	config.CompressionType = compressionType
+
+	if v.IsSet(NetworkCompressionZstdDictFileKey) {
+		path := GetExpandedArg(v, NetworkCompressionZstdDictFileKey)
+		config.CompressionZstdDict, err = os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't read %s: %w", NetworkCompressionZstdDictFileKey, err)
+		}
+	}
+
+	for _, pinnedPeer := range strings.Split(v.GetString(NetworkPinnedPeersKey), ",") {
+		if pinnedPeer == "" {
+			continue
+		}
+		nodeIDStr, ipStr, ok := strings.Cut(pinnedPeer, "@")
+		if !ok {
+			return network.Config{}, fmt.Errorf("couldn't parse pinned peer %s: expected NodeID@IP:port", pinnedPeer)
+		}
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't parse pinned peer id %s: %w", nodeIDStr, err)
+		}
+		ip, err := ips.ToIPPort(ipStr)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't parse pinned peer ip %s: %w", ipStr, err)
+		}
+		config.PinnedPeers = append(config.PinnedPeers, network.PinnedPeer{
+			NodeID: nodeID,
+			IP:     ip,
+		})
+	}
+
+	for _, id := range strings.Split(v.GetString(NetworkAllowedNodeIDsKey), ",") {
+		if id == "" {
+			continue
+		}
+		nodeID, err := ids.NodeIDFromString(id)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't parse allowed node id %s: %w", id, err)
+		}
+		config.AllowedNodeIDs = append(config.AllowedNodeIDs, nodeID)
+	}
+
+	classKeys := map[message.Class]string{
+		message.ConsensusClass: OutboundThrottlerNodeMaxConsensusBytesKey,
+		message.BootstrapClass: OutboundThrottlerNodeMaxBootstrapBytesKey,
+		message.AppClass:       OutboundThrottlerNodeMaxAppBytesKey,
+		message.GossipClass:    OutboundThrottlerNodeMaxGossipBytesKey,
+	}
+	for class, maxBytes := range config.ThrottlerConfig.OutboundMsgThrottlerConfig.NodeMaxClassBytes {
+		if maxBytes != 0 && maxBytes < constants.DefaultMaxMessageSize {
+			return network.Config{}, fmt.Errorf("%s must be 0 or >= %d", classKeys[class], constants.DefaultMaxMessageSize)
+		}
+	}
+
 	switch {
+	case config.CompressionType != compression.TypeGzip && config.CompressionType != compression.TypeZstd:
+		return network.Config{}, fmt.Errorf("%s must be %q or %q", NetworkCompressionTypeKey, compression.TypeGzip, compression.TypeZstd)
 	case config.HealthConfig.MaxTimeSinceMsgSent < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkHealthMaxTimeSinceMsgSentKey)
 	case config.HealthConfig.MaxTimeSinceMsgReceived < 0:
@@ -440,6 +631,20 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.ReputationConfig.BanThreshold < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationBanThresholdKey)
+	case config.ReputationConfig.BanDuration <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkReputationBanDurationKey)
+	case config.ReputationConfig.ScoreHalflife < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationScoreHalflifeKey)
+	case config.ReputationConfig.MaxScores <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkReputationMaxScoresKey)
+	case config.ReputationConfig.SyncFrequency <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkReputationSyncFrequencyKey)
+	case config.ReputationConfig.ToleratedViolations < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationToleratedViolationsKey)
+	case config.ReputationConfig.ToleratedViolationsWindow < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationToleratedViolationsWindowKey)
 	}
 	return config, nil
 }
@@ -837,13 +1042,20 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 		}
 	}
 
+	prefixStatsFrequency := v.GetDuration(DBPrefixStatsFrequencyKey)
+	if prefixStatsFrequency < 0 {
+		return node.DatabaseConfig{}, fmt.Errorf("%s must be >= 0", DBPrefixStatsFrequencyKey)
+	}
+
 	return node.DatabaseConfig{
 		Name: v.GetString(DBTypeKey),
 		Path: filepath.Join(
 			GetExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:               configBytes,
+		ExportDir:            GetExpandedArg(v, DBExportDirKey),
+		PrefixStatsFrequency: prefixStatsFrequency,
 	}, nil
 }
 
@@ -1228,6 +1440,7 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 
 	// Metrics
 	nodeConfig.MeterVMEnabled = v.GetBool(MeterVMsEnabledKey)
+	nodeConfig.MetricsChainLabelsEnabled = v.GetBool(MetricsChainLabelsEnabledKey)
 
 	// Adaptive Timeout Config
 	nodeConfig.AdaptiveTimeoutConfig, err = getAdaptiveTimeoutConfig(v)
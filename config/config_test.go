@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -19,6 +21,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -592,6 +596,33 @@ func setupFile(t *testing.T, path string, fileName string, value string) {
 	assert.NoError(t, os.WriteFile(filePath, []byte(value), 0o600))
 }
 
+func TestGetNetworkConfigStaticPeers(t *testing.T) {
+	assert := assert.New(t)
+
+	pinnedID := ids.GenerateTestNodeID()
+	allowedID := ids.GenerateTestNodeID()
+
+	v := setupViperFlags()
+	v.Set(NetworkPinnedPeersKey, fmt.Sprintf("%s@127.0.0.1:9651", pinnedID))
+	v.Set(NetworkAllowedNodeIDsKey, fmt.Sprintf("%s,%s", allowedID, pinnedID))
+	config, err := getNetworkConfig(v, time.Minute)
+	assert.NoError(err)
+	assert.Equal([]network.PinnedPeer{
+		{
+			NodeID: pinnedID,
+			IP: ips.IPPort{
+				IP:   net.IPv4(127, 0, 0, 1),
+				Port: 9651,
+			},
+		},
+	}, config.PinnedPeers)
+	assert.Equal([]ids.NodeID{allowedID, pinnedID}, config.AllowedNodeIDs)
+
+	v.Set(NetworkPinnedPeersKey, "127.0.0.1:9651")
+	_, err = getNetworkConfig(v, time.Minute)
+	assert.Error(err)
+}
+
 func setupViperFlags() *viper.Viper {
 	v := viper.New()
 	fs := BuildFlagSet()
@@ -621,3 +652,20 @@ func setupViper(configFilePath string) *viper.Viper {
 	}
 	return v
 }
+
+func TestGetHTTPConfigLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	v := setupViperFlags()
+	_, err := getHTTPConfig(v)
+	assert.NoError(err)
+
+	v.Set(HTTPMaxBatchSizeKey, -1)
+	_, err = getHTTPConfig(v)
+	assert.ErrorIs(err, errInvalidMaxBatchSize)
+
+	v.Set(HTTPMaxBatchSizeKey, 0)
+	v.Set(HTTPMaxRequestSizeKey, 0)
+	_, err = getHTTPConfig(v)
+	assert.ErrorIs(err, errInvalidMaxRequestSize)
+}
@@ -18,9 +18,12 @@ import (
 
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/database/rocksdb"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/units"
 )
@@ -28,6 +31,7 @@ import (
 const (
 	DefaultHTTPPort    = 9650
 	DefaultStakingPort = 9651
+	DefaultGRPCPort    = 9652
 
 	AvalancheGoDataDirVar    = "AVALANCHEGO_DATA_DIR"
 	defaultUnexpandedDataDir = "$" + AvalancheGoDataDirVar
@@ -37,6 +41,7 @@ var (
 	// [defaultUnexpandedDataDir] will be expanded when reading the flags
 	defaultDataDir         = filepath.Join("$HOME", ".avalanchego")
 	defaultDBDir           = filepath.Join(defaultUnexpandedDataDir, "db")
+	defaultDBExportDir     = filepath.Join(defaultUnexpandedDataDir, "db-exports")
 	defaultLogDir          = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir      = filepath.Join(defaultUnexpandedDataDir, "profiles")
 	defaultStakingPath     = filepath.Join(defaultUnexpandedDataDir, "staking")
@@ -106,10 +111,12 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(CreateBlockchainTxFeeKey, genesis.LocalParams.CreateBlockchainTxFee, "Transaction fee, in nAVAX, for transactions that create new blockchains")
 
 	// Database
-	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s, %s}", leveldb.Name, rocksdb.Name, memdb.Name))
+	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s, %s, %s}", leveldb.Name, rocksdb.Name, pebbledb.Name, memdb.Name))
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
+	fs.String(DBExportDirKey, defaultDBExportDir, "Path to the directory that database archives are exported to by the Admin API")
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.Duration(DBPrefixStatsFrequencyKey, 10*time.Minute, "Frequency at which the disk usage of the registered database prefixes is estimated. If 0, the disk usage is never estimated in the background")
 
 	// Logging
 	fs.String(LogsDirKey, defaultLogDir, "Logging directory for Avalanche")
@@ -168,11 +175,23 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
 	fs.Bool(NetworkCompressionEnabledKey, true, "If true, compress certain outbound messages. This node will be able to parse compressed inbound messages regardless of this flag's value")
+	fs.String(NetworkCompressionTypeKey, compression.TypeGzip.String(), fmt.Sprintf("Compression type to compress outbound messages with, if compression is enabled. Must be %q or %q. Messages are gzip compressed for peers that don't support the compression type. This node will be able to parse inbound messages compressed with either type regardless of this flag's value", compression.TypeGzip, compression.TypeZstd))
+	fs.String(NetworkCompressionZstdDictFileKey, "", "Path to a zstd dictionary to compress outbound zstd messages with, for peers that are configured with the same dictionary. Other peers are sent zstd messages compressed without a dictionary. This node will be able to parse inbound messages compressed with the dictionary only if it's set")
+	fs.Bool(NetworkProtoCodecEnabledKey, true, "If true, send protobuf encoded messages to peers that support them. This node will be able to parse protobuf encoded inbound messages regardless of this flag's value")
 	fs.Duration(NetworkMaxClockDifferenceKey, time.Minute, "Max allowed clock difference value between this node and peers")
 	fs.Bool(NetworkAllowPrivateIPsKey, true, "Allows the node to initiate outbound connection attempts to peers with private IPs")
 	fs.Bool(NetworkRequireValidatorToConnectKey, false, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Float64(NetworkReputationBanThresholdKey, 100, "Score at which a peer is banned. A peer's score increases each time it violates the p2p protocol. If 0, peers are never banned automatically")
+	fs.Duration(NetworkReputationBanDurationKey, 24*time.Hour, "Amount of time a peer is banned for when its score reaches the ban threshold, or when it is banned without a duration through the admin API")
+	fs.Duration(NetworkReputationScoreHalflifeKey, time.Hour, "Halflife of a peer's score. If 0, scores never decay")
+	fs.Int(NetworkReputationMaxScoresKey, 10000, "Maximum number of peer scores kept. Once reached, the score of the peer that least recently violated the p2p protocol is dropped")
+	fs.Duration(NetworkReputationSyncFrequencyKey, time.Minute, "Frequency that peer scores and automatic bans are written to disk at, and that expired bans and decayed scores are deleted at")
+	fs.Int(NetworkReputationToleratedViolationsKey, 10, fmt.Sprintf("Number of times a peer can report a skewed clock, or fail to respond to a request in time, within %s before its score is increased for it", NetworkReputationToleratedViolationsWindowKey))
+	fs.Duration(NetworkReputationToleratedViolationsWindowKey, 10*time.Minute, fmt.Sprintf("Duration of the window that the violations tolerated by %s are counted in", NetworkReputationToleratedViolationsKey))
+	fs.String(NetworkPinnedPeersKey, "", "Comma separated list of peers, formatted as NodeID@IP:port, that this node always attempts to stay connected to. Connections to pinned peers are retried forever at the given IP")
+	fs.String(NetworkAllowedNodeIDsKey, "", "Comma separated list of node IDs. If non-empty, this node only connects to these nodes and the pinned peers")
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, 10, "Number of consecutive failed queries before benchlisting a node")
@@ -206,6 +225,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, 32*units.MiB, "Size, in bytes, of at-large byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerVdrAllocSizeKey, 32*units.MiB, "Size, in bytes, of validator byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerNodeMaxAtLargeBytesKey, constants.DefaultMaxMessageSize, "Max number of bytes a node can take from the outbound message throttler's at-large allocation.  Must be at least the max message size")
+	fs.Uint64(OutboundThrottlerNodeMaxConsensusBytesKey, 0, "Max number of bytes of consensus messages that can be waiting to be sent to a node. If 0, there is no limit. Otherwise, must be at least the max message size")
+	fs.Uint64(OutboundThrottlerNodeMaxBootstrapBytesKey, 4*constants.DefaultMaxMessageSize, "Max number of bytes of bootstrapping and state sync messages that can be waiting to be sent to a node. If 0, there is no limit. Otherwise, must be at least the max message size")
+	fs.Uint64(OutboundThrottlerNodeMaxAppBytesKey, 2*constants.DefaultMaxMessageSize, "Max number of bytes of application level messages that can be waiting to be sent to a node. If 0, there is no limit. Otherwise, must be at least the max message size")
+	fs.Uint64(OutboundThrottlerNodeMaxGossipBytesKey, 2*constants.DefaultMaxMessageSize, "Max number of bytes of handshake and peer gossip messages that can be waiting to be sent to a node. If 0, there is no limit. Otherwise, must be at least the max message size")
 
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server")
@@ -215,9 +238,20 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
 	fs.String(HTTPSCertFileKey, "", fmt.Sprintf("TLS certificate file for the HTTPs server. Ignored if %s is specified", HTTPSCertContentKey))
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
+	fs.String(HTTPSClientCAFileKey, "", fmt.Sprintf("PEM file of the CA certificates that must have issued the TLS certificates of HTTPs clients. If set, clients are authenticated by their certificates. Ignored if %s is specified", HTTPSClientCAContentKey))
+	fs.String(HTTPSClientCAContentKey, "", "Specifies base64 encoded PEM CA certificates that must have issued the TLS certificates of HTTPs clients")
+	fs.Bool(HTTPSClientCertRequiredKey, false, "If true, HTTPs clients must present a TLS certificate. Otherwise, presenting one is optional")
+	fs.String(HTTPSClientPermissionsFileKey, "", fmt.Sprintf("Path to the JSON file that maps the TLS certificates of HTTPs clients to API permissions. Ignored if %s is specified", HTTPSClientPermissionsContentKey))
+	fs.String(HTTPSClientPermissionsContentKey, "", "Specifies base64 encoded mapping of the TLS certificates of HTTPs clients to API permissions")
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.avax.network https://*.avax-test.network")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
 	fs.Duration(HTTPShutdownTimeoutKey, 10*time.Second, "Maximum duration to wait for existing connections to complete during node shutdown")
+	fs.String(HTTPRateLimitConfigFileKey, "", fmt.Sprintf("Path to the JSON file that configures the per-client API rate limits. Ignored if %s is specified", HTTPRateLimitConfigContentKey))
+	fs.String(HTTPRateLimitConfigContentKey, "", "Specifies base64 encoded per-client API rate limit config content")
+	fs.Int64(HTTPMaxRequestSizeKey, json.DefaultMaxRequestSize, "Maximum size, in bytes, of the body of an HTTP API request")
+	fs.Int(HTTPMaxBatchSizeKey, json.DefaultMaxBatchSize, "Maximum number of requests in a JSON-RPC batch. If 0, batches aren't supported")
+	fs.Bool(GRPCAPIEnabledKey, false, fmt.Sprintf("If true, this node serves the info, health and index APIs and the X-Chain and P-Chain read APIs over gRPC on %s. Calls are authorized as calls of the HTTP APIs, and use TLS if %s is true", GRPCPortKey, HTTPSEnabledKey))
+	fs.Uint(GRPCPortKey, DefaultGRPCPort, "Port of the gRPC server. It listens on the host of the HTTP server")
 	fs.Bool(APIAuthRequiredKey, false, "Require authorization token to call HTTP APIs")
 	fs.String(APIAuthPasswordFileKey, "",
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
@@ -306,6 +340,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 
 	// Metrics
 	fs.Bool(MeterVMsEnabledKey, true, "Enable Meter VMs to track VM performance with more granularity")
+	fs.Bool(MetricsChainLabelsEnabledKey, false, "If true, the metrics of chains are named avalanche_chain_* and avalanche_chain_vm_*, and are labeled with the chain and subnet IDs. Otherwise, they're named by the primary alias of the chain, such as avalanche_X_*")
 	fs.Duration(UptimeMetricFreqKey, 30*time.Second, "Frequency of renewing this node's average uptime metric")
 
 	// IPC
@@ -33,7 +33,9 @@ const (
 	DBTypeKey                                          = "db-type"
 	DBPathKey                                          = "db-dir"
 	DBConfigFileKey                                    = "db-config-file"
+	DBExportDirKey                                     = "db-export-dir"
 	DBConfigContentKey                                 = "db-config-file-content"
+	DBPrefixStatsFrequencyKey                          = "db-prefix-stats-frequency"
 	PublicIPKey                                        = "public-ip"
 	DynamicUpdateDurationKey                           = "dynamic-update-duration"
 	DynamicPublicIPResolverKey                         = "dynamic-public-ip"
@@ -50,9 +52,20 @@ const (
 	HTTPSKeyContentKey                                 = "http-tls-key-file-content"
 	HTTPSCertFileKey                                   = "http-tls-cert-file"
 	HTTPSCertContentKey                                = "http-tls-cert-file-content"
+	HTTPSClientCAFileKey                               = "http-tls-client-ca-file"
+	HTTPSClientCAContentKey                            = "http-tls-client-ca-file-content"
+	HTTPSClientCertRequiredKey                         = "http-tls-client-cert-required"
+	HTTPSClientPermissionsFileKey                      = "http-tls-client-permissions-file"
+	HTTPSClientPermissionsContentKey                   = "http-tls-client-permissions-file-content"
 	HTTPAllowedOrigins                                 = "http-allowed-origins"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
 	HTTPShutdownWaitKey                                = "http-shutdown-wait"
+	HTTPRateLimitConfigFileKey                         = "http-rate-limit-config-file"
+	HTTPRateLimitConfigContentKey                      = "http-rate-limit-config-file-content"
+	HTTPMaxRequestSizeKey                              = "http-max-request-size"
+	HTTPMaxBatchSizeKey                                = "http-max-batch-size"
+	GRPCAPIEnabledKey                                  = "grpc-api-enabled"
+	GRPCPortKey                                        = "grpc-port"
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
@@ -91,11 +104,23 @@ const (
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionEnabledKey                       = "network-compression-enabled"
+	NetworkProtoCodecEnabledKey                        = "network-proto-codec-enabled"
+	NetworkCompressionTypeKey                          = "network-compression-type"
+	NetworkCompressionZstdDictFileKey                  = "network-compression-zstd-dict-file"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkReputationBanThresholdKey                   = "network-reputation-ban-threshold"
+	NetworkReputationBanDurationKey                    = "network-reputation-ban-duration"
+	NetworkReputationScoreHalflifeKey                  = "network-reputation-score-halflife"
+	NetworkReputationMaxScoresKey                      = "network-reputation-max-scores"
+	NetworkReputationSyncFrequencyKey                  = "network-reputation-sync-frequency"
+	NetworkReputationToleratedViolationsKey            = "network-reputation-tolerated-violations"
+	NetworkReputationToleratedViolationsWindowKey      = "network-reputation-tolerated-violations-window"
+	NetworkPinnedPeersKey                              = "network-pinned-peers"
+	NetworkAllowedNodeIDsKey                           = "network-allowed-node-ids"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
@@ -131,6 +156,7 @@ const (
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
 	IpcsPathKey                                        = "ipcs-path"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
+	MetricsChainLabelsEnabledKey                       = "metrics-chain-labels-enabled"
 	ConsensusGossipFrequencyKey                        = "consensus-gossip-frequency"
 	ConsensusGossipAcceptedFrontierValidatorSizeKey    = "consensus-accepted-frontier-gossip-validator-size"
 	ConsensusGossipAcceptedFrontierNonValidatorSizeKey = "consensus-accepted-frontier-gossip-non-validator-size"
@@ -187,6 +213,10 @@ const (
 	OutboundThrottlerAtLargeAllocSizeKey               = "throttler-outbound-at-large-alloc-size"
 	OutboundThrottlerVdrAllocSizeKey                   = "throttler-outbound-validator-alloc-size"
 	OutboundThrottlerNodeMaxAtLargeBytesKey            = "throttler-outbound-node-max-at-large-bytes"
+	OutboundThrottlerNodeMaxConsensusBytesKey          = "throttler-outbound-node-max-consensus-bytes"
+	OutboundThrottlerNodeMaxBootstrapBytesKey          = "throttler-outbound-node-max-bootstrap-bytes"
+	OutboundThrottlerNodeMaxAppBytesKey                = "throttler-outbound-node-max-app-bytes"
+	OutboundThrottlerNodeMaxGossipBytesKey             = "throttler-outbound-node-max-gossip-bytes"
 	UptimeMetricFreqKey                                = "uptime-metric-freq"
 	VMAliasesFileKey                                   = "vm-aliases-file"
 	VMAliasesContentKey                                = "vm-aliases-file-content"
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+// PrefixRegistrar wraps the RegisterPrefix method of a data store that can
+// account for the storage used by the keys under a prefix.
+type PrefixRegistrar interface {
+	// RegisterPrefix requests that the keys in the data store that start with
+	// [prefix] are accounted for under [name]. A nil prefix registers every
+	// key in the data store. If [countKeys] is true, the keys under [prefix]
+	// are counted when exact stats are requested, which iterates over all of
+	// them, so it should only be set for prefixes of bounded size.
+	RegisterPrefix(name string, prefix []byte, countKeys bool) error
+}
+
+// SizeEstimator wraps the EstimateSize method of a backing data store.
+type SizeEstimator interface {
+	// EstimateSize returns the approximate amount of disk space, in bytes,
+	// used by the keys in the range [start, limit). A nil limit is treated as
+	// a key after all keys in the data store.
+	EstimateSize(start, limit []byte) (uint64, error)
+}
+
+// RegisterPrefix registers [prefix] under [name] with [db] if [db] supports
+// prefix accounting. Accounting is best effort, so databases that don't
+// support it are ignored.
+func RegisterPrefix(db interface{}, name string, prefix []byte, countKeys bool) error {
+	registrar, ok := db.(PrefixRegistrar)
+	if !ok {
+		return nil
+	}
+	return registrar.RegisterPrefix(name, prefix, countKeys)
+}
+
+// EstimateSize returns the approximate amount of disk space used by the keys
+// in the range [start, limit) of [db]. Returns [ErrNotSupported] if [db]
+// can't estimate its disk usage.
+func EstimateSize(db interface{}, start, limit []byte) (uint64, error) {
+	estimator, ok := db.(SizeEstimator)
+	if !ok {
+		return 0, ErrNotSupported
+	}
+	return estimator.EstimateSize(start, limit)
+}
@@ -0,0 +1,322 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package archive implements a portable, compressed and checksummed archive
+// format for the versioned databases of a node.
+//
+// An archive is a gzip stream containing:
+//   - a header with a magic string and the format version
+//   - the length prefixed JSON encoding of the Manifest
+//   - one section per database listed in the manifest, made up of a sequence
+//     of length prefixed key-value records, followed by an end marker, the
+//     number of records and the sha256 checksum of the records
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	magic         = "AVAXDBAR"
+	formatVersion = uint16(0)
+
+	recordTag = byte(1)
+	endTag    = byte(0)
+
+	maxManifestSize = units.MiB
+	maxRecordSize   = 256 * units.MiB
+
+	// restoreBatchSize is the number of bytes that are buffered before being
+	// written into a restored database.
+	restoreBatchSize = 4 * units.MiB
+)
+
+var (
+	errInvalidMagic         = errors.New("not a database archive")
+	errUnknownFormatVersion = errors.New("unknown archive format version")
+	errManifestTooLarge     = errors.New("manifest is too large")
+	errRecordTooLarge       = errors.New("record is too large")
+	errInvalidTag           = errors.New("invalid record tag")
+	errChecksumMismatch     = errors.New("checksum mismatch")
+	errKeyCountMismatch     = errors.New("key count mismatch")
+	errAlreadyRestored      = errors.New("archive was already restored")
+)
+
+// Export writes an archive of [dbs] to [w]. [manifest.Databases] is populated
+// from [dbs]. Keys that start with any of [skippedPrefixes] aren't archived.
+//
+// A snapshot of every database is taken before any data is written, so the
+// archive is a consistent view of the databases even if they are being
+// written to concurrently.
+func Export(w io.Writer, manifest *Manifest, dbs []*manager.VersionedDatabase, skippedPrefixes [][]byte) error {
+	snapshots := make([]database.Snapshot, 0, len(dbs))
+	defer func() {
+		for _, snapshot := range snapshots {
+			snapshot.Release()
+		}
+	}()
+	manifest.Databases = make([]string, len(dbs))
+	for i, db := range dbs {
+		snapshot, err := db.Database.NewSnapshot()
+		if err != nil {
+			return fmt.Errorf("couldn't snapshot database %s: %w", db.Version, err)
+		}
+		snapshots = append(snapshots, snapshot)
+		manifest.Databases[i] = db.Version.String()
+	}
+
+	manifestBytes, err := stdjson.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if len(manifestBytes) > maxManifestSize {
+		return errManifestTooLarge
+	}
+
+	gw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(gw)
+
+	var header [len(magic) + 2]byte
+	copy(header[:], magic)
+	binary.BigEndian.PutUint16(header[len(magic):], formatVersion)
+	if _, err := bw.Write(header[:]); err != nil {
+		return err
+	}
+	if err := writeBytes(bw, manifestBytes); err != nil {
+		return err
+	}
+
+	for i, snapshot := range snapshots {
+		if err := writeDatabase(bw, snapshot, skippedPrefixes); err != nil {
+			return fmt.Errorf("couldn't export database %s: %w", manifest.Databases[i], err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeDatabase(w io.Writer, db database.Iteratee, skippedPrefixes [][]byte) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		hasher = sha256.New()
+		hw     = io.MultiWriter(w, hasher)
+		keys   uint64
+	)
+	for it.Next() {
+		if hasAnyPrefix(it.Key(), skippedPrefixes) {
+			continue
+		}
+		if _, err := w.Write([]byte{recordTag}); err != nil {
+			return err
+		}
+		if err := writeBytes(hw, it.Key()); err != nil {
+			return err
+		}
+		if err := writeBytes(hw, it.Value()); err != nil {
+			return err
+		}
+		keys++
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	var trailer [1 + 8]byte
+	trailer[0] = endTag
+	binary.BigEndian.PutUint64(trailer[1:], keys)
+	if _, err := w.Write(trailer[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(hasher.Sum(nil))
+	return err
+}
+
+func writeBytes(w io.Writer, b []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(b)))
+	if _, err := w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// Reader restores the databases contained in an archive.
+type Reader struct {
+	r        *bufio.Reader
+	manifest Manifest
+	restored bool
+}
+
+// NewReader reads the header and manifest of the archive in [r].
+func NewReader(r io.Reader) (*Reader, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidMagic, err)
+	}
+	br := bufio.NewReader(gr)
+
+	var header [len(magic) + 2]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("couldn't read archive header: %w", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, errInvalidMagic
+	}
+	if v := binary.BigEndian.Uint16(header[len(magic):]); v != formatVersion {
+		return nil, fmt.Errorf("%w: %d", errUnknownFormatVersion, v)
+	}
+
+	manifestBytes, err := readBytes(br, maxManifestSize)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read manifest: %w", err)
+	}
+	reader := &Reader{r: br}
+	if err := stdjson.Unmarshal(manifestBytes, &reader.manifest); err != nil {
+		return nil, fmt.Errorf("couldn't parse manifest: %w", err)
+	}
+	return reader, nil
+}
+
+// Manifest returns the manifest of the archive.
+func (r *Reader) Manifest() *Manifest { return &r.manifest }
+
+// Restore writes every database in the archive into the database returned by
+// [newDB] for its version. Each database's key count and checksum are
+// verified once all of its records have been written, so if an error is
+// returned the restored databases should be discarded. Restore can only be
+// called once.
+func (r *Reader) Restore(newDB func(*version.Semantic) (database.Database, error)) error {
+	if r.restored {
+		return errAlreadyRestored
+	}
+	r.restored = true
+
+	for _, versionStr := range r.manifest.Databases {
+		v, err := version.Parse(versionStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse database version %q: %w", versionStr, err)
+		}
+		db, err := newDB(v)
+		if err != nil {
+			return fmt.Errorf("couldn't open database %s: %w", v, err)
+		}
+		err = r.restoreDatabase(db)
+		if closeErr := db.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't restore database %s: %w", v, err)
+		}
+	}
+
+	// Ensure that the gzip checksum of the stream is verified.
+	if _, err := io.Copy(io.Discard, r.r); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *Reader) restoreDatabase(db database.Batcher) error {
+	var (
+		batch  = db.NewBatch()
+		hasher = sha256.New()
+		keys   uint64
+	)
+	for {
+		tag, err := r.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if tag == endTag {
+			break
+		}
+		if tag != recordTag {
+			return fmt.Errorf("%w: %d", errInvalidTag, tag)
+		}
+
+		key, err := readHashedBytes(r.r, hasher)
+		if err != nil {
+			return err
+		}
+		value, err := readHashedBytes(r.r, hasher)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+		keys++
+
+		if batch.Size() >= restoreBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	var trailer [8 + sha256.Size]byte
+	if _, err := io.ReadFull(r.r, trailer[:]); err != nil {
+		return err
+	}
+	if expectedKeys := binary.BigEndian.Uint64(trailer[:8]); expectedKeys != keys {
+		return fmt.Errorf("%w: expected %d keys but read %d", errKeyCountMismatch, expectedKeys, keys)
+	}
+	expectedChecksum, checksum := trailer[8:], hasher.Sum(nil)
+	if !bytes.Equal(expectedChecksum, checksum) {
+		return fmt.Errorf("%w: expected %x but calculated %x", errChecksumMismatch, expectedChecksum, checksum)
+	}
+	return nil
+}
+
+// readHashedBytes reads a length prefixed byte slice from [r] and adds the
+// encoded bytes to [h].
+func readHashedBytes(r io.Reader, h hash.Hash) ([]byte, error) {
+	return readBytes(io.TeeReader(r, h), maxRecordSize)
+}
+
+func readBytes(r io.Reader, maxSize int) ([]byte, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if uint64(size) > uint64(maxSize) {
+		return nil, fmt.Errorf("%w: %d bytes", errRecordTooLarge, size)
+	}
+	b := make([]byte, size)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+func hasAnyPrefix(key []byte, prefixes [][]byte) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func newTestDBs(t *testing.T) []*manager.VersionedDatabase {
+	current := memdb.New()
+	for i := 0; i < 1000; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		value := bytes.Repeat([]byte{byte(i)}, i%17)
+		if err := current.Put(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prev := memdb.New()
+	if err := prev.Put([]byte("old"), []byte("state")); err != nil {
+		t.Fatal(err)
+	}
+	return []*manager.VersionedDatabase{
+		{
+			Database: current,
+			Version:  version.CurrentDatabase,
+		},
+		{
+			Database: prev,
+			Version:  version.PrevDatabase,
+		},
+	}
+}
+
+func assertEqualDBs(t *testing.T, expected, actual database.Iteratee) {
+	expectedIt := expected.NewIterator()
+	defer expectedIt.Release()
+	actualIt := actual.NewIterator()
+	defer actualIt.Release()
+
+	for expectedIt.Next() {
+		if !actualIt.Next() {
+			t.Fatalf("missing key %s", expectedIt.Key())
+		}
+		assert.Equal(t, expectedIt.Key(), actualIt.Key())
+		assert.Equal(t, expectedIt.Value(), actualIt.Value())
+	}
+	assert.False(t, actualIt.Next(), "unexpected extra key")
+	assert.NoError(t, expectedIt.Error())
+	assert.NoError(t, actualIt.Error())
+}
+
+// restoreToMemDBs restores the archive in [r] into in-memory databases,
+// indexed by version.
+func restoreToMemDBs(r *Reader) (map[string]*memdb.Database, error) {
+	restored := make(map[string]*memdb.Database)
+	err := r.Restore(func(v *version.Semantic) (database.Database, error) {
+		db := memdb.New()
+		restored[v.String()] = db
+		// Keep the contents around after the restore closes the database.
+		return &unclosableDB{Database: db}, nil
+	})
+	return restored, err
+}
+
+type unclosableDB struct {
+	*memdb.Database
+}
+
+func (*unclosableDB) Close() error { return nil }
+
+func TestExportRestore(t *testing.T) {
+	dbs := newTestDBs(t)
+	chainID := ids.GenerateTestID()
+	manifest := &Manifest{
+		NodeVersion: version.Current.String(),
+		NetworkID:   json.Uint32(constants.LocalID),
+		Timestamp:   time.Unix(1, 0).UTC(),
+		Chains: []ChainInfo{
+			{
+				ID:           chainID,
+				Name:         "C",
+				LastAccepted: ids.GenerateTestID(),
+				Height:       5,
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Export(buf, manifest, dbs, nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{version.CurrentDatabase.String(), version.PrevDatabase.String()}, manifest.Databases)
+
+	r, err := NewReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, manifest, r.Manifest())
+	assert.NoError(t, r.Manifest().Verify(constants.LocalID))
+
+	restored, err := restoreToMemDBs(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, restored, 2)
+	for _, db := range dbs {
+		assertEqualDBs(t, db.Database, restored[db.Version.String()])
+	}
+
+	_, err = restoreToMemDBs(r)
+	assert.ErrorIs(t, err, errAlreadyRestored)
+}
+
+func TestExportSkipsPrefixes(t *testing.T) {
+	dbs := newTestDBs(t)
+
+	buf := &bytes.Buffer{}
+	if err := Export(buf, &Manifest{}, dbs, [][]byte{[]byte("key-00"), []byte("old")}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := restoreToMemDBs(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := memdb.New()
+	it := dbs[0].Database.NewIteratorWithStart([]byte("key-01"))
+	for it.Next() {
+		assert.NoError(t, expected.Put(it.Key(), it.Value()))
+	}
+	it.Release()
+	assertEqualDBs(t, expected, restored[version.CurrentDatabase.String()])
+	assertEqualDBs(t, memdb.New(), restored[version.PrevDatabase.String()])
+}
+
+func TestExportIsConsistent(t *testing.T) {
+	dbs := newTestDBs(t)
+	expected := memdb.New()
+	it := dbs[0].Database.NewIterator()
+	for it.Next() {
+		assert.NoError(t, expected.Put(it.Key(), it.Value()))
+	}
+	it.Release()
+
+	// Writes made while the archive is being written must not be included.
+	w := &writeHookWriter{
+		onWrite: func() {
+			_ = dbs[0].Database.Put([]byte("key-00500"), []byte("modified"))
+			_ = dbs[0].Database.Put([]byte("zzz"), []byte("new"))
+		},
+	}
+	if err := Export(w, &Manifest{}, dbs, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(&w.buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := restoreToMemDBs(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqualDBs(t, expected, restored[version.CurrentDatabase.String()])
+}
+
+type writeHookWriter struct {
+	buf     bytes.Buffer
+	onWrite func()
+}
+
+func (w *writeHookWriter) Write(b []byte) (int, error) {
+	w.onWrite()
+	return w.buf.Write(b)
+}
+
+func TestRestoreCorrupted(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := Export(buf, &Manifest{}, newTestDBs(t), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Decompress the archive, flip a byte in the last value of the current
+	// database and compress it again.
+	gr, err := gzip.NewReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := bytes.Index(raw, []byte("key-00999"))
+	if index < 0 {
+		t.Fatal("couldn't find key in archive")
+	}
+	raw[index+len("key-00999")+4] ^= 0xFF
+
+	corrupted := &bytes.Buffer{}
+	gw := gzip.NewWriter(corrupted)
+	_, err = gw.Write(raw)
+	assert.NoError(t, err)
+	assert.NoError(t, gw.Close())
+
+	r, err := NewReader(corrupted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = restoreToMemDBs(r)
+	assert.ErrorIs(t, err, errChecksumMismatch)
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	_, err := gw.Write([]byte("NOTANARCHIVE"))
+	assert.NoError(t, err)
+	assert.NoError(t, gw.Close())
+
+	_, err = NewReader(buf)
+	assert.ErrorIs(t, err, errInvalidMagic)
+
+	_, err = NewReader(bytes.NewReader([]byte("not gzip")))
+	assert.ErrorIs(t, err, errInvalidMagic)
+}
+
+func TestManifestVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		manifest    Manifest
+		expectedErr error
+	}{
+		{
+			name: "compatible",
+			manifest: Manifest{
+				NetworkID: json.Uint32(constants.LocalID),
+				Databases: []string{version.CurrentDatabase.String(), version.PrevDatabase.String()},
+			},
+		},
+		{
+			name: "wrong network",
+			manifest: Manifest{
+				NetworkID: json.Uint32(constants.MainnetID),
+				Databases: []string{version.CurrentDatabase.String()},
+			},
+			expectedErr: errWrongNetwork,
+		},
+		{
+			name: "missing current database",
+			manifest: Manifest{
+				NetworkID: json.Uint32(constants.LocalID),
+				Databases: []string{version.PrevDatabase.String()},
+			},
+			expectedErr: errWrongDatabaseVersion,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.manifest.Verify(constants.LocalID)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	errWrongNetwork         = errors.New("archive was created for a different network")
+	errWrongDatabaseVersion = errors.New("archive doesn't contain the current database version")
+)
+
+// Manifest describes the contents of an archive. It is written at the start
+// of the archive so that it can be inspected before any database is restored.
+type Manifest struct {
+	// NodeVersion is the version of the node that created the archive.
+	NodeVersion string `json:"nodeVersion"`
+	// NetworkID is the ID of the network the archived databases belong to.
+	NetworkID json.Uint32 `json:"networkID"`
+	// Timestamp is when the archive was created.
+	Timestamp time.Time `json:"timestamp"`
+	// Databases lists the archived database versions in the order they
+	// appear in the archive, from the current version to the oldest.
+	Databases []string `json:"databases"`
+	// Chains lists the chains that were running when the archive was
+	// created. The archived databases contain at least the accepted state
+	// described here.
+	Chains []ChainInfo `json:"chains"`
+}
+
+// ChainInfo describes the state of a chain at the time an archive was
+// created.
+type ChainInfo struct {
+	ID   ids.ID `json:"id"`
+	Name string `json:"name"`
+	// LastAccepted and Height are only reported for linear chains and are
+	// left empty for DAG based chains.
+	LastAccepted ids.ID      `json:"lastAccepted"`
+	Height       json.Uint64 `json:"height"`
+}
+
+// Verify returns nil iff the databases in the archive can be used by a node
+// running on [networkID] with the current database version.
+func (m *Manifest) Verify(networkID uint32) error {
+	if uint32(m.NetworkID) != networkID {
+		return fmt.Errorf("%w: archive is for %s but node is running on %s",
+			errWrongNetwork,
+			constants.NetworkName(uint32(m.NetworkID)),
+			constants.NetworkName(networkID),
+		)
+	}
+	current := version.CurrentDatabase.String()
+	for _, v := range m.Databases {
+		if v == current {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %s", errWrongDatabaseVersion, current)
+}
@@ -11,8 +11,9 @@ import (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
+	_ database.Database      = &Database{}
+	_ database.SizeEstimator = &Database{}
+	_ database.Batch         = &batch{}
 )
 
 // CorruptableDB is a wrapper around Database
@@ -79,6 +80,25 @@ func (db *Database) HealthCheck() (interface{}, error) {
 	return db.Database.HealthCheck()
 }
 
+// NewSnapshot returns a snapshot of the database if it isn't corrupted
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	s, err := db.Database.NewSnapshot()
+	return s, db.handleError(err)
+}
+
+// EstimateSize returns the estimated disk usage of the range [start, limit) if
+// the underlying database supports it. Estimation failures don't mark the
+// database as corrupted.
+func (db *Database) EstimateSize(start, limit []byte) (uint64, error) {
+	if err := db.corrupted(); err != nil {
+		return 0, err
+	}
+	return database.EstimateSize(db.Database, start, limit)
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{
 		Batch: db.Database.NewBatch(),
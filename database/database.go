@@ -73,6 +73,7 @@ type Database interface {
 	KeyValueReaderWriterDeleter
 	Batcher
 	Iteratee
+	Snapshotter
 	Compacter
 	io.Closer
 	health.Checker
@@ -26,6 +26,7 @@ var (
 	_ database.Database = &Database{}
 	_ database.Batch    = &batch{}
 	_ database.Iterator = &iterator{}
+	_ database.Snapshot = &snapshot{}
 )
 
 // Database encrypts all values that are provided
@@ -133,6 +134,49 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewIteratorWithRange(start, limit),
+		db:       db,
+	}
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithRange(start, limit),
+		db:       db,
+	}
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	s, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -171,6 +215,72 @@ func (db *Database) HealthCheck() (interface{}, error) {
 	return db.db.HealthCheck()
 }
 
+// snapshot decrypts all values read from the underlying snapshot
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	return s.Snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	encVal, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decrypt(encVal)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: s.Snapshot.NewReverseIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -176,3 +176,19 @@ func ClearPrefix(readerDB Iteratee, deleterDB KeyValueDeleter, prefix []byte) er
 	}
 	return iterator.Error()
 }
+
+// PrefixLimit returns the smallest key that is larger than every key starting
+// with [prefix]. If no such key exists, nil is returned. The result can be
+// used as the limit of a range to iterate over all keys with [prefix].
+func PrefixLimit(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] == 0xff {
+			continue
+		}
+		limit := make([]byte, i+1)
+		copy(limit, prefix)
+		limit[i]++
+		return limit
+	}
+	return nil
+}
@@ -59,4 +59,17 @@ type Iteratee interface {
 	// database content with a particular key prefix starting at a specified
 	// key.
 	NewIteratorWithStartAndPrefix(start, prefix []byte) Iterator
+
+	// NewIteratorWithRange creates an iterator over the subset of database
+	// content with keys in the range [start, limit). An empty start is treated
+	// as a key before all keys in the database and an empty limit is treated
+	// as a key after all keys in the database.
+	NewIteratorWithRange(start, limit []byte) Iterator
+
+	// NewReverseIteratorWithRange creates an iterator over the subset of
+	// database content with keys in the range [start, limit) that returns the
+	// keys in descending order. An empty start is treated as a key before all
+	// keys in the database and an empty limit is treated as a key after all
+	// keys in the database.
+	NewReverseIteratorWithRange(start, limit []byte) Iterator
 }
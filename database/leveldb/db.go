@@ -61,9 +61,11 @@ const (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iter{}
+	_ database.Database      = &Database{}
+	_ database.SizeEstimator = &Database{}
+	_ database.Batch         = &batch{}
+	_ database.Iterator      = &iter{}
+	_ database.Snapshot      = &snapshot{}
 )
 
 // Database is a persistent key-value store. Apart from basic data storage
@@ -322,6 +324,38 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database keys in the range [start, limit)
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iter{
+		db:       db,
+		Iterator: db.DB.NewIterator(newRange(start, limit), nil),
+	}
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database keys in the range [start, limit)
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iter{
+		db:       db,
+		Iterator: db.DB.NewIterator(newRange(start, limit), nil),
+		reverse:  true,
+	}
+}
+
+// NewSnapshot returns a point-in-time view of the database that is backed by
+// a native levelDB snapshot
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snap, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		Snapshot: snap,
+	}, nil
+}
+
 // This comment is basically copy pasted from the underlying levelDB library:
 
 // Compact the underlying DB for the given key range.
@@ -337,6 +371,36 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return updateError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+// EstimateSize returns the approximate amount of disk space used by the keys
+// in the range [start, limit). Data that hasn't been flushed from the memtable
+// yet isn't included.
+func (db *Database) EstimateSize(start, limit []byte) (uint64, error) {
+	if db.closed.GetValue() {
+		return 0, database.ErrClosed
+	}
+
+	if limit == nil {
+		// leveldb treats a nil limit as a key before all keys, so the key
+		// immediately after the largest key in the database is used instead.
+		it := db.DB.NewIterator(nil, nil)
+		if !it.Last() {
+			it.Release()
+			return 0, updateError(it.Error())
+		}
+		limit = make([]byte, len(it.Key())+1)
+		copy(limit, it.Key())
+		it.Release()
+		if err := it.Error(); err != nil {
+			return 0, updateError(err)
+		}
+	}
+	sizes, err := db.DB.SizeOf([]util.Range{{Start: start, Limit: limit}})
+	if err != nil {
+		return 0, updateError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
 func (db *Database) Close() error {
 	db.closed.SetValue(true)
 	db.closeOnce.Do(func() {
@@ -419,10 +483,100 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writerDeleter.Delete(key)
 }
 
+// snapshot is a wrapper around a levelDB snapshot to match the database
+// interfaces.
+type snapshot struct {
+	db *Database
+	*leveldb.Snapshot
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(new(util.Range), nil),
+	}
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(&util.Range{Start: start}, nil),
+	}
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(util.BytesPrefix(prefix), nil),
+	}
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(iterRange, nil),
+	}
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot keys in the range [start, limit)
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(newRange(start, limit), nil),
+	}
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot keys in the range [start, limit)
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(newRange(start, limit), nil),
+		reverse:  true,
+	}
+}
+
+// newRange returns the levelDB range [start, limit). levelDB only treats a nil
+// limit as unbounded, so an empty limit is replaced with nil.
+func newRange(start, limit []byte) *util.Range {
+	if len(limit) == 0 {
+		limit = nil
+	}
+	return &util.Range{Start: start, Limit: limit}
+}
+
 type iter struct {
 	db *Database
 	iterator.Iterator
 
+	// reverse is true if the keys should be returned in descending order
+	reverse, started bool
+
 	key, val []byte
 	err      error
 }
@@ -436,7 +590,17 @@ func (it *iter) Next() bool {
 		return false
 	}
 
-	hasNext := it.Iterator.Next()
+	var hasNext bool
+	switch {
+	case !it.reverse:
+		hasNext = it.Iterator.Next()
+	case !it.started:
+		hasNext = it.Iterator.Last()
+	default:
+		hasNext = it.Iterator.Prev()
+	}
+	it.started = true
+
 	if hasNext {
 		it.key = utils.CopyBytes(it.Iterator.Key())
 		it.val = utils.CopyBytes(it.Iterator.Value())
@@ -460,7 +624,7 @@ func (it *iter) Value() []byte { return it.val }
 
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
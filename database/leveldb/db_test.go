@@ -8,6 +8,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -30,6 +32,42 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestEstimateSize(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	size, err := database.EstimateSize(db, nil, nil)
+	assert.NoError(err)
+	assert.Zero(size)
+
+	value := make([]byte, 1024)
+	for i := 0; i < 256; i++ {
+		assert.NoError(db.Put([]byte{'a', byte(i)}, value))
+		assert.NoError(db.Put([]byte{'b', byte(i)}, value))
+	}
+	// Flush the writes to disk so that they are included in the estimates.
+	assert.NoError(db.Compact(nil, nil))
+
+	size, err = database.EstimateSize(db, []byte("a"), []byte("b"))
+	assert.NoError(err)
+	assert.NotZero(size)
+
+	size, err = database.EstimateSize(db, []byte("c"), nil)
+	assert.NoError(err)
+	assert.Zero(size)
+
+	size, err = database.EstimateSize(db, nil, nil)
+	assert.NoError(err)
+	assert.NotZero(size)
+
+	assert.NoError(db.Close())
+
+	_, err = database.EstimateSize(db, nil, nil)
+	assert.ErrorIs(err, database.ErrClosed)
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
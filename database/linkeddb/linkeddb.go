@@ -4,6 +4,7 @@
 package linkeddb
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/cache"
@@ -31,6 +32,9 @@ type LinkedDB interface {
 
 	NewIterator() database.Iterator
 	NewIteratorWithStart(start []byte) database.Iterator
+	NewIteratorWithRange(start, limit []byte) database.Iterator
+	NewReverseIterator() database.Iterator
+	NewReverseIteratorWithRange(start, limit []byte) database.Iterator
 }
 
 type linkedDB struct {
@@ -246,6 +250,76 @@ func (ldb *linkedDB) NewIteratorWithStart(start []byte) database.Iterator {
 	return ldb.NewIterator()
 }
 
+// NewIteratorWithRange returns an iterator that starts at [start] and stops
+// before [limit].
+// This iterator does not guarantee that keys are returned in lexicographic
+// order.
+// If [start] is not in the list, starts iterating from the list head. If
+// [limit] is not in the list, or doesn't follow [start], iterates until the end
+// of the list.
+func (ldb *linkedDB) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	hasLimitKey, err := ldb.Has(limit)
+	if err != nil || !hasLimitKey {
+		return ldb.NewIteratorWithStart(start)
+	}
+	if hasStartKey, err := ldb.Has(start); err == nil && hasStartKey {
+		return &iterator{
+			ldb:         ldb,
+			initialized: true,
+			exhausted:   bytes.Equal(start, limit),
+			nextKey:     start,
+			limit:       limit,
+		}
+	}
+	return &iterator{
+		ldb:   ldb,
+		limit: limit,
+	}
+}
+
+// NewReverseIterator returns an iterator that starts at the end of the list
+// and walks towards the head.
+// This iterator does not guarantee that keys are returned in lexicographic
+// order.
+// Finding the end of the list requires walking the full list.
+func (ldb *linkedDB) NewReverseIterator() database.Iterator {
+	return &iterator{
+		ldb:     ldb,
+		reverse: true,
+	}
+}
+
+// NewReverseIteratorWithRange returns an iterator that returns the same keys as
+// [NewIteratorWithRange] in the opposite order. That is, it starts at the key
+// preceding [limit] and walks towards the head until [start] is returned.
+// This iterator does not guarantee that keys are returned in lexicographic
+// order.
+// If [limit] is not in the list, starts iterating from the end of the list. If
+// [start] is not in the list, or doesn't precede [limit], iterates until the
+// head of the list.
+func (ldb *linkedDB) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	it := &iterator{
+		ldb:     ldb,
+		reverse: true,
+	}
+	if hasStartKey, err := ldb.Has(start); err == nil && hasStartKey {
+		it.start = start
+	}
+
+	ldb.lock.RLock()
+	defer ldb.lock.RUnlock()
+
+	limitNode, err := ldb.getNode(limit)
+	if err != nil {
+		// If the limit key isn't present, start from the end of the list
+		return it
+	}
+	it.initialized = true
+	it.exhausted = !limitNode.HasPrevious || bytes.Equal(start, limit)
+	it.nextKey = limitNode.Previous
+	return it
+}
+
 func (ldb *linkedDB) getHeadKey() ([]byte, error) {
 	// If the ldb read lock is held, then there needs to be additional
 	// synchronization here to avoid racy behavior.
@@ -273,6 +347,25 @@ func (ldb *linkedDB) getHeadKey() ([]byte, error) {
 	return headKey, err
 }
 
+// getTailKey returns the key of the last node in the list. Assumes the ldb read
+// lock is held.
+func (ldb *linkedDB) getTailKey() ([]byte, error) {
+	key, err := ldb.getHeadKey()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		n, err := ldb.getNode(key)
+		if err != nil {
+			return nil, err
+		}
+		if !n.HasNext {
+			return key, nil
+		}
+		key = n.Next
+	}
+}
+
 func (ldb *linkedDB) putHeadKey(key []byte) error {
 	ldb.headKeyIsUpdated = true
 	ldb.updatedHeadKeyExists = true
@@ -357,10 +450,13 @@ func (ldb *linkedDB) writeBatch() error {
 }
 
 type iterator struct {
-	ldb                    *linkedDB
-	initialized, exhausted bool
-	key, value, nextKey    []byte
-	err                    error
+	ldb                             *linkedDB
+	initialized, exhausted, reverse bool
+	key, value, nextKey             []byte
+	// start is the last key returned when iterating in reverse. limit is the
+	// key at which forward iteration stops.
+	start, limit []byte
+	err          error
 }
 
 func (it *iterator) Next() bool {
@@ -377,7 +473,15 @@ func (it *iterator) Next() bool {
 	// If the iterator was not yet initialized, do it now.
 	if !it.initialized {
 		it.initialized = true
-		headKey, err := it.ldb.getHeadKey()
+		var (
+			firstKey []byte
+			err      error
+		)
+		if it.reverse {
+			firstKey, err = it.ldb.getTailKey()
+		} else {
+			firstKey, err = it.ldb.getHeadKey()
+		}
 		if err == database.ErrNotFound {
 			it.exhausted = true
 			it.key = nil
@@ -391,7 +495,13 @@ func (it *iterator) Next() bool {
 			it.err = err
 			return false
 		}
-		it.nextKey = headKey
+		it.nextKey = firstKey
+		if !it.reverse && it.limit != nil && bytes.Equal(firstKey, it.limit) {
+			it.exhausted = true
+			it.key = nil
+			it.value = nil
+			return false
+		}
 	}
 
 	nextNode, err := it.ldb.getNode(it.nextKey)
@@ -410,8 +520,13 @@ func (it *iterator) Next() bool {
 	}
 	it.key = it.nextKey
 	it.value = nextNode.Value
-	it.nextKey = nextNode.Next
-	it.exhausted = !nextNode.HasNext
+	if it.reverse {
+		it.nextKey = nextNode.Previous
+		it.exhausted = !nextNode.HasPrevious || (it.start != nil && bytes.Equal(it.key, it.start))
+	} else {
+		it.nextKey = nextNode.Next
+		it.exhausted = !nextNode.HasNext || (it.limit != nil && bytes.Equal(it.nextKey, it.limit))
+	}
 	return true
 }
 
@@ -501,3 +501,162 @@ func TestLinkedDBHead(t *testing.T) {
 	assert.Equal(key0, headKey)
 	assert.Equal(value0, headVal)
 }
+
+func TestMultipleLinkedDBReverseIterator(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	ldb := NewDefault(db)
+
+	key0 := []byte("hello0")
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+	value0 := []byte("world0")
+	value1 := []byte("world1")
+	value2 := []byte("world2")
+
+	err := ldb.Put(key0, value0)
+	assert.NoError(err)
+
+	err = ldb.Put(key1, value1)
+	assert.NoError(err)
+
+	err = ldb.Put(key2, value2)
+	assert.NoError(err)
+
+	iterator := ldb.NewReverseIterator()
+	next := iterator.Next()
+	assert.True(next, "The iterator shouldn't be exhausted yet")
+
+	k := iterator.Key()
+	assert.Equal(key0, k, "The iterator returned the wrong key")
+
+	v := iterator.Value()
+	assert.Equal(value0, v, "The iterator returned the wrong value")
+
+	next = iterator.Next()
+	assert.True(next, "The iterator shouldn't be exhausted yet")
+
+	k = iterator.Key()
+	assert.Equal(key1, k, "The iterator returned the wrong key")
+
+	v = iterator.Value()
+	assert.Equal(value1, v, "The iterator returned the wrong value")
+
+	next = iterator.Next()
+	assert.True(next, "The iterator shouldn't be exhausted yet")
+
+	k = iterator.Key()
+	assert.Equal(key2, k, "The iterator returned the wrong key")
+
+	v = iterator.Value()
+	assert.Equal(value2, v, "The iterator returned the wrong value")
+
+	next = iterator.Next()
+	assert.False(next, "The iterator should now be exhausted")
+
+	err = iterator.Error()
+	assert.NoError(err)
+
+	iterator.Release()
+}
+
+func TestEmptyLinkedDBReverseIterator(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	ldb := NewDefault(db)
+
+	iterator := ldb.NewReverseIterator()
+	next := iterator.Next()
+	assert.False(next, "The iterator should now be exhausted")
+
+	err := iterator.Error()
+	assert.NoError(err)
+
+	iterator.Release()
+}
+
+func TestLinkedDBIteratorRange(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	ldb := NewDefault(db)
+
+	key0 := []byte("hello0")
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+	key3 := []byte("hello3")
+
+	// The list is ordered [key3, key2, key1, key0]
+	for _, key := range [][]byte{key0, key1, key2, key3} {
+		err := ldb.Put(key, key)
+		assert.NoError(err)
+	}
+
+	tests := []struct {
+		name     string
+		iterator database.Iterator
+		expected [][]byte
+	}{
+		{
+			name:     "forward",
+			iterator: ldb.NewIteratorWithRange(key2, key0),
+			expected: [][]byte{key2, key1},
+		},
+		{
+			name:     "forward missing start",
+			iterator: ldb.NewIteratorWithRange([]byte("missing"), key1),
+			expected: [][]byte{key3, key2},
+		},
+		{
+			name:     "forward missing limit",
+			iterator: ldb.NewIteratorWithRange(key1, []byte("missing")),
+			expected: [][]byte{key1, key0},
+		},
+		{
+			name:     "forward limit is head",
+			iterator: ldb.NewIteratorWithRange(nil, key3),
+			expected: nil,
+		},
+		{
+			name:     "forward start is limit",
+			iterator: ldb.NewIteratorWithRange(key2, key2),
+			expected: nil,
+		},
+		{
+			name:     "reverse",
+			iterator: ldb.NewReverseIteratorWithRange(key2, key0),
+			expected: [][]byte{key1, key2},
+		},
+		{
+			name:     "reverse missing start",
+			iterator: ldb.NewReverseIteratorWithRange([]byte("missing"), key1),
+			expected: [][]byte{key2, key3},
+		},
+		{
+			name:     "reverse missing limit",
+			iterator: ldb.NewReverseIteratorWithRange(key1, []byte("missing")),
+			expected: [][]byte{key0, key1},
+		},
+		{
+			name:     "reverse limit is head",
+			iterator: ldb.NewReverseIteratorWithRange(nil, key3),
+			expected: nil,
+		},
+		{
+			name:     "reverse start is limit",
+			iterator: ldb.NewReverseIteratorWithRange(key2, key2),
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		var keys [][]byte
+		for test.iterator.Next() {
+			keys = append(keys, test.iterator.Key())
+		}
+		assert.NoError(test.iterator.Error(), test.name)
+		assert.Equal(test.expected, keys, test.name)
+		test.iterator.Release()
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/meterdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/rocksdb"
 	"github.com/ava-labs/avalanchego/utils"
@@ -120,6 +121,29 @@ func NewLevelDB(
 	)
 }
 
+// NewPebbleDB creates a database manager of pebbleDBs at [filePath] by creating
+// a database instance from each directory with a version <= [currentVersion].
+// If [includePreviousVersions], opens previous database versions and includes
+// them in the returned Manager.
+func NewPebbleDB(
+	dbDirPath string,
+	dbConfig []byte,
+	log logging.Logger,
+	currentVersion *version.Semantic,
+	namespace string,
+	reg prometheus.Registerer,
+) (Manager, error) {
+	return new(
+		pebbledb.New,
+		dbDirPath,
+		dbConfig,
+		log,
+		currentVersion,
+		namespace,
+		reg,
+	)
+}
+
 // new creates a database manager at [filePath] by creating a database instance
 // from each directory with a version <= [currentVersion]. If
 // [includePreviousVersions], opens previous database versions and includes them
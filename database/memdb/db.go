@@ -25,6 +25,7 @@ var (
 	_ database.Database = &Database{}
 	_ database.Batch    = &batch{}
 	_ database.Iterator = &iterator{}
+	_ database.Snapshot = &snapshot{}
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -136,6 +137,44 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 	sort.Strings(keys) // Keys need to be in sorted order
+	return db.newIterator(keys)
+}
+
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newRangeIterator(start, limit, false)
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newRangeIterator(start, limit, true)
+}
+
+func (db *Database) newRangeIterator(start, limit []byte, reverse bool) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+
+	startString := string(start)
+	limitString := string(limit)
+	keys := make([]string, 0, len(db.db))
+	for key := range db.db {
+		if key >= startString && (len(limit) == 0 || key < limitString) {
+			keys = append(keys, key)
+		}
+	}
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
+	return db.newIterator(keys)
+}
+
+// newIterator returns an iterator over [keys] in the provided order. Assumes
+// [db.lock] is held.
+func (db *Database) newIterator(keys []string) *iterator {
 	values := make([][]byte, 0, len(keys))
 	for _, key := range keys {
 		values = append(values, db.db[key])
@@ -147,6 +186,25 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot returns a snapshot of the database. As there is no native
+// snapshot support, the current key/value pairs are copied into the snapshot.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+
+	// Values are never modified in place, so they can be shared with the
+	// snapshot.
+	snapshotDB := NewWithSize(len(db.db))
+	for key, value := range db.db {
+		snapshotDB.db[key] = value
+	}
+	return &snapshot{db: snapshotDB}, nil
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -164,6 +222,39 @@ func (db *Database) HealthCheck() (interface{}, error) {
 	return nil, nil
 }
 
+// snapshot exposes the read-only methods of a copy of the database.
+type snapshot struct {
+	db *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) { return s.db.Has(key) }
+
+func (s *snapshot) Get(key []byte) ([]byte, error) { return s.db.Get(key) }
+
+func (s *snapshot) NewIterator() database.Iterator { return s.db.NewIterator() }
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.db.NewIteratorWithStart(start)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.NewIteratorWithPrefix(prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.db.NewIteratorWithStartAndPrefix(start, prefix)
+}
+
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.db.NewIteratorWithRange(start, limit)
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.db.NewReverseIteratorWithRange(start, limit)
+}
+
+func (s *snapshot) Release() { _ = s.db.Close() }
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -4,6 +4,9 @@
 package meterdb
 
 import (
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -11,17 +14,28 @@ import (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	_ database.Database        = &Database{}
+	_ database.PrefixRegistrar = &Database{}
+	_ database.SizeEstimator   = &Database{}
+	_ database.Batch           = &batch{}
+	_ database.Iterator        = &iterator{}
+	_ database.Snapshot        = &snapshot{}
 )
 
 // Database tracks the amount of time each operation takes and how many bytes
 // are read/written to the underlying database instance.
 type Database struct {
 	metrics
-	db    database.Database
-	clock mockable.Clock
+	prefixes *prefixMeter
+	db       database.Database
+	clock    mockable.Clock
+
+	// estimatePrefixesOnce ensures that the disk sizes of the prefixes are
+	// only estimated periodically by one goroutine.
+	estimatePrefixesOnce sync.Once
+	closeOnce            sync.Once
+	// closed is closed when the database is closed.
+	closed chan struct{}
 }
 
 // New returns a new database with added metrics
@@ -31,9 +45,15 @@ func New(
 	db database.Database,
 ) (*Database, error) {
 	metrics, err := newMetrics(namespace, registerer)
+	prefixes := newPrefixMeter(namespace)
+	if err == nil {
+		err = registerer.Register(prefixes)
+	}
 	return &Database{
-		metrics: metrics,
-		db:      db,
+		metrics:  metrics,
+		prefixes: prefixes,
+		db:       db,
+		closed:   make(chan struct{}),
 	}, err
 }
 
@@ -64,6 +84,9 @@ func (db *Database) Put(key, value []byte) error {
 	db.writeSize.Observe(float64(len(key) + len(value)))
 	db.put.Observe(float64(end.Sub(start)))
 	db.putSize.Observe(float64(len(key) + len(value)))
+	if err == nil {
+		_ = db.prefixes.Put(key, value)
+	}
 	return err
 }
 
@@ -74,6 +97,9 @@ func (db *Database) Delete(key []byte) error {
 	db.writeSize.Observe(float64(len(key)))
 	db.delete.Observe(float64(end.Sub(start)))
 	db.deleteSize.Observe(float64(len(key)))
+	if err == nil {
+		_ = db.prefixes.Delete(key)
+	}
 	return err
 }
 
@@ -114,6 +140,42 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewIteratorWithRange(start, limit),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewReverseIteratorWithRange(start, limit),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	s, err := db.db.NewSnapshot()
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		snapshot: s,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	startTime := db.clock.Time()
 	err := db.db.Compact(start, limit)
@@ -127,9 +189,68 @@ func (db *Database) Close() error {
 	err := db.db.Close()
 	end := db.clock.Time()
 	db.close.Observe(float64(end.Sub(start)))
+	db.closeOnce.Do(func() {
+		close(db.closed)
+	})
 	return err
 }
 
+// RegisterPrefix accounts for the keys starting with [prefix] under [name]. If
+// the underlying database supports prefix accounting, the registration is
+// forwarded to it, so that a prefix is only accounted for by the meterdb
+// closest to the storage.
+func (db *Database) RegisterPrefix(name string, prefix []byte, countKeys bool) error {
+	if registrar, ok := db.db.(database.PrefixRegistrar); ok {
+		return registrar.RegisterPrefix(name, prefix, countKeys)
+	}
+	return db.prefixes.register(name, prefix, countKeys)
+}
+
+// PrefixStats returns the storage used by each prefix registered with this
+// database. The disk sizes are those of the last estimate, and the key counts
+// and sizes are those of the last call to UpdatePrefixStats.
+func (db *Database) PrefixStats() []PrefixStats {
+	return db.prefixes.stats()
+}
+
+// UpdatePrefixStats counts the keys and sizes of the registered prefixes that
+// count their keys, and estimates the disk sizes of every registered prefix.
+// This iterates over every key under the counted prefixes, so it can take a
+// long time on large databases.
+func (db *Database) UpdatePrefixStats() error {
+	return db.prefixes.update(db.db)
+}
+
+// EstimatePrefixSizesPeriodically starts estimating the disk sizes of the
+// registered prefixes every [frequency], until the database is closed. The
+// keys of the prefixes are never counted in the background. Only the first
+// call has an effect.
+func (db *Database) EstimatePrefixSizesPeriodically(frequency time.Duration) {
+	db.estimatePrefixesOnce.Do(func() {
+		go db.estimatePrefixSizesPeriodically(frequency)
+	})
+}
+
+func (db *Database) estimatePrefixSizesPeriodically(frequency time.Duration) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// The estimates are best effort, so a failed estimate is retried
+			// at the next tick.
+			_ = db.prefixes.estimate(db.db)
+		case <-db.closed:
+			return
+		}
+	}
+}
+
+func (db *Database) EstimateSize(start, limit []byte) (uint64, error) {
+	return database.EstimateSize(db.db, start, limit)
+}
+
 func (db *Database) HealthCheck() (interface{}, error) {
 	start := db.clock.Time()
 	result, err := db.db.HealthCheck()
@@ -177,6 +298,10 @@ func (b *batch) Write() error {
 	b.db.writeSize.Observe(batchSize)
 	b.db.bWrite.Observe(float64(end.Sub(start)))
 	b.db.bWriteSize.Observe(batchSize)
+	if err == nil && !b.db.prefixes.isEmpty() {
+		// Account for the written operations under their prefixes.
+		_ = b.batch.Replay(b.db.prefixes)
+	}
 	return err
 }
 
@@ -203,6 +328,86 @@ func (b *batch) Inner() database.Batch {
 	return inner
 }
 
+type snapshot struct {
+	snapshot database.Snapshot
+	db       *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	start := s.db.clock.Time()
+	has, err := s.snapshot.Has(key)
+	end := s.db.clock.Time()
+	s.db.readSize.Observe(float64(len(key)))
+	s.db.sHas.Observe(float64(end.Sub(start)))
+	s.db.sHasSize.Observe(float64(len(key)))
+	return has, err
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	start := s.db.clock.Time()
+	value, err := s.snapshot.Get(key)
+	end := s.db.clock.Time()
+	s.db.readSize.Observe(float64(len(key) + len(value)))
+	s.db.sGet.Observe(float64(end.Sub(start)))
+	s.db.sGetSize.Observe(float64(len(key) + len(value)))
+	return value, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewReverseIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) Release() {
+	start := s.db.clock.Time()
+	s.snapshot.Release()
+	end := s.db.clock.Time()
+	s.db.sRelease.Observe(float64(end.Sub(start)))
+}
+
 type iterator struct {
 	iterator database.Iterator
 	db       *Database
@@ -0,0 +1,288 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	_ prometheus.Collector           = &prefixMeter{}
+	_ database.KeyValueWriterDeleter = &prefixMeter{}
+
+	errDuplicatePrefixName = errors.New("duplicate prefix name")
+
+	prefixLabels = []string{"prefix"}
+)
+
+// PrefixStats describes the storage used by the keys under a registered
+// prefix.
+type PrefixStats struct {
+	Name   string
+	Prefix []byte
+
+	// CountKeys is true if [Keys] and [Size] are calculated for the prefix.
+	CountKeys bool
+	// UpdatedAt is when [Keys] and [Size] were last calculated, or the zero
+	// time if they haven't been calculated.
+	UpdatedAt time.Time
+
+	// Keys is the number of keys under the prefix.
+	Keys uint64
+	// Size is the number of key and value bytes under the prefix.
+	Size uint64
+	// DiskSize is the underlying database's last estimate of the disk space
+	// used by the prefix, or 0 if the database can't estimate its disk usage.
+	DiskSize uint64
+
+	// BytesWritten is the number of key and value bytes written under the
+	// prefix since it was registered.
+	BytesWritten uint64
+	// Puts is the number of keys put under the prefix since it was
+	// registered.
+	Puts uint64
+	// Deletes is the number of keys deleted under the prefix since it was
+	// registered.
+	Deletes uint64
+}
+
+type registeredPrefix struct {
+	// updated atomically. Kept at the start of the struct to guarantee 64-bit
+	// alignment.
+	bytesWritten, puts, deletes uint64
+	// updated atomically by the last stats calculation
+	keys, size, diskSize uint64
+	updatedAt            int64
+
+	name      string
+	prefix    []byte
+	countKeys bool
+}
+
+// prefixMeter accounts for the writes made under each registered prefix and
+// reports them as metrics.
+type prefixMeter struct {
+	lock     sync.RWMutex
+	prefixes []*registeredPrefix
+
+	// updateLock ensures that the stats are only calculated by one caller at
+	// a time.
+	updateLock sync.Mutex
+
+	bytesWritten,
+	puts,
+	deletes,
+	keys,
+	size,
+	diskSize *prometheus.Desc
+}
+
+func newPrefixMeter(namespace string) *prefixMeter {
+	newDesc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", name),
+			help,
+			prefixLabels,
+			nil,
+		)
+	}
+	return &prefixMeter{
+		bytesWritten: newDesc("prefix_bytes_written", "key and value bytes written under the prefix"),
+		puts:         newDesc("prefix_puts", "number of keys put under the prefix"),
+		deletes:      newDesc("prefix_deletes", "number of keys deleted under the prefix"),
+		keys:         newDesc("prefix_keys", "number of keys under the prefix as of the last time they were counted"),
+		size:         newDesc("prefix_size", "key and value bytes under the prefix as of the last time they were counted"),
+		diskSize:     newDesc("prefix_disk_size", "estimated disk space used by the prefix as of the last estimate"),
+	}
+}
+
+// register [prefix] under [name]. Registering the same prefix under the same
+// name again is a no-op, so that a store can register its prefixes every time
+// it's initialized.
+func (p *prefixMeter) register(name string, prefix []byte, countKeys bool) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, registered := range p.prefixes {
+		if registered.name != name {
+			continue
+		}
+		if bytes.Equal(registered.prefix, prefix) && registered.countKeys == countKeys {
+			return nil
+		}
+		return fmt.Errorf("%w: %q", errDuplicatePrefixName, name)
+	}
+	p.prefixes = append(p.prefixes, &registeredPrefix{
+		name:      name,
+		prefix:    append([]byte{}, prefix...),
+		countKeys: countKeys,
+	})
+	sort.Slice(p.prefixes, func(i, j int) bool {
+		return p.prefixes[i].name < p.prefixes[j].name
+	})
+	return nil
+}
+
+// registered returns a copy of the registered prefixes.
+func (p *prefixMeter) registered() []*registeredPrefix {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	prefixes := make([]*registeredPrefix, len(p.prefixes))
+	copy(prefixes, p.prefixes)
+	return prefixes
+}
+
+func (p *prefixMeter) isEmpty() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return len(p.prefixes) == 0
+}
+
+// Put accounts for [key] being put with [value] in every prefix that contains
+// [key].
+func (p *prefixMeter) Put(key, value []byte) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for _, registered := range p.prefixes {
+		if bytes.HasPrefix(key, registered.prefix) {
+			atomic.AddUint64(&registered.bytesWritten, uint64(len(key)+len(value)))
+			atomic.AddUint64(&registered.puts, 1)
+		}
+	}
+	return nil
+}
+
+// Delete accounts for [key] being deleted in every prefix that contains
+// [key].
+func (p *prefixMeter) Delete(key []byte) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for _, registered := range p.prefixes {
+		if bytes.HasPrefix(key, registered.prefix) {
+			atomic.AddUint64(&registered.bytesWritten, uint64(len(key)))
+			atomic.AddUint64(&registered.deletes, 1)
+		}
+	}
+	return nil
+}
+
+// estimate the disk space used by each registered prefix in [db]. This is
+// cheap, as it doesn't iterate over the keys.
+func (p *prefixMeter) estimate(db database.Database) error {
+	p.updateLock.Lock()
+	defer p.updateLock.Unlock()
+
+	return p.estimateDiskSizes(db)
+}
+
+// update counts the keys of the registered prefixes that count them, and
+// estimates the disk space used by every registered prefix, in [db].
+func (p *prefixMeter) update(db database.Database) error {
+	p.updateLock.Lock()
+	defer p.updateLock.Unlock()
+
+	for _, registered := range p.registered() {
+		if !registered.countKeys {
+			continue
+		}
+		keys, size, err := prefixSize(db, registered.prefix)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate size of prefix %q: %w", registered.name, err)
+		}
+
+		atomic.StoreUint64(&registered.keys, keys)
+		atomic.StoreUint64(&registered.size, size)
+		atomic.StoreInt64(&registered.updatedAt, time.Now().UnixNano())
+	}
+	return p.estimateDiskSizes(db)
+}
+
+// Assumes [p.updateLock] is held.
+func (p *prefixMeter) estimateDiskSizes(db database.Database) error {
+	for _, registered := range p.registered() {
+		diskSize, err := database.EstimateSize(db, registered.prefix, database.PrefixLimit(registered.prefix))
+		if errors.Is(err, database.ErrNotSupported) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't estimate disk size of prefix %q: %w", registered.name, err)
+		}
+		atomic.StoreUint64(&registered.diskSize, diskSize)
+	}
+	return nil
+}
+
+// stats returns the usage of each registered prefix as of the last update.
+func (p *prefixMeter) stats() []PrefixStats {
+	prefixes := p.registered()
+	stats := make([]PrefixStats, len(prefixes))
+	for i, registered := range prefixes {
+		var updatedAt time.Time
+		if nanos := atomic.LoadInt64(&registered.updatedAt); nanos != 0 {
+			updatedAt = time.Unix(0, nanos)
+		}
+		stats[i] = PrefixStats{
+			Name:         registered.name,
+			Prefix:       registered.prefix,
+			CountKeys:    registered.countKeys,
+			UpdatedAt:    updatedAt,
+			Keys:         atomic.LoadUint64(&registered.keys),
+			Size:         atomic.LoadUint64(&registered.size),
+			DiskSize:     atomic.LoadUint64(&registered.diskSize),
+			BytesWritten: atomic.LoadUint64(&registered.bytesWritten),
+			Puts:         atomic.LoadUint64(&registered.puts),
+			Deletes:      atomic.LoadUint64(&registered.deletes),
+		}
+	}
+	return stats
+}
+
+// prefixSize returns the number of keys and the number of key and value bytes
+// in [db] that start with [prefix].
+func prefixSize(db database.Iteratee, prefix []byte) (uint64, uint64, error) {
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var keys, size uint64
+	for it.Next() {
+		keys++
+		size += uint64(len(it.Key()) + len(it.Value()))
+	}
+	return keys, size, it.Error()
+}
+
+func (p *prefixMeter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.bytesWritten
+	ch <- p.puts
+	ch <- p.deletes
+	ch <- p.keys
+	ch <- p.size
+	ch <- p.diskSize
+}
+
+func (p *prefixMeter) Collect(ch chan<- prometheus.Metric) {
+	for _, registered := range p.registered() {
+		name := registered.name
+		ch <- prometheus.MustNewConstMetric(p.bytesWritten, prometheus.CounterValue, float64(atomic.LoadUint64(&registered.bytesWritten)), name)
+		ch <- prometheus.MustNewConstMetric(p.puts, prometheus.CounterValue, float64(atomic.LoadUint64(&registered.puts)), name)
+		ch <- prometheus.MustNewConstMetric(p.deletes, prometheus.CounterValue, float64(atomic.LoadUint64(&registered.deletes)), name)
+		ch <- prometheus.MustNewConstMetric(p.keys, prometheus.GaugeValue, float64(atomic.LoadUint64(&registered.keys)), name)
+		ch <- prometheus.MustNewConstMetric(p.size, prometheus.GaugeValue, float64(atomic.LoadUint64(&registered.size)), name)
+		ch <- prometheus.MustNewConstMetric(p.diskSize, prometheus.GaugeValue, float64(atomic.LoadUint64(&registered.diskSize)), name)
+	}
+}
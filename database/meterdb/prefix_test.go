@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+)
+
+func TestPrefixStats(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := New("", prometheus.NewRegistry(), memdb.New())
+	assert.NoError(err)
+
+	assert.NoError(db.RegisterPrefix("a", []byte("a"), true))
+	assert.NoError(db.RegisterPrefix("ab", []byte("ab"), true))
+	assert.NoError(db.RegisterPrefix("all", nil, false))
+
+	// Registering the same prefix again is a no-op, but a name can't be
+	// reused for a different prefix.
+	assert.NoError(db.RegisterPrefix("a", []byte("a"), true))
+	assert.ErrorIs(db.RegisterPrefix("a", []byte("c"), true), errDuplicatePrefixName)
+
+	assert.NoError(db.Put([]byte("a1"), []byte("1")))
+	assert.NoError(db.Put([]byte("ab1"), []byte("12")))
+	assert.NoError(db.Put([]byte("b1"), []byte("123")))
+	assert.NoError(db.Delete([]byte("a1")))
+
+	batch := db.NewBatch()
+	assert.NoError(batch.Put([]byte("ab2"), []byte("1234")))
+	assert.NoError(batch.Put([]byte("ab3"), []byte("12345")))
+
+	// Operations in a batch aren't accounted for until the batch is written.
+	stats := db.PrefixStats()
+	assert.Len(stats, 3)
+	assert.EqualValues(2, stats[0].Puts)
+	assert.EqualValues(1, stats[1].Puts)
+	assert.EqualValues(3, stats[2].Puts)
+
+	assert.NoError(batch.Write())
+
+	// The key counts and sizes are only calculated when the stats are
+	// updated.
+	stats = db.PrefixStats()
+	assert.True(stats[0].UpdatedAt.IsZero())
+	assert.Zero(stats[0].Keys)
+
+	// The keys of prefixes that don't count them are never counted.
+	assert.NoError(db.UpdatePrefixStats())
+	stats = db.PrefixStats()
+	assert.True(stats[2].UpdatedAt.IsZero())
+	for i := range stats[:2] {
+		assert.False(stats[i].UpdatedAt.IsZero())
+		stats[i].UpdatedAt = time.Time{}
+	}
+	assert.Equal([]PrefixStats{
+		{
+			Name:         "a",
+			Prefix:       []byte("a"),
+			CountKeys:    true,
+			Keys:         3,
+			Size:         3 + 2 + 3 + 4 + 3 + 5,
+			BytesWritten: 2 + 1 + 3 + 2 + 2 + 3 + 4 + 3 + 5,
+			Puts:         4,
+			Deletes:      1,
+		},
+		{
+			Name:         "ab",
+			Prefix:       []byte("ab"),
+			CountKeys:    true,
+			Keys:         3,
+			Size:         3 + 2 + 3 + 4 + 3 + 5,
+			BytesWritten: 3 + 2 + 3 + 4 + 3 + 5,
+			Puts:         3,
+		},
+		{
+			Name:         "all",
+			Prefix:       []byte{},
+			BytesWritten: 2 + 1 + 3 + 2 + 2 + 3 + 2 + 3 + 4 + 3 + 5,
+			Puts:         5,
+			Deletes:      1,
+		},
+	}, stats)
+}
+
+func TestPrefixStatsForwarding(t *testing.T) {
+	assert := assert.New(t)
+
+	// Mimic the chain databases, which are meterdbs wrapping the node's
+	// meterdb, with prefixdbs and versiondbs on top.
+	nodeDB, err := New("", prometheus.NewRegistry(), memdb.New())
+	assert.NoError(err)
+	chainDB, err := New("", prometheus.NewRegistry(), nodeDB)
+	assert.NoError(err)
+	vmDB := prefixdb.New([]byte("vm"), chainDB)
+	stateDB := versiondb.New(vmDB)
+	utxoDB := prefixdb.New([]byte("utxo"), stateDB)
+
+	assert.NoError(database.RegisterPrefix(vmDB, "vm", nil, true))
+	assert.NoError(database.RegisterPrefix(utxoDB, "utxo", nil, true))
+
+	assert.NoError(utxoDB.Put([]byte("key"), []byte("value")))
+	assert.NoError(stateDB.Put([]byte("other"), []byte("value")))
+	assert.NoError(stateDB.Commit())
+
+	// The registrations should only be handled by the node's meterdb.
+	assert.Empty(chainDB.PrefixStats())
+
+	assert.NoError(nodeDB.UpdatePrefixStats())
+	stats := nodeDB.PrefixStats()
+	assert.Len(stats, 2)
+	assert.Equal("utxo", stats[0].Name)
+	assert.EqualValues(1, stats[0].Keys)
+	assert.EqualValues(1, stats[0].Puts)
+	assert.Equal("vm", stats[1].Name)
+	assert.EqualValues(2, stats[1].Keys)
+	assert.EqualValues(2, stats[1].Puts)
+}
+
+func TestPrefixMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	db, err := New("db", registry, memdb.New())
+	assert.NoError(err)
+
+	assert.NoError(db.RegisterPrefix("a", []byte("a"), true))
+	assert.NoError(db.Put([]byte("a1"), []byte("1")))
+	assert.NoError(db.UpdatePrefixStats())
+
+	families, err := registry.Gather()
+	assert.NoError(err)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != "prefix" || label.GetValue() != "a" {
+					continue
+				}
+				switch {
+				case metric.Counter != nil:
+					values[family.GetName()] = metric.Counter.GetValue()
+				case metric.Gauge != nil:
+					values[family.GetName()] = metric.Gauge.GetValue()
+				}
+			}
+		}
+	}
+	assert.Equal(map[string]float64{
+		"db_prefix_bytes_written": 3,
+		"db_prefix_puts":          1,
+		"db_prefix_deletes":       0,
+		"db_prefix_keys":          1,
+		"db_prefix_size":          3,
+		"db_prefix_disk_size":     0,
+	}, values)
+}
+
+// estimatingDB is a memdb that estimates each range to use 100 bytes.
+type estimatingDB struct {
+	*memdb.Database
+}
+
+func (*estimatingDB) EstimateSize(_, _ []byte) (uint64, error) { return 100, nil }
+
+func TestPrefixSizesEstimatedPeriodically(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := New("", prometheus.NewRegistry(), &estimatingDB{Database: memdb.New()})
+	assert.NoError(err)
+	defer db.Close()
+
+	assert.NoError(db.Put([]byte("a1"), []byte("1")))
+	assert.NoError(db.RegisterPrefix("a", []byte("a"), true))
+	db.EstimatePrefixSizesPeriodically(time.Millisecond)
+
+	assert.Eventually(func() bool {
+		stats := db.PrefixStats()
+		return stats[0].DiskSize == 100
+	}, time.Second, time.Millisecond)
+
+	// The keys are only counted on demand.
+	stats := db.PrefixStats()
+	assert.True(stats[0].UpdatedAt.IsZero())
+	assert.Zero(stats[0].Keys)
+}
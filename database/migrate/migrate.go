@@ -0,0 +1,403 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migrate copies the versioned databases of a node from one database
+// backend to another so that the node can switch backends without
+// bootstrapping from scratch.
+package migrate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/database/rocksdb"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// DefaultBatchSize is the default number of bytes copied into the target
+// database before the batch is written and the progress is recorded.
+const DefaultBatchSize = 4 * units.MiB
+
+var (
+	errUnknownDBType    = errors.New("unknown database type")
+	errSameDBType       = errors.New("source and target database types must differ")
+	errSourceNotFound   = errors.New("source database not found")
+	errTargetExists     = errors.New("target database already exists")
+	errInvalidBatchSize = errors.New("batch size must be positive")
+	errVerification     = errors.New("verification failed")
+)
+
+type backend struct {
+	newDB      func(string, []byte, logging.Logger, string, prometheus.Registerer) (database.Database, error)
+	newManager func(string, []byte, logging.Logger, *version.Semantic, string, prometheus.Registerer) (manager.Manager, error)
+}
+
+// backends contains the database backends that can be migrated between,
+// indexed by the name used for the --db-type flag.
+var backends = map[string]backend{
+	leveldb.Name: {
+		newDB:      leveldb.New,
+		newManager: manager.NewLevelDB,
+	},
+	rocksdb.Name: {
+		newDB:      rocksdb.New,
+		newManager: manager.NewRocksDB,
+	},
+	pebbledb.Name: {
+		newDB:      pebbledb.New,
+		newManager: manager.NewPebbleDB,
+	},
+}
+
+// Path returns the directory that a node with a database directory of [dir]
+// stores databases of type [dbType] in.
+func Path(dbType, dir string) string {
+	if dbType == leveldb.Name {
+		return dir
+	}
+	return filepath.Join(dir, dbType)
+}
+
+// NewManager opens the versioned databases of type [dbType] that a node with
+// a database directory of [dir] uses.
+func NewManager(dbType, dir string, config []byte, log logging.Logger) (manager.Manager, error) {
+	b, ok := backends[dbType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownDBType, dbType)
+	}
+	return b.newManager(
+		Path(dbType, dir),
+		config,
+		log,
+		version.CurrentDatabase,
+		"",
+		prometheus.NewRegistry(),
+	)
+}
+
+// NewDatabase opens the database of type [dbType] with version [v] that a node
+// with a database directory of [dir] uses.
+func NewDatabase(dbType, dir string, v *version.Semantic, config []byte, log logging.Logger) (database.Database, error) {
+	b, ok := backends[dbType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownDBType, dbType)
+	}
+	path := filepath.Join(Path(dbType, dir), v.String())
+	return b.newDB(path, config, log, "", prometheus.NewRegistry())
+}
+
+// Config describes a migration of every versioned database of a node from
+// one backend to another.
+type Config struct {
+	// Dir is the database directory of the node, including the network
+	// name.
+	Dir string
+
+	SourceType   string
+	SourceConfig []byte
+
+	TargetType   string
+	TargetConfig []byte
+
+	// BatchSize is the number of bytes that are copied between each progress
+	// checkpoint.
+	BatchSize int
+
+	Log logging.Logger
+}
+
+// Result describes the contents of a migrated versioned database.
+type Result struct {
+	Version  *version.Semantic
+	Keys     uint64
+	Checksum [sha256.Size]byte
+}
+
+// Run copies every versioned database found in the source backend's
+// directory into the target backend's directory and verifies that the key
+// counts and checksums of the copies match the originals.
+//
+// Progress is recorded in the target directory after every batch, so an
+// interrupted migration continues from where it left off when Run is called
+// again with the same config.
+func Run(config Config) ([]Result, error) {
+	if config.SourceType == config.TargetType {
+		return nil, errSameDBType
+	}
+	if config.BatchSize <= 0 {
+		return nil, errInvalidBatchSize
+	}
+	if _, ok := backends[config.SourceType]; !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownDBType, config.SourceType)
+	}
+	if _, ok := backends[config.TargetType]; !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownDBType, config.TargetType)
+	}
+
+	sourceDir := Path(config.SourceType, config.Dir)
+	currentSourcePath := filepath.Join(sourceDir, version.CurrentDatabase.String())
+	if _, err := os.Stat(currentSourcePath); err != nil {
+		return nil, fmt.Errorf("%w at %s: %s", errSourceNotFound, currentSourcePath, err)
+	}
+
+	source, err := NewManager(config.SourceType, config.Dir, config.SourceConfig, config.Log)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open source databases: %w", err)
+	}
+	defer func() {
+		if err := source.Close(); err != nil {
+			config.Log.Error("failed to close source databases: %s", err)
+		}
+	}()
+
+	targetDir := Path(config.TargetType, config.Dir)
+	if err := os.MkdirAll(targetDir, perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create target directory %s: %w", targetDir, err)
+	}
+
+	m := &migrator{
+		log:       config.Log,
+		targetDir: targetDir,
+		newTarget: func(v *version.Semantic) (database.Database, error) {
+			return NewDatabase(config.TargetType, config.Dir, v, config.TargetConfig, config.Log)
+		},
+		batchSize: config.BatchSize,
+	}
+	return m.migrate(source)
+}
+
+type migrator struct {
+	log       logging.Logger
+	targetDir string
+	newTarget func(v *version.Semantic) (database.Database, error)
+	batchSize int
+	progress  *progress
+}
+
+func (m *migrator) migrate(source manager.Manager) ([]Result, error) {
+	progress, err := loadProgress(m.targetDir)
+	if err != nil {
+		return nil, err
+	}
+	m.progress = progress
+
+	dbs := source.GetDatabases()
+	results := make([]Result, 0, len(dbs))
+	for _, db := range dbs {
+		result, err := m.migrateVersion(db)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't migrate database %s: %w", db.Version, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// migrateVersion copies [db] into the target directory, unless a previous
+// run already completed it, and then verifies the copy.
+func (m *migrator) migrateVersion(db *manager.VersionedDatabase) (Result, error) {
+	versionStr := db.Version.String()
+	targetPath := filepath.Join(m.targetDir, versionStr)
+
+	status, started := m.progress.Databases[versionStr]
+	if !started {
+		// Refuse to write into a database that this tool didn't create to
+		// avoid mixing the migrated keys with unrelated state.
+		if _, err := os.Stat(targetPath); err == nil {
+			return Result{}, fmt.Errorf("%w at %s", errTargetExists, targetPath)
+		}
+		status = &versionProgress{}
+		m.progress.Databases[versionStr] = status
+		if err := m.progress.write(m.targetDir); err != nil {
+			return Result{}, err
+		}
+	}
+
+	target, err := m.newTarget(db.Version)
+	if err != nil {
+		return Result{}, fmt.Errorf("couldn't open target database at %s: %w", targetPath, err)
+	}
+	defer func() {
+		if err := target.Close(); err != nil {
+			m.log.Error("failed to close target database at %s: %s", targetPath, err)
+		}
+	}()
+
+	if status.LastKey != nil {
+		if err := m.rewind(target, status); err != nil {
+			return Result{}, fmt.Errorf("couldn't check the progress of database %s: %w", versionStr, err)
+		}
+	}
+
+	if !status.Complete {
+		if status.Keys > 0 {
+			m.log.Info("resuming migration of database %s after %d keys", versionStr, status.Keys)
+		} else {
+			m.log.Info("migrating database %s", versionStr)
+		}
+		if err := m.copy(db.Database, target, status); err != nil {
+			return Result{}, err
+		}
+	}
+
+	m.log.Info("verifying database %s", versionStr)
+	sourceKeys, sourceChecksum, err := digest(db.Database)
+	if err != nil {
+		return Result{}, fmt.Errorf("couldn't digest source database: %w", err)
+	}
+	targetKeys, targetChecksum, err := digest(target)
+	if err != nil {
+		return Result{}, fmt.Errorf("couldn't digest target database: %w", err)
+	}
+	if sourceKeys != targetKeys {
+		return Result{}, fmt.Errorf("%w: source has %d keys but target has %d keys", errVerification, sourceKeys, targetKeys)
+	}
+	if sourceChecksum != targetChecksum {
+		return Result{}, fmt.Errorf("%w: source checksum %x doesn't match target checksum %x", errVerification, sourceChecksum, targetChecksum)
+	}
+
+	m.log.Info("migrated database %s with %d keys and checksum %x", versionStr, targetKeys, targetChecksum)
+	return Result{
+		Version:  db.Version,
+		Keys:     targetKeys,
+		Checksum: targetChecksum,
+	}, nil
+}
+
+// rewind moves [status] back to the last key of [target] if [target] is
+// missing keys that were recorded as copied. Batches aren't written to the
+// target synchronously, so batches that were checkpointed may be lost if the
+// migration crashed. Because keys are copied in order, only the keys after the
+// last key of [target] can be missing.
+func (m *migrator) rewind(target database.Database, status *versionProgress) error {
+	it := target.NewReverseIteratorWithRange(nil, nil)
+	var lastKey []byte
+	if it.Next() {
+		lastKey = utils.CopyBytes(it.Key())
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+	if bytes.Compare(lastKey, status.LastKey) >= 0 {
+		return nil
+	}
+
+	keys, _, err := digest(target)
+	if err != nil {
+		return err
+	}
+	m.log.Warn("target is missing keys that were recorded as copied, copying them again after %d keys", keys)
+	status.LastKey = lastKey
+	status.Keys = keys
+	status.Complete = false
+	return m.progress.write(m.targetDir)
+}
+
+// copy writes every key of [source] after [status.LastKey] into [target],
+// recording the progress after every batch.
+func (m *migrator) copy(source, target database.Database, status *versionProgress) error {
+	var start []byte
+	if status.LastKey != nil {
+		// The smallest key after [LastKey] is [LastKey] with a zero byte
+		// appended.
+		start = make([]byte, len(status.LastKey)+1)
+		copy(start, status.LastKey)
+	}
+
+	it := source.NewIteratorWithStart(start)
+	defer it.Release()
+
+	batch := target.NewBatch()
+	var (
+		lastKey   []byte
+		batchKeys uint64
+	)
+	checkpoint := func() error {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+
+		status.LastKey = lastKey
+		status.Keys += batchKeys
+		batchKeys = 0
+		return m.progress.write(m.targetDir)
+	}
+
+	for it.Next() {
+		key := it.Key()
+		if err := batch.Put(key, it.Value()); err != nil {
+			return err
+		}
+		lastKey = key
+		batchKeys++
+
+		if batch.Size() >= m.batchSize {
+			if err := checkpoint(); err != nil {
+				return err
+			}
+			m.log.Debug("copied %d keys", status.Keys)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if batchKeys > 0 {
+		if err := checkpoint(); err != nil {
+			return err
+		}
+	}
+
+	status.Complete = true
+	return m.progress.write(m.targetDir)
+}
+
+// digest returns the number of keys in [db] and the checksum of its
+// key-value pairs in key order.
+func digest(db database.Iteratee) (uint64, [sha256.Size]byte, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		keys     uint64
+		checksum [sha256.Size]byte
+		hasher   = sha256.New()
+	)
+	for it.Next() {
+		writeLengthPrefixed(hasher, it.Key())
+		writeLengthPrefixed(hasher, it.Value())
+		keys++
+	}
+	if err := it.Error(); err != nil {
+		return 0, checksum, err
+	}
+	copy(checksum[:], hasher.Sum(nil))
+	return keys, checksum, nil
+}
+
+// writeLengthPrefixed writes [b] to [h] prefixed by its length so that
+// different splits of the same bytes into keys and values hash differently.
+func writeLengthPrefixed(h hash.Hash, b []byte) {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(b)))
+	_, _ = h.Write(length[:n])
+	_, _ = h.Write(b)
+}
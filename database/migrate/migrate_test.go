@@ -0,0 +1,338 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// newTestDB creates a database of type [dbType] for [v] in the node database
+// directory [dir] and fills it with [numKeys] keys.
+func newTestDB(t *testing.T, dbType, dir string, v *version.Semantic, numKeys int) {
+	path := filepath.Join(Path(dbType, dir), v.String())
+	db, err := backends[dbType].newDB(path, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("%s-key-%05d", v, i))
+		value := []byte(fmt.Sprintf("value-%d", i))
+		if err := db.Put(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func openTestDB(t *testing.T, dbType, dir string, v *version.Semantic) database.Database {
+	path := filepath.Join(Path(dbType, dir), v.String())
+	db, err := backends[dbType].newDB(path, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func assertEqualDBs(t *testing.T, expected, actual database.Iteratee) {
+	expectedIt := expected.NewIterator()
+	defer expectedIt.Release()
+	actualIt := actual.NewIterator()
+	defer actualIt.Release()
+
+	for expectedIt.Next() {
+		if !actualIt.Next() {
+			t.Fatalf("missing key %s", expectedIt.Key())
+		}
+		assert.Equal(t, expectedIt.Key(), actualIt.Key())
+		assert.Equal(t, expectedIt.Value(), actualIt.Value())
+	}
+	assert.False(t, actualIt.Next(), "unexpected extra key")
+	assert.NoError(t, expectedIt.Error())
+	assert.NoError(t, actualIt.Error())
+}
+
+func TestMigrate(t *testing.T) {
+	dir := t.TempDir()
+	newTestDB(t, leveldb.Name, dir, version.CurrentDatabase, 1000)
+	newTestDB(t, leveldb.Name, dir, version.PrevDatabase, 100)
+
+	results, err := Run(Config{
+		Dir:        dir,
+		SourceType: leveldb.Name,
+		TargetType: pebbledb.Name,
+		BatchSize:  1024,
+		Log:        logging.NoLog{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, version.CurrentDatabase, results[0].Version)
+	assert.EqualValues(t, 1000, results[0].Keys)
+	assert.Equal(t, version.PrevDatabase, results[1].Version)
+	assert.EqualValues(t, 100, results[1].Keys)
+
+	for _, v := range []*version.Semantic{version.CurrentDatabase, version.PrevDatabase} {
+		source := openTestDB(t, leveldb.Name, dir, v)
+		target := openTestDB(t, pebbledb.Name, dir, v)
+		assertEqualDBs(t, source, target)
+		assert.NoError(t, source.Close())
+		assert.NoError(t, target.Close())
+	}
+
+	// Running the migration again should only re-verify the databases.
+	rerunResults, err := Run(Config{
+		Dir:        dir,
+		SourceType: leveldb.Name,
+		TargetType: pebbledb.Name,
+		BatchSize:  1024,
+		Log:        logging.NoLog{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, results, rerunResults)
+}
+
+func TestMigrateResume(t *testing.T) {
+	dir := t.TempDir()
+	newTestDB(t, leveldb.Name, dir, version.CurrentDatabase, 100)
+
+	// Simulate an interrupted migration that copied the first half of the
+	// keys.
+	targetDir := Path(pebbledb.Name, dir)
+	source := openTestDB(t, leveldb.Name, dir, version.CurrentDatabase)
+	target := openTestDB(t, pebbledb.Name, dir, version.CurrentDatabase)
+
+	it := source.NewIterator()
+	var lastKey []byte
+	for i := 0; i < 50 && it.Next(); i++ {
+		lastKey = it.Key()
+		if err := target.Put(it.Key(), it.Value()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	it.Release()
+
+	p := &progress{
+		Databases: map[string]*versionProgress{
+			version.CurrentDatabase.String(): {
+				LastKey: lastKey,
+				Keys:    50,
+			},
+		},
+	}
+	if err := p.write(targetDir); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, source.Close())
+	assert.NoError(t, target.Close())
+
+	results, err := Run(Config{
+		Dir:        dir,
+		SourceType: leveldb.Name,
+		TargetType: pebbledb.Name,
+		BatchSize:  DefaultBatchSize,
+		Log:        logging.NoLog{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, results, 1)
+	assert.EqualValues(t, 100, results[0].Keys)
+
+	p, err = loadProgress(targetDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := p.Databases[version.CurrentDatabase.String()]
+	assert.True(t, status.Complete)
+	assert.EqualValues(t, 100, status.Keys)
+}
+
+// newResumedTestDB creates a source database with [numKeys] keys, copies its
+// first [copiedKeys] keys into the target and records that every key up to
+// key [lastKeyIndex] was copied.
+func newResumedTestDB(t *testing.T, dir string, numKeys, copiedKeys, lastKeyIndex int) {
+	newTestDB(t, leveldb.Name, dir, version.CurrentDatabase, numKeys)
+
+	source := openTestDB(t, leveldb.Name, dir, version.CurrentDatabase)
+	target := openTestDB(t, pebbledb.Name, dir, version.CurrentDatabase)
+	it := source.NewIterator()
+	for i := 0; i < copiedKeys && it.Next(); i++ {
+		if err := target.Put(it.Key(), it.Value()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	it.Release()
+	assert.NoError(t, source.Close())
+	assert.NoError(t, target.Close())
+
+	p := &progress{
+		Databases: map[string]*versionProgress{
+			version.CurrentDatabase.String(): {
+				LastKey: []byte(fmt.Sprintf("%s-key-%05d", version.CurrentDatabase, lastKeyIndex)),
+				Keys:    uint64(lastKeyIndex + 1),
+			},
+		},
+	}
+	if err := p.write(Path(pebbledb.Name, dir)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrateResumeSkipsCopiedKeys(t *testing.T) {
+	dir := t.TempDir()
+	newResumedTestDB(t, dir, 10, 5, 4)
+
+	results, err := Run(Config{
+		Dir:        dir,
+		SourceType: leveldb.Name,
+		TargetType: pebbledb.Name,
+		BatchSize:  DefaultBatchSize,
+		Log:        logging.NoLog{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, 10, results[0].Keys)
+
+	// The keys that were already copied aren't counted again
+	p, err := loadProgress(Path(pebbledb.Name, dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, 10, p.Databases[version.CurrentDatabase.String()].Keys)
+}
+
+func TestMigrateResumeCopiesLostKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	// The last batches that were recorded as copied never reached the
+	// target's disk.
+	newResumedTestDB(t, dir, 10, 3, 7)
+
+	results, err := Run(Config{
+		Dir:        dir,
+		SourceType: leveldb.Name,
+		TargetType: pebbledb.Name,
+		BatchSize:  DefaultBatchSize,
+		Log:        logging.NoLog{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualValues(t, 10, results[0].Keys)
+
+	p, err := loadProgress(Path(pebbledb.Name, dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := p.Databases[version.CurrentDatabase.String()]
+	assert.True(t, status.Complete)
+	assert.EqualValues(t, 10, status.Keys)
+
+	source := openTestDB(t, leveldb.Name, dir, version.CurrentDatabase)
+	target := openTestDB(t, pebbledb.Name, dir, version.CurrentDatabase)
+	assertEqualDBs(t, source, target)
+	assert.NoError(t, source.Close())
+	assert.NoError(t, target.Close())
+}
+
+func TestMigrateTargetExists(t *testing.T) {
+	dir := t.TempDir()
+	newTestDB(t, leveldb.Name, dir, version.CurrentDatabase, 10)
+	newTestDB(t, pebbledb.Name, dir, version.CurrentDatabase, 1)
+
+	_, err := Run(Config{
+		Dir:        dir,
+		SourceType: leveldb.Name,
+		TargetType: pebbledb.Name,
+		BatchSize:  DefaultBatchSize,
+		Log:        logging.NoLog{},
+	})
+	assert.ErrorIs(t, err, errTargetExists)
+}
+
+func TestMigrateInvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "same type",
+			config: Config{
+				Dir:        dir,
+				SourceType: leveldb.Name,
+				TargetType: leveldb.Name,
+				BatchSize:  DefaultBatchSize,
+			},
+			expectedErr: errSameDBType,
+		},
+		{
+			name: "unknown source type",
+			config: Config{
+				Dir:        dir,
+				SourceType: "memdb",
+				TargetType: leveldb.Name,
+				BatchSize:  DefaultBatchSize,
+			},
+			expectedErr: errUnknownDBType,
+		},
+		{
+			name: "unknown target type",
+			config: Config{
+				Dir:        dir,
+				SourceType: leveldb.Name,
+				TargetType: "memdb",
+				BatchSize:  DefaultBatchSize,
+			},
+			expectedErr: errUnknownDBType,
+		},
+		{
+			name: "invalid batch size",
+			config: Config{
+				Dir:        dir,
+				SourceType: leveldb.Name,
+				TargetType: pebbledb.Name,
+			},
+			expectedErr: errInvalidBatchSize,
+		},
+		{
+			name: "missing source",
+			config: Config{
+				Dir:        dir,
+				SourceType: leveldb.Name,
+				TargetType: pebbledb.Name,
+				BatchSize:  DefaultBatchSize,
+			},
+			expectedErr: errSourceNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.Log = logging.NoLog{}
+			_, err := Run(test.config)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+// progressFile is the name of the file in the target directory that records
+// how far the migration has gotten. Plain files in the database directory are
+// ignored by the database manager, so it doesn't interfere with the node.
+const progressFile = "migration_progress.json"
+
+// progress is the persisted state of a migration.
+type progress struct {
+	// Databases maps a database version to the progress of copying it.
+	Databases map[string]*versionProgress `json:"databases"`
+}
+
+type versionProgress struct {
+	// LastKey is the last key that was copied into the target. The batch
+	// containing it may not have been synced to disk, so it's checked against
+	// the target before resuming.
+	LastKey []byte `json:"lastKey"`
+	// Keys is the number of keys that have been copied into the target.
+	Keys uint64 `json:"keys"`
+	// Complete is true once every key has been copied.
+	Complete bool `json:"complete"`
+}
+
+// loadProgress reads the progress file from [dir]. If the file doesn't exist,
+// an empty progress is returned.
+func loadProgress(dir string) (*progress, error) {
+	p := &progress{
+		Databases: make(map[string]*versionProgress),
+	}
+	path := filepath.Join(dir, progressFile)
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read migration progress from %s: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, p); err != nil {
+		return nil, fmt.Errorf("couldn't parse migration progress from %s: %w", path, err)
+	}
+	if p.Databases == nil {
+		p.Databases = make(map[string]*versionProgress)
+	}
+	return p, nil
+}
+
+// write atomically replaces the progress file in [dir] so that an interrupted
+// write never leaves a partially written file behind.
+func (p *progress) write(dir string) error {
+	bytes, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, progressFile)
+	tmpPath := path + ".tmp"
+	if err := perms.WriteFile(tmpPath, bytes, perms.ReadWrite); err != nil {
+		return fmt.Errorf("couldn't write migration progress to %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("couldn't write migration progress to %s: %w", path, err)
+	}
+	return nil
+}
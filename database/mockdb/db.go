@@ -33,6 +33,9 @@ type Database struct {
 	OnNewIteratorWithStart          func([]byte) database.Iterator
 	OnNewIteratorWithPrefix         func([]byte) database.Iterator
 	OnNewIteratorWithStartAndPrefix func([]byte, []byte) database.Iterator
+	OnNewIteratorWithRange          func([]byte, []byte) database.Iterator
+	OnNewReverseIteratorWithRange   func([]byte, []byte) database.Iterator
+	OnNewSnapshot                   func() (database.Snapshot, error)
 	OnCompact                       func([]byte, []byte) error
 	OnClose                         func() error
 	OnHealthCheck                   func() (interface{}, error)
@@ -104,6 +107,27 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return db.OnNewIteratorWithStartAndPrefix(start, prefix)
 }
 
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	if db.OnNewIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewIteratorWithRange(start, limit)
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithRange(start, limit)
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if db.OnNewSnapshot == nil {
+		return nil, errNoFunction
+	}
+	return db.OnNewSnapshot()
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	if db.OnCompact == nil {
 		return errNoFunction
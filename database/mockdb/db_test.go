@@ -43,6 +43,15 @@ func TestDefaultError(t *testing.T) {
 	if iterator := db.NewIteratorWithStartAndPrefix([]byte{}, []byte{}); iterator != nil {
 		t.Fatal("should have errored")
 	}
+	if iterator := db.NewIteratorWithRange([]byte{}, []byte{}); iterator != nil {
+		t.Fatal("should have errored")
+	}
+	if iterator := db.NewReverseIteratorWithRange([]byte{}, []byte{}); iterator != nil {
+		t.Fatal("should have errored")
+	}
+	if _, err := db.NewSnapshot(); err == nil {
+		t.Fatal("should have errored")
+	}
 	if err := db.Compact([]byte{}, []byte{}); err == nil {
 		t.Fatal("should have errored")
 	}
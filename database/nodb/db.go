@@ -45,6 +45,15 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return &Iterator{}
 }
 
+// NewIteratorWithRange returns a new empty iterator
+func (*Database) NewIteratorWithRange(_, _ []byte) database.Iterator { return &Iterator{} }
+
+// NewReverseIteratorWithRange returns a new empty iterator
+func (*Database) NewReverseIteratorWithRange(_, _ []byte) database.Iterator { return &Iterator{} }
+
+// NewSnapshot returns error
+func (*Database) NewSnapshot() (database.Snapshot, error) { return nil, database.ErrClosed }
+
 // Compact returns nil
 func (*Database) Compact(_, _ []byte) error { return database.ErrClosed }
 
@@ -0,0 +1,825 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/nodb"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Name is the name of this database for database switches
+	Name = "pebbledb"
+
+	// DefaultCacheSize is the number of bytes to use for block caching in
+	// pebble.
+	DefaultCacheSize = 512 * units.MiB
+
+	// DefaultBytesPerSync is the number of bytes of sstables to write before
+	// syncing them to disk in the background.
+	DefaultBytesPerSync = 512 * units.KiB
+
+	// DefaultMemTableSize is the number of bytes a memtable can grow to before
+	// it is flushed to disk.
+	DefaultMemTableSize = 16 * units.MiB
+
+	// DefaultMemTableStopWritesThreshold is the number of queued memtables at
+	// which writes are stopped.
+	DefaultMemTableStopWritesThreshold = 8
+
+	// DefaultMaxOpenFiles is the number of files descriptors to cap pebble to
+	// use.
+	DefaultMaxOpenFiles = 4096
+
+	// DefaultMaxConcurrentCompactions is the maximum number of compactions that
+	// can run concurrently.
+	DefaultMaxConcurrentCompactions = 1
+
+	// DefaultBitsPerKey is the number of bits to add to the bloom filter per
+	// key.
+	DefaultBitsPerKey = 10
+
+	// DefaultMetricUpdateFrequency is the frequency to poll the pebble metrics.
+	DefaultMetricUpdateFrequency = 10 * time.Second
+
+	// pebbleByteOverhead is the number of bytes of constant overhead that
+	// should be added to a batch size per operation.
+	pebbleByteOverhead = 8
+
+	// numLevels is the number of levels in the LSM tree of pebble.
+	numLevels = 7
+)
+
+var (
+	_ database.Database      = &Database{}
+	_ database.SizeEstimator = &Database{}
+	_ database.Batch         = &batch{}
+	_ database.Iterator      = &iter{}
+	_ database.Snapshot      = &snapshot{}
+)
+
+// Database is a persistent key-value store. Apart from basic data storage
+// functionality it also supports batch writes and iterating over the keyspace
+// in binary-alphabetical order.
+type Database struct {
+	// lock protects [db] from being closed concurrently with an operation.
+	// pebble panics rather than returning an error when it is used after being
+	// closed.
+	lock   sync.RWMutex
+	db     *pebble.DB
+	closed bool
+	// openIterators and openSnapshots are released when the database is
+	// closed, as pebble reports them as leaked otherwise.
+	//
+	// iteratorsLock protects [openIterators] from concurrent iterator creation,
+	// which only holds [lock] for reading.
+	iteratorsLock sync.Mutex
+	openIterators map[*iter]struct{}
+	openSnapshots map[*snapshot]struct{}
+
+	// metrics is only initialized and used when [MetricUpdateFrequency] is > 0
+	// in the config
+	metrics metrics
+	// closeCh is closed when Close() is called.
+	closeCh chan struct{}
+}
+
+type config struct {
+	// CacheSize is the number of bytes to use for caching blocks.
+	//
+	// The default value is 512MiB.
+	CacheSize int64 `json:"cacheSize"`
+	// BytesPerSync defines the number of bytes of sstables to write before
+	// syncing them in the background. This smooths out disk writes.
+	//
+	// The default value is 512KiB.
+	BytesPerSync int `json:"bytesPerSync"`
+	// WALBytesPerSync defines the number of bytes of the WAL to write before
+	// syncing it in the background. Use 0 to disable background syncing of the
+	// WAL.
+	//
+	// The default value is 0.
+	WALBytesPerSync int `json:"walBytesPerSync"`
+	// MemTableSize defines the maximum size of a memtable before it is flushed
+	// to an sstable.
+	//
+	// The default value is 16MiB.
+	MemTableSize int `json:"memTableSize"`
+	// MemTableStopWritesThreshold is the hard limit on the number of queued
+	// memtables. Writes are stopped when this limit is reached.
+	//
+	// The default value is 8.
+	MemTableStopWritesThreshold int `json:"memTableStopWritesThreshold"`
+	// MaxOpenFiles defines the capacity of the open files caching.
+	//
+	// The default value is 4096.
+	MaxOpenFiles int `json:"maxOpenFiles"`
+	// MaxConcurrentCompactions is the maximum number of compactions that can
+	// run concurrently.
+	//
+	// The default value is 1.
+	MaxConcurrentCompactions int `json:"maxConcurrentCompactions"`
+	// FilterBitsPerKey is the number of bits per key to use for the bloom
+	// filter of each level.
+	//
+	// The default value is 10.
+	FilterBitsPerKey int `json:"filterBitsPerKey"`
+
+	// MetricUpdateFrequency is the frequency to poll pebble metrics.
+	// If <= 0, pebble metrics aren't polled.
+	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+}
+
+// New returns a wrapped pebble object.
+func New(file string, configBytes []byte, log logging.Logger, namespace string, reg prometheus.Registerer) (database.Database, error) {
+	parsedConfig := config{
+		CacheSize:                   DefaultCacheSize,
+		BytesPerSync:                DefaultBytesPerSync,
+		MemTableSize:                DefaultMemTableSize,
+		MemTableStopWritesThreshold: DefaultMemTableStopWritesThreshold,
+		MaxOpenFiles:                DefaultMaxOpenFiles,
+		MaxConcurrentCompactions:    DefaultMaxConcurrentCompactions,
+		FilterBitsPerKey:            DefaultBitsPerKey,
+		MetricUpdateFrequency:       DefaultMetricUpdateFrequency,
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+	configJSON, err := json.Marshal(&parsedConfig)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("pebbledb config: %s", string(configJSON))
+
+	cache := pebble.NewCache(parsedConfig.CacheSize)
+	defer cache.Unref()
+
+	opts := &pebble.Options{
+		Cache:                       cache,
+		BytesPerSync:                parsedConfig.BytesPerSync,
+		WALBytesPerSync:             parsedConfig.WALBytesPerSync,
+		MemTableSize:                parsedConfig.MemTableSize,
+		MemTableStopWritesThreshold: parsedConfig.MemTableStopWritesThreshold,
+		MaxOpenFiles:                parsedConfig.MaxOpenFiles,
+		MaxConcurrentCompactions: func() int {
+			return parsedConfig.MaxConcurrentCompactions
+		},
+		Levels: make([]pebble.LevelOptions, numLevels),
+		Logger: &logger{log: log},
+	}
+	filterPolicy := bloom.FilterPolicy(parsedConfig.FilterBitsPerKey)
+	for i := range opts.Levels {
+		opts.Levels[i].FilterPolicy = filterPolicy
+		opts.Levels[i].FilterType = pebble.TableFilter
+	}
+
+	db, err := pebble.Open(file, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedDB := &Database{
+		db:            db,
+		openIterators: make(map[*iter]struct{}),
+		openSnapshots: make(map[*snapshot]struct{}),
+		closeCh:       make(chan struct{}),
+	}
+	if parsedConfig.MetricUpdateFrequency > 0 {
+		metrics, err := newMetrics(namespace, reg)
+		if err != nil {
+			// Drop any close error to report the original error
+			_ = db.Close()
+			return nil, err
+		}
+		wrappedDB.metrics = metrics
+		go func() {
+			t := time.NewTicker(parsedConfig.MetricUpdateFrequency)
+			defer t.Stop()
+
+			for {
+				err := wrappedDB.updateMetrics()
+				if err != nil && err != database.ErrClosed {
+					log.Warn("failed to update pebbledb metrics: %s", err)
+				}
+
+				select {
+				case <-t.C:
+				case <-wrappedDB.closeCh:
+					return
+				}
+			}
+		}()
+	}
+	return wrappedDB, nil
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+	return has(db.db, key)
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return get(db.db, key)
+}
+
+// Put sets the value of the provided key to the provided value. As with the
+// leveldb backend, the write isn't synced to disk before returning.
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.db.Set(key, value, pebble.NoSync))
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.db.Delete(key, pebble.NoSync))
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.newIterator(&pebble.IterOptions{}, nil, false)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.newIterator(newStartAndPrefixOptions(start, nil), nil, false)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(newStartAndPrefixOptions(nil, prefix), nil, false)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(newStartAndPrefixOptions(start, prefix), nil, false)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database keys in the range [start, limit)
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIterator(newRangeOptions(start, limit), nil, false)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database keys in the range [start, limit)
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIterator(newRangeOptions(start, limit), nil, true)
+}
+
+// newIterator creates an iterator and registers it to be released when the
+// database is closed. If [s] is nil, the iterator reads from the database.
+// Otherwise, the iterator reads from [s] and is invalidated when [s] is
+// released.
+func (db *Database) newIterator(opts *pebble.IterOptions, s *snapshot, reverse bool) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+
+	var reader pebble.Reader = db.db
+	if s != nil {
+		// [s.snapshot] is only modified while [db.lock] is held.
+		if s.snapshot == nil {
+			return &nodb.Iterator{Err: database.ErrClosed}
+		}
+		reader = s.snapshot
+	}
+
+	it := &iter{
+		db:       db,
+		snapshot: s,
+		iter:     reader.NewIter(opts),
+		reverse:  reverse,
+	}
+
+	db.iteratorsLock.Lock()
+	db.openIterators[it] = struct{}{}
+	db.iteratorsLock.Unlock()
+	return it
+}
+
+// NewSnapshot returns a point-in-time view of the database that is backed by
+// a native pebble snapshot
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	s := &snapshot{
+		db:       db,
+		snapshot: db.db.NewSnapshot(),
+	}
+	db.openSnapshots[s] = struct{}{}
+	return s, nil
+}
+
+// Compact the underlying DB for the given key range.
+// Specifically, deleted and overwritten versions are discarded,
+// and the data is rearranged to reduce the cost of operations
+// needed to access the data. This operation should typically only
+// be invoked by users who understand the underlying implementation.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil limit is treated as a key after all keys in the DB.
+// Therefore if both are nil then it will compact entire DB.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		// pebble treats a nil limit as a key before all keys and excludes the
+		// limit from the compaction, so the key immediately after the largest
+		// key in the database is used instead.
+		lastKey, ok, err := db.lastKey()
+		if err != nil || !ok {
+			// If the database is empty, there is nothing to compact.
+			return err
+		}
+		limit = append(lastKey, 0)
+	}
+	// pebble requires that start < limit
+	if pebble.DefaultComparer.Compare(start, limit) >= 0 {
+		return nil
+	}
+	return updateError(db.db.Compact(start, limit, true /*=parallelize*/))
+}
+
+// EstimateSize returns the approximate amount of disk space used by the keys
+// in the range [start, limit). Data that hasn't been flushed from the memtable
+// yet isn't included.
+func (db *Database) EstimateSize(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+
+	if limit == nil {
+		lastKey, ok, err := db.lastKey()
+		if err != nil || !ok {
+			return 0, err
+		}
+		limit = lastKey
+	}
+	if pebble.DefaultComparer.Compare(start, limit) > 0 {
+		return 0, nil
+	}
+	size, err := db.db.EstimateDiskUsage(start, limit)
+	return size, updateError(err)
+}
+
+// lastKey returns the largest key in the database and true, or false if the
+// database is empty. Assumes [db.lock] is held.
+func (db *Database) lastKey() ([]byte, bool, error) {
+	it := db.db.NewIter(&pebble.IterOptions{})
+	if !it.Last() {
+		return nil, false, updateError(it.Close())
+	}
+	key := utils.CopyBytes(it.Key())
+	return key, true, updateError(it.Close())
+}
+
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+	close(db.closeCh)
+
+	for it := range db.openIterators {
+		it.release()
+	}
+	db.openIterators = nil
+	for s := range db.openSnapshots {
+		s.release()
+	}
+	db.openSnapshots = nil
+	return updateError(db.db.Close())
+}
+
+func (db *Database) isClosed() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.closed
+}
+
+func (db *Database) HealthCheck() (interface{}, error) {
+	if db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	return nil, nil
+}
+
+// batch is a wrapper around a pebble batch to contain sizes.
+type batch struct {
+	batch *pebble.Batch
+	db    *Database
+	size  int
+
+	// written is true if [batch] has been committed to the database. pebble
+	// doesn't allow a batch to be committed more than once.
+	written bool
+}
+
+// Put the value into the batch for later writing
+func (b *batch) Put(key, value []byte) error {
+	b.size += len(key) + len(value) + pebbleByteOverhead
+	return b.batch.Set(key, value, nil)
+}
+
+// Delete the key during writing
+func (b *batch) Delete(key []byte) error {
+	b.size += len(key) + pebbleByteOverhead
+	return b.batch.Delete(key, nil)
+}
+
+// Size retrieves the amount of data queued up for writing.
+func (b *batch) Size() int { return b.size }
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.closed {
+		return database.ErrClosed
+	}
+
+	if b.written {
+		// The batch was already committed, so a copy of it is committed
+		// instead.
+		newBatch := b.db.db.NewBatch()
+		if err := newBatch.Apply(b.batch, nil); err != nil {
+			return err
+		}
+		b.batch = newBatch
+	}
+	b.written = true
+	return updateError(b.batch.Commit(pebble.NoSync))
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.batch.Reset()
+	b.written = false
+	b.size = 0
+}
+
+// Replay the batch contents.
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	reader := b.batch.Reader()
+	for {
+		kind, key, value, ok := reader.Next()
+		if !ok {
+			return nil
+		}
+		switch kind {
+		case pebble.InternalKeyKindSet:
+			if err := w.Put(key, value); err != nil {
+				return err
+			}
+		case pebble.InternalKeyKindDelete:
+			if err := w.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Inner returns itself
+func (b *batch) Inner() database.Batch { return b }
+
+// snapshot is a wrapper around a pebble snapshot to match the database
+// interfaces.
+type snapshot struct {
+	db *Database
+
+	// lock protects [snapshot] from being released concurrently with a read.
+	// [snapshot] is only modified while both [db.lock] and [lock] are held.
+	lock     sync.RWMutex
+	snapshot *pebble.Snapshot
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.snapshot == nil {
+		return false, database.ErrClosed
+	}
+	return has(s.snapshot, key)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.snapshot == nil {
+		return nil, database.ErrClosed
+	}
+	return get(s.snapshot, key)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.newIterator(&pebble.IterOptions{}, false)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.newIterator(newStartAndPrefixOptions(start, nil), false)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.newIterator(newStartAndPrefixOptions(nil, prefix), false)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.newIterator(newStartAndPrefixOptions(start, prefix), false)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot keys in the range [start, limit)
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIterator(newRangeOptions(start, limit), false)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot keys in the range [start, limit)
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIterator(newRangeOptions(start, limit), true)
+}
+
+func (s *snapshot) newIterator(opts *pebble.IterOptions, reverse bool) database.Iterator {
+	return s.db.newIterator(opts, s, reverse)
+}
+
+// Release frees the pebble snapshot
+func (s *snapshot) Release() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	if s.db.closed {
+		// The snapshot was released when the database was closed.
+		return
+	}
+	s.release()
+	delete(s.db.openSnapshots, s)
+}
+
+// release frees the pebble snapshot. Assumes [s.db.lock] is held.
+func (s *snapshot) release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.snapshot == nil {
+		return
+	}
+	_ = s.snapshot.Close()
+	s.snapshot = nil
+}
+
+func (s *snapshot) isReleased() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.snapshot == nil
+}
+
+type iter struct {
+	db *Database
+	// snapshot is the snapshot this iterator was created from, or nil if it
+	// was created from the database.
+	snapshot *snapshot
+	iter     *pebble.Iterator
+
+	// reverse is true if the keys should be returned in descending order
+	reverse, started, released bool
+
+	key, val []byte
+	err      error
+}
+
+func (it *iter) Next() bool {
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	// Short-circuit and set an error if the underlying database or snapshot
+	// has been closed.
+	if it.db.closed || it.released || (it.snapshot != nil && it.snapshot.isReleased()) {
+		it.key = nil
+		it.val = nil
+		it.err = database.ErrClosed
+		return false
+	}
+
+	var hasNext bool
+	switch {
+	case !it.started && it.reverse:
+		hasNext = it.iter.Last()
+	case !it.started:
+		hasNext = it.iter.First()
+	case it.reverse:
+		hasNext = it.iter.Prev()
+	default:
+		hasNext = it.iter.Next()
+	}
+	it.started = true
+
+	if hasNext {
+		it.key = utils.CopyBytes(it.iter.Key())
+		it.val = utils.CopyBytes(it.iter.Value())
+	} else {
+		it.key = nil
+		it.val = nil
+	}
+	return hasNext
+}
+
+func (it *iter) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	if it.released {
+		return nil
+	}
+	return updateError(it.iter.Error())
+}
+
+func (it *iter) Key() []byte { return it.key }
+
+func (it *iter) Value() []byte { return it.val }
+
+func (it *iter) Release() {
+	it.db.lock.Lock()
+	defer it.db.lock.Unlock()
+
+	if it.released {
+		return
+	}
+	it.release()
+	delete(it.db.openIterators, it)
+}
+
+// release closes the pebble iterator. Assumes [it.db.lock] is held.
+func (it *iter) release() {
+	if it.released {
+		return
+	}
+	it.released = true
+	it.err = updateError(it.iter.Close())
+}
+
+// newStartAndPrefixOptions returns the iterator options to iterate over the
+// keys with [prefix] that are >= [start].
+func newStartAndPrefixOptions(start, prefix []byte) *pebble.IterOptions {
+	lowerBound := prefix
+	if pebble.DefaultComparer.Compare(start, prefix) == 1 {
+		lowerBound = start
+	}
+	return &pebble.IterOptions{
+		LowerBound: utils.CopyBytes(lowerBound),
+		UpperBound: database.PrefixLimit(prefix),
+	}
+}
+
+// newRangeOptions returns the iterator options to iterate over the keys in the
+// range [start, limit). pebble only treats a nil limit as unbounded, so an
+// empty limit is replaced with nil.
+func newRangeOptions(start, limit []byte) *pebble.IterOptions {
+	if len(limit) == 0 {
+		limit = nil
+	}
+	return &pebble.IterOptions{
+		LowerBound: utils.CopyBytes(start),
+		UpperBound: utils.CopyBytes(limit),
+	}
+}
+
+// has returns if [key] is set in [reader].
+func has(reader pebble.Reader, key []byte) (bool, error) {
+	_, closer, err := reader.Get(key)
+	switch err {
+	case nil:
+		return true, closer.Close()
+	case pebble.ErrNotFound:
+		return false, nil
+	default:
+		return false, updateError(err)
+	}
+}
+
+// get returns a copy of the value that [key] maps to in [reader].
+func get(reader pebble.Reader, key []byte) ([]byte, error) {
+	value, closer, err := reader.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	value = utils.CopyBytes(value)
+	return value, closer.Close()
+}
+
+func updateError(err error) error {
+	switch err {
+	case pebble.ErrClosed:
+		return database.ErrClosed
+	case pebble.ErrNotFound:
+		return database.ErrNotFound
+	default:
+		return err
+	}
+}
+
+// logger forwards the logs of pebble to an avalanchego logger.
+type logger struct {
+	log logging.Logger
+}
+
+func (l *logger) Infof(format string, args ...interface{}) {
+	l.log.Info(format, args...)
+}
+
+func (l *logger) Fatalf(format string, args ...interface{}) {
+	l.log.Fatal(format, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var levelLabels = []string{"level"}
+
+type metrics struct {
+	// total number of flushes performed
+	flushes prometheus.Counter
+	// total number of compactions performed
+	compactions prometheus.Counter
+	// estimated number of bytes that need to be compacted for the LSM to
+	// reach a stable state
+	compactionDebt prometheus.Gauge
+	// number of compactions that are currently in progress
+	compactionsInProgress prometheus.Gauge
+	// current read amplification of the database
+	readAmplification prometheus.Gauge
+
+	// number of currently alive snapshots
+	aliveSnapshots prometheus.Gauge
+	// number of currently open sstable iterators
+	aliveTableIterators prometheus.Gauge
+
+	// total number of bytes allocated by memtables
+	memTableSize prometheus.Gauge
+	// current number of memtables
+	memTableCount prometheus.Gauge
+
+	// total number of bytes of cached data blocks
+	blockCacheSize prometheus.Gauge
+	// total number of block cache hits
+	blockCacheHits prometheus.Counter
+	// total number of block cache misses
+	blockCacheMisses prometheus.Counter
+
+	// size of the live data in the WAL
+	walSize prometheus.Gauge
+	// total amount of data written to the WAL
+	walWrite prometheus.Counter
+
+	// total amount of disk space used by the database
+	diskUsage prometheus.Gauge
+
+	// number of tables per level
+	levelTableCount *prometheus.GaugeVec
+	// size of each level
+	levelSize *prometheus.GaugeVec
+	// amount of bytes read while compacting each level
+	levelReads *prometheus.CounterVec
+	// amount of bytes written while compacting or flushing into each level
+	levelWrites *prometheus.CounterVec
+
+	priorStats *pebble.Metrics
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (metrics, error) {
+	m := metrics{
+		flushes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "flushes",
+			Help:      "total number of memtable flushes performed",
+		}),
+		compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comps",
+			Help:      "total number of compactions performed",
+		}),
+		compactionDebt: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "comp_debt",
+			Help:      "estimated number of bytes that need to be compacted for the database to reach a stable state",
+		}),
+		compactionsInProgress: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "comps_in_progress",
+			Help:      "number of compactions that are currently in progress",
+		}),
+		readAmplification: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "read_amp",
+			Help:      "current read amplification of the database",
+		}),
+
+		aliveSnapshots: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_snapshots",
+			Help:      "number of currently alive snapshots",
+		}),
+		aliveTableIterators: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_table_iterators",
+			Help:      "number of currently open sstable iterators",
+		}),
+
+		memTableSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "mem_table_size",
+			Help:      "number of bytes allocated by memtables",
+		}),
+		memTableCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "mem_table_count",
+			Help:      "number of memtables",
+		}),
+
+		blockCacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "block_cache_size",
+			Help:      "total size of cached blocks",
+		}),
+		blockCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_hits",
+			Help:      "cumulative number of block cache hits",
+		}),
+		blockCacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "block_cache_misses",
+			Help:      "cumulative number of block cache misses",
+		}),
+
+		walSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "wal_size",
+			Help:      "size of the live data in the WAL",
+		}),
+		walWrite: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "wal_write",
+			Help:      "cumulative amount of bytes written to the WAL",
+		}),
+
+		diskUsage: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "disk_usage",
+			Help:      "total amount of disk space used by the database",
+		}),
+
+		levelTableCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "table_count",
+				Help:      "number of tables allocated by level",
+			},
+			levelLabels,
+		),
+		levelSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "amount of bytes allocated by level",
+			},
+			levelLabels,
+		),
+		levelReads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reads",
+				Help:      "amount of bytes read during compaction by level",
+			},
+			levelLabels,
+		),
+		levelWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "writes",
+				Help:      "amount of bytes written during compaction and flushes by level",
+			},
+			levelLabels,
+		),
+
+		priorStats: &pebble.Metrics{},
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.flushes),
+		reg.Register(m.compactions),
+		reg.Register(m.compactionDebt),
+		reg.Register(m.compactionsInProgress),
+		reg.Register(m.readAmplification),
+
+		reg.Register(m.aliveSnapshots),
+		reg.Register(m.aliveTableIterators),
+
+		reg.Register(m.memTableSize),
+		reg.Register(m.memTableCount),
+
+		reg.Register(m.blockCacheSize),
+		reg.Register(m.blockCacheHits),
+		reg.Register(m.blockCacheMisses),
+
+		reg.Register(m.walSize),
+		reg.Register(m.walWrite),
+
+		reg.Register(m.diskUsage),
+
+		reg.Register(m.levelTableCount),
+		reg.Register(m.levelSize),
+		reg.Register(m.levelReads),
+		reg.Register(m.levelWrites),
+	)
+	return m, errs.Err
+}
+
+func (db *Database) updateMetrics() error {
+	db.lock.RLock()
+	if db.closed {
+		db.lock.RUnlock()
+		return database.ErrClosed
+	}
+	// Retrieve the database stats
+	currentStats := db.db.Metrics()
+	db.lock.RUnlock()
+
+	metrics := &db.metrics
+	priorStats := metrics.priorStats
+
+	metrics.flushes.Add(float64(currentStats.Flush.Count - priorStats.Flush.Count))
+	metrics.compactions.Add(float64(currentStats.Compact.Count - priorStats.Compact.Count))
+	metrics.compactionDebt.Set(float64(currentStats.Compact.EstimatedDebt))
+	metrics.compactionsInProgress.Set(float64(currentStats.Compact.NumInProgress))
+	metrics.readAmplification.Set(float64(currentStats.ReadAmp()))
+
+	metrics.aliveSnapshots.Set(float64(currentStats.Snapshots.Count))
+	metrics.aliveTableIterators.Set(float64(currentStats.TableIters))
+
+	metrics.memTableSize.Set(float64(currentStats.MemTable.Size))
+	metrics.memTableCount.Set(float64(currentStats.MemTable.Count))
+
+	metrics.blockCacheSize.Set(float64(currentStats.BlockCache.Size))
+	metrics.blockCacheHits.Add(float64(currentStats.BlockCache.Hits - priorStats.BlockCache.Hits))
+	metrics.blockCacheMisses.Add(float64(currentStats.BlockCache.Misses - priorStats.BlockCache.Misses))
+
+	metrics.walSize.Set(float64(currentStats.WAL.Size))
+	metrics.walWrite.Add(float64(currentStats.WAL.BytesWritten - priorStats.WAL.BytesWritten))
+
+	metrics.diskUsage.Set(float64(currentStats.DiskSpaceUsage()))
+
+	for level, levelStats := range currentStats.Levels {
+		levelStr := strconv.Itoa(level)
+		priorLevelStats := priorStats.Levels[level]
+
+		metrics.levelTableCount.WithLabelValues(levelStr).Set(float64(levelStats.NumFiles))
+		metrics.levelSize.WithLabelValues(levelStr).Set(float64(levelStats.Size))
+		metrics.levelReads.WithLabelValues(levelStr).Add(float64(levelStats.BytesRead - priorLevelStats.BytesRead))
+		metrics.levelWrites.WithLabelValues(levelStr).Add(float64(
+			levelStats.BytesCompacted + levelStats.BytesFlushed -
+				priorLevelStats.BytesCompacted - priorLevelStats.BytesFlushed,
+		))
+	}
+
+	// update the priorStats to update the counters correctly next time this
+	// method is called
+	metrics.priorStats = currentStats
+	return nil
+}
@@ -4,6 +4,7 @@
 package prefixdb
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -16,9 +17,13 @@ const (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	errDifferentDatabases = errors.New("databases wrap different databases")
+
+	_ database.Database        = &Database{}
+	_ database.PrefixRegistrar = &Database{}
+	_ database.Batch           = &batch{}
+	_ database.Iterator        = &iterator{}
+	_ database.Snapshot        = &snapshot{}
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -47,11 +52,17 @@ func New(prefix []byte, db database.Database) *Database {
 	return NewNested(prefix, db)
 }
 
+// MakePrefix returns the bytes that are prepended to the keys written to a
+// database created with [prefix] on top of a database that isn't a prefixdb.
+func MakePrefix(prefix []byte) []byte {
+	return hashing.ComputeHash256(prefix)
+}
+
 // NewNested returns a new prefixed database without attempting to compress
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
 	return &Database{
-		dbPrefix: hashing.ComputeHash256(prefix),
+		dbPrefix: MakePrefix(prefix),
 		db:       db,
 		bufferPool: sync.Pool{
 			New: func() interface{} {
@@ -165,6 +176,116 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// It is safe to modify [start] and [limit] after this method returns.
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.db, start, limit, false)
+}
+
+// It is safe to modify [start] and [limit] after this method returns.
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.db, start, limit, true)
+}
+
+// newRangeIterator maps the range [start, limit) into this db's prefix before
+// creating the iterator on [iteratee]. An empty limit is mapped to the end of
+// this db's prefix.
+// Assumes it is safe to modify the arguments to the iterator constructors
+// after they return.
+func (db *Database) newRangeIterator(iteratee database.Iteratee, start, limit []byte, reverse bool) database.Iterator {
+	prefixedStart := db.prefix(start)
+	var prefixedLimit []byte
+	if len(limit) == 0 {
+		prefixedLimit = database.PrefixLimit(db.dbPrefix)
+	} else {
+		prefixedLimit = db.prefix(limit)
+	}
+
+	var it database.Iterator
+	if reverse {
+		it = iteratee.NewReverseIteratorWithRange(prefixedStart, prefixedLimit)
+	} else {
+		it = iteratee.NewIteratorWithRange(prefixedStart, prefixedLimit)
+	}
+	db.bufferPool.Put(prefixedStart)
+	if len(limit) != 0 {
+		db.bufferPool.Put(prefixedLimit)
+	}
+	return &iterator{
+		Iterator: it,
+		db:       db,
+	}
+}
+
+// NewSnapshot returns a snapshot of the underlying database that only exposes
+// the keys in this db's prefix.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	s, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+	}, nil
+}
+
+// NewSnapshots returns snapshots of [dbs] that are all taken at the same point
+// in time. [dbs] must all wrap the same underlying database. The snapshots
+// share a single snapshot of the underlying database, so releasing any of them
+// releases all of them.
+func NewSnapshots(dbs ...*Database) ([]database.Snapshot, error) {
+	if len(dbs) == 0 {
+		return nil, nil
+	}
+
+	var baseDB database.Database
+	for _, db := range dbs {
+		db.lock.RLock()
+		dbDB := db.db
+		db.lock.RUnlock()
+
+		switch {
+		case dbDB == nil:
+			return nil, database.ErrClosed
+		case baseDB == nil:
+			baseDB = dbDB
+		case dbDB != baseDB:
+			return nil, errDifferentDatabases
+		}
+	}
+
+	s, err := baseDB.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]database.Snapshot, len(dbs))
+	for i, db := range dbs {
+		snapshots[i] = &snapshot{
+			Snapshot: s,
+			db:       db,
+		}
+	}
+	return snapshots, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -175,6 +296,21 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(db.prefix(start), db.prefix(limit))
 }
 
+// RegisterPrefix forwards the registration of [prefix] to the underlying
+// database, which sees the keys of this database under [db.dbPrefix].
+func (db *Database) RegisterPrefix(name string, prefix []byte, countKeys bool) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	prefixedPrefix := make([]byte, len(db.dbPrefix)+len(prefix))
+	copy(prefixedPrefix, db.dbPrefix)
+	copy(prefixedPrefix[len(db.dbPrefix):], prefix)
+	return database.RegisterPrefix(db.db, name, prefixedPrefix, countKeys)
+}
+
 func (db *Database) Close() error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -311,6 +447,78 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	return nil
 }
 
+// snapshot prefixes all keys before reading from the underlying snapshot
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// [key] may be modified after this method returns.
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+// It is safe to modify [start] and [limit] after this method returns.
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.Snapshot, start, limit, false)
+}
+
+// It is safe to modify [start] and [limit] after this method returns.
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.Snapshot, start, limit, true)
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
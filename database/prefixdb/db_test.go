@@ -6,6 +6,8 @@ package prefixdb
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 )
@@ -22,6 +24,35 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestNewSnapshots(t *testing.T) {
+	assert := assert.New(t)
+
+	baseDB := memdb.New()
+	db0 := New([]byte("hello"), baseDB)
+	db1 := New([]byte("world"), baseDB)
+	assert.NoError(db0.Put([]byte("key"), []byte("value0")))
+	assert.NoError(db1.Put([]byte("key"), []byte("value1")))
+
+	snapshots, err := NewSnapshots(db0, db1)
+	assert.NoError(err)
+	assert.Len(snapshots, 2)
+	defer snapshots[0].Release()
+
+	assert.NoError(db0.Put([]byte("key"), []byte("value2")))
+	assert.NoError(db1.Delete([]byte("key")))
+
+	value, err := snapshots[0].Get([]byte("key"))
+	assert.NoError(err)
+	assert.Equal([]byte("value0"), value)
+
+	value, err = snapshots[1].Get([]byte("key"))
+	assert.NoError(err)
+	assert.Equal([]byte("value1"), value)
+
+	_, err = NewSnapshots(db0, New([]byte("hello"), memdb.New()))
+	assert.ErrorIs(err, errDifferentDatabases)
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
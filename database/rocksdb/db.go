@@ -42,6 +42,7 @@ var (
 	_ database.Database = &Database{}
 	_ database.Batch    = &batch{}
 	_ database.Iterator = &iterator{}
+	_ database.Snapshot = &snapshot{}
 )
 
 // Database is a persistent key-value store. Apart from basic data storage
@@ -111,8 +112,13 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 	if db.db == nil {
 		return nil, database.ErrClosed
 	}
+	return db.get(db.readOptions, key)
+}
 
-	value, err := db.db.GetBytes(db.readOptions, key)
+// get returns the value the key maps to in the database using the provided
+// read options. Assumes [db.lock] is held and the database isn't closed.
+func (db *Database) get(readOptions *grocksdb.ReadOptions, key []byte) ([]byte, error) {
+	value, err := db.db.GetBytes(readOptions, key)
 	if err != nil {
 		return nil, err
 	}
@@ -233,8 +239,13 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.db == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return db.newIterator(db.iteratorOptions, start, prefix)
+}
 
-	it := db.db.NewIterator(db.iteratorOptions)
+// newIterator creates a lexicographically ordered iterator using the provided
+// read options. Assumes [db.lock] is held and the database isn't closed.
+func (db *Database) newIterator(iteratorOptions *grocksdb.ReadOptions, start, prefix []byte) database.Iterator {
+	it := db.db.NewIterator(iteratorOptions)
 	if it == nil {
 		return &nodb.Iterator{Err: errFailedToCreateIterator}
 	}
@@ -250,6 +261,87 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database keys in the range [start, limit)
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.iteratorOptions, start, limit, false)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database keys in the range [start, limit)
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.iteratorOptions, start, limit, true)
+}
+
+// newRangeIterator creates an iterator over the keys in the range
+// [start, limit) using the provided read options. Assumes [db.lock] is held and
+// the database isn't closed.
+func (db *Database) newRangeIterator(iteratorOptions *grocksdb.ReadOptions, start, limit []byte, reverse bool) database.Iterator {
+	it := db.db.NewIterator(iteratorOptions)
+	if it == nil {
+		return &nodb.Iterator{Err: errFailedToCreateIterator}
+	}
+	switch {
+	case !reverse:
+		it.Seek(start)
+	case len(limit) == 0:
+		it.SeekToLast()
+	default:
+		// SeekForPrev positions the iterator at the last key <= limit, but
+		// limit is exclusive.
+		it.SeekForPrev(limit)
+		if it.Valid() && bytes.Equal(it.Key().Data(), limit) {
+			it.Prev()
+		}
+	}
+	return &iterator{
+		it:      it,
+		db:      db,
+		start:   utils.CopyBytes(start),
+		limit:   utils.CopyBytes(limit),
+		reverse: reverse,
+	}
+}
+
+// NewSnapshot returns a point-in-time view of the database that is backed by
+// a native RocksDB snapshot
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+
+	snap := db.db.NewSnapshot()
+
+	readOptions := grocksdb.NewDefaultReadOptions()
+	readOptions.SetSnapshot(snap)
+
+	iteratorOptions := grocksdb.NewDefaultReadOptions()
+	iteratorOptions.SetFillCache(false)
+	iteratorOptions.SetSnapshot(snap)
+
+	return &snapshot{
+		db:              db,
+		snapshot:        snap,
+		readOptions:     readOptions,
+		iteratorOptions: iteratorOptions,
+	}, nil
+}
+
 // Compact the underlying DB for the given key range.
 // Specifically, deleted and overwritten versions are discarded,
 // and the data is rearranged to reduce the cost of operations
@@ -365,10 +457,125 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	return nil
 }
 
+// snapshot is a wrapper around a RocksDB snapshot. Reads are served using read
+// options that are pinned to the snapshot.
+type snapshot struct {
+	db *Database
+
+	// lock protects [snapshot] from being released concurrently with a read.
+	lock            sync.RWMutex
+	snapshot        *grocksdb.Snapshot
+	readOptions     *grocksdb.ReadOptions
+	iteratorOptions *grocksdb.ReadOptions
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	_, err := s.Get(key)
+	switch err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db.db == nil || s.snapshot == nil {
+		return nil, database.ErrClosed
+	}
+	return s.db.get(s.readOptions, key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db.db == nil || s.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newIterator(s.iteratorOptions, start, prefix)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot keys in the range [start, limit)
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db.db == nil || s.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.iteratorOptions, start, limit, false)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot keys in the range [start, limit)
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db.db == nil || s.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.iteratorOptions, start, limit, true)
+}
+
+// Release releases the native snapshot, if the database hasn't been closed
+func (s *snapshot) Release() {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.snapshot == nil {
+		return
+	}
+	if s.db.db != nil {
+		s.db.db.ReleaseSnapshot(s.snapshot)
+	}
+	s.readOptions.Destroy()
+	s.iteratorOptions.Destroy()
+	s.snapshot = nil
+}
+
 type iterator struct {
-	it      *grocksdb.Iterator
-	db      *Database
-	prefix  []byte
+	it     *grocksdb.Iterator
+	db     *Database
+	prefix []byte
+	// start and limit bound the keys of range iterators
+	start, limit []byte
+	// reverse is true if the keys should be returned in descending order
+	reverse bool
 	started bool
 	key     []byte
 	value   []byte
@@ -407,7 +614,11 @@ func (it *iterator) Next() bool {
 		return false
 	}
 	if it.started {
-		it.it.Next()
+		if it.reverse {
+			it.it.Prev()
+		} else {
+			it.it.Next()
+		}
 	}
 	it.started = true
 
@@ -420,7 +631,9 @@ func (it *iterator) Next() bool {
 	it.key = it.it.Key().Data()
 	it.value = it.it.Value().Data()
 
-	if !bytes.HasPrefix(it.key, it.prefix) {
+	if !bytes.HasPrefix(it.key, it.prefix) ||
+		(it.reverse && bytes.Compare(it.key, it.start) < 0) ||
+		(!it.reverse && len(it.limit) > 0 && bytes.Compare(it.key, it.limit) >= 0) {
 		it.key = nil
 		it.value = nil
 		return false
@@ -6,6 +6,7 @@ package rpcdb
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"sync/atomic"
 
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -31,6 +32,8 @@ var (
 	_ database.Database = &DatabaseClient{}
 	_ database.Batch    = &batch{}
 	_ database.Iterator = &iterator{}
+	_ database.Snapshot = &snapshot{}
+	_ database.Iterator = &snapshotIterator{}
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -121,6 +124,47 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	}
 }
 
+// NewIteratorWithRange returns an iterator over the keys in [start, limit)
+func (db *DatabaseClient) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newRangeIterator(start, limit, false)
+}
+
+// NewReverseIteratorWithRange returns an iterator over the keys in
+// [start, limit) in descending order
+func (db *DatabaseClient) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newRangeIterator(start, limit, true)
+}
+
+func (db *DatabaseClient) newRangeIterator(start, limit []byte, reverse bool) database.Iterator {
+	resp, err := db.client.NewIteratorWithRange(context.Background(), &rpcdbpb.NewIteratorWithRangeRequest{
+		Start:   start,
+		Limit:   limit,
+		Reverse: reverse,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: db,
+		id: resp.Id,
+	}
+}
+
+// NewSnapshot returns a snapshot of the remote database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbpb.NewSnapshotRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
 // Compact attempts to optimize the space utilization in the provided range
 func (db *DatabaseClient) Compact(start, limit []byte) error {
 	resp, err := db.client.Compact(context.Background(), &rpcdbpb.CompactRequest{
@@ -252,6 +296,192 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 
 func (b *batch) Inner() database.Batch { return b }
 
+type snapshot struct {
+	db *DatabaseClient
+	id uint64
+
+	released utils.AtomicBool
+}
+
+// Has attempts to return if the snapshot has a key with the provided value.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.released.GetValue() {
+		return false, database.ErrClosed
+	}
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbpb.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Has, errCodeToError[resp.Err]
+}
+
+// Get attempts to return the value that was mapped to the key that was provided
+// when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.released.GetValue() {
+		return nil, database.ErrClosed
+	}
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbpb.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, errCodeToError[resp.Err]
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns an iterator that streams the contents
+// of the snapshot from the server
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.iterate(&rpcdbpb.SnapshotIterateRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+// NewIteratorWithRange returns an iterator that streams the keys of the
+// snapshot in [start, limit) from the server
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.iterate(&rpcdbpb.SnapshotIterateRequest{
+		Id:    s.id,
+		Start: start,
+		Limit: limit,
+		Range: true,
+	})
+}
+
+// NewReverseIteratorWithRange returns an iterator that streams the keys of the
+// snapshot in [start, limit) from the server in descending order
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.iterate(&rpcdbpb.SnapshotIterateRequest{
+		Id:      s.id,
+		Start:   start,
+		Limit:   limit,
+		Reverse: true,
+		Range:   true,
+	})
+}
+
+func (s *snapshot) iterate(req *rpcdbpb.SnapshotIterateRequest) database.Iterator {
+	if s.released.GetValue() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := s.db.client.SnapshotIterate(ctx, req)
+	if err != nil {
+		cancel()
+		return &nodb.Iterator{Err: err}
+	}
+	return &snapshotIterator{
+		db:     s.db,
+		stream: stream,
+		cancel: cancel,
+	}
+}
+
+// Release frees any resources held by the snapshot
+func (s *snapshot) Release() {
+	if s.released.GetValue() {
+		return
+	}
+	s.released.SetValue(true)
+
+	// Release should always succeed, so any error is dropped.
+	_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbpb.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
+
+// snapshotIterator reads the chunks streamed by the server. Cancelling the
+// stream's context frees the server side iterator.
+type snapshotIterator struct {
+	db     *DatabaseClient
+	stream rpcdbpb.Database_SnapshotIterateClient
+	cancel context.CancelFunc
+
+	data []*rpcdbpb.PutRequest
+	done bool
+	errs wrappers.Errs
+}
+
+// Next attempts to move the iterator to the next element and returns if this
+// succeeded
+func (it *snapshotIterator) Next() bool {
+	if it.db.closed.GetValue() {
+		it.data = nil
+		it.errs.Add(database.ErrClosed)
+		return false
+	}
+	if len(it.data) > 1 {
+		it.data[0] = nil
+		it.data = it.data[1:]
+		return true
+	}
+
+	it.data = nil
+	for !it.done {
+		resp, err := it.stream.Recv()
+		if err == io.EOF {
+			it.done = true
+			break
+		}
+		if err != nil {
+			it.errs.Add(err)
+			it.done = true
+			break
+		}
+		it.errs.Add(errCodeToError[resp.Err])
+		if len(resp.Data) > 0 {
+			it.data = resp.Data
+			return true
+		}
+	}
+	return false
+}
+
+// Error returns any that occurred while iterating
+func (it *snapshotIterator) Error() error { return it.errs.Err }
+
+// Key returns the key of the current element
+func (it *snapshotIterator) Key() []byte {
+	if len(it.data) == 0 {
+		return nil
+	}
+	return it.data[0].Key
+}
+
+// Value returns the value of the current element
+func (it *snapshotIterator) Value() []byte {
+	if len(it.data) == 0 {
+		return nil
+	}
+	return it.data[0].Value
+}
+
+// Release frees any resources held by the iterator
+func (it *snapshotIterator) Release() {
+	it.cancel()
+	it.data = nil
+}
+
 type iterator struct {
 	db *DatabaseClient
 	id uint64
@@ -16,7 +16,10 @@ import (
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 )
 
-var errUnknownIterator = errors.New("unknown iterator")
+var (
+	errUnknownIterator = errors.New("unknown iterator")
+	errUnknownSnapshot = errors.New("unknown snapshot")
+)
 
 // DatabaseServer is a database that is managed over RPC.
 type DatabaseServer struct {
@@ -39,6 +42,12 @@ type DatabaseServer struct {
 	iteratorLock   sync.RWMutex
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	// snapshotLock protects [nextSnapshotID] and [snapshots] from concurrent
+	// modifications.
+	snapshotLock   sync.RWMutex
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -47,6 +56,7 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batches:   make(map[int64]database.Batch),
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
@@ -88,8 +98,18 @@ func (db *DatabaseServer) Compact(_ context.Context, req *rpcdbpb.CompactRequest
 	return &rpcdbpb.CompactResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
 }
 
-// Close delegates the Close call to the managed database and returns the result
+// Close releases any snapshots that the client didn't release and delegates
+// the Close call to the managed database and returns the result
 func (db *DatabaseServer) Close(context.Context, *rpcdbpb.CloseRequest) (*rpcdbpb.CloseResponse, error) {
+	db.snapshotLock.Lock()
+	snapshots := db.snapshots
+	db.snapshots = make(map[uint64]database.Snapshot)
+	db.snapshotLock.Unlock()
+
+	for _, snapshot := range snapshots {
+		snapshot.Release()
+	}
+
 	err := db.db.Close()
 	return &rpcdbpb.CloseResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
 }
@@ -162,6 +182,24 @@ func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *
 	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
 }
 
+// NewIteratorWithRange allocates a range iterator and returns the iterator ID
+func (db *DatabaseServer) NewIteratorWithRange(_ context.Context, req *rpcdbpb.NewIteratorWithRangeRequest) (*rpcdbpb.NewIteratorWithRangeResponse, error) {
+	var it database.Iterator
+	if req.Reverse {
+		it = db.db.NewReverseIteratorWithRange(req.Start, req.Limit)
+	} else {
+		it = db.db.NewIteratorWithRange(req.Start, req.Limit)
+	}
+
+	db.iteratorLock.Lock()
+	defer db.iteratorLock.Unlock()
+
+	id := db.nextIteratorID
+	db.iterators[id] = it
+	db.nextIteratorID++
+	return &rpcdbpb.NewIteratorWithRangeResponse{Id: id}, nil
+}
+
 // IteratorNext attempts to call next on the requested iterator
 func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbpb.IteratorNextRequest) (*rpcdbpb.IteratorNextResponse, error) {
 	db.iteratorLock.RLock()
@@ -214,3 +252,124 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbpb.Iterat
 	it.Release()
 	return &rpcdbpb.IteratorReleaseResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
 }
+
+// NewSnapshot allocates a snapshot and returns the snapshot ID
+func (db *DatabaseServer) NewSnapshot(context.Context, *rpcdbpb.NewSnapshotRequest) (*rpcdbpb.NewSnapshotResponse, error) {
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return &rpcdbpb.NewSnapshotResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+
+	db.snapshotLock.Lock()
+	defer db.snapshotLock.Unlock()
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+	db.nextSnapshotID++
+	return &rpcdbpb.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotHas delegates the Has call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotHas(_ context.Context, req *rpcdbpb.SnapshotHasRequest) (*rpcdbpb.HasResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	has, err := snapshot.Has(req.Key)
+	return &rpcdbpb.HasResponse{
+		Has: has,
+		Err: errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotGet delegates the Get call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotGet(_ context.Context, req *rpcdbpb.SnapshotGetRequest) (*rpcdbpb.GetResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	value, err := snapshot.Get(req.Key)
+	return &rpcdbpb.GetResponse{
+		Value: value,
+		Err:   errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotIterate streams the contents of the requested snapshot in chunks of
+// at most [maxBatchSize]. The final response reports any iteration error.
+func (db *DatabaseServer) SnapshotIterate(req *rpcdbpb.SnapshotIterateRequest, stream rpcdbpb.Database_SnapshotIterateServer) error {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return err
+	}
+
+	var it database.Iterator
+	switch {
+	case !req.Range:
+		it = snapshot.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	case req.Reverse:
+		it = snapshot.NewReverseIteratorWithRange(req.Start, req.Limit)
+	default:
+		it = snapshot.NewIteratorWithRange(req.Start, req.Limit)
+	}
+	defer it.Release()
+
+	size := 0
+	data := []*rpcdbpb.PutRequest(nil)
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		size += len(key) + len(value)
+
+		data = append(data, &rpcdbpb.PutRequest{
+			Key:   key,
+			Value: value,
+		})
+		if size < maxBatchSize {
+			continue
+		}
+
+		if err := stream.Send(&rpcdbpb.SnapshotIterateResponse{Data: data}); err != nil {
+			return err
+		}
+		size = 0
+		data = nil
+	}
+
+	err = it.Error()
+	if rpcErr := errorToRPCError(err); rpcErr != nil {
+		return rpcErr
+	}
+	return stream.Send(&rpcdbpb.SnapshotIterateResponse{
+		Data: data,
+		Err:  errorToErrCode[err],
+	})
+}
+
+// SnapshotRelease attempts to release the resources allocated to a snapshot
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbpb.SnapshotReleaseRequest) (*rpcdbpb.SnapshotReleaseResponse, error) {
+	db.snapshotLock.Lock()
+	snapshot, exists := db.snapshots[req.Id]
+	if !exists {
+		db.snapshotLock.Unlock()
+		return &rpcdbpb.SnapshotReleaseResponse{Err: 0}, nil
+	}
+	delete(db.snapshots, req.Id)
+	db.snapshotLock.Unlock()
+
+	snapshot.Release()
+	return &rpcdbpb.SnapshotReleaseResponse{}, nil
+}
+
+func (db *DatabaseServer) getSnapshot(id uint64) (database.Snapshot, error) {
+	db.snapshotLock.RLock()
+	defer db.snapshotLock.RUnlock()
+
+	snapshot, exists := db.snapshots[id]
+	if !exists {
+		return nil, errUnknownSnapshot
+	}
+	return snapshot, nil
+}
@@ -150,3 +150,53 @@ func TestHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotIterateMultipleChunks(t *testing.T) {
+	assert := assert.New(t)
+
+	db := setupDB(t)
+	defer db.closeFn()
+
+	// Each value is large enough that the server must stream the snapshot in
+	// multiple chunks.
+	const numKeys = 16
+	value := make([]byte, maxBatchSize/4)
+	for i := byte(0); i < numKeys; i++ {
+		assert.NoError(db.client.Put([]byte{i}, value))
+	}
+
+	snapshot, err := db.client.NewSnapshot()
+	assert.NoError(err)
+	defer snapshot.Release()
+
+	assert.NoError(db.client.Delete([]byte{0}))
+
+	it := snapshot.NewIterator()
+	defer it.Release()
+
+	for i := byte(0); i < numKeys; i++ {
+		assert.True(it.Next())
+		assert.Equal([]byte{i}, it.Key())
+		assert.Equal(value, it.Value())
+	}
+	assert.False(it.Next())
+	assert.NoError(it.Error())
+}
+
+func TestCloseReleasesSnapshots(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewServer(memdb.New())
+	resp, err := server.NewSnapshot(context.Background(), &rpcdbpb.NewSnapshotRequest{})
+	assert.NoError(err)
+	snapshot, err := server.getSnapshot(resp.Id)
+	assert.NoError(err)
+
+	_, err = server.Close(context.Background(), &rpcdbpb.CloseRequest{})
+	assert.NoError(err)
+
+	_, err = server.getSnapshot(resp.Id)
+	assert.ErrorIs(err, errUnknownSnapshot)
+	_, err = snapshot.Get([]byte{0})
+	assert.ErrorIs(err, database.ErrClosed)
+}
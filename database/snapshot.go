@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+// Snapshot is a read-only, point-in-time view of a database. Writes that are
+// made to the host database after the snapshot was created are not visible
+// through the snapshot.
+//
+// A snapshot must be released after use. Iterators created from a snapshot
+// should be released before the snapshot is released. Once the snapshot has
+// been released, reads return [ErrClosed].
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed and
+	// can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot creates a consistent, read-only view of the current state of
+	// the key-value data store.
+	NewSnapshot() (Snapshot, error)
+}
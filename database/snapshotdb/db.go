@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	_ database.Database = &Database{}
+	_ database.Batch    = &batch{}
+	_ database.Snapshot = &snapshot{}
+)
+
+// Database exposes a snapshot as a read-only database, so that code written
+// against database.Database can read a consistent view of its host database.
+// Writes return database.ErrNotSupported. Closing the database releases the
+// snapshot.
+type Database struct {
+	database.Snapshot
+}
+
+// New returns a read-only database that reads from [snapshot]
+func New(snapshot database.Snapshot) *Database {
+	return &Database{Snapshot: snapshot}
+}
+
+// Put returns database.ErrNotSupported
+func (*Database) Put(_, _ []byte) error { return database.ErrNotSupported }
+
+// Delete returns database.ErrNotSupported
+func (*Database) Delete([]byte) error { return database.ErrNotSupported }
+
+// NewBatch returns a batch that can't be written
+func (*Database) NewBatch() database.Batch { return &batch{} }
+
+// NewSnapshot returns the snapshot the database reads from. Releasing the
+// returned snapshot doesn't release the database's snapshot.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	return &snapshot{Snapshot: db.Snapshot}, nil
+}
+
+// Compact does nothing
+func (*Database) Compact(_, _ []byte) error { return nil }
+
+// Close releases the snapshot
+func (db *Database) Close() error {
+	db.Release()
+	return nil
+}
+
+// HealthCheck returns nil
+func (*Database) HealthCheck() (interface{}, error) { return nil, nil }
+
+type snapshot struct {
+	database.Snapshot
+}
+
+func (*snapshot) Release() {}
+
+type batch struct{}
+
+func (*batch) Put(_, _ []byte) error                       { return database.ErrNotSupported }
+func (*batch) Delete([]byte) error                         { return database.ErrNotSupported }
+func (*batch) Size() int                                   { return 0 }
+func (*batch) Write() error                                { return database.ErrNotSupported }
+func (*batch) Reset()                                      {}
+func (*batch) Replay(database.KeyValueWriterDeleter) error { return nil }
+func (b *batch) Inner() database.Batch                     { return b }
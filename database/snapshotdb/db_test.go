@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+)
+
+func TestReadOnlySnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	baseDB := memdb.New()
+	assert.NoError(baseDB.Put([]byte("key1"), []byte("value1")))
+
+	snapshot, err := baseDB.NewSnapshot()
+	assert.NoError(err)
+	db := New(snapshot)
+
+	assert.NoError(baseDB.Put([]byte("key2"), []byte("value2")))
+
+	value, err := db.Get([]byte("key1"))
+	assert.NoError(err)
+	assert.Equal([]byte("value1"), value)
+	has, err := db.Has([]byte("key2"))
+	assert.NoError(err)
+	assert.False(has)
+
+	assert.ErrorIs(db.Put([]byte("key3"), nil), database.ErrNotSupported)
+	assert.ErrorIs(db.Delete([]byte("key1")), database.ErrNotSupported)
+	batch := db.NewBatch()
+	assert.ErrorIs(batch.Put([]byte("key3"), nil), database.ErrNotSupported)
+	assert.ErrorIs(batch.Write(), database.ErrNotSupported)
+
+	// Releasing a nested snapshot doesn't release the database's snapshot
+	nested, err := db.NewSnapshot()
+	assert.NoError(err)
+	nested.Release()
+	_, err = db.Get([]byte("key1"))
+	assert.NoError(err)
+
+	// Wrapped databases read from the snapshot
+	prefixed := prefixdb.New([]byte("prefix"), baseDB)
+	assert.NoError(prefixed.Put([]byte("key"), []byte("value")))
+	prefixedSnapshot, err := prefixed.NewSnapshot()
+	assert.NoError(err)
+	assert.NoError(prefixed.Delete([]byte("key")))
+	value, err = New(prefixedSnapshot).Get([]byte("key"))
+	assert.NoError(err)
+	assert.Equal([]byte("value"), value)
+
+	assert.NoError(db.Close())
+	_, err = db.Get([]byte("key1"))
+	assert.ErrorIs(err, database.ErrClosed)
+}
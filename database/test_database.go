@@ -31,11 +31,19 @@ var Tests = []func(t *testing.T, db Database){
 	TestIteratorStart,
 	TestIteratorPrefix,
 	TestIteratorStartPrefix,
+	TestIteratorRange,
+	TestReverseIterator,
+	TestReverseIteratorRange,
+	TestRangeIteratorClosed,
 	TestIteratorMemorySafety,
 	TestIteratorClosed,
 	TestIteratorError,
 	TestIteratorErrorAfterRelease,
 	TestCompactNoPanic,
+	TestSnapshot,
+	TestSnapshotIteratorPrefix,
+	TestSnapshotIteratorRange,
+	TestSnapshotRelease,
 	TestMemorySafetyDatabase,
 	TestMemorySafetyBatch,
 	TestClear,
@@ -823,6 +831,209 @@ func TestIteratorStartPrefix(t *testing.T, db Database) {
 	}
 }
 
+// TestIteratorRange tests to make sure the iterator returns exactly the
+// keys in the requested range.
+func TestIteratorRange(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	err := db.Put(key1, value1)
+	assert.NoError(err)
+
+	err = db.Put(key2, value2)
+	assert.NoError(err)
+
+	err = db.Put(key3, value3)
+	assert.NoError(err)
+
+	iterator := db.NewIteratorWithRange(key1, key3)
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.Equal(value1, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.Nil(iterator.Key())
+	assert.Nil(iterator.Value())
+	assert.NoError(iterator.Error())
+
+	iterator = db.NewIteratorWithRange([]byte("hello11"), nil)
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key3, iterator.Key())
+	assert.Equal(value3, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+
+	iterator = db.NewIteratorWithRange(key2, key2)
+	defer iterator.Release()
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+}
+
+// TestReverseIterator tests to make sure the reverse iterator returns all
+// the keys in descending order.
+func TestReverseIterator(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("z")
+	value3 := []byte("world3")
+
+	err := db.Put(key1, value1)
+	assert.NoError(err)
+
+	err = db.Put(key2, value2)
+	assert.NoError(err)
+
+	err = db.Put(key3, value3)
+	assert.NoError(err)
+
+	iterator := db.NewReverseIteratorWithRange(nil, nil)
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key3, iterator.Key())
+	assert.Equal(value3, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.Equal(value1, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.Nil(iterator.Key())
+	assert.Nil(iterator.Value())
+	assert.NoError(iterator.Error())
+}
+
+// TestReverseIteratorRange tests to make sure the reverse iterator returns
+// exactly the keys in the requested range in descending order.
+func TestReverseIteratorRange(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	key4 := []byte("hello4")
+	value4 := []byte("world4")
+
+	err := db.Put(key1, value1)
+	assert.NoError(err)
+
+	err = db.Put(key2, value2)
+	assert.NoError(err)
+
+	err = db.Put(key3, value3)
+	assert.NoError(err)
+
+	err = db.Put(key4, value4)
+	assert.NoError(err)
+
+	err = db.Delete(key3)
+	assert.NoError(err)
+
+	iterator := db.NewReverseIteratorWithRange(key1, key4)
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.Equal(value1, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+
+	iterator = db.NewReverseIteratorWithRange([]byte("hello11"), []byte("hello31"))
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+
+	iterator = db.NewReverseIteratorWithRange(key2, nil)
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key4, iterator.Key())
+	assert.Equal(value4, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+
+	iterator = db.NewReverseIteratorWithRange(key2, key2)
+	defer iterator.Release()
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+}
+
+// TestRangeIteratorClosed tests to make sure that range iterators report
+// [ErrClosed] once the database has been closed.
+func TestRangeIteratorClosed(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	err := db.Put([]byte("hello1"), []byte("world1"))
+	assert.NoError(err)
+
+	err = db.Close()
+	assert.NoError(err)
+
+	iterator := db.NewIteratorWithRange(nil, nil)
+	assert.False(iterator.Next())
+	assert.Equal(ErrClosed, iterator.Error())
+	iterator.Release()
+
+	iterator = db.NewReverseIteratorWithRange(nil, nil)
+	assert.False(iterator.Next())
+	assert.Equal(ErrClosed, iterator.Error())
+	iterator.Release()
+}
+
 // TestIteratorMemorySafety tests to make sure that keys can values are able to
 // be modified from the returned iterator.
 func TestIteratorMemorySafety(t *testing.T, db Database) {
@@ -1084,6 +1295,215 @@ func TestCompactNoPanic(t *testing.T, db Database) {
 	}
 }
 
+// TestSnapshot tests to make sure that writes made after a snapshot is taken
+// are not visible through the snapshot.
+func TestSnapshot(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	updatedValue1 := []byte("world1 updated")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	err := db.Put(key1, value1)
+	assert.NoError(err)
+
+	err = db.Put(key2, value2)
+	assert.NoError(err)
+
+	snapshot, err := db.NewSnapshot()
+	assert.NoError(err)
+	defer snapshot.Release()
+
+	err = db.Put(key1, updatedValue1)
+	assert.NoError(err)
+
+	err = db.Delete(key2)
+	assert.NoError(err)
+
+	err = db.Put(key3, value3)
+	assert.NoError(err)
+
+	value, err := snapshot.Get(key1)
+	assert.NoError(err)
+	assert.Equal(value1, value)
+
+	has, err := snapshot.Has(key2)
+	assert.NoError(err)
+	assert.True(has)
+
+	has, err = snapshot.Has(key3)
+	assert.NoError(err)
+	assert.False(has)
+
+	_, err = snapshot.Get(key3)
+	assert.Equal(ErrNotFound, err)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.Equal(value1, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.Nil(iterator.Key())
+	assert.Nil(iterator.Value())
+	assert.NoError(iterator.Error())
+
+	value, err = db.Get(key1)
+	assert.NoError(err)
+	assert.Equal(updatedValue1, value)
+}
+
+// TestSnapshotIteratorPrefix tests to make sure that iterators created from a
+// snapshot respect the requested start and prefix.
+func TestSnapshotIteratorPrefix(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("z")
+	value3 := []byte("world3")
+
+	err := db.Put(key1, value1)
+	assert.NoError(err)
+
+	err = db.Put(key2, value2)
+	assert.NoError(err)
+
+	err = db.Put(key3, value3)
+	assert.NoError(err)
+
+	snapshot, err := db.NewSnapshot()
+	assert.NoError(err)
+	defer snapshot.Release()
+
+	err = db.Delete(key2)
+	assert.NoError(err)
+
+	iterator := snapshot.NewIteratorWithStartAndPrefix(key2, []byte("hello"))
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+}
+
+// TestSnapshotIteratorRange tests to make sure that range iterators created
+// from a snapshot only return the keys in the requested range as of the
+// snapshot.
+func TestSnapshotIteratorRange(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	err := db.Put(key1, value1)
+	assert.NoError(err)
+
+	err = db.Put(key2, value2)
+	assert.NoError(err)
+
+	err = db.Put(key3, value3)
+	assert.NoError(err)
+
+	snapshot, err := db.NewSnapshot()
+	assert.NoError(err)
+	defer snapshot.Release()
+
+	err = db.Delete(key2)
+	assert.NoError(err)
+
+	err = db.Put([]byte("hello21"), value2)
+	assert.NoError(err)
+
+	iterator := snapshot.NewIteratorWithRange(key2, nil)
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key3, iterator.Key())
+	assert.Equal(value3, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+
+	iterator = snapshot.NewReverseIteratorWithRange(nil, key3)
+	defer iterator.Release()
+
+	assert.True(iterator.Next())
+	assert.Equal(key2, iterator.Key())
+	assert.Equal(value2, iterator.Value())
+
+	assert.True(iterator.Next())
+	assert.Equal(key1, iterator.Key())
+	assert.Equal(value1, iterator.Value())
+
+	assert.False(iterator.Next())
+	assert.NoError(iterator.Error())
+}
+
+// TestSnapshotRelease tests to make sure that a snapshot can no longer be read
+// after it has been released.
+func TestSnapshotRelease(t *testing.T, db Database) {
+	assert := assert.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	err := db.Put(key, value)
+	assert.NoError(err)
+
+	snapshot, err := db.NewSnapshot()
+	assert.NoError(err)
+
+	snapshot.Release()
+	snapshot.Release()
+
+	_, err = snapshot.Get(key)
+	assert.Equal(ErrClosed, err)
+
+	_, err = snapshot.Has(key)
+	assert.Equal(ErrClosed, err)
+
+	iterator := snapshot.NewIterator()
+	assert.False(iterator.Next())
+	assert.Equal(ErrClosed, iterator.Error())
+	iterator.Release()
+
+	err = db.Close()
+	assert.NoError(err)
+
+	_, err = db.NewSnapshot()
+	assert.Equal(ErrClosed, err)
+}
+
 // TestClear tests to make sure the deletion helper works as expected.
 func TestClear(t *testing.T, db Database) {
 	assert := assert.New(t)
@@ -19,10 +19,12 @@ const (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ Commitable        = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iterator{}
+	_ database.Database        = &Database{}
+	_ database.PrefixRegistrar = &Database{}
+	_ Commitable               = &Database{}
+	_ database.Batch           = &batch{}
+	_ database.Iterator        = &iterator{}
+	_ database.Snapshot        = &snapshot{}
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -128,29 +130,136 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.mem == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return db.newIterator(db.mem, db.db, start, prefix)
+}
+
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.mem, db.db, start, limit, false)
+}
+
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
 
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIterator(db.mem, db.db, start, limit, true)
+}
+
+// newIterator merges the values in [mem] with the values in [db]. Assumes
+// [db.lock] is held if [mem] is [db.mem].
+func (db *Database) newIterator(
+	mem map[string]valueDelete,
+	inner database.Iteratee,
+	start,
+	prefix []byte,
+) database.Iterator {
 	startString := string(start)
 	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
+	return db.newMergedIterator(
+		mem,
+		inner.NewIteratorWithStartAndPrefix(start, prefix),
+		func(key string) bool {
+			return strings.HasPrefix(key, prefixString) && key >= startString
+		},
+		false,
+	)
+}
+
+// newRangeIterator merges the values in [mem] that are in [start, limit) with
+// the values in [db]. Assumes [db.lock] is held if [mem] is [db.mem].
+func (db *Database) newRangeIterator(
+	mem map[string]valueDelete,
+	inner database.Iteratee,
+	start,
+	limit []byte,
+	reverse bool,
+) database.Iterator {
+	var innerIt database.Iterator
+	if reverse {
+		innerIt = inner.NewReverseIteratorWithRange(start, limit)
+	} else {
+		innerIt = inner.NewIteratorWithRange(start, limit)
+	}
+	startString := string(start)
+	limitString := string(limit)
+	return db.newMergedIterator(
+		mem,
+		innerIt,
+		func(key string) bool {
+			return key >= startString && (len(limitString) == 0 || key < limitString)
+		},
+		reverse,
+	)
+}
+
+// newMergedIterator merges the values in [mem] that satisfy [include] with the
+// values returned by [inner]. If [reverse] is true, [inner] must iterate in
+// descending order.
+func (db *Database) newMergedIterator(
+	mem map[string]valueDelete,
+	inner database.Iterator,
+	include func(key string) bool,
+	reverse bool,
+) database.Iterator {
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if include(key) {
 			keys = append(keys, key)
 		}
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
+	// Keys need to be in sorted order
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
 	values := make([]valueDelete, len(keys))
 	for i, key := range keys {
-		values[i] = db.mem[key]
+		values[i] = mem[key]
 	}
 
 	return &iterator{
 		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: inner,
 		keys:     keys,
 		values:   values,
+		reverse:  reverse,
 	}
 }
 
+// NewSnapshot returns a snapshot of the database, including any uncommitted
+// operations, that is backed by a snapshot of the underlying database.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+
+	s, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	mem := make(map[string]valueDelete, len(db.mem))
+	for key, value := range db.mem {
+		mem[key] = value
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+		mem:      mem,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -161,6 +270,18 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
 
+// RegisterPrefix forwards the registration of [prefix] to the underlying
+// database, which committed keys are written to unchanged.
+func (db *Database) RegisterPrefix(name string, prefix []byte, countKeys bool) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+	return database.RegisterPrefix(db.db, name, prefix, countKeys)
+}
+
 // SetDatabase changes the underlying database to the specified database
 func (db *Database) SetDatabase(newDB database.Database) error {
 	db.lock.Lock()
@@ -282,6 +403,95 @@ func (db *Database) HealthCheck() (interface{}, error) {
 	return db.db.HealthCheck()
 }
 
+// snapshot layers a copy of the in memory operations on top of a snapshot of
+// the underlying database.
+type snapshot struct {
+	database.Snapshot
+	db *Database
+
+	lock sync.RWMutex
+	mem  map[string]valueDelete
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.Snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(val.value), nil
+	}
+	return s.Snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newIterator(s.mem, s.Snapshot, start, prefix)
+}
+
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.mem, s.Snapshot, start, limit, false)
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newRangeIterator(s.mem, s.Snapshot, start, limit, true)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.mem = nil
+	s.Snapshot.Release()
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -362,7 +572,7 @@ type iterator struct {
 	keys   []string
 	values []valueDelete
 
-	initialized, exhausted bool
+	initialized, exhausted, reverse bool
 }
 
 // Next moves the iterator to the next key/value pair. It returns whether the
@@ -415,7 +625,7 @@ func (it *iterator) Next() bool {
 
 			dbStringKey := string(dbKey)
 			switch {
-			case memKey < dbStringKey:
+			case it.before(memKey, dbStringKey):
 				it.keys[0] = ""
 				it.keys = it.keys[1:]
 				it.values[0].value = nil
@@ -426,7 +636,7 @@ func (it *iterator) Next() bool {
 					it.value = memValue.value
 					return true
 				}
-			case dbStringKey < memKey:
+			case it.before(dbStringKey, memKey):
 				it.key = dbKey
 				it.value = it.Iterator.Value()
 				it.exhausted = !it.Iterator.Next()
@@ -448,6 +658,14 @@ func (it *iterator) Next() bool {
 	}
 }
 
+// before returns whether [a] should be returned by the iterator before [b].
+func (it *iterator) before(a, b string) bool {
+	if it.reverse {
+		return a > b
+	}
+	return a < b
+}
+
 func (it *iterator) Error() error {
 	if it.err != nil {
 		return it.err
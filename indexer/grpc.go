@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	indexpb "github.com/ava-labs/avalanchego/proto/pb/index"
+)
+
+var (
+	errUnknownIndex = errors.New("unknown index")
+
+	_ indexpb.IndexServer = &grpcServer{}
+)
+
+// grpcServer serves the indexes of an indexer over gRPC
+type grpcServer struct {
+	indexpb.UnsafeIndexServer
+
+	indexer *indexer
+}
+
+// getSubscriptions returns the subscriptions to the index [indexID]
+func (i *indexer) getSubscriptions(indexID *indexpb.IndexID) (*subscriptions, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	chainID, ok := i.chainIDs[indexID.GetChain()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s: chain %q isn't indexed", errUnknownIndex, indexID.GetChain())
+	}
+	subs, ok := i.subscriptions[chainID][indexID.GetIndex()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s: chain %q has no %q index", errUnknownIndex, indexID.GetChain(), indexID.GetIndex())
+	}
+	return subs, nil
+}
+
+func (s *grpcServer) index(indexID *indexpb.IndexID) (Index, error) {
+	subs, err := s.indexer.getSubscriptions(indexID)
+	if err != nil {
+		return nil, err
+	}
+	return subs.index, nil
+}
+
+func (s *grpcServer) GetLastAccepted(_ context.Context, req *indexpb.GetLastAcceptedRequest) (*indexpb.Container, error) {
+	index, err := s.index(req.GetIndexId())
+	if err != nil {
+		return nil, err
+	}
+	container, err := index.GetLastAccepted()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newIndexedContainer(index, container)
+}
+
+func (s *grpcServer) GetContainerByIndex(_ context.Context, req *indexpb.GetContainerByIndexRequest) (*indexpb.Container, error) {
+	index, err := s.index(req.GetIndexId())
+	if err != nil {
+		return nil, err
+	}
+	container, err := index.GetContainerByIndex(req.GetIndex())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newContainer(container, req.GetIndex()), nil
+}
+
+func (s *grpcServer) GetContainerRange(_ context.Context, req *indexpb.GetContainerRangeRequest) (*indexpb.GetContainerRangeResponse, error) {
+	index, err := s.index(req.GetIndexId())
+	if err != nil {
+		return nil, err
+	}
+	containers, err := index.GetContainerRange(req.GetStartIndex(), req.GetNumToFetch())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp := &indexpb.GetContainerRangeResponse{
+		Containers: make([]*indexpb.Container, len(containers)),
+	}
+	for i, container := range containers {
+		resp.Containers[i] = newContainer(container, req.GetStartIndex()+uint64(i))
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) GetContainerByID(_ context.Context, req *indexpb.GetContainerByIDRequest) (*indexpb.Container, error) {
+	index, err := s.index(req.GetIndexId())
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := ids.ToID(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	container, err := index.GetContainerByID(containerID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newIndexedContainer(index, container)
+}
+
+func (s *grpcServer) GetIndex(_ context.Context, req *indexpb.GetIndexRequest) (*indexpb.GetIndexResponse, error) {
+	index, err := s.index(req.GetIndexId())
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := ids.ToID(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	i, err := index.GetIndex(containerID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &indexpb.GetIndexResponse{Index: i}, nil
+}
+
+func (s *grpcServer) IsAccepted(_ context.Context, req *indexpb.IsAcceptedRequest) (*indexpb.IsAcceptedResponse, error) {
+	index, err := s.index(req.GetIndexId())
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := ids.ToID(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	_, err = index.GetIndex(containerID)
+	switch {
+	case err == nil:
+		return &indexpb.IsAcceptedResponse{IsAccepted: true}, nil
+	case errors.Is(err, database.ErrNotFound):
+		return &indexpb.IsAcceptedResponse{IsAccepted: false}, nil
+	default:
+		return nil, err
+	}
+}
+
+// Subscribe streams the containers accepted into the index, as the websocket
+// endpoint of the index does.
+func (s *grpcServer) Subscribe(req *indexpb.SubscribeRequest, stream indexpb.Index_SubscribeServer) error {
+	subs, err := s.indexer.getSubscriptions(req.GetIndexId())
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before looking up the next index so that no container accepted
+	// in between is missed.
+	wake := subs.subscribe()
+	defer subs.unsubscribe(wake)
+
+	cursor, err := subs.startCursor(req.Cursor)
+	if err != nil {
+		if errors.Is(err, errInvalidCursor) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return err
+	}
+
+	for {
+		cursor, err = subs.send(cursor, func(container Container, index uint64) error {
+			return stream.Send(newContainer(container, index))
+		})
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-wake:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// newIndexedContainer returns [container], which was accepted into [index]
+func newIndexedContainer(index Index, container Container) (*indexpb.Container, error) {
+	i, err := index.GetIndex(container.ID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get index: %w", err)
+	}
+	return newContainer(container, i), nil
+}
+
+func newContainer(container Container, index uint64) *indexpb.Container {
+	return &indexpb.Container{
+		Id:        container.ID[:],
+		Bytes:     container.Bytes,
+		Timestamp: timestamppb.New(time.Unix(0, container.Timestamp)),
+		Index:     index,
+	}
+}
+
+// grpcError returns [err] with the NotFound code if the requested container
+// doesn't exist
+func grpcError(err error) error {
+	if errors.Is(err, database.ErrNotFound) || errors.Is(err, errNoneAccepted) || errors.Is(err, errNoContainer) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
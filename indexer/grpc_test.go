@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	indexpb "github.com/ava-labs/avalanchego/proto/pb/index"
+)
+
+const testChain = "X"
+
+var testIndexID = &indexpb.IndexID{
+	Chain: testChain,
+	Index: "tx",
+}
+
+// newTestIndexClient returns a client of the gRPC service of an indexer with
+// a tx index on [testChain]
+func newTestIndexClient(t *testing.T) (*subscriptions, indexpb.IndexClient) {
+	s, _ := newTestSubscriptions(t)
+	chainID := ids.GenerateTestID()
+	i := &indexer{
+		chainIDs: map[string]ids.ID{
+			testChain: chainID,
+		},
+		subscriptions: map[ids.ID]map[string]*subscriptions{
+			chainID: {"tx": s},
+		},
+	}
+
+	server := grpc.NewServer()
+	indexpb.RegisterIndexServer(server, &grpcServer{indexer: i})
+	listener := bufconn.Listen(units.MiB)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return s, indexpb.NewIndexClient(conn)
+}
+
+func TestGRPCServer(t *testing.T) {
+	assert := assert.New(t)
+
+	s, client := newTestIndexClient(t)
+	ctx := context.Background()
+
+	_, err := client.GetLastAccepted(ctx, &indexpb.GetLastAcceptedRequest{IndexId: testIndexID})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	containerIDs := make([]ids.ID, 3)
+	containers := make([][]byte, 3)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		containers[i] = utils.RandomBytes(32)
+		assert.NoError(s.Accept(snow.DefaultConsensusContextTest(), containerIDs[i], containers[i]))
+	}
+
+	container, err := client.GetLastAccepted(ctx, &indexpb.GetLastAcceptedRequest{IndexId: testIndexID})
+	assert.NoError(err)
+	assert.Equal(containerIDs[2][:], container.Id)
+	assert.Equal(containers[2], container.Bytes)
+	assert.EqualValues(2, container.Index)
+
+	container, err = client.GetContainerByIndex(ctx, &indexpb.GetContainerByIndexRequest{
+		IndexId: testIndexID,
+		Index:   1,
+	})
+	assert.NoError(err)
+	assert.Equal(containerIDs[1][:], container.Id)
+	assert.EqualValues(1, container.Index)
+
+	_, err = client.GetContainerByIndex(ctx, &indexpb.GetContainerByIndexRequest{
+		IndexId: testIndexID,
+		Index:   3,
+	})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	containerRange, err := client.GetContainerRange(ctx, &indexpb.GetContainerRangeRequest{
+		IndexId:    testIndexID,
+		StartIndex: 1,
+		NumToFetch: 2,
+	})
+	assert.NoError(err)
+	assert.Len(containerRange.Containers, 2)
+	for i, container := range containerRange.Containers {
+		assert.Equal(containerIDs[i+1][:], container.Id)
+		assert.EqualValues(i+1, container.Index)
+	}
+
+	_, err = client.GetContainerRange(ctx, &indexpb.GetContainerRangeRequest{
+		IndexId:    testIndexID,
+		StartIndex: 0,
+		NumToFetch: 0,
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	container, err = client.GetContainerByID(ctx, &indexpb.GetContainerByIDRequest{
+		IndexId: testIndexID,
+		Id:      containerIDs[0][:],
+	})
+	assert.NoError(err)
+	assert.Equal(containers[0], container.Bytes)
+	assert.EqualValues(0, container.Index)
+
+	index, err := client.GetIndex(ctx, &indexpb.GetIndexRequest{
+		IndexId: testIndexID,
+		Id:      containerIDs[1][:],
+	})
+	assert.NoError(err)
+	assert.EqualValues(1, index.Index)
+
+	unknownID := ids.GenerateTestID()
+	_, err = client.GetIndex(ctx, &indexpb.GetIndexRequest{
+		IndexId: testIndexID,
+		Id:      unknownID[:],
+	})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	_, err = client.GetIndex(ctx, &indexpb.GetIndexRequest{
+		IndexId: testIndexID,
+		Id:      []byte{1},
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	accepted, err := client.IsAccepted(ctx, &indexpb.IsAcceptedRequest{
+		IndexId: testIndexID,
+		Id:      containerIDs[2][:],
+	})
+	assert.NoError(err)
+	assert.True(accepted.IsAccepted)
+
+	accepted, err = client.IsAccepted(ctx, &indexpb.IsAcceptedRequest{
+		IndexId: testIndexID,
+		Id:      unknownID[:],
+	})
+	assert.NoError(err)
+	assert.False(accepted.IsAccepted)
+}
+
+func TestGRPCServerUnknownIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexID *indexpb.IndexID
+	}{
+		{
+			name: "unknown chain",
+			indexID: &indexpb.IndexID{
+				Chain: "P",
+				Index: "tx",
+			},
+		},
+		{
+			name: "unknown index",
+			indexID: &indexpb.IndexID{
+				Chain: testChain,
+				Index: "block",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, client := newTestIndexClient(t)
+			_, err := client.GetLastAccepted(context.Background(), &indexpb.GetLastAcceptedRequest{IndexId: test.indexID})
+			assert.Equal(codes.NotFound, status.Code(err))
+
+			stream, err := client.Subscribe(context.Background(), &indexpb.SubscribeRequest{IndexId: test.indexID})
+			assert.NoError(err)
+			_, err = stream.Recv()
+			assert.Equal(codes.NotFound, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCServerSubscribe(t *testing.T) {
+	assert := assert.New(t)
+
+	s, client := newTestIndexClient(t)
+	snowCtx := snow.DefaultConsensusContextTest()
+
+	containerIDs := make([]ids.ID, 3)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+	assert.NoError(s.Accept(snowCtx, containerIDs[0], utils.RandomBytes(32)))
+
+	// A cursor after the next accepted index is invalid
+	cursor := uint64(2)
+	stream, err := client.Subscribe(context.Background(), &indexpb.SubscribeRequest{
+		IndexId: testIndexID,
+		Cursor:  &cursor,
+	})
+	assert.NoError(err)
+	_, err = stream.Recv()
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cursor = 0
+	stream, err = client.Subscribe(ctx, &indexpb.SubscribeRequest{
+		IndexId: testIndexID,
+		Cursor:  &cursor,
+	})
+	assert.NoError(err)
+
+	// The containers accepted before the cursor are sent first, then the
+	// newly accepted containers.
+	container, err := stream.Recv()
+	assert.NoError(err)
+	assert.Equal(containerIDs[0][:], container.Id)
+	assert.EqualValues(0, container.Index)
+
+	assert.NoError(s.Accept(snowCtx, containerIDs[1], utils.RandomBytes(32)))
+	assert.NoError(s.Accept(snowCtx, containerIDs[2], utils.RandomBytes(32)))
+	for i := 1; i < 3; i++ {
+		container, err := stream.Recv()
+		assert.NoError(err)
+		assert.Equal(containerIDs[i][:], container.Id)
+		assert.EqualValues(i, container.Index)
+	}
+
+	cancel()
+	_, err = stream.Recv()
+	assert.Equal(codes.Canceled, status.Code(err))
+}
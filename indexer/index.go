@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/snapshotdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -33,6 +34,7 @@ var (
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
 	errNoneAccepted        = errors.New("no containers have been accepted")
+	errNoContainer         = errors.New("no container")
 	errNumToFetchZero      = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
 
 	_ Index = &index{}
@@ -184,16 +186,16 @@ func (i *index) GetContainerByIndex(index uint64) (Container, error) {
 func (i *index) getContainerByIndex(index uint64) (Container, error) {
 	lastAcceptedIndex, ok := i.lastAcceptedIndex()
 	if !ok || index > lastAcceptedIndex {
-		return Container{}, fmt.Errorf("no container at index %d", index)
+		return Container{}, fmt.Errorf("%w at index %d", errNoContainer, index)
 	}
 	indexBytes := database.PackUInt64(index)
-	return i.getContainerByIndexBytes(indexBytes)
+	return i.getContainerByIndexBytes(i.indexToContainer, indexBytes)
 }
 
 // [indexBytes] is the byte representation of the index to fetch.
-// Assumes [i.lock] is held
-func (i *index) getContainerByIndexBytes(indexBytes []byte) (Container, error) {
-	containerBytes, err := i.indexToContainer.Get(indexBytes)
+// Assumes [i.lock] is held or [indexToContainer] is a snapshot.
+func (i *index) getContainerByIndexBytes(indexToContainer database.KeyValueReader, indexBytes []byte) (Container, error) {
+	containerBytes, err := indexToContainer.Get(indexBytes)
 	if err != nil {
 		i.log.Error("couldn't read container from database: %s", err)
 		return Container{}, fmt.Errorf("couldn't read from database: %w", err)
@@ -218,14 +220,24 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	}
 
 	i.lock.RLock()
-	defer i.lock.RUnlock()
-
 	lastAcceptedIndex, ok := i.lastAcceptedIndex()
 	if !ok {
+		i.lock.RUnlock()
 		return nil, errNoneAccepted
 	} else if startIndex > lastAcceptedIndex {
+		i.lock.RUnlock()
 		return nil, fmt.Errorf("start index (%d) > last accepted index (%d)", startIndex, lastAcceptedIndex)
 	}
+	// Accepted containers are committed to [i.baseDB] while [i.lock] is held,
+	// so the snapshot contains every container up to [lastAcceptedIndex]. The
+	// range is read from the snapshot so that large pages don't block Accept.
+	snapshot, err := i.baseDB.NewSnapshot()
+	i.lock.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't snapshot database: %w", err)
+	}
+	defer snapshot.Release()
+	indexToContainer := prefixdb.New(indexToContainerPrefix, snapshotdb.New(snapshot))
 
 	// Calculate the last index we will fetch
 	lastIndex := math.Min64(startIndex+numToFetch-1, lastAcceptedIndex)
@@ -234,9 +246,9 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	containers := make([]Container, int(lastIndex)-int(startIndex)+1)
 
 	n := 0
-	var err error
 	for j := startIndex; j <= lastIndex; j++ {
-		containers[n], err = i.getContainerByIndex(j)
+		indexBytes := database.PackUInt64(j)
+		containers[n], err = i.getContainerByIndexBytes(indexToContainer, indexBytes)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't get container at index %d: %w", j, err)
 		}
@@ -262,7 +274,7 @@ func (i *index) GetContainerByID(id ids.ID) (Container, error) {
 	if err != nil {
 		return Container{}, err
 	}
-	return i.getContainerByIndexBytes(indexBytes)
+	return i.getContainerByIndexBytes(i.indexToContainer, indexBytes)
 }
 
 // GetLastAccepted returns the last accepted container.
@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/http"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
+	"google.golang.org/grpc"
 
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/codec"
@@ -28,6 +30,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	indexpb "github.com/ava-labs/avalanchego/proto/pb/index"
 )
 
 const (
@@ -62,7 +66,12 @@ type Config struct {
 	DecisionAcceptorGroup  snow.AcceptorGroup
 	ConsensusAcceptorGroup snow.AcceptorGroup
 	APIServer              server.PathAdder
-	ShutdownF              func()
+	// Origins that index subscriptions may be made from. See
+	// server.NewOriginChecker.
+	AllowedOrigins []string
+	// If not nil, the indexes are also served over gRPC
+	GRPCServer grpc.ServiceRegistrar
+	ShutdownF  func()
 }
 
 // Indexer causes accepted containers for a given chain
@@ -88,7 +97,10 @@ func NewIndexer(config Config) (Indexer, error) {
 		txIndices:              map[ids.ID]Index{},
 		vtxIndices:             map[ids.ID]Index{},
 		blockIndices:           map[ids.ID]Index{},
+		chainIDs:               map[string]ids.ID{},
+		subscriptions:          map[ids.ID]map[string]*subscriptions{},
 		pathAdder:              config.APIServer,
+		checkOrigin:            server.NewOriginChecker(config.AllowedOrigins),
 		shutdownF:              config.ShutdownF,
 	}
 
@@ -98,6 +110,9 @@ func NewIndexer(config Config) (Indexer, error) {
 	); err != nil {
 		return nil, fmt.Errorf("couldn't register codec: %w", err)
 	}
+	if config.GRPCServer != nil {
+		indexpb.RegisterIndexServer(config.GRPCServer, &grpcServer{indexer: indexer})
+	}
 	hasRun, err := indexer.hasRun()
 	if err != nil {
 		return nil, err
@@ -123,6 +138,10 @@ type indexer struct {
 	// Used to add API endpoint for new indices
 	pathAdder server.PathAdder
 
+	// Reports whether an index subscription may be made from the origin of
+	// the request
+	checkOrigin func(*http.Request) bool
+
 	// If true, allow running in such a way that could allow the creation
 	// of an index which could be missing accepted containers.
 	allowIncompleteIndex bool
@@ -137,6 +156,12 @@ type indexer struct {
 	// Chain ID --> index of txs of that chain (if applicable)
 	txIndices map[ids.ID]Index
 
+	// Alias or ID of an indexed chain --> ID of the chain
+	chainIDs map[string]ids.ID
+	// Chain ID --> name of an index of the chain ("block", "vtx" or "tx") -->
+	// subscriptions to the index
+	subscriptions map[ids.ID]map[string]*subscriptions
+
 	// Notifies of newly accepted transactions
 	decisionAcceptorGroup snow.AcceptorGroup
 	// Notifies of newly accepted blocks and vertices
@@ -222,6 +247,9 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		return
 	}
+	i.chainIDs[name] = chainID
+	i.chainIDs[chainID.String()] = chainID
+	i.subscriptions[chainID] = make(map[string]*subscriptions)
 
 	switch engine.(type) {
 	case snowman.Engine:
@@ -279,14 +307,20 @@ func (i *indexer) registerChainHelper(
 		return nil, err
 	}
 
-	// Register index to learn about new accepted vertices
-	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), index, true); err != nil {
+	// Register index to learn about new accepted vertices. Subscribers are
+	// notified of the containers once they're indexed.
+	subscriptions := newSubscriptions(index, i.log, i.checkOrigin)
+	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), subscriptions, true); err != nil {
 		_ = index.Close()
 		return nil, err
 	}
 
 	// Create an API endpoint for this index
-	apiServer := rpc.NewServer()
+	apiServer, err := openrpc.NewServer()
+	if err != nil {
+		_ = index.Close()
+		return nil, err
+	}
 	codec := json.NewCodec()
 	apiServer.RegisterCodec(codec, "application/json")
 	apiServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -299,6 +333,14 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+
+	// Create a websocket endpoint to subscribe to this index
+	subscriptionHandler := &common.HTTPHandler{LockOptions: common.NoLock, Handler: subscriptions}
+	if err := i.pathAdder.AddRoute(subscriptionHandler, &sync.RWMutex{}, "index/"+name, "/"+endpoint+"/events"); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
+	i.subscriptions[chainID][endpoint] = subscriptions
 	return index, nil
 }
 
@@ -174,9 +174,10 @@ func TestIndexer(t *testing.T) {
 	assert.NoError(err)
 	assert.True(previouslyIndexed)
 	server := config.APIServer.(*apiServerMock)
-	assert.EqualValues(1, server.timesCalled)
+	assert.EqualValues(2, server.timesCalled) // block index and its subscriptions
 	assert.EqualValues("index/chain1", server.bases[0])
 	assert.EqualValues("/block", server.endpoints[0])
+	assert.EqualValues("/block/events", server.endpoints[1])
 	assert.Len(idxr.blockIndices, 1)
 	assert.Len(idxr.txIndices, 0)
 	assert.Len(idxr.vtxIndices, 0)
@@ -274,10 +275,12 @@ func TestIndexer(t *testing.T) {
 	idxr.RegisterChain("chain2", dagEngine)
 	assert.NoError(err)
 	server = config.APIServer.(*apiServerMock)
-	assert.EqualValues(3, server.timesCalled) // block index, vtx index, tx index
+	assert.EqualValues(6, server.timesCalled) // vtx index, tx index and their subscriptions
 	assert.Contains(server.bases, "index/chain2")
 	assert.Contains(server.endpoints, "/vtx")
+	assert.Contains(server.endpoints, "/vtx/events")
 	assert.Contains(server.endpoints, "/tx")
+	assert.Contains(server.endpoints, "/tx/events")
 	assert.Len(idxr.blockIndices, 1)
 	assert.Len(idxr.txIndices, 1)
 	assert.Len(idxr.vtxIndices, 1)
@@ -0,0 +1,283 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Query parameter of the index of the first container to send
+	cursorParam = "cursor"
+	// Query parameter of the encoding of the sent containers
+	encodingParam = "encoding"
+
+	// Size of the ws read and write buffers
+	wsBufferSize = units.KiB
+	// Time allowed to write a message to the client
+	writeWait = 10 * time.Second
+	// Time allowed to read the next pong message from the client
+	pongWait = 60 * time.Second
+	// Send pings to the client with this period. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+	// Maximum message size allowed from the client. Clients aren't expected
+	// to send anything other than control messages.
+	maxMessageSize = units.KiB
+)
+
+var (
+	errInvalidCursor = errors.New("invalid cursor")
+
+	_ snow.Acceptor = &subscriptions{}
+	_ http.Handler  = &subscriptions{}
+)
+
+type subscriptionError struct {
+	Error string `json:"error"`
+}
+
+// subscriptions streams the containers accepted into an index to websocket
+// clients, in the order they were accepted.
+//
+// A client may pass the index of the first container it wants as the cursor
+// query parameter. If it's omitted, only containers accepted after the client
+// connects are sent. Every sent container includes its index, so a client that
+// reconnects with a cursor of the last received index + 1 doesn't miss any
+// containers.
+type subscriptions struct {
+	index    Index
+	log      logging.Logger
+	upgrader websocket.Upgrader
+
+	lock sync.Mutex
+	// Each subscriber is woken up by a send on its channel when a container is
+	// accepted.
+	subscribers map[chan struct{}]struct{}
+}
+
+// newSubscriptions returns the subscriptions to [index]. Websocket connections
+// are only accepted from the origins that [checkOrigin] allows.
+func newSubscriptions(index Index, log logging.Logger, checkOrigin func(*http.Request) bool) *subscriptions {
+	return &subscriptions{
+		index: index,
+		log:   log,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  wsBufferSize,
+			WriteBufferSize: wsBufferSize,
+			CheckOrigin:     checkOrigin,
+		},
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Accept indexes the container, then notifies the subscribers. This is
+// registered on the acceptor group in place of the index, so that subscribers
+// are only notified once the container can be read from the index.
+func (s *subscriptions) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	if err := s.index.Accept(ctx, containerID, container); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for wake := range s.subscribers {
+		select {
+		case wake <- struct{}{}:
+		default:
+			// The subscriber already has a pending notification.
+		}
+	}
+	return nil
+}
+
+func (s *subscriptions) subscribe() chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	wake := make(chan struct{}, 1)
+	s.subscribers[wake] = struct{}{}
+	return wake
+}
+
+func (s *subscriptions) unsubscribe(wake chan struct{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.subscribers, wake)
+}
+
+func (s *subscriptions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Subscribe before looking up the next index so that no container accepted
+	// in between is missed.
+	wake := s.subscribe()
+	defer s.unsubscribe(wake)
+
+	cursor, encoding, err := s.parseParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade index subscription: %s", err)
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// The client isn't expected to send anything, but reading is required to
+	// handle control messages and to notice when the client disconnects.
+	done := make(chan struct{})
+	go readPump(conn, done)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		cursor, err = s.send(cursor, func(container Container, index uint64) error {
+			formatted, err := newFormattedContainer(container, index, encoding)
+			if err != nil {
+				return err
+			}
+			if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return err
+			}
+			return conn.WriteJSON(formatted)
+		})
+		if err != nil {
+			s.log.Debug("closing index subscription: %s", err)
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			_ = conn.WriteJSON(subscriptionError{Error: err.Error()})
+			return
+		}
+
+		select {
+		case <-wake:
+		case <-ticker.C:
+			if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// parseParams returns the cursor and encoding requested by [r]
+func (s *subscriptions) parseParams(r *http.Request) (uint64, formatting.Encoding, error) {
+	query := r.URL.Query()
+
+	encoding := formatting.Hex
+	if encodingStr := query.Get(encodingParam); encodingStr != "" {
+		if err := encoding.UnmarshalJSON([]byte(strconv.Quote(encodingStr))); err != nil {
+			return 0, 0, fmt.Errorf("invalid %s: %w", encodingParam, err)
+		}
+	}
+
+	cursorStr := query.Get(cursorParam)
+	if cursorStr == "" {
+		cursor, err := s.startCursor(nil)
+		return cursor, encoding, err
+	}
+	cursor, err := strconv.ParseUint(cursorStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid %s: %w", cursorParam, err)
+	}
+	cursor, err = s.startCursor(&cursor)
+	return cursor, encoding, err
+}
+
+// startCursor returns the index of the first container to send to a
+// subscriber that requested [cursor]. If [cursor] is nil, only containers
+// accepted from now on are sent.
+func (s *subscriptions) startCursor(cursor *uint64) (uint64, error) {
+	nextIndex, err := nextAcceptedIndex(s.index)
+	if err != nil {
+		return 0, err
+	}
+	if cursor == nil {
+		return nextIndex, nil
+	}
+	if *cursor > nextIndex {
+		return 0, fmt.Errorf("%w: %s %d is after the next accepted index %d", errInvalidCursor, cursorParam, *cursor, nextIndex)
+	}
+	return *cursor, nil
+}
+
+// send calls [f] with each container from [cursor] to the last accepted
+// container, in order. Returns the index of the next container to send.
+func (s *subscriptions) send(cursor uint64, f func(container Container, index uint64) error) (uint64, error) {
+	nextIndex, err := nextAcceptedIndex(s.index)
+	if err != nil {
+		return cursor, err
+	}
+	for cursor < nextIndex {
+		containers, err := s.index.GetContainerRange(cursor, math.Min64(nextIndex-cursor, MaxFetchedByRange))
+		if err != nil {
+			return cursor, err
+		}
+		for _, container := range containers {
+			if err := f(container, cursor); err != nil {
+				return cursor, err
+			}
+			cursor++
+		}
+	}
+	return cursor, nil
+}
+
+// nextAcceptedIndex returns the index the next container accepted into
+// [index] will have
+func nextAcceptedIndex(index Index) (uint64, error) {
+	container, err := index.GetLastAccepted()
+	if errors.Is(err, errNoneAccepted) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	lastIndex, err := index.GetIndex(container.ID)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get index: %w", err)
+	}
+	return lastIndex + 1, nil
+}
+
+// readPump reads from [conn] until it's closed, then closes [done]
+func readPump(conn *websocket.Conn, done chan struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(maxMessageSize)
+	if err := conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		return
+	}
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
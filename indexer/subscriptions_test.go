@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const testOrigin = "https://allowed.example.com"
+
+func newTestSubscriptions(t *testing.T) (*subscriptions, *httptest.Server) {
+	codec := codec.NewDefaultManager()
+	if err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault()); err != nil {
+		t.Fatal(err)
+	}
+	index, err := newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newSubscriptions(index, logging.NoLog{}, server.NewOriginChecker([]string{testOrigin}))
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func dial(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func readContainer(t *testing.T, conn *websocket.Conn) FormattedContainer {
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	container := FormattedContainer{}
+	if err := conn.ReadJSON(&container); err != nil {
+		t.Fatal(err)
+	}
+	return container
+}
+
+func TestSubscriptions(t *testing.T) {
+	assert := assert.New(t)
+
+	s, server := newTestSubscriptions(t)
+	ctx := snow.DefaultConsensusContextTest()
+
+	containerIDs := make([]ids.ID, 4)
+	containers := make([][]byte, 4)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		containers[i] = utils.RandomBytes(32)
+	}
+	assert.NoError(s.Accept(ctx, containerIDs[0], containers[0]))
+	assert.NoError(s.Accept(ctx, containerIDs[1], containers[1]))
+
+	// A client resuming from a cursor should get the containers accepted
+	// before it connected, then the newly accepted containers.
+	resumed := dial(t, server, "cursor=1&encoding=hexnc")
+	// A client without a cursor should only get the newly accepted
+	// containers.
+	latest := dial(t, server, "")
+
+	container := readContainer(t, resumed)
+	assert.EqualValues(1, container.Index)
+	assert.Equal(containerIDs[1], container.ID)
+	assert.Equal(formatting.HexNC, container.Encoding)
+	expectedBytes, err := formatting.Encode(formatting.HexNC, containers[1])
+	assert.NoError(err)
+	assert.Equal(expectedBytes, container.Bytes)
+
+	// Clients are subscribed before their connection is upgraded, so they
+	// should get every container accepted from now on.
+	assert.NoError(s.Accept(ctx, containerIDs[2], containers[2]))
+	assert.NoError(s.Accept(ctx, containerIDs[3], containers[3]))
+	for _, conn := range []*websocket.Conn{resumed, latest} {
+		for i := 2; i < 4; i++ {
+			container := readContainer(t, conn)
+			assert.EqualValues(i, container.Index)
+			assert.Equal(containerIDs[i], container.ID)
+		}
+	}
+}
+
+func TestSubscriptionsInvalidParams(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{
+			name:  "cursor after next accepted index",
+			query: "cursor=1",
+		},
+		{
+			name:  "malformed cursor",
+			query: "cursor=one",
+		},
+		{
+			name:  "unknown encoding",
+			query: "encoding=base58",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			_, server := newTestSubscriptions(t)
+			url := "ws" + strings.TrimPrefix(server.URL, "http") + "?" + test.query
+			_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+			assert.Error(err)
+			assert.Equal(http.StatusBadRequest, resp.StatusCode)
+			_ = resp.Body.Close()
+		})
+	}
+}
+
+func TestSubscriptionsOrigin(t *testing.T) {
+	assert := assert.New(t)
+
+	_, server := newTestSubscriptions(t)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{testOrigin}})
+	assert.NoError(err)
+	_ = conn.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://evil.example.com"}})
+	assert.Error(err)
+	assert.Equal(http.StatusForbidden, resp.StatusCode)
+	_ = resp.Body.Close()
+}
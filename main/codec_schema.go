@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+)
+
+// codecSchemaCommand is the subcommand that writes the wire format of the
+// X-chain and P-chain codecs as JSON, so that they can be (un)marshaled by
+// implementations in other languages.
+const codecSchemaCommand = "codec-schema"
+
+const (
+	chainsKey = "chains"
+	outputKey = "output"
+)
+
+// chainCodecs are the codecs whose schemas may be written, by chain alias.
+var chainCodecs = map[string]codec.Manager{
+	"X": x.Parser.Codec(),
+	"P": platformvm.Codec,
+}
+
+// runCodecSchema parses [args] and writes the schemas of the requested chains'
+// codecs. Returns the exit code of the process.
+func runCodecSchema(args []string) int {
+	fs := pflag.NewFlagSet(codecSchemaCommand, pflag.ContinueOnError)
+	chains := fs.StringSlice(chainsKey, []string{"X", "P"}, "Aliases of the chains whose codec schemas are written")
+	output := fs.String(outputKey, "", "Path of the file to write the schemas to. If empty, the schemas are written to stdout")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 1
+	}
+
+	schemas := make(map[string][]codec.VersionSchema, len(*chains))
+	for _, chain := range *chains {
+		manager, ok := chainCodecs[chain]
+		if !ok {
+			fmt.Printf("unknown chain %q\n", chain)
+			return 1
+		}
+		chainSchemas, err := manager.Schema()
+		if err != nil {
+			fmt.Printf("couldn't describe the %s-chain codec: %s\n", chain, err)
+			return 1
+		}
+		schemas[chain] = chainSchemas
+	}
+
+	schemaJSON, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		fmt.Printf("couldn't marshal schemas: %s\n", err)
+		return 1
+	}
+	schemaJSON = append(schemaJSON, '\n')
+
+	if *output == "" {
+		if _, err := os.Stdout.Write(schemaJSON); err != nil {
+			return 1
+		}
+		return 0
+	}
+	if err := os.WriteFile(*output, schemaJSON, perms.ReadWrite); err != nil {
+		fmt.Printf("couldn't write schemas: %s\n", err)
+		return 1
+	}
+	return 0
+}
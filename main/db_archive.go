@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/archive"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/migrate"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/database/rocksdb"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// dbExportCommand is the subcommand that writes an archive of the databases
+// of a stopped node. Running nodes should use the admin.exportDatabase API
+// instead, which also records the state of the node's chains.
+const dbExportCommand = "db-export"
+
+// dbImportCommand is the subcommand that restores the databases of a stopped
+// node from an archive.
+const dbImportCommand = "db-import"
+
+const archiveKey = "archive"
+
+// dbArchiveFlags are the flags shared by the archive subcommands.
+type dbArchiveFlags struct {
+	fs           *pflag.FlagSet
+	dbDir        *string
+	networkName  *string
+	dbType       *string
+	dbConfigFile *string
+	archivePath  *string
+}
+
+func newDBArchiveFlags(command string) *dbArchiveFlags {
+	fs := pflag.NewFlagSet(command, pflag.ContinueOnError)
+	return &dbArchiveFlags{
+		fs:           fs,
+		dbDir:        fs.String(config.DBPathKey, filepath.Join("$HOME", ".avalanchego", "db"), "Path to database directory"),
+		networkName:  fs.String(config.NetworkNameKey, constants.MainnetName, "Network ID of the databases"),
+		dbType:       fs.String(config.DBTypeKey, leveldb.Name, fmt.Sprintf("Database type of the node. Should be one of {%s, %s, %s}", leveldb.Name, rocksdb.Name, pebbledb.Name)),
+		dbConfigFile: fs.String(config.DBConfigFileKey, "", "Path to the database config file"),
+		archivePath:  fs.String(archiveKey, "", "Path to the archive"),
+	}
+}
+
+// parse parses [args] and returns the network ID, the node's database
+// directory and the database config. Returns the exit code of the process if
+// the subcommand shouldn't continue.
+func (f *dbArchiveFlags) parse(args []string) (uint32, string, []byte, int, bool) {
+	if err := f.fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0, "", nil, 0, false
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 0, "", nil, 1, false
+	}
+	if *f.archivePath == "" {
+		fmt.Printf("--%s must be specified\n", archiveKey)
+		return 0, "", nil, 1, false
+	}
+	networkID, err := constants.NetworkID(*f.networkName)
+	if err != nil {
+		fmt.Printf("couldn't parse network ID: %s\n", err)
+		return 0, "", nil, 1, false
+	}
+	dbConfig, err := readDBConfigFile(*f.dbConfigFile)
+	if err != nil {
+		fmt.Printf("couldn't read database config: %s\n", err)
+		return 0, "", nil, 1, false
+	}
+	dir := filepath.Join(
+		os.ExpandEnv(*f.dbDir),
+		constants.NetworkName(networkID),
+	)
+	return networkID, dir, dbConfig, 0, true
+}
+
+// runDBExport parses [args] and exports the databases. Returns the exit code
+// of the process.
+func runDBExport(args []string) int {
+	flags := newDBArchiveFlags(dbExportCommand)
+	networkID, dir, dbConfig, code, ok := flags.parse(args)
+	if !ok {
+		return code
+	}
+
+	log := newCommandLogger()
+	defer log.Stop()
+
+	currentPath := filepath.Join(migrate.Path(*flags.dbType, dir), version.CurrentDatabase.String())
+	if _, err := os.Stat(currentPath); err != nil {
+		log.Error("couldn't find database at %s: %s", currentPath, err)
+		return 1
+	}
+
+	dbManager, err := migrate.NewManager(*flags.dbType, dir, dbConfig, log)
+	if err != nil {
+		log.Error("couldn't open databases: %s", err)
+		return 1
+	}
+	defer func() {
+		if err := dbManager.Close(); err != nil {
+			log.Error("failed to close databases: %s", err)
+		}
+	}()
+
+	f, err := os.OpenFile(*flags.archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perms.ReadWrite)
+	if err != nil {
+		log.Error("couldn't create archive: %s", err)
+		return 1
+	}
+
+	manifest := &archive.Manifest{
+		NodeVersion: version.Current.String(),
+		NetworkID:   json.Uint32(networkID),
+		Timestamp:   time.Now().UTC(),
+	}
+	err = archive.Export(f, manifest, dbManager.GetDatabases(), nil)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(*flags.archivePath)
+		log.Error("export failed: %s", err)
+		return 1
+	}
+
+	log.Info("exported databases %v to %s", manifest.Databases, *flags.archivePath)
+	return 0
+}
+
+// runDBImport parses [args] and restores the databases. Returns the exit code
+// of the process.
+func runDBImport(args []string) int {
+	flags := newDBArchiveFlags(dbImportCommand)
+	networkID, dir, dbConfig, code, ok := flags.parse(args)
+	if !ok {
+		return code
+	}
+
+	log := newCommandLogger()
+	defer log.Stop()
+
+	f, err := os.Open(*flags.archivePath)
+	if err != nil {
+		log.Error("couldn't open archive: %s", err)
+		return 1
+	}
+	defer f.Close()
+
+	r, err := archive.NewReader(f)
+	if err != nil {
+		log.Error("couldn't read archive: %s", err)
+		return 1
+	}
+	manifest := r.Manifest()
+	log.Info("archive was created by %s at %s and contains databases %v",
+		manifest.NodeVersion,
+		manifest.Timestamp,
+		manifest.Databases,
+	)
+	for _, chain := range manifest.Chains {
+		log.Info("chain %s (%s) was at height %d with last accepted %s",
+			chain.Name,
+			chain.ID,
+			chain.Height,
+			chain.LastAccepted,
+		)
+	}
+	if err := manifest.Verify(networkID); err != nil {
+		log.Error("archive is incompatible with this node: %s", err)
+		return 1
+	}
+
+	dbDir := migrate.Path(*flags.dbType, dir)
+	for _, v := range manifest.Databases {
+		path := filepath.Join(dbDir, v)
+		if _, err := os.Stat(path); err == nil {
+			log.Error("database already exists at %s", path)
+			return 1
+		}
+	}
+
+	var restored []string
+	err = r.Restore(func(v *version.Semantic) (database.Database, error) {
+		restored = append(restored, filepath.Join(dbDir, v.String()))
+		return migrate.NewDatabase(*flags.dbType, dir, v, dbConfig, log)
+	})
+	if err != nil {
+		log.Error("import failed: %s", err)
+		for _, path := range restored {
+			if err := os.RemoveAll(path); err != nil {
+				log.Error("failed to remove partially restored database at %s: %s", path, err)
+			}
+		}
+		return 1
+	}
+
+	log.Info("imported databases %v into %s", manifest.Databases, dbDir)
+	return 0
+}
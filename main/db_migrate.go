@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/migrate"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/database/rocksdb"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// dbMigrateCommand is the subcommand that copies the node's databases from
+// one backend to another. The node must not be running while it executes.
+const dbMigrateCommand = "db-migrate"
+
+const (
+	sourceDBTypeKey       = "source-db-type"
+	sourceDBConfigFileKey = "source-db-config-file"
+	targetDBTypeKey       = "target-db-type"
+	targetDBConfigFileKey = "target-db-config-file"
+	batchSizeKey          = "batch-size"
+)
+
+// runDBMigrate parses [args] and migrates the databases. Returns the exit code
+// of the process.
+func runDBMigrate(args []string) int {
+	fs := pflag.NewFlagSet(dbMigrateCommand, pflag.ContinueOnError)
+	dbDir := fs.String(config.DBPathKey, filepath.Join("$HOME", ".avalanchego", "db"), "Path to database directory")
+	networkName := fs.String(config.NetworkNameKey, constants.MainnetName, "Network ID of the databases to migrate")
+	sourceType := fs.String(sourceDBTypeKey, leveldb.Name, fmt.Sprintf("Database type to migrate from. Should be one of {%s, %s, %s}", leveldb.Name, rocksdb.Name, pebbledb.Name))
+	sourceConfigFile := fs.String(sourceDBConfigFileKey, "", "Path to the database config file of the source database")
+	targetType := fs.String(targetDBTypeKey, pebbledb.Name, fmt.Sprintf("Database type to migrate to. Should be one of {%s, %s, %s}", leveldb.Name, rocksdb.Name, pebbledb.Name))
+	targetConfigFile := fs.String(targetDBConfigFileKey, "", "Path to the database config file of the target database")
+	batchSize := fs.Int(batchSizeKey, migrate.DefaultBatchSize, "Number of bytes to copy between progress checkpoints")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		fmt.Printf("couldn't parse flags: %s\n", err)
+		return 1
+	}
+
+	networkID, err := constants.NetworkID(*networkName)
+	if err != nil {
+		fmt.Printf("couldn't parse network ID: %s\n", err)
+		return 1
+	}
+	sourceConfig, err := readDBConfigFile(*sourceConfigFile)
+	if err != nil {
+		fmt.Printf("couldn't read source database config: %s\n", err)
+		return 1
+	}
+	targetConfig, err := readDBConfigFile(*targetConfigFile)
+	if err != nil {
+		fmt.Printf("couldn't read target database config: %s\n", err)
+		return 1
+	}
+
+	log := newCommandLogger()
+	defer log.Stop()
+
+	results, err := migrate.Run(migrate.Config{
+		Dir: filepath.Join(
+			os.ExpandEnv(*dbDir),
+			constants.NetworkName(networkID),
+		),
+		SourceType:   *sourceType,
+		SourceConfig: sourceConfig,
+		TargetType:   *targetType,
+		TargetConfig: targetConfig,
+		BatchSize:    *batchSize,
+		Log:          log,
+	})
+	if err != nil {
+		log.Error("migration failed: %s", err)
+		return 1
+	}
+	for _, result := range results {
+		fmt.Printf("database %s: %d keys, checksum %x\n", result.Version, result.Keys, result.Checksum)
+	}
+	return 0
+}
+
+// newCommandLogger returns a logger that writes to stdout for use by
+// subcommands.
+func newCommandLogger() logging.Logger {
+	return logging.NewLogger(
+		false,
+		"",
+		logging.NewWrappedCore(logging.Info, os.Stdout, logging.Colors.ConsoleEncoder()),
+	)
+}
+
+func readDBConfigFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(os.ExpandEnv(path))
+}
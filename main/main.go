@@ -16,6 +16,19 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case dbMigrateCommand:
+			os.Exit(runDBMigrate(os.Args[2:]))
+		case dbExportCommand:
+			os.Exit(runDBExport(os.Args[2:]))
+		case dbImportCommand:
+			os.Exit(runDBImport(os.Args[2:]))
+		case codecSchemaCommand:
+			os.Exit(runCodecSchema(os.Args[2:]))
+		}
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
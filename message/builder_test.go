@@ -5,6 +5,7 @@ package message
 
 import (
 	"net"
+	"os"
 	"testing"
 	"time"
 
@@ -73,6 +74,7 @@ func TestBuildVersion(t *testing.T) {
 	assert.NotNil(t, parsedMsg)
 	assert.Equal(t, Version, parsedMsg.Op())
 	assert.EqualValues(t, networkID, parsedMsg.Get(NetworkID))
+	assert.EqualValues(t, SupportedCapabilities, parsedMsg.Get(Capabilities))
 	assert.EqualValues(t, myTime, parsedMsg.Get(MyTime))
 	assert.EqualValues(t, ip, parsedMsg.Get(IP))
 	assert.EqualValues(t, myVersionStr, parsedMsg.Get(VersionStr))
@@ -81,6 +83,30 @@ func TestBuildVersion(t *testing.T) {
 	assert.EqualValues(t, subnetIDs, parsedMsg.Get(TrackedSubnets))
 }
 
+// Test that nodes configured with a zstd dictionary advertise its ID
+func TestBuildVersionZstdDict(t *testing.T) {
+	assert := assert.New(t)
+
+	dict, err := os.ReadFile("../utils/compression/testdata/zstd.dict")
+	assert.NoError(err)
+
+	for _, zstdDict := range [][]byte{nil, dict} {
+		mc, err := NewCreator(prometheus.NewRegistry(), true, "", 10*time.Second, zstdDict)
+		assert.NoError(err)
+
+		msg, err := mc.Version(1, 0, ips.IPPort{IP: net.IPv4(1, 2, 3, 4)}, "avalanche/1.2.3", 0, nil, nil)
+		assert.NoError(err)
+
+		parsedMsg, err := mc.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		assert.NoError(err)
+
+		capabilities := Capability(parsedMsg.Get(Capabilities).(uint32))
+		assert.True(capabilities.Has(SupportedCapabilities))
+		assert.Equal(ZstdDictID(zstdDict), capabilities.ZstdDictID())
+		assert.Equal(len(zstdDict) > 0, capabilities.ZstdDictID() != 0)
+	}
+}
+
 func TestBuildGetAcceptedFrontier(t *testing.T) {
 	chainID := ids.Empty.Prefix(0)
 	requestID := uint32(5)
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Capability is a flag that a node sets in the Capabilities field of its
+// Version message to advertise that it supports a feature. Older nodes leave
+// every flag unset.
+//
+// The bits above [zstdDictIDShift] aren't flags. They hold the ID of the zstd
+// dictionary the node is configured with, or 0 if it isn't configured with
+// one. The legacy codec can't add a field to the Version message without
+// breaking older nodes, so the ID shares the Capabilities field.
+type Capability uint32
+
+const (
+	// ProtoCodecCapability is set by nodes that can parse messages packed by
+	// the protobuf codec
+	ProtoCodecCapability Capability = 1 << iota
+	// ZstdCapability is set by nodes that can decompress zstd compressed
+	// messages
+	ZstdCapability
+)
+
+// SupportedCapabilities are advertised by every node
+const SupportedCapabilities = ProtoCodecCapability | ZstdCapability
+
+const (
+	zstdDictIDShift = 8
+	zstdDictIDMask  = 1<<(32-zstdDictIDShift) - 1
+	flagsMask       = 1<<zstdDictIDShift - 1
+)
+
+// ZstdDictID returns the ID that nodes configured with the zstd dictionary
+// [dict] advertise, or 0 if [dict] is empty. The ID is derived from a hash of
+// [dict] rather than read from its header, as raw content dictionaries have
+// no ID, so two nodes only advertise the same ID if they're configured with
+// the same dictionary.
+func ZstdDictID(dict []byte) uint32 {
+	if len(dict) == 0 {
+		return 0
+	}
+	hash := hashing.ComputeHash256(dict)
+	id := binary.BigEndian.Uint32(hash) & zstdDictIDMask
+	if id == 0 {
+		// 0 means that no dictionary is configured.
+		id = 1
+	}
+	return id
+}
+
+// Has returns true if [c] includes every flag of [capability]
+func (c Capability) Has(capability Capability) bool {
+	return c&capability == capability
+}
+
+// ZstdDictID returns the ID of the zstd dictionary advertised by [c], or 0 if
+// [c] doesn't advertise a dictionary.
+func (c Capability) ZstdDictID() uint32 {
+	return uint32(c >> zstdDictIDShift)
+}
+
+// WithZstdDictID returns [c] advertising the zstd dictionary with ID [id]
+func (c Capability) WithZstdDictID(id uint32) Capability {
+	return c&flagsMask | Capability(id&zstdDictIDMask)<<zstdDictIDShift
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZstdDictID(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Zero(ZstdDictID(nil))
+	assert.Zero(ZstdDictID([]byte{}))
+
+	dict0 := []byte("dictionary 0")
+	dict1 := []byte("dictionary 1")
+	assert.NotZero(ZstdDictID(dict0))
+	assert.Equal(ZstdDictID(dict0), ZstdDictID(append([]byte{}, dict0...)))
+	assert.NotEqual(ZstdDictID(dict0), ZstdDictID(dict1))
+}
+
+func TestCapabilityZstdDictID(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Zero(SupportedCapabilities.ZstdDictID())
+
+	id := ZstdDictID([]byte("dictionary"))
+	capabilities := SupportedCapabilities.WithZstdDictID(id)
+	assert.Equal(id, capabilities.ZstdDictID())
+	assert.True(capabilities.Has(SupportedCapabilities))
+
+	// The ID doesn't change the advertised flags, and can be replaced.
+	capabilities = ProtoCodecCapability.WithZstdDictID(id).WithZstdDictID(0)
+	assert.Equal(ProtoCodecCapability, capabilities)
+	assert.False(capabilities.Has(ZstdCapability))
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+// Class is the priority class of an outbound message. Messages of different
+// classes are queued separately so that large or frequent messages of one
+// class don't delay the messages of another.
+type Class byte
+
+const (
+	// Latency critical consensus messages
+	ConsensusClass Class = iota
+	// Bootstrapping and state sync messages
+	BootstrapClass
+	// Application level messages
+	AppClass
+	// Handshake and peer gossip messages
+	GossipClass
+)
+
+// Classes is the list of all message classes
+var Classes = []Class{
+	ConsensusClass,
+	BootstrapClass,
+	AppClass,
+	GossipClass,
+}
+
+func (c Class) String() string {
+	switch c {
+	case ConsensusClass:
+		return "consensus"
+	case BootstrapClass:
+		return "bootstrap"
+	case AppClass:
+		return "app"
+	case GossipClass:
+		return "gossip"
+	default:
+		return "unknown"
+	}
+}
+
+// Class returns the priority class of messages of type [op]
+func (op Op) Class() Class {
+	switch op {
+	case Get, Put, PushQuery, PullQuery, Chits, ChitsV2:
+		return ConsensusClass
+	case GetAcceptedFrontier, AcceptedFrontier, GetAccepted, Accepted,
+		GetAncestors, Ancestors,
+		GetStateSummaryFrontier, StateSummaryFrontier,
+		GetAcceptedStateSummary, AcceptedStateSummary:
+		return BootstrapClass
+	case AppRequest, AppResponse, AppGossip:
+		return AppClass
+	default:
+		return GossipClass
+	}
+}
@@ -15,12 +15,19 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
-	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 var (
+	// ErrUnknownOp is returned by Parse if the message's op isn't known. This
+	// is expected from peers running a newer version.
+	ErrUnknownOp = errors.New("unknown message op")
+	// ErrDecompression is returned by Parse if the message's payload can't be
+	// decompressed, such as when it's compressed with a compression type or
+	// dictionary this node doesn't support.
+	ErrDecompression = errors.New("couldn't decompress message")
+
 	errMissingField = errors.New("message missing field")
 	errBadOp        = errors.New("input field has invalid operation")
 
@@ -58,47 +65,28 @@ type codec struct {
 
 	clock mockable.Clock
 
-	compressTimeMetrics   map[Op]metric.Averager
-	decompressTimeMetrics map[Op]metric.Averager
-	compressor            compression.Compressor
-	maxMessageTimeout     time.Duration
+	compressors       *compressors
+	maxMessageTimeout time.Duration
 }
 
 func NewCodecWithMemoryPool(namespace string, metrics prometheus.Registerer, maxMessageSize int64, maxMessageTimeout time.Duration) (Codec, error) {
-	c := &codec{
+	return newCodec(namespace, metrics, maxMessageSize, maxMessageTimeout, nil)
+}
+
+func newCodec(namespace string, metrics prometheus.Registerer, maxMessageSize int64, maxMessageTimeout time.Duration, zstdDict []byte) (*codec, error) {
+	compressors, err := newCompressors(namespace, metrics, maxMessageSize, zstdDict)
+	if err != nil {
+		return nil, err
+	}
+	return &codec{
 		byteSlicePool: sync.Pool{
 			New: func() interface{} {
 				return make([]byte, 0, constants.DefaultByteSliceCap)
 			},
 		},
-		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
-		compressor:            compression.NewGzipCompressor(maxMessageSize),
-		maxMessageTimeout:     maxMessageTimeout,
-	}
-
-	errs := wrappers.Errs{}
-	for _, op := range ExternalOps {
-		if !op.Compressible() {
-			continue
-		}
-
-		c.compressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages", op),
-			metrics,
-			&errs,
-		)
-		c.decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages", op),
-			metrics,
-			&errs,
-		)
-	}
-	return c, errs.Err
+		compressors:       compressors,
+		maxMessageTimeout: maxMessageTimeout,
+	}, nil
 }
 
 func (c *codec) SetTime(t time.Time) {
@@ -107,10 +95,7 @@ func (c *codec) SetTime(t time.Time) {
 
 // Pack attempts to pack a map of fields into a message.
 // The first byte of the message is the opcode of the message.
-// Uses [buffer] to hold the message's byte repr.
-// [buffer]'s contents may be overwritten by this method.
-// [buffer] may be nil.
-// If [compress], compress the payload.
+// If [compress], compress the payload with gzip.
 // If [bypassThrottling], mark the message to avoid outbound throttling checks.
 func (c *codec) Pack(
 	op Op,
@@ -118,10 +103,25 @@ func (c *codec) Pack(
 	compress bool,
 	bypassThrottling bool,
 ) (OutboundMessage, error) {
+	return c.pack(op, fieldValues, Encoding{Compression: compressionTypeOf(compress)}, bypassThrottling)
+}
+
+// pack attempts to pack a map of fields into a message whose payload is
+// compressed as specified by [encoding]. The format of [encoding] is ignored.
+func (c *codec) pack(
+	op Op,
+	fieldValues map[Field]interface{},
+	encoding Encoding,
+	bypassThrottling bool,
+) (OutboundMessage, error) {
+	compressionType := encoding.Compression
 	msgFields, ok := messages[op]
 	if !ok {
 		return nil, errBadOp
 	}
+	if compressionType != compression.TypeNone && !op.Compressible() {
+		return nil, fmt.Errorf("%w: %s", errUnexpectedCompress, op)
+	}
 
 	buffer := c.byteSlicePool.Get().([]byte)
 	p := wrappers.Packer{
@@ -131,9 +131,11 @@ func (c *codec) Pack(
 	// Pack the op code (message type)
 	p.PackByte(byte(op))
 
-	// Optionally, pack whether the payload is compressed
+	// Optionally, pack the type of compression of the payload. Note that the
+	// types none and gzip are packed the same way as the boolean of whether
+	// the payload is compressed used to be.
 	if op.Compressible() {
-		p.PackBool(compress)
+		p.PackByte(byte(compressionType))
 	}
 
 	// Pack the uncompressed payload
@@ -154,23 +156,22 @@ func (c *codec) Pack(
 		c:                c,
 		bypassThrottling: bypassThrottling,
 	}
-	if !compress {
+	if compressionType == compression.TypeNone {
 		return msg, nil
 	}
 
-	// If [compress], compress the payload (not the op code, not isCompressed).
+	// Compress the payload (not the op code, not the compression type).
 	// The slice below is guaranteed to be in-bounds because [p.Err] == nil
 	// implies that len(msg.bytes) >= 2
-	payloadBytes := msg.bytes[wrappers.BoolLen+wrappers.ByteLen:]
-	startTime := time.Now()
-	compressedPayloadBytes, err := c.compressor.Compress(payloadBytes)
+	payloadBytes := msg.bytes[2*wrappers.ByteLen:]
+	compressedPayloadBytes, err := c.compressors.compress(encoding, op, payloadBytes)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't compress payload of %s message: %w", op, err)
+		return nil, err
 	}
-	c.compressTimeMetrics[op].Observe(float64(time.Since(startTime)))
 	msg.bytesSavedCompression = len(payloadBytes) - len(compressedPayloadBytes) // may be negative
-	// Remove the uncompressed payload (keep just the message type and isCompressed)
-	msg.bytes = msg.bytes[:wrappers.BoolLen+wrappers.ByteLen]
+	// Remove the uncompressed payload (keep just the message type and the
+	// compression type)
+	msg.bytes = msg.bytes[:2*wrappers.ByteLen]
 	// Attach the compressed payload
 	msg.bytes = append(msg.bytes, compressedPayloadBytes...)
 	return msg, nil
@@ -187,13 +188,13 @@ func (c *codec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()
 
 	msgFields, ok := messages[op]
 	if !ok { // Unknown message type
-		return nil, errBadOp
+		return nil, fmt.Errorf("%w: %d", ErrUnknownOp, op)
 	}
 
 	// See if messages of this type may be compressed
-	compressed := false
+	compressionType := compression.TypeNone
 	if op.Compressible() {
-		compressed = p.UnpackBool()
+		compressionType = compression.Type(p.UnpackByte())
 	}
 	if p.Err != nil {
 		return nil, p.Err
@@ -202,21 +203,23 @@ func (c *codec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()
 	bytesSaved := 0
 
 	// If the payload is compressed, decompress it
-	if compressed {
+	if compressionType != compression.TypeNone {
 		// The slice below is guaranteed to be in-bounds because [p.Err] == nil
-		compressedPayloadBytes := p.Bytes[wrappers.ByteLen+wrappers.BoolLen:]
-		startTime := time.Now()
-		payloadBytes, err := c.compressor.Decompress(compressedPayloadBytes)
+		compressedPayloadBytes := p.Bytes[2*wrappers.ByteLen:]
+		payloadBytes, decompressTime, err := c.compressors.decompress(compressionType, compressedPayloadBytes)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't decompress payload of %s message: %w", op, err)
+			return nil, fmt.Errorf("couldn't parse %s message: %w", op, err)
+		}
+		if err := c.compressors.observeDecompression(compressionType, op, decompressTime); err != nil {
+			return nil, err
 		}
-		c.decompressTimeMetrics[op].Observe(float64(time.Since(startTime)))
 		// Replace the compressed payload with the decompressed payload.
-		// Remove the compressed payload and isCompressed; keep just the message type
+		// Remove the compressed payload and the compression type; keep just
+		// the message type
 		p.Bytes = p.Bytes[:wrappers.ByteLen]
-		// Rewind offset by 1 because we removed the bool flag
-		// since the data now is uncompressed
-		p.Offset -= wrappers.BoolLen
+		// Rewind offset by 1 because we removed the compression type since the
+		// data now is uncompressed
+		p.Offset -= wrappers.ByteLen
 		// Attach the decompressed payload.
 		p.Bytes = append(p.Bytes, payloadBytes...)
 		bytesSaved = len(payloadBytes) - len(compressedPayloadBytes)
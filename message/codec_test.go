@@ -6,6 +6,7 @@ package message
 import (
 	"math"
 	"net"
+	"os"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/units"
 )
@@ -103,7 +105,7 @@ func TestCodecPackParseGzip(t *testing.T) {
 			op: Version,
 			fields: map[Field]interface{}{
 				NetworkID:      uint32(0),
-				NodeID:         uint32(1337),
+				Capabilities:   uint32(1337),
 				MyTime:         uint64(time.Now().Unix()),
 				IP:             ips.IPPort{IP: net.IPv4(1, 2, 3, 4)},
 				VersionStr:     "v1.2.3",
@@ -243,3 +245,88 @@ func TestCodecPackParseGzip(t *testing.T) {
 		assert.EqualValues(t, len(m.fields), len(unpacked.fields))
 	}
 }
+
+// Test that the compression type of a message is packed where the boolean of
+// whether it's compressed used to be, and that zstd compressed messages can be
+// parsed
+func TestCodecPackParseCompressionTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := newCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second, nil)
+	assert.NoError(err)
+	id := ids.GenerateTestID()
+
+	fields := map[Field]interface{}{
+		ChainID:        id[:],
+		RequestID:      uint32(1337),
+		ContainerID:    id[:],
+		ContainerBytes: make([]byte, 1024),
+	}
+	for _, compressionType := range []compression.Type{compression.TypeNone, compression.TypeGzip, compression.TypeZstd} {
+		msg, err := c.pack(Put, fields, Encoding{Compression: compressionType}, false)
+		assert.NoError(err)
+		assert.Equal(byte(compressionType), msg.Bytes()[1])
+
+		parsedMsg, err := c.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		assert.NoError(err)
+		assert.Equal(fields[ContainerBytes], parsedMsg.Get(ContainerBytes))
+		assert.Equal(msg.BytesSavedCompression(), parsedMsg.BytesSavedCompression())
+	}
+
+	_, err = c.pack(Get, map[Field]interface{}{
+		ChainID:     id[:],
+		RequestID:   uint32(1337),
+		Deadline:    uint64(time.Second),
+		ContainerID: id[:],
+	}, Encoding{Compression: compression.TypeZstd}, false)
+	assert.ErrorIs(err, errUnexpectedCompress)
+
+	_, err = c.Parse([]byte{byte(Put), 0xff}, dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, ErrDecompression)
+
+	_, err = c.Parse([]byte{0xff}, dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, ErrUnknownOp)
+}
+
+// Test that messages compressed with the zstd dictionary can only be parsed by
+// codecs configured with it
+func TestCodecPackParseZstdDict(t *testing.T) {
+	assert := assert.New(t)
+
+	dict, err := os.ReadFile("../utils/compression/testdata/zstd.dict")
+	assert.NoError(err)
+
+	dictCodec, err := newCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second, dict)
+	assert.NoError(err)
+	noDictCodec, err := newCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second, nil)
+	assert.NoError(err)
+	id := ids.GenerateTestID()
+
+	fields := map[Field]interface{}{
+		ChainID:        id[:],
+		RequestID:      uint32(1337),
+		ContainerID:    id[:],
+		ContainerBytes: []byte(`{"chainID":"0x5f1ab9ce","height":1337,"parentID":"0x24c5e3f0"}`),
+	}
+
+	// Messages compressed without the dictionary can be parsed by either codec
+	msg, err := dictCodec.pack(Put, fields, Encoding{Compression: compression.TypeZstd}, false)
+	assert.NoError(err)
+	_, err = noDictCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.NoError(err)
+
+	dictMsg, err := dictCodec.pack(Put, fields, Encoding{Compression: compression.TypeZstd, ZstdDict: true}, false)
+	assert.NoError(err)
+	assert.Equal(byte(compression.TypeZstd), dictMsg.Bytes()[1])
+	assert.Less(len(dictMsg.Bytes()), len(msg.Bytes()))
+
+	parsedMsg, err := dictCodec.Parse(dictMsg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.NoError(err)
+	assert.Equal(fields[ContainerBytes], parsedMsg.Get(ContainerBytes))
+
+	_, err = noDictCodec.Parse(dictMsg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.Error(err)
+
+	_, err = noDictCodec.pack(Put, fields, Encoding{Compression: compression.TypeZstd, ZstdDict: true}, false)
+	assert.ErrorIs(err, errNoZstdDict)
+}
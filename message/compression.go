@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	errUnexpectedCompress     = errors.New("message type can't be compressed")
+	errUnknownCompressionType = errors.New("unknown compression type")
+	errNoZstdDict             = errors.New("no zstd dictionary configured")
+
+	// Compression types that messages may be compressed with
+	compressionTypes = []compression.Type{
+		compression.TypeGzip,
+		compression.TypeZstd,
+	}
+)
+
+// compressionMetrics of the messages of an op compressed with a compression
+// type
+type compressionMetrics struct {
+	compressTime   metric.Averager
+	decompressTime metric.Averager
+	bytesSaved     metric.Averager
+}
+
+// compressors compresses and decompresses the payloads of messages with every
+// supported compression type, and reports the time spent and bytes saved per
+// compression type and op.
+type compressors struct {
+	compressors map[compression.Type]compression.Compressor
+	// zstdDict compresses with the zstd dictionary, if one is configured.
+	// Payloads compressed with the dictionary are decompressed by the zstd
+	// compressor in [compressors].
+	zstdDict compression.Compressor
+	metrics  map[compression.Type]map[Op]*compressionMetrics
+}
+
+// newCompressors returns compressors of messages of at most [maxMessageSize]
+// bytes. If [zstdDict] isn't empty, zstd payloads may be compressed with it,
+// and zstd payloads compressed with it can be decompressed.
+func newCompressors(namespace string, registerer prometheus.Registerer, maxMessageSize int64, zstdDict []byte) (*compressors, error) {
+	var (
+		decoderDicts       [][]byte
+		zstdDictCompressor compression.Compressor
+	)
+	if len(zstdDict) > 0 {
+		decoderDicts = append(decoderDicts, zstdDict)
+
+		var err error
+		zstdDictCompressor, err = compression.NewZstdCompressor(maxMessageSize, zstdDict)
+		if err != nil {
+			return nil, err
+		}
+	}
+	zstdCompressor, err := compression.NewZstdCompressor(maxMessageSize, nil, decoderDicts...)
+	if err != nil {
+		return nil, err
+	}
+	c := &compressors{
+		compressors: map[compression.Type]compression.Compressor{
+			compression.TypeGzip: compression.NewGzipCompressor(maxMessageSize),
+			compression.TypeZstd: zstdCompressor,
+		},
+		zstdDict: zstdDictCompressor,
+		metrics:  make(map[compression.Type]map[Op]*compressionMetrics, len(compressionTypes)),
+	}
+
+	errs := wrappers.Errs{}
+	for _, compressionType := range compressionTypes {
+		// The metrics of gzip keep the names they had before other compression
+		// types were supported.
+		prefix := ""
+		if compressionType != compression.TypeGzip {
+			prefix = fmt.Sprintf("%s_", compressionType)
+		}
+
+		opMetrics := make(map[Op]*compressionMetrics, len(ExternalOps))
+		for _, op := range ExternalOps {
+			if !op.Compressible() {
+				continue
+			}
+
+			opMetrics[op] = &compressionMetrics{
+				compressTime: metric.NewAveragerWithErrs(
+					namespace,
+					fmt.Sprintf("%s_%scompress_time", op, prefix),
+					fmt.Sprintf("time (in ns) to %s compress %s messages", compressionType, op),
+					registerer,
+					&errs,
+				),
+				decompressTime: metric.NewAveragerWithErrs(
+					namespace,
+					fmt.Sprintf("%s_%sdecompress_time", op, prefix),
+					fmt.Sprintf("time (in ns) to %s decompress %s messages", compressionType, op),
+					registerer,
+					&errs,
+				),
+				bytesSaved: metric.NewAveragerWithErrs(
+					namespace,
+					fmt.Sprintf("%s_%scompress_bytes_saved", op, prefix),
+					fmt.Sprintf("bytes saved by %s compressing %s messages", compressionType, op),
+					registerer,
+					&errs,
+				),
+			}
+		}
+		c.metrics[compressionType] = opMetrics
+	}
+	return c, errs.Err
+}
+
+// compress the payload of a message of type [op] as specified by [encoding]
+func (c *compressors) compress(encoding Encoding, op Op, payload []byte) ([]byte, error) {
+	compressionType := encoding.Compression
+	compressor, ok := c.compressors[compressionType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errUnknownCompressionType, compressionType)
+	}
+	if compressionType == compression.TypeZstd && encoding.ZstdDict {
+		if c.zstdDict == nil {
+			return nil, errNoZstdDict
+		}
+		compressor = c.zstdDict
+	}
+	opMetrics, ok := c.metrics[compressionType][op]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnexpectedCompress, op)
+	}
+
+	startTime := time.Now()
+	compressedPayload, err := compressor.Compress(payload)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't %s compress payload of %s message: %w", compressionType, op, err)
+	}
+	opMetrics.compressTime.Observe(float64(time.Since(startTime)))
+	opMetrics.bytesSaved.Observe(float64(len(payload) - len(compressedPayload)))
+	return compressedPayload, nil
+}
+
+// decompress a payload compressed with [compressionType]. Returns the time it
+// took to decompress it.
+func (c *compressors) decompress(compressionType compression.Type, compressedPayload []byte) ([]byte, time.Duration, error) {
+	compressor, ok := c.compressors[compressionType]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %s: %d", ErrDecompression, errUnknownCompressionType, compressionType)
+	}
+
+	startTime := time.Now()
+	payload, err := compressor.Decompress(compressedPayload)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w with %s: %s", ErrDecompression, compressionType, err)
+	}
+	return payload, time.Since(startTime), nil
+}
+
+// observeDecompression reports that a message of type [op] was decompressed
+// with [compressionType] in [duration]
+func (c *compressors) observeDecompression(compressionType compression.Type, op Op, duration time.Duration) error {
+	opMetrics, ok := c.metrics[compressionType][op]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnexpectedCompress, op)
+	}
+	opMetrics.decompressTime.Observe(float64(duration))
+	return nil
+}
+
+// compressionTypeOf returns the compression type messages are packed with when
+// [compress] is passed to Pack
+func compressionTypeOf(compress bool) compression.Type {
+	if compress {
+		return compression.TypeGzip
+	}
+	return compression.TypeNone
+}
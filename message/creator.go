@@ -25,14 +25,24 @@ type creator struct {
 	InternalMsgBuilder
 }
 
-func NewCreator(metrics prometheus.Registerer, compressionEnabled bool, parentNamespace string, maxInboundMessageTimeout time.Duration) (Creator, error) {
+// NewCreator returns a Creator of messages. If [zstdDict] isn't empty, zstd
+// compressed messages may be compressed with it, and nodes are told the ID of
+// the dictionary so that only nodes with the same dictionary use it.
+func NewCreator(metrics prometheus.Registerer, compressionEnabled bool, parentNamespace string, maxInboundMessageTimeout time.Duration, zstdDict []byte) (Creator, error) {
 	namespace := fmt.Sprintf("%s_codec", parentNamespace)
-	codec, err := NewCodecWithMemoryPool(namespace, metrics, int64(constants.DefaultMaxMessageSize), maxInboundMessageTimeout)
+	legacyCodec, err := newCodec(namespace, metrics, int64(constants.DefaultMaxMessageSize), maxInboundMessageTimeout, zstdDict)
 	if err != nil {
 		return nil, err
 	}
+	protoNamespace := fmt.Sprintf("%s_proto_codec", parentNamespace)
+	protoCodec, err := newProtoCodec(protoNamespace, metrics, int64(constants.DefaultMaxMessageSize), maxInboundMessageTimeout, zstdDict)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := SupportedCapabilities.WithZstdDictID(ZstdDictID(zstdDict))
+	codec := newMultiCodec(legacyCodec, protoCodec)
 	return &creator{
-		OutboundMsgBuilder: NewOutboundBuilder(codec, compressionEnabled),
+		OutboundMsgBuilder: newOutboundBuilder(codec, compressionEnabled, capabilities),
 		InboundMsgBuilder:  NewInboundBuilder(codec),
 		InternalMsgBuilder: NewInternalBuilder(),
 	}, nil
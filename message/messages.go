@@ -10,11 +10,14 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
 )
 
 var (
-	_ InboundMessage  = &inboundMessage{}
-	_ OutboundMessage = &outboundMessage{}
+	_ InboundMessage     = &inboundMessage{}
+	_ OutboundMessage    = &outboundMessage{}
+	_ MultiFormatMessage = &multiFormatMessage{}
+	_ OutboundMessage    = &encodedMessage{}
 )
 
 // InboundMessage represents a set of fields for an inbound message that can be serialized into a byte stream
@@ -104,6 +107,33 @@ type OutboundMessage interface {
 	DecRef()
 }
 
+// Encoding is a wire format and compression type a message can be sent with
+type Encoding struct {
+	// Proto is true if the message is packed by the protobuf codec rather
+	// than the legacy codec
+	Proto bool
+	// Compression is the type the message is compressed with, if the message
+	// is compressed
+	Compression compression.Type
+	// ZstdDict is true if the message is zstd compressed with the configured
+	// dictionary
+	ZstdDict bool
+}
+
+// DefaultEncoding can be parsed by every compatible peer
+var DefaultEncoding = Encoding{Compression: compression.TypeGzip}
+
+// MultiFormatMessage is an outbound message that can be sent with any
+// encoding, to peers that can parse it
+type MultiFormatMessage interface {
+	OutboundMessage
+
+	// Encode returns this message packed with [encoding]. The message is only
+	// packed once per encoding. The returned message shares the references of
+	// this message. Bytes returns this message packed with DefaultEncoding.
+	Encode(encoding Encoding) (OutboundMessage, error)
+}
+
 type outboundMessage struct {
 	bytes                 []byte
 	bytesSavedCompression int
@@ -141,7 +171,8 @@ func (outMsg *outboundMessage) DecRef() {
 	defer outMsg.refLock.Unlock()
 
 	outMsg.refs--
-	if outMsg.refs == 0 {
+	// Messages packed by the protobuf codec aren't pooled
+	if outMsg.refs == 0 && outMsg.c != nil {
 		outMsg.c.byteSlicePool.Put(outMsg.bytes)
 	}
 }
@@ -170,3 +201,103 @@ func (*TestMsg) BytesSavedCompression() int { return 0 }
 func (*TestMsg) AddRef()                    {}
 func (*TestMsg) DecRef()                    {}
 func (m *TestMsg) BypassThrottling() bool   { return m.bypassThrottling }
+
+// multiFormatMessage is a message that is packed with an encoding the first
+// time it's requested with that encoding
+type multiFormatMessage struct {
+	op               Op
+	bypassThrottling bool
+	// True if the payload of the message is compressed
+	compressed bool
+	pack       func(encoding Encoding) (OutboundMessage, error)
+
+	lock sync.Mutex
+	refs int
+	// Encoding --> the message packed with that encoding
+	encoded map[Encoding]*encodedMessage
+}
+
+// encodedMessage is a multiFormatMessage packed with one encoding
+type encodedMessage struct {
+	parent *multiFormatMessage
+	msg    OutboundMessage
+	err    error
+}
+
+func (outMsg *multiFormatMessage) Op() Op { return outMsg.op }
+
+func (outMsg *multiFormatMessage) BypassThrottling() bool { return outMsg.bypassThrottling }
+
+func (outMsg *multiFormatMessage) Bytes() []byte {
+	encoded, err := outMsg.Encode(DefaultEncoding)
+	if err != nil {
+		return nil
+	}
+	return encoded.Bytes()
+}
+
+func (outMsg *multiFormatMessage) BytesSavedCompression() int {
+	encoded, err := outMsg.Encode(DefaultEncoding)
+	if err != nil {
+		return 0
+	}
+	return encoded.BytesSavedCompression()
+}
+
+func (outMsg *multiFormatMessage) AddRef() {
+	outMsg.lock.Lock()
+	defer outMsg.lock.Unlock()
+
+	outMsg.refs++
+}
+
+// Once the reference count of this message goes to 0, the bytes of its
+// encodings should not be inspected.
+func (outMsg *multiFormatMessage) DecRef() {
+	outMsg.lock.Lock()
+	defer outMsg.lock.Unlock()
+
+	outMsg.refs--
+	if outMsg.refs != 0 {
+		return
+	}
+	for _, encoded := range outMsg.encoded {
+		if encoded.msg != nil {
+			encoded.msg.DecRef()
+		}
+	}
+	outMsg.encoded = nil
+}
+
+func (outMsg *multiFormatMessage) Encode(encoding Encoding) (OutboundMessage, error) {
+	if !outMsg.compressed {
+		encoding.Compression = compression.TypeNone
+	}
+	if encoding.Compression != compression.TypeZstd {
+		encoding.ZstdDict = false
+	}
+
+	outMsg.lock.Lock()
+	defer outMsg.lock.Unlock()
+
+	encoded, ok := outMsg.encoded[encoding]
+	if !ok {
+		encoded = &encodedMessage{parent: outMsg}
+		encoded.msg, encoded.err = outMsg.pack(encoding)
+		if outMsg.encoded == nil {
+			outMsg.encoded = make(map[Encoding]*encodedMessage)
+		}
+		outMsg.encoded[encoding] = encoded
+	}
+	if encoded.err != nil {
+		return nil, encoded.err
+	}
+	return encoded, nil
+}
+
+func (m *encodedMessage) Op() Op                     { return m.parent.Op() }
+func (m *encodedMessage) Bytes() []byte              { return m.msg.Bytes() }
+func (m *encodedMessage) BytesSavedCompression() int { return m.msg.BytesSavedCompression() }
+func (m *encodedMessage) BypassThrottling() bool     { return m.parent.BypassThrottling() }
+func (m *encodedMessage) AddRef()                    { m.parent.AddRef() }
+func (m *encodedMessage) DecRef()                    { m.parent.DecRef() }
@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var _ Codec = &multiCodec{}
+
+// multiCodec packs messages with an encoding the first time they're requested
+// with that encoding, so that a message is only packed with the encodings of
+// the peers it's sent to. It parses messages of either format and any
+// compression type, so that nodes that do and don't support the protobuf
+// format or a compression type can interoperate.
+type multiCodec struct {
+	legacy *codec
+	proto  *protoCodec
+}
+
+// newMultiCodec returns a codec that packs messages as MultiFormatMessages
+// and parses messages packed by either [legacy] or [proto].
+func newMultiCodec(legacy *codec, proto *protoCodec) Codec {
+	return &multiCodec{
+		legacy: legacy,
+		proto:  proto,
+	}
+}
+
+func (c *multiCodec) SetTime(t time.Time) {
+	c.legacy.SetTime(t)
+	c.proto.SetTime(t)
+}
+
+func (c *multiCodec) Pack(
+	op Op,
+	fieldValues map[Field]interface{},
+	compress bool,
+	bypassThrottling bool,
+) (OutboundMessage, error) {
+	// The message isn't packed until it's sent, so report malformed messages
+	// now
+	msgFields, ok := messages[op]
+	if !ok {
+		return nil, errBadOp
+	}
+	if compress && !op.Compressible() {
+		return nil, fmt.Errorf("%w: %s", errUnexpectedCompress, op)
+	}
+	for _, field := range msgFields {
+		if _, ok := fieldValues[field]; !ok {
+			return nil, errMissingField
+		}
+	}
+	return &multiFormatMessage{
+		op:               op,
+		bypassThrottling: bypassThrottling,
+		compressed:       compress,
+		refs:             1,
+		pack: func(encoding Encoding) (OutboundMessage, error) {
+			if encoding.Proto {
+				return c.proto.pack(op, fieldValues, encoding, bypassThrottling)
+			}
+			return c.legacy.pack(op, fieldValues, encoding, bypassThrottling)
+		},
+	}, nil
+}
+
+func (c *multiCodec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()) (InboundMessage, error) {
+	if IsProto(bytes) {
+		return c.proto.Parse(bytes, nodeID, onFinishedHandling)
+	}
+	return c.legacy.Parse(bytes, nodeID, onFinishedHandling)
+}
@@ -162,12 +162,19 @@ type OutboundMsgBuilder interface {
 type outMsgBuilder struct {
 	c        Codec
 	compress bool
+	// capabilities advertised in Version messages
+	capabilities Capability
 }
 
 func NewOutboundBuilder(c Codec, enableCompression bool) OutboundMsgBuilder {
+	return newOutboundBuilder(c, enableCompression, SupportedCapabilities)
+}
+
+func newOutboundBuilder(c Codec, enableCompression bool, capabilities Capability) OutboundMsgBuilder {
 	return &outMsgBuilder{
-		c:        c,
-		compress: enableCompression,
+		c:            c,
+		compress:     enableCompression,
+		capabilities: capabilities,
 	}
 }
 
@@ -189,7 +196,7 @@ func (b *outMsgBuilder) Version(
 		Version,
 		map[Field]interface{}{
 			NetworkID:      networkID,
-			NodeID:         uint32(0),
+			Capabilities:   uint32(b.capabilities),
 			MyTime:         myTime,
 			IP:             ip,
 			VersionStr:     myVersion,
@@ -0,0 +1,688 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	p2ppb "github.com/ava-labs/avalanchego/proto/pb/p2p"
+)
+
+// The first byte of every message encoded by the protobuf codec is at least
+// protoFirstByte, whereas the first byte of a legacy message is its op code.
+const protoFirstByte = 0x80
+
+var (
+	errBadFieldType      = errors.New("input field has invalid type")
+	errBadHashLen        = errors.New("hash has invalid length")
+	errBadIPLen          = errors.New("ip has invalid length")
+	errBadPort           = errors.New("port is out of range")
+	errBadUptime         = errors.New("uptime is out of range")
+	errNestedCompression = errors.New("compressed message contains a compressed message")
+
+	_ Codec = &protoCodec{}
+)
+
+// IsProto returns true if [bytes] were packed by the protobuf codec rather
+// than the legacy codec.
+func IsProto(bytes []byte) bool {
+	return len(bytes) > 0 && bytes[0] >= protoFirstByte
+}
+
+// protoCodec packs and parses messages with the protobuf schema defined in
+// proto/p2p. The field values it consumes and produces are the same as those
+// of the legacy codec. It's safe for multiple goroutines to call Pack and
+// Parse concurrently.
+type protoCodec struct {
+	clock mockable.Clock
+
+	compressors       *compressors
+	maxMessageTimeout time.Duration
+}
+
+func NewProtoCodec(namespace string, metrics prometheus.Registerer, maxMessageSize int64, maxMessageTimeout time.Duration) (Codec, error) {
+	return newProtoCodec(namespace, metrics, maxMessageSize, maxMessageTimeout, nil)
+}
+
+func newProtoCodec(namespace string, metrics prometheus.Registerer, maxMessageSize int64, maxMessageTimeout time.Duration, zstdDict []byte) (*protoCodec, error) {
+	compressors, err := newCompressors(namespace, metrics, maxMessageSize, zstdDict)
+	if err != nil {
+		return nil, err
+	}
+	return &protoCodec{
+		compressors:       compressors,
+		maxMessageTimeout: maxMessageTimeout,
+	}, nil
+}
+
+func (c *protoCodec) SetTime(t time.Time) {
+	c.clock.Set(t)
+}
+
+// Pack attempts to pack a map of fields into a protobuf message.
+// If [compress], the encoded message is gzip compressed and wrapped in another
+// message.
+// If [bypassThrottling], mark the message to avoid outbound throttling checks.
+func (c *protoCodec) Pack(
+	op Op,
+	fieldValues map[Field]interface{},
+	compress bool,
+	bypassThrottling bool,
+) (OutboundMessage, error) {
+	return c.pack(op, fieldValues, Encoding{Compression: compressionTypeOf(compress)}, bypassThrottling)
+}
+
+// pack attempts to pack a map of fields into a protobuf message that is
+// compressed as specified by [encoding]. The format of [encoding] is ignored.
+func (c *protoCodec) pack(
+	op Op,
+	fieldValues map[Field]interface{},
+	encoding Encoding,
+	bypassThrottling bool,
+) (OutboundMessage, error) {
+	compressionType := encoding.Compression
+	m, err := fieldsToProto(op, fieldValues)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	msg := &outboundMessage{
+		op:               op,
+		bytes:            bytes,
+		refs:             1,
+		bypassThrottling: bypassThrottling,
+	}
+	if compressionType == compression.TypeNone {
+		return msg, nil
+	}
+
+	compressedBytes, err := c.compressors.compress(encoding, op, bytes)
+	if err != nil {
+		return nil, err
+	}
+	compressed := &p2ppb.Message{}
+	switch compressionType {
+	case compression.TypeGzip:
+		compressed.Message = &p2ppb.Message_CompressedGzip{
+			CompressedGzip: compressedBytes,
+		}
+	case compression.TypeZstd:
+		compressed.Message = &p2ppb.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownCompressionType, compressionType)
+	}
+	msg.bytes, err = proto.Marshal(compressed)
+	if err != nil {
+		return nil, err
+	}
+	msg.bytesSavedCompression = len(bytes) - len(msg.bytes) // may be negative
+	return msg, nil
+}
+
+// Parse attempts to convert protobuf encoded bytes into a message.
+// Overrides client specified deadline in a message to maxDeadlineDuration
+func (c *protoCodec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()) (InboundMessage, error) {
+	m := &p2ppb.Message{}
+	if err := proto.Unmarshal(bytes, m); err != nil {
+		return nil, err
+	}
+
+	var (
+		compressionType, compressedBytes = compressedPayload(m)
+		decompressTime                   time.Duration
+		bytesSaved                       int
+	)
+	// If the message is compressed, decompress it
+	if compressionType != compression.TypeNone {
+		decompressedBytes, duration, err := c.compressors.decompress(compressionType, compressedBytes)
+		if err != nil {
+			return nil, err
+		}
+		decompressTime = duration
+
+		m = &p2ppb.Message{}
+		if err := proto.Unmarshal(decompressedBytes, m); err != nil {
+			return nil, err
+		}
+		if nestedType, _ := compressedPayload(m); nestedType != compression.TypeNone {
+			return nil, errNestedCompression
+		}
+		bytesSaved = len(decompressedBytes) - len(bytes)
+	}
+
+	op, fieldValues, err := protoToFields(m)
+	if err != nil {
+		return nil, err
+	}
+	if compressionType != compression.TypeNone {
+		if err := c.compressors.observeDecompression(compressionType, op, decompressTime); err != nil {
+			return nil, err
+		}
+	}
+
+	var expirationTime time.Time
+	if deadline, hasDeadline := fieldValues[Deadline]; hasDeadline {
+		deadlineDuration := time.Duration(deadline.(uint64))
+		if deadlineDuration > c.maxMessageTimeout {
+			deadlineDuration = c.maxMessageTimeout
+		}
+		expirationTime = c.clock.Time().Add(deadlineDuration)
+	}
+
+	return &inboundMessage{
+		op:                    op,
+		fields:                fieldValues,
+		bytesSavedCompression: bytesSaved,
+		nodeID:                nodeID,
+		expirationTime:        expirationTime,
+		onFinishedHandling:    onFinishedHandling,
+	}, nil
+}
+
+// compressedPayload returns the compression type and compressed bytes of [m],
+// or compression.TypeNone if [m] isn't compressed
+func compressedPayload(m *p2ppb.Message) (compression.Type, []byte) {
+	switch msg := m.GetMessage().(type) {
+	case *p2ppb.Message_CompressedGzip:
+		return compression.TypeGzip, msg.CompressedGzip
+	case *p2ppb.Message_CompressedZstd:
+		return compression.TypeZstd, msg.CompressedZstd
+	default:
+		return compression.TypeNone, nil
+	}
+}
+
+// fieldsToProto returns the protobuf message of type [op] with the field
+// values [fieldValues]
+func fieldsToProto(op Op, fieldValues map[Field]interface{}) (*p2ppb.Message, error) {
+	fv := fieldReader{values: fieldValues}
+	m := &p2ppb.Message{}
+	switch op {
+	case Version:
+		ip := fv.ip(IP)
+		m.Message = &p2ppb.Message_Version{Version: &p2ppb.Version{
+			NetworkId:      fv.uint32(NetworkID),
+			Capabilities:   fv.uint32(Capabilities),
+			MyTime:         fv.uint64(MyTime),
+			IpAddr:         ip.IP.To16(),
+			IpPort:         uint32(ip.Port),
+			MyVersion:      fv.string(VersionStr),
+			MyVersionTime:  fv.uint64(VersionTime),
+			Sig:            fv.bytes(SigBytes),
+			TrackedSubnets: fv.bytesSlice(TrackedSubnets),
+		}}
+	case PeerList:
+		peers := fv.claimedIPPorts(Peers)
+		claimedIPPorts := make([]*p2ppb.ClaimedIpPort, len(peers))
+		for i, peer := range peers {
+			var certBytes []byte
+			if peer.Cert != nil {
+				certBytes = peer.Cert.Raw
+			}
+			claimedIPPorts[i] = &p2ppb.ClaimedIpPort{
+				X509Certificate: certBytes,
+				IpAddr:          peer.IPPort.IP.To16(),
+				IpPort:          uint32(peer.IPPort.Port),
+				Timestamp:       peer.Timestamp,
+				Signature:       peer.Signature,
+			}
+		}
+		m.Message = &p2ppb.Message_PeerList{PeerList: &p2ppb.PeerList{
+			ClaimedIpPorts: claimedIPPorts,
+		}}
+	case Ping:
+		m.Message = &p2ppb.Message_Ping{Ping: &p2ppb.Ping{}}
+	case Pong:
+		m.Message = &p2ppb.Message_Pong{Pong: &p2ppb.Pong{
+			Uptime: uint32(fv.uint8(Uptime)),
+		}}
+	case GetStateSummaryFrontier:
+		m.Message = &p2ppb.Message_GetStateSummaryFrontier{GetStateSummaryFrontier: &p2ppb.GetStateSummaryFrontier{
+			ChainId:   fv.bytes(ChainID),
+			RequestId: fv.uint32(RequestID),
+			Deadline:  fv.uint64(Deadline),
+		}}
+	case StateSummaryFrontier:
+		m.Message = &p2ppb.Message_StateSummaryFrontier_{StateSummaryFrontier_: &p2ppb.StateSummaryFrontier{
+			ChainId:   fv.bytes(ChainID),
+			RequestId: fv.uint32(RequestID),
+			Summary:   fv.bytes(SummaryBytes),
+		}}
+	case GetAcceptedStateSummary:
+		m.Message = &p2ppb.Message_GetAcceptedStateSummary{GetAcceptedStateSummary: &p2ppb.GetAcceptedStateSummary{
+			ChainId:   fv.bytes(ChainID),
+			RequestId: fv.uint32(RequestID),
+			Deadline:  fv.uint64(Deadline),
+			Heights:   fv.uint64Slice(SummaryHeights),
+		}}
+	case AcceptedStateSummary:
+		m.Message = &p2ppb.Message_AcceptedStateSummary_{AcceptedStateSummary_: &p2ppb.AcceptedStateSummary{
+			ChainId:    fv.bytes(ChainID),
+			RequestId:  fv.uint32(RequestID),
+			SummaryIds: fv.bytesSlice(SummaryIDs),
+		}}
+	case GetAcceptedFrontier:
+		m.Message = &p2ppb.Message_GetAcceptedFrontier{GetAcceptedFrontier: &p2ppb.GetAcceptedFrontier{
+			ChainId:   fv.bytes(ChainID),
+			RequestId: fv.uint32(RequestID),
+			Deadline:  fv.uint64(Deadline),
+		}}
+	case AcceptedFrontier:
+		m.Message = &p2ppb.Message_AcceptedFrontier_{AcceptedFrontier_: &p2ppb.AcceptedFrontier{
+			ChainId:      fv.bytes(ChainID),
+			RequestId:    fv.uint32(RequestID),
+			ContainerIds: fv.bytesSlice(ContainerIDs),
+		}}
+	case GetAccepted:
+		m.Message = &p2ppb.Message_GetAccepted{GetAccepted: &p2ppb.GetAccepted{
+			ChainId:      fv.bytes(ChainID),
+			RequestId:    fv.uint32(RequestID),
+			Deadline:     fv.uint64(Deadline),
+			ContainerIds: fv.bytesSlice(ContainerIDs),
+		}}
+	case Accepted:
+		m.Message = &p2ppb.Message_Accepted_{Accepted_: &p2ppb.Accepted{
+			ChainId:      fv.bytes(ChainID),
+			RequestId:    fv.uint32(RequestID),
+			ContainerIds: fv.bytesSlice(ContainerIDs),
+		}}
+	case GetAncestors:
+		m.Message = &p2ppb.Message_GetAncestors{GetAncestors: &p2ppb.GetAncestors{
+			ChainId:     fv.bytes(ChainID),
+			RequestId:   fv.uint32(RequestID),
+			Deadline:    fv.uint64(Deadline),
+			ContainerId: fv.bytes(ContainerID),
+		}}
+	case Ancestors:
+		m.Message = &p2ppb.Message_Ancestors_{Ancestors_: &p2ppb.Ancestors{
+			ChainId:    fv.bytes(ChainID),
+			RequestId:  fv.uint32(RequestID),
+			Containers: fv.bytesSlice(MultiContainerBytes),
+		}}
+	case Get:
+		m.Message = &p2ppb.Message_Get{Get: &p2ppb.Get{
+			ChainId:     fv.bytes(ChainID),
+			RequestId:   fv.uint32(RequestID),
+			Deadline:    fv.uint64(Deadline),
+			ContainerId: fv.bytes(ContainerID),
+		}}
+	case Put:
+		m.Message = &p2ppb.Message_Put{Put: &p2ppb.Put{
+			ChainId:     fv.bytes(ChainID),
+			RequestId:   fv.uint32(RequestID),
+			ContainerId: fv.bytes(ContainerID),
+			Container:   fv.bytes(ContainerBytes),
+		}}
+	case PushQuery:
+		m.Message = &p2ppb.Message_PushQuery{PushQuery: &p2ppb.PushQuery{
+			ChainId:     fv.bytes(ChainID),
+			RequestId:   fv.uint32(RequestID),
+			Deadline:    fv.uint64(Deadline),
+			ContainerId: fv.bytes(ContainerID),
+			Container:   fv.bytes(ContainerBytes),
+		}}
+	case PullQuery:
+		m.Message = &p2ppb.Message_PullQuery{PullQuery: &p2ppb.PullQuery{
+			ChainId:     fv.bytes(ChainID),
+			RequestId:   fv.uint32(RequestID),
+			Deadline:    fv.uint64(Deadline),
+			ContainerId: fv.bytes(ContainerID),
+		}}
+	case Chits:
+		m.Message = &p2ppb.Message_Chits{Chits: &p2ppb.Chits{
+			ChainId:      fv.bytes(ChainID),
+			RequestId:    fv.uint32(RequestID),
+			ContainerIds: fv.bytesSlice(ContainerIDs),
+		}}
+	case ChitsV2:
+		m.Message = &p2ppb.Message_ChitsV2{ChitsV2: &p2ppb.ChitsV2{
+			ChainId:      fv.bytes(ChainID),
+			RequestId:    fv.uint32(RequestID),
+			ContainerIds: fv.bytesSlice(ContainerIDs),
+			ContainerId:  fv.bytes(ContainerID),
+		}}
+	case AppRequest:
+		m.Message = &p2ppb.Message_AppRequest{AppRequest: &p2ppb.AppRequest{
+			ChainId:   fv.bytes(ChainID),
+			RequestId: fv.uint32(RequestID),
+			Deadline:  fv.uint64(Deadline),
+			AppBytes:  fv.bytes(AppBytes),
+		}}
+	case AppResponse:
+		m.Message = &p2ppb.Message_AppResponse{AppResponse: &p2ppb.AppResponse{
+			ChainId:   fv.bytes(ChainID),
+			RequestId: fv.uint32(RequestID),
+			AppBytes:  fv.bytes(AppBytes),
+		}}
+	case AppGossip:
+		m.Message = &p2ppb.Message_AppGossip{AppGossip: &p2ppb.AppGossip{
+			ChainId:  fv.bytes(ChainID),
+			AppBytes: fv.bytes(AppBytes),
+		}}
+	default:
+		return nil, errBadOp
+	}
+	return m, fv.err
+}
+
+// protoToFields returns the op and field values of [m]. The field values have
+// the same types and are validated the same way as those parsed by the legacy
+// codec.
+func protoToFields(m *p2ppb.Message) (Op, map[Field]interface{}, error) {
+	var (
+		fv = fieldWriter{}
+		op Op
+	)
+	switch msg := m.GetMessage().(type) {
+	case *p2ppb.Message_Version:
+		op = Version
+		fv.set(NetworkID, msg.Version.NetworkId)
+		fv.set(Capabilities, msg.Version.Capabilities)
+		fv.set(MyTime, msg.Version.MyTime)
+		fv.set(IP, fv.ip(msg.Version.IpAddr, msg.Version.IpPort))
+		fv.set(VersionStr, msg.Version.MyVersion)
+		fv.set(VersionTime, msg.Version.MyVersionTime)
+		fv.set(SigBytes, msg.Version.Sig)
+		fv.set(TrackedSubnets, fv.hashes(msg.Version.TrackedSubnets))
+	case *p2ppb.Message_PeerList:
+		op = PeerList
+		peers := make([]ips.ClaimedIPPort, len(msg.PeerList.ClaimedIpPorts))
+		for i, peer := range msg.PeerList.ClaimedIpPorts {
+			peers[i] = ips.ClaimedIPPort{
+				Cert:      fv.cert(peer.X509Certificate),
+				IPPort:    fv.ip(peer.IpAddr, peer.IpPort),
+				Timestamp: peer.Timestamp,
+				Signature: peer.Signature,
+			}
+		}
+		fv.set(Peers, peers)
+	case *p2ppb.Message_Ping:
+		op = Ping
+	case *p2ppb.Message_Pong:
+		op = Pong
+		fv.set(Uptime, fv.uptime(msg.Pong.Uptime))
+	case *p2ppb.Message_GetStateSummaryFrontier:
+		op = GetStateSummaryFrontier
+		fv.set(ChainID, fv.hash(msg.GetStateSummaryFrontier.ChainId))
+		fv.set(RequestID, msg.GetStateSummaryFrontier.RequestId)
+		fv.set(Deadline, msg.GetStateSummaryFrontier.Deadline)
+	case *p2ppb.Message_StateSummaryFrontier_:
+		op = StateSummaryFrontier
+		fv.set(ChainID, fv.hash(msg.StateSummaryFrontier_.ChainId))
+		fv.set(RequestID, msg.StateSummaryFrontier_.RequestId)
+		fv.set(SummaryBytes, msg.StateSummaryFrontier_.Summary)
+	case *p2ppb.Message_GetAcceptedStateSummary:
+		op = GetAcceptedStateSummary
+		fv.set(ChainID, fv.hash(msg.GetAcceptedStateSummary.ChainId))
+		fv.set(RequestID, msg.GetAcceptedStateSummary.RequestId)
+		fv.set(Deadline, msg.GetAcceptedStateSummary.Deadline)
+		fv.set(SummaryHeights, msg.GetAcceptedStateSummary.Heights)
+	case *p2ppb.Message_AcceptedStateSummary_:
+		op = AcceptedStateSummary
+		fv.set(ChainID, fv.hash(msg.AcceptedStateSummary_.ChainId))
+		fv.set(RequestID, msg.AcceptedStateSummary_.RequestId)
+		fv.set(SummaryIDs, fv.hashes(msg.AcceptedStateSummary_.SummaryIds))
+	case *p2ppb.Message_GetAcceptedFrontier:
+		op = GetAcceptedFrontier
+		fv.set(ChainID, fv.hash(msg.GetAcceptedFrontier.ChainId))
+		fv.set(RequestID, msg.GetAcceptedFrontier.RequestId)
+		fv.set(Deadline, msg.GetAcceptedFrontier.Deadline)
+	case *p2ppb.Message_AcceptedFrontier_:
+		op = AcceptedFrontier
+		fv.set(ChainID, fv.hash(msg.AcceptedFrontier_.ChainId))
+		fv.set(RequestID, msg.AcceptedFrontier_.RequestId)
+		fv.set(ContainerIDs, fv.hashes(msg.AcceptedFrontier_.ContainerIds))
+	case *p2ppb.Message_GetAccepted:
+		op = GetAccepted
+		fv.set(ChainID, fv.hash(msg.GetAccepted.ChainId))
+		fv.set(RequestID, msg.GetAccepted.RequestId)
+		fv.set(Deadline, msg.GetAccepted.Deadline)
+		fv.set(ContainerIDs, fv.hashes(msg.GetAccepted.ContainerIds))
+	case *p2ppb.Message_Accepted_:
+		op = Accepted
+		fv.set(ChainID, fv.hash(msg.Accepted_.ChainId))
+		fv.set(RequestID, msg.Accepted_.RequestId)
+		fv.set(ContainerIDs, fv.hashes(msg.Accepted_.ContainerIds))
+	case *p2ppb.Message_GetAncestors:
+		op = GetAncestors
+		fv.set(ChainID, fv.hash(msg.GetAncestors.ChainId))
+		fv.set(RequestID, msg.GetAncestors.RequestId)
+		fv.set(Deadline, msg.GetAncestors.Deadline)
+		fv.set(ContainerID, fv.hash(msg.GetAncestors.ContainerId))
+	case *p2ppb.Message_Ancestors_:
+		op = Ancestors
+		fv.set(ChainID, fv.hash(msg.Ancestors_.ChainId))
+		fv.set(RequestID, msg.Ancestors_.RequestId)
+		fv.set(MultiContainerBytes, msg.Ancestors_.Containers)
+	case *p2ppb.Message_Get:
+		op = Get
+		fv.set(ChainID, fv.hash(msg.Get.ChainId))
+		fv.set(RequestID, msg.Get.RequestId)
+		fv.set(Deadline, msg.Get.Deadline)
+		fv.set(ContainerID, fv.hash(msg.Get.ContainerId))
+	case *p2ppb.Message_Put:
+		op = Put
+		fv.set(ChainID, fv.hash(msg.Put.ChainId))
+		fv.set(RequestID, msg.Put.RequestId)
+		fv.set(ContainerID, fv.hash(msg.Put.ContainerId))
+		fv.set(ContainerBytes, msg.Put.Container)
+	case *p2ppb.Message_PushQuery:
+		op = PushQuery
+		fv.set(ChainID, fv.hash(msg.PushQuery.ChainId))
+		fv.set(RequestID, msg.PushQuery.RequestId)
+		fv.set(Deadline, msg.PushQuery.Deadline)
+		fv.set(ContainerID, fv.hash(msg.PushQuery.ContainerId))
+		fv.set(ContainerBytes, msg.PushQuery.Container)
+	case *p2ppb.Message_PullQuery:
+		op = PullQuery
+		fv.set(ChainID, fv.hash(msg.PullQuery.ChainId))
+		fv.set(RequestID, msg.PullQuery.RequestId)
+		fv.set(Deadline, msg.PullQuery.Deadline)
+		fv.set(ContainerID, fv.hash(msg.PullQuery.ContainerId))
+	case *p2ppb.Message_Chits:
+		op = Chits
+		fv.set(ChainID, fv.hash(msg.Chits.ChainId))
+		fv.set(RequestID, msg.Chits.RequestId)
+		fv.set(ContainerIDs, fv.hashes(msg.Chits.ContainerIds))
+	case *p2ppb.Message_ChitsV2:
+		op = ChitsV2
+		fv.set(ChainID, fv.hash(msg.ChitsV2.ChainId))
+		fv.set(RequestID, msg.ChitsV2.RequestId)
+		fv.set(ContainerIDs, fv.hashes(msg.ChitsV2.ContainerIds))
+		fv.set(ContainerID, fv.hash(msg.ChitsV2.ContainerId))
+	case *p2ppb.Message_AppRequest:
+		op = AppRequest
+		fv.set(ChainID, fv.hash(msg.AppRequest.ChainId))
+		fv.set(RequestID, msg.AppRequest.RequestId)
+		fv.set(Deadline, msg.AppRequest.Deadline)
+		fv.set(AppBytes, msg.AppRequest.AppBytes)
+	case *p2ppb.Message_AppResponse:
+		op = AppResponse
+		fv.set(ChainID, fv.hash(msg.AppResponse.ChainId))
+		fv.set(RequestID, msg.AppResponse.RequestId)
+		fv.set(AppBytes, msg.AppResponse.AppBytes)
+	case *p2ppb.Message_AppGossip:
+		op = AppGossip
+		fv.set(ChainID, fv.hash(msg.AppGossip.ChainId))
+		fv.set(AppBytes, msg.AppGossip.AppBytes)
+	default:
+		return 0, nil, ErrUnknownOp
+	}
+	return op, fv.values, fv.err
+}
+
+// fieldReader reads field values of a message to be packed. It records the
+// first field that is missing or has an unexpected type.
+type fieldReader struct {
+	values map[Field]interface{}
+	err    error
+}
+
+func (r *fieldReader) get(field Field) interface{} {
+	value, ok := r.values[field]
+	if !ok && r.err == nil {
+		r.err = fmt.Errorf("%w: %d", errMissingField, field)
+	}
+	return value
+}
+
+func (r *fieldReader) badType(field Field) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: %d", errBadFieldType, field)
+	}
+}
+
+func (r *fieldReader) uint8(field Field) uint8 {
+	value, ok := r.get(field).(uint8)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) uint32(field Field) uint32 {
+	value, ok := r.get(field).(uint32)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) uint64(field Field) uint64 {
+	value, ok := r.get(field).(uint64)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) string(field Field) string {
+	value, ok := r.get(field).(string)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) bytes(field Field) []byte {
+	value, ok := r.get(field).([]byte)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) bytesSlice(field Field) [][]byte {
+	value, ok := r.get(field).([][]byte)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) uint64Slice(field Field) []uint64 {
+	value, ok := r.get(field).([]uint64)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) ip(field Field) ips.IPPort {
+	value, ok := r.get(field).(ips.IPPort)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+func (r *fieldReader) claimedIPPorts(field Field) []ips.ClaimedIPPort {
+	value, ok := r.get(field).([]ips.ClaimedIPPort)
+	if !ok {
+		r.badType(field)
+	}
+	return value
+}
+
+// fieldWriter collects the field values of a parsed message. It records the
+// first value that fails validation.
+type fieldWriter struct {
+	values map[Field]interface{}
+	err    error
+}
+
+func (w *fieldWriter) set(field Field, value interface{}) {
+	if w.values == nil {
+		w.values = make(map[Field]interface{})
+	}
+	w.values[field] = value
+}
+
+func (w *fieldWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *fieldWriter) hash(hash []byte) []byte {
+	if len(hash) != hashing.HashLen {
+		w.fail(fmt.Errorf("%w: %d", errBadHashLen, len(hash)))
+	}
+	return hash
+}
+
+func (w *fieldWriter) hashes(hashes [][]byte) [][]byte {
+	for _, hash := range hashes {
+		w.hash(hash)
+	}
+	return hashes
+}
+
+func (w *fieldWriter) ip(ip []byte, port uint32) ips.IPPort {
+	if len(ip) != net.IPv6len {
+		w.fail(fmt.Errorf("%w: %d", errBadIPLen, len(ip)))
+	}
+	if port > math.MaxUint16 {
+		w.fail(fmt.Errorf("%w: %d", errBadPort, port))
+	}
+	return ips.IPPort{
+		IP:   ip,
+		Port: uint16(port),
+	}
+}
+
+func (w *fieldWriter) uptime(uptime uint32) uint8 {
+	if uptime > math.MaxUint8 {
+		w.fail(fmt.Errorf("%w: %d", errBadUptime, uptime))
+	}
+	return uint8(uptime)
+}
+
+func (w *fieldWriter) cert(certBytes []byte) *x509.Certificate {
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		w.fail(err)
+	}
+	return cert
+}
@@ -0,0 +1,320 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func newTestCodecs(t *testing.T) (Codec, Codec) {
+	legacy, err := NewCodecWithMemoryPool("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second)
+	assert.NoError(t, err)
+	proto, err := NewProtoCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second)
+	assert.NoError(t, err)
+	return legacy, proto
+}
+
+// Test that every external message parsed from the protobuf format has the
+// same fields as when parsed from the legacy format
+func TestProtoCodecMatchesLegacy(t *testing.T) {
+	assert := assert.New(t)
+
+	legacy, proto := newTestCodecs(t)
+	id := ids.GenerateTestID()
+
+	tlsCert, err := staking.NewTLSCert()
+	assert.NoError(err)
+
+	msgs := map[Op]map[Field]interface{}{
+		Version: {
+			NetworkID:      uint32(1),
+			Capabilities:   uint32(1337),
+			MyTime:         uint64(time.Now().Unix()),
+			IP:             ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+			VersionStr:     "avalanche/1.2.3",
+			VersionTime:    uint64(time.Now().Unix()),
+			SigBytes:       []byte{'y', 'e', 'e', 't'},
+			TrackedSubnets: [][]byte{id[:]},
+		},
+		PeerList: {
+			Peers: []ips.ClaimedIPPort{{
+				Cert:      tlsCert.Leaf,
+				IPPort:    ips.IPPort{IP: net.IPv6loopback, Port: 9651},
+				Timestamp: uint64(time.Now().Unix()),
+				Signature: make([]byte, 65),
+			}},
+		},
+		Ping: {},
+		Pong: {Uptime: uint8(80)},
+		GetStateSummaryFrontier: {
+			ChainID:   id[:],
+			RequestID: uint32(1337),
+			Deadline:  uint64(time.Second),
+		},
+		StateSummaryFrontier: {
+			ChainID:      id[:],
+			RequestID:    uint32(1337),
+			SummaryBytes: []byte{1, 2, 3},
+		},
+		GetAcceptedStateSummary: {
+			ChainID:        id[:],
+			RequestID:      uint32(1337),
+			Deadline:       uint64(time.Second),
+			SummaryHeights: []uint64{1, 2},
+		},
+		AcceptedStateSummary: {
+			ChainID:    id[:],
+			RequestID:  uint32(1337),
+			SummaryIDs: [][]byte{id[:]},
+		},
+		GetAcceptedFrontier: {
+			ChainID:   id[:],
+			RequestID: uint32(1337),
+			Deadline:  uint64(time.Second),
+		},
+		AcceptedFrontier: {
+			ChainID:      id[:],
+			RequestID:    uint32(1337),
+			ContainerIDs: [][]byte{id[:]},
+		},
+		GetAccepted: {
+			ChainID:      id[:],
+			RequestID:    uint32(1337),
+			Deadline:     uint64(time.Second),
+			ContainerIDs: [][]byte{id[:]},
+		},
+		Accepted: {
+			ChainID:      id[:],
+			RequestID:    uint32(1337),
+			ContainerIDs: [][]byte{id[:]},
+		},
+		GetAncestors: {
+			ChainID:     id[:],
+			RequestID:   uint32(1337),
+			Deadline:    uint64(time.Second),
+			ContainerID: id[:],
+		},
+		Ancestors: {
+			ChainID:             id[:],
+			RequestID:           uint32(1337),
+			MultiContainerBytes: [][]byte{id[:]},
+		},
+		Get: {
+			ChainID:     id[:],
+			RequestID:   uint32(1337),
+			Deadline:    uint64(time.Second),
+			ContainerID: id[:],
+		},
+		Put: {
+			ChainID:        id[:],
+			RequestID:      uint32(1337),
+			ContainerID:    id[:],
+			ContainerBytes: make([]byte, 1024),
+		},
+		PushQuery: {
+			ChainID:        id[:],
+			RequestID:      uint32(1337),
+			Deadline:       uint64(time.Second),
+			ContainerID:    id[:],
+			ContainerBytes: make([]byte, 1024),
+		},
+		PullQuery: {
+			ChainID:     id[:],
+			RequestID:   uint32(1337),
+			Deadline:    uint64(time.Second),
+			ContainerID: id[:],
+		},
+		Chits: {
+			ChainID:      id[:],
+			RequestID:    uint32(1337),
+			ContainerIDs: [][]byte{id[:]},
+		},
+		ChitsV2: {
+			ChainID:      id[:],
+			RequestID:    uint32(1337),
+			ContainerIDs: [][]byte{id[:]},
+			ContainerID:  id[:],
+		},
+		AppRequest: {
+			ChainID:   id[:],
+			RequestID: uint32(1337),
+			Deadline:  uint64(time.Second),
+			AppBytes:  []byte{1, 2, 3},
+		},
+		AppResponse: {
+			ChainID:   id[:],
+			RequestID: uint32(1337),
+			AppBytes:  []byte{1, 2, 3},
+		},
+		AppGossip: {
+			ChainID:  id[:],
+			AppBytes: []byte{1, 2, 3},
+		},
+	}
+	assert.Len(msgs, len(ExternalOps))
+
+	for op, fields := range msgs {
+		for _, compress := range []bool{false, true} {
+			if compress && !op.Compressible() {
+				continue
+			}
+
+			legacyMsg, err := legacy.Pack(op, fields, compress, false)
+			assert.NoError(err, "failed to pack %s", op)
+			protoMsg, err := proto.Pack(op, fields, compress, false)
+			assert.NoError(err, "failed to pack %s", op)
+			assert.True(IsProto(protoMsg.Bytes()))
+			assert.False(IsProto(legacyMsg.Bytes()))
+
+			legacyParsed, err := legacy.Parse(legacyMsg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+			assert.NoError(err, "failed to parse %s", op)
+			protoParsed, err := proto.Parse(protoMsg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+			assert.NoError(err, "failed to parse %s", op)
+
+			assert.Equal(op, protoParsed.Op())
+			for _, field := range messages[op] {
+				assert.Equal(legacyParsed.Get(field), protoParsed.Get(field), "field %d of %s", field, op)
+			}
+			assert.Equal(legacyParsed.ExpirationTime().IsZero(), protoParsed.ExpirationTime().IsZero())
+			if compress {
+				assert.Equal(protoMsg.BytesSavedCompression(), protoParsed.BytesSavedCompression())
+			}
+		}
+	}
+}
+
+func TestProtoCodecPackErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, proto := newTestCodecs(t)
+
+	_, err := proto.Pack(Timeout, map[Field]interface{}{}, false, false)
+	assert.ErrorIs(err, errBadOp)
+
+	_, err = proto.Pack(Get, map[Field]interface{}{}, false, false)
+	assert.ErrorIs(err, errMissingField)
+
+	_, err = proto.Pack(Pong, map[Field]interface{}{Uptime: uint32(80)}, false, false)
+	assert.ErrorIs(err, errBadFieldType)
+
+	_, err = proto.Pack(Ping, map[Field]interface{}{}, true, false)
+	assert.ErrorIs(err, errUnexpectedCompress)
+}
+
+func TestProtoCodecParseInvalidFields(t *testing.T) {
+	assert := assert.New(t)
+
+	_, proto := newTestCodecs(t)
+
+	msg, err := proto.Pack(Get, map[Field]interface{}{
+		ChainID:     []byte{1},
+		RequestID:   uint32(1337),
+		Deadline:    uint64(time.Second),
+		ContainerID: []byte{2},
+	}, false, false)
+	assert.NoError(err)
+
+	_, err = proto.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, errBadHashLen)
+
+	_, err = proto.Parse([]byte{protoFirstByte}, dummyNodeID, dummyOnFinishedHandling)
+	assert.Error(err)
+
+	// A message type added by a newer version is an unknown field (100 here)
+	// to this version.
+	_, err = proto.Parse([]byte{0xa0, 0x06, 0x01}, dummyNodeID, dummyOnFinishedHandling)
+	assert.ErrorIs(err, ErrUnknownOp)
+}
+
+func TestMultiCodec(t *testing.T) {
+	assert := assert.New(t)
+
+	legacy, err := newCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second, nil)
+	assert.NoError(err)
+	proto, err := newProtoCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second, nil)
+	assert.NoError(err)
+	c := newMultiCodec(legacy, proto)
+	id := ids.GenerateTestID()
+
+	fields := map[Field]interface{}{
+		ChainID:   id[:],
+		RequestID: uint32(1337),
+		AppBytes:  make([]byte, 1024),
+	}
+	msg, err := c.Pack(AppResponse, fields, true, false)
+	assert.NoError(err)
+
+	multiFormatMsg, ok := msg.(MultiFormatMessage)
+	assert.True(ok)
+
+	// The message is only packed with the encodings it's requested with
+	assert.Empty(multiFormatMsg.(*multiFormatMessage).encoded)
+
+	encodings := []Encoding{
+		DefaultEncoding,
+		{Compression: compression.TypeZstd},
+		{Proto: true, Compression: compression.TypeGzip},
+		{Proto: true, Compression: compression.TypeZstd},
+	}
+	for _, encoding := range encodings {
+		encodedMsg, err := multiFormatMsg.Encode(encoding)
+		assert.NoError(err)
+		assert.Equal(encoding.Proto, IsProto(encodedMsg.Bytes()))
+		assert.Equal(AppResponse, encodedMsg.Op())
+
+		// The message is packed once per encoding
+		encodedAgain, err := multiFormatMsg.Encode(encoding)
+		assert.NoError(err)
+		assert.Same(encodedMsg, encodedAgain)
+
+		parsedMsg, err := c.Parse(encodedMsg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		assert.NoError(err)
+		assert.Equal(AppResponse, parsedMsg.Op())
+		assert.Equal(fields[AppBytes], parsedMsg.Get(AppBytes))
+		assert.Positive(parsedMsg.BytesSavedCompression())
+		assert.Equal(encodedMsg.BytesSavedCompression(), parsedMsg.BytesSavedCompression())
+	}
+	assert.Len(multiFormatMsg.(*multiFormatMessage).encoded, len(encodings))
+	assert.False(IsProto(msg.Bytes()))
+
+	// The encoded messages share the references of the message
+	encodedMsg, err := multiFormatMsg.Encode(DefaultEncoding)
+	assert.NoError(err)
+	encodedMsg.AddRef()
+	msg.DecRef()
+	assert.Len(multiFormatMsg.(*multiFormatMessage).encoded, len(encodings))
+	encodedMsg.DecRef()
+	assert.Empty(multiFormatMsg.(*multiFormatMessage).encoded)
+}
+
+func TestMultiCodecPackErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	legacy, err := newCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second, nil)
+	assert.NoError(err)
+	proto, err := newProtoCodec("", prometheus.NewRegistry(), 2*units.MiB, 10*time.Second, nil)
+	assert.NoError(err)
+	c := newMultiCodec(legacy, proto)
+
+	_, err = c.Pack(Op(255), nil, false, false)
+	assert.ErrorIs(err, errBadOp)
+
+	_, err = c.Pack(Ping, nil, true, false)
+	assert.ErrorIs(err, errUnexpectedCompress)
+
+	_, err = c.Pack(Pong, nil, false, false)
+	assert.ErrorIs(err, errMissingField)
+}
@@ -10,10 +10,12 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
@@ -93,10 +95,16 @@ type DelayConfig struct {
 type ThrottlerConfig struct {
 	InboundConnUpgradeThrottlerConfig throttling.InboundConnUpgradeThrottlerConfig `json:"inboundConnUpgradeThrottlerConfig"`
 	InboundMsgThrottlerConfig         throttling.InboundMsgThrottlerConfig         `json:"inboundMsgThrottlerConfig"`
-	OutboundMsgThrottlerConfig        throttling.MsgByteThrottlerConfig            `json:"outboundMsgThrottlerConfig"`
+	OutboundMsgThrottlerConfig        throttling.OutboundMsgThrottlerConfig        `json:"outboundMsgThrottlerConfig"`
 	MaxInboundConnsPerSec             float64                                      `json:"maxInboundConnsPerSec"`
 }
 
+// PinnedPeer is a peer that the node always attempts to stay connected to
+type PinnedPeer struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     ips.IPPort `json:"ip"`
+}
+
 type Config struct {
 	HealthConfig         `json:"healthConfig"`
 	PeerListGossipConfig `json:"peerListGossipConfig"`
@@ -119,6 +127,19 @@ type Config struct {
 	// true.
 	CompressionEnabled bool `json:"compressionEnabled"`
 
+	// CompressionType is the type outbound messages are compressed with, if
+	// compression is enabled, for peers that support it.
+	CompressionType compression.Type `json:"compressionType"`
+
+	// CompressionZstdDict is the dictionary outbound zstd messages are
+	// compressed with, for peers that are configured with it. If empty, no
+	// dictionary is used.
+	CompressionZstdDict []byte `json:"-"`
+
+	// ProtoCodecEnabled will send protobuf encoded messages to peers that
+	// support them when set to true.
+	ProtoCodecEnabled bool `json:"protoCodecEnabled"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 
@@ -145,6 +166,15 @@ type Config struct {
 	// the network negatively.
 	RequireValidatorToConnect bool `json:"requireValidatorToConnect"`
 
+	// PinnedPeers are always connected to. Connections to pinned peers are
+	// only ever attempted at their configured IP and are retried with backoff
+	// forever. They are first dialed when the network is dispatched.
+	PinnedPeers []PinnedPeer `json:"pinnedPeers"`
+
+	// AllowedNodeIDs, if non-empty, are the only nodes, along with the pinned
+	// peers, that this node will connect to.
+	AllowedNodeIDs []ids.NodeID `json:"allowedNodeIDs"`
+
 	// MaximumInboundMessageTimeout is the maximum deadline duration in a
 	// message. Messages sent by clients setting values higher than this value
 	// will be reset to this value.
@@ -168,4 +198,11 @@ type Config struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
+
+	// ReputationConfig configures when peers are banned for the protocol
+	// violations they commit.
+	ReputationConfig reputation.Config `json:"reputationConfig"`
+
+	// Records the protocol violations of peers and the bans of nodes and IPs.
+	ReputationManager reputation.Manager `json:"-"`
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -77,6 +78,28 @@ type Network interface {
 	// connect to this ID.
 	ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort)
 
+	// PinPeer makes the network always attempt to stay connected to [nodeID].
+	// Connections to [nodeID] are only attempted at [ip]. Pinned peers are
+	// allowed even if they aren't in the allowed node IDs, and are never
+	// banned automatically.
+	PinPeer(nodeID ids.NodeID, ip ips.IPPort)
+
+	// UnpinPeer stops the network from always attempting to stay connected to
+	// [nodeID]. Returns false if [nodeID] wasn't pinned.
+	UnpinPeer(nodeID ids.NodeID) bool
+
+	// PinnedPeers returns the pinned peers, sorted by node ID.
+	PinnedPeers() []PinnedPeer
+
+	// SetAllowedNodeIDs replaces the node IDs that the network is allowed to
+	// connect to. If [nodeIDs] is empty, all nodes are allowed. Connections to
+	// nodes that are no longer allowed are closed at the next ping.
+	SetAllowedNodeIDs(nodeIDs []ids.NodeID)
+
+	// AllowedNodeIDs returns the sorted node IDs that the network is allowed to
+	// connect to. If empty, all nodes are allowed.
+	AllowedNodeIDs() []ids.NodeID
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
@@ -127,9 +150,15 @@ type network struct {
 	// finished the handshake.
 	trackedIPs         map[ids.NodeID]*trackedIP
 	manuallyTrackedIDs ids.NodeIDSet
-	connectingPeers    peer.Set
-	connectedPeers     peer.Set
-	closing            bool
+	// pinnedPeers maps the node IDs of the pinned peers to the IPs they are
+	// dialed at.
+	pinnedPeers map[ids.NodeID]ips.IPPort
+	// allowedNodeIDs, if non-empty, are the only nodes, other than the pinned
+	// peers, that we will connect to.
+	allowedNodeIDs  ids.NodeIDSet
+	connectingPeers peer.Set
+	connectedPeers  peer.Set
+	closing         bool
 
 	// router is notified about all peer [Connected] and [Disconnected] events
 	// as well as all non-handshake peer messages.
@@ -220,6 +249,9 @@ func NewNetwork(
 		MaxClockDifference:   config.MaxClockDifference,
 		ResourceTracker:      config.ResourceTracker,
 		PingMessage:          pingMessge,
+		ProtoCodecEnabled:    config.ProtoCodecEnabled,
+		CompressionType:      config.CompressionType,
+		ZstdDictID:           message.ZstdDictID(config.CompressionZstdDict),
 	}
 	onCloseCtx, cancel := context.WithCancel(context.Background())
 	n := &network{
@@ -245,11 +277,18 @@ func NewNetwork(
 		)),
 
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
+		pinnedPeers:     make(map[ids.NodeID]ips.IPPort),
 		connectingPeers: peer.NewSet(),
 		connectedPeers:  peer.NewSet(),
 		router:          router,
 	}
 	n.peerConfig.Network = n
+	n.SetAllowedNodeIDs(config.AllowedNodeIDs)
+	// The pinned peers are only dialed once the network is dispatched, as
+	// messages can't be routed before then.
+	for _, pinnedPeer := range config.PinnedPeers {
+		n.pinnedPeers[pinnedPeer.NodeID] = pinnedPeer.IP
+	}
 	return n, nil
 }
 
@@ -369,13 +408,20 @@ func (n *network) Connected(nodeID ids.NodeID) {
 }
 
 // AllowConnection returns true if this node should have a connection to the
-// provided nodeID. If the node is attempting to connect to the minimum number
-// of peers, then it should only connect if this node is a validator, or the
-// peer is a validator/beacon.
+// provided nodeID. Banned nodes and nodes that aren't allowed are never
+// connected to. If the node is attempting to connect to the minimum number of
+// peers, then it should only connect if this node is a validator, or the peer
+// is a validator/beacon.
 func (n *network) AllowConnection(nodeID ids.NodeID) bool {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	if !n.isAllowed(nodeID) {
+		return false
+	}
 	return !n.config.RequireValidatorToConnect ||
 		n.config.Validators.Contains(constants.PrimaryNetworkID, n.config.MyNodeID) ||
-		n.WantsConnection(nodeID)
+		n.wantsConnection(nodeID)
 }
 
 func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
@@ -413,6 +459,11 @@ func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
 		return false
 	}
 
+	if _, pinned := n.pinnedPeers[nodeID]; pinned {
+		// Pinned peers are only connected to at their configured IP.
+		return false
+	}
+
 	tracked, isTracked := n.trackedIPs[nodeID]
 	switch {
 	case isTracked:
@@ -481,6 +532,21 @@ func (n *network) Peers() (message.OutboundMessage, error) {
 	return n.peerConfig.MessageCreator.PeerList(peers, true)
 }
 
+// Violated records the violation with the reputation manager, unless
+// [nodeID] is pinned. Pinned peers are trusted by the operator, so they're
+// never banned automatically.
+func (n *network) Violated(nodeID ids.NodeID, violation reputation.Violation) {
+	n.peersLock.RLock()
+	_, pinned := n.pinnedPeers[nodeID]
+	n.peersLock.RUnlock()
+
+	if pinned {
+		n.peerConfig.Log.Debug("not scoring violation %s of pinned peer %s", violation, nodeID)
+		return
+	}
+	n.config.ReputationManager.Violated(nodeID, violation)
+}
+
 func (n *network) Pong(nodeID ids.NodeID) (message.OutboundMessage, error) {
 	uptimePercentFloat, err := n.config.UptimeCalculator.CalculateUptimePercent(nodeID)
 	if err != nil {
@@ -494,6 +560,7 @@ func (n *network) Pong(nodeID ids.NodeID) (message.OutboundMessage, error) {
 // Dispatch starts accepting connections from other nodes attempting to connect
 // to this node.
 func (n *network) Dispatch() error {
+	n.dialPinnedPeers()
 	go n.runTimers() // Periodically perform operations
 	go n.inboundConnUpgradeThrottler.Dispatch()
 	errs := wrappers.Errs{}
@@ -564,10 +631,42 @@ func (n *network) WantsConnection(nodeID ids.NodeID) bool {
 }
 
 func (n *network) wantsConnection(nodeID ids.NodeID) bool {
+	if !n.isAllowed(nodeID) {
+		return false
+	}
+	if _, pinned := n.pinnedPeers[nodeID]; pinned {
+		return true
+	}
 	return n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) ||
 		n.manuallyTrackedIDs.Contains(nodeID)
 }
 
+// shouldDial returns true if attempts to connect to [nodeID] should continue.
+// Pinned peers keep being dialed while they're banned, so that they're
+// reconnected to once their ban is lifted.
+//
+// Assumes [n.peersLock] is held.
+func (n *network) shouldDial(nodeID ids.NodeID) bool {
+	if _, pinned := n.pinnedPeers[nodeID]; pinned {
+		return true
+	}
+	return n.wantsConnection(nodeID)
+}
+
+// isAllowed returns true if [nodeID] isn't banned and is either pinned or
+// allowed.
+//
+// Assumes [n.peersLock] is held.
+func (n *network) isAllowed(nodeID ids.NodeID) bool {
+	if n.config.ReputationManager.IsBanned(nodeID) {
+		return false
+	}
+	if _, pinned := n.pinnedPeers[nodeID]; pinned {
+		return true
+	}
+	return n.allowedNodeIDs.Len() == 0 || n.allowedNodeIDs.Contains(nodeID)
+}
+
 func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	n.peersLock.Lock()
 	defer n.peersLock.Unlock()
@@ -593,6 +692,98 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	}
 }
 
+func (n *network) PinPeer(nodeID ids.NodeID, ip ips.IPPort) {
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	n.pinnedPeers[nodeID] = ip
+	n.dialPinnedPeer(nodeID, ip)
+}
+
+// dialPinnedPeers starts dialing the pinned peers that aren't connected
+func (n *network) dialPinnedPeers() {
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	for nodeID, ip := range n.pinnedPeers {
+		n.dialPinnedPeer(nodeID, ip)
+	}
+}
+
+// dialPinnedPeer starts dialing the pinned peer [nodeID] at [ip], unless it's
+// connected or already being dialed at [ip].
+// Assumes [n.peersLock] is held.
+func (n *network) dialPinnedPeer(nodeID ids.NodeID, ip ips.IPPort) {
+	if _, connected := n.connectedPeers.GetByID(nodeID); connected {
+		return
+	}
+
+	unsignedIP := &peer.UnsignedIP{
+		IP:        ip,
+		Timestamp: 0,
+	}
+	tracked, isTracked := n.trackedIPs[nodeID]
+	switch {
+	case !isTracked:
+		tracked = newTrackedIP(unsignedIP)
+	case !tracked.ip.IP.Equal(ip):
+		// Stop dialing the previous IP and dial the pinned IP instead.
+		tracked = tracked.trackNewIP(unsignedIP)
+	default:
+		return
+	}
+	n.trackedIPs[nodeID] = tracked
+	n.dial(n.onCloseCtx, nodeID, tracked)
+}
+
+func (n *network) UnpinPeer(nodeID ids.NodeID) bool {
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	// If the peer is no longer wanted, any attempt to connect to it will stop
+	// on its own.
+	_, pinned := n.pinnedPeers[nodeID]
+	delete(n.pinnedPeers, nodeID)
+	return pinned
+}
+
+func (n *network) PinnedPeers() []PinnedPeer {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	nodeIDs := make([]ids.NodeID, 0, len(n.pinnedPeers))
+	for nodeID := range n.pinnedPeers {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	ids.SortNodeIDs(nodeIDs)
+
+	pinnedPeers := make([]PinnedPeer, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		pinnedPeers[i] = PinnedPeer{
+			NodeID: nodeID,
+			IP:     n.pinnedPeers[nodeID],
+		}
+	}
+	return pinnedPeers
+}
+
+func (n *network) SetAllowedNodeIDs(nodeIDs []ids.NodeID) {
+	allowedNodeIDs := ids.NewNodeIDSet(len(nodeIDs))
+	allowedNodeIDs.Add(nodeIDs...)
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	n.allowedNodeIDs = allowedNodeIDs
+}
+
+func (n *network) AllowedNodeIDs() []ids.NodeID {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	return n.allowedNodeIDs.SortedList()
+}
+
 func (n *network) TracksSubnet(nodeID ids.NodeID, subnetID ids.ID) bool {
 	if n.config.MyNodeID == nodeID {
 		return subnetID == constants.PrimaryNetworkID || n.config.WhitelistedSubnets.Contains(subnetID)
@@ -754,7 +945,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 	// The peer that is disconnecting from us didn't finish the handshake
 	tracked, ok := n.trackedIPs[nodeID]
 	if ok {
-		if n.wantsConnection(nodeID) {
+		if n.shouldDial(nodeID) {
 			tracked := tracked.trackNewIP(tracked.ip)
 			n.trackedIPs[nodeID] = tracked
 			n.dial(n.onCloseCtx, nodeID, tracked)
@@ -776,8 +967,14 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.connectedPeers.Remove(nodeID)
 
 	// The peer that is disconnecting from us finished the handshake
-	if n.wantsConnection(nodeID) {
-		tracked := newTrackedIP(&peer.IP().IP)
+	if n.shouldDial(nodeID) {
+		ip := peer.IP().IP
+		if pinnedIP, pinned := n.pinnedPeers[nodeID]; pinned {
+			// Pinned peers are only connected to at their configured IP.
+			ip.IP = pinnedIP
+			ip.Timestamp = 0
+		}
+		tracked := newTrackedIP(&ip)
 		n.trackedIPs[nodeID] = tracked
 		n.dial(n.onCloseCtx, nodeID, tracked)
 	} else {
@@ -807,6 +1004,11 @@ func (n *network) shouldTrack(nodeID ids.NodeID, ip ips.ClaimedIPPort) bool {
 		return false
 	}
 
+	if _, pinned := n.pinnedPeers[nodeID]; pinned {
+		// Pinned peers are only connected to at their configured IP.
+		return false
+	}
+
 	tracked, isTracked := n.trackedIPs[nodeID]
 	if isTracked {
 		return tracked.ip.Timestamp < ip.Timestamp
@@ -830,6 +1032,9 @@ func (n *network) shouldTrack(nodeID ids.NodeID, ip ips.ClaimedIPPort) bool {
 // If [nodeID] is no longer marked as desired then this goroutine will exit and
 // the entry in the [trackedIP]s set will be removed.
 //
+// If [nodeID] is pinned but banned, then no connection is attempted until the
+// ban is lifted.
+//
 // If initiating a connection to [ip] fails, then dial will reattempt. However,
 // there is a randomized exponential backoff to avoid spamming connection
 // attempts.
@@ -849,7 +1054,7 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 			}
 
 			n.peersLock.Lock()
-			if !n.wantsConnection(nodeID) {
+			if !n.shouldDial(nodeID) {
 				// Typically [n.trackedIPs[nodeID]] will already equal [ip], but
 				// the reference to [ip] is refreshed to avoid any potential
 				// race conditions before removing the entry.
@@ -860,6 +1065,7 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				n.peersLock.Unlock()
 				return
 			}
+			wantsConnection := n.wantsConnection(nodeID)
 			_, connecting := n.connectingPeers.GetByID(nodeID)
 			_, connected := n.connectedPeers.GetByID(nodeID)
 			n.peersLock.Unlock()
@@ -884,6 +1090,17 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				n.config.MaxReconnectDelay,
 			)
 
+			if !wantsConnection {
+				// The peer is pinned but banned, so the connection would be
+				// dropped.
+				n.peerConfig.Log.Verbo(
+					"not dialing banned pinned peer %s, checking again in %s",
+					nodeID,
+					ip.delay,
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(ctx, ip.ip.IP)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
@@ -917,6 +1134,15 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 // connection will be used to create a new peer. Otherwise the connection will
 // be immediately closed.
 func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
+	// Banned IPs are dropped before spending any resources on the TLS
+	// handshake.
+	remoteAddr := conn.RemoteAddr().String()
+	if ip, err := ips.ToIPPort(remoteAddr); err == nil && n.config.ReputationManager.IsIPBanned(ip.IP) {
+		_ = conn.Close()
+		n.peerConfig.Log.Verbo("dropping connection to banned IP %s", ip.IP)
+		return nil
+	}
+
 	if conn, ok := conn.(*net.TCPConn); ok {
 		// If a connection is closed, we shouldn't bother keeping any messages
 		// in memory.
@@ -1031,6 +1257,9 @@ func (n *network) StartClose() {
 			n.peerConfig.Log.Debug("closing the network listener failed with: %s", err)
 		}
 
+		// Persist the peer reputations before the database is closed
+		n.syncReputations()
+
 		n.peersLock.Lock()
 		defer n.peersLock.Unlock()
 
@@ -1104,9 +1333,11 @@ func (n *network) NodeUptime() (UptimeResult, bool) {
 func (n *network) runTimers() {
 	gossipPeerlists := time.NewTicker(n.config.PeerListGossipFreq)
 	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	syncReputations := time.NewTicker(n.config.ReputationConfig.SyncFrequency)
 	defer func() {
 		gossipPeerlists.Stop()
 		updateUptimes.Stop()
+		syncReputations.Stop()
 	}()
 
 	for {
@@ -1144,6 +1375,16 @@ func (n *network) runTimers() {
 			result, _ := n.NodeUptime()
 			n.metrics.nodeUptimeWeightedAverage.Set(result.WeightedAveragePercentage)
 			n.metrics.nodeUptimeRewardingStake.Set(result.RewardingStakePercentage)
+
+		case <-syncReputations.C:
+			n.syncReputations()
 		}
 	}
 }
+
+// syncReputations persists the peer scores and automatic bans
+func (n *network) syncReputations() {
+	if err := n.config.ReputationManager.Sync(); err != nil {
+		n.peerConfig.Log.Warn("failed to persist peer reputations: %s", err)
+	}
+}
@@ -14,9 +14,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -78,10 +80,12 @@ var (
 				MaxRecheckDelay: 50 * time.Millisecond,
 			},
 		},
-		OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
-			VdrAllocSize:        1 * units.GiB,
-			AtLargeAllocSize:    1 * units.GiB,
-			NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
+		OutboundMsgThrottlerConfig: throttling.OutboundMsgThrottlerConfig{
+			MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
+				VdrAllocSize:        1 * units.GiB,
+				AtLargeAllocSize:    1 * units.GiB,
+				NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
+			},
 		},
 		MaxInboundConnsPerSec: 100,
 	}
@@ -89,6 +93,15 @@ var (
 		ThrottleRps:       100,
 		ConnectionTimeout: time.Second,
 	}
+	defaultReputationConfig = reputation.Config{
+		BanThreshold:              100,
+		BanDuration:               time.Hour,
+		ScoreHalflife:             time.Hour,
+		MaxScores:                 100,
+		SyncFrequency:             time.Minute,
+		ToleratedViolations:       10,
+		ToleratedViolationsWindow: time.Minute,
+	}
 
 	defaultConfig = Config{
 		HealthConfig:         defaultHealthConfig,
@@ -97,7 +110,8 @@ var (
 		DelayConfig:          defaultDelayConfig,
 		ThrottlerConfig:      defaultThrottlerConfig,
 
-		DialerConfig: defaultDialerConfig,
+		DialerConfig:     defaultDialerConfig,
+		ReputationConfig: defaultReputationConfig,
 
 		Namespace:          "",
 		NetworkID:          49463,
@@ -162,6 +176,16 @@ func newTestNetwork(t *testing.T, count int) (*testDialer, []*testListener, []id
 		config.MyIPPort = ip
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
 
+		reputationManager, err := reputation.NewManager(
+			config.ReputationConfig,
+			memdb.New(),
+			logging.NoLog{},
+			"",
+			prometheus.NewRegistry(),
+		)
+		assert.NoError(t, err)
+		config.ReputationManager = reputationManager
+
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
 		configs[i] = &config
@@ -176,6 +200,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 		true,
 		"",
 		10*time.Second,
+		nil,
 	)
 	assert.NoError(t, err)
 	return mc
@@ -355,3 +380,178 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBannedNodesNotAllowed(t *testing.T) {
+	assert := assert.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	nodeID := ids.GenerateTestNodeID()
+	err := network.config.Validators.AddWeight(constants.PrimaryNetworkID, nodeID, 1)
+	assert.NoError(err)
+	assert.True(network.AllowConnection(nodeID))
+	assert.True(network.WantsConnection(nodeID))
+
+	err = network.config.ReputationManager.BanNode(nodeID, time.Hour, "")
+	assert.NoError(err)
+	assert.False(network.AllowConnection(nodeID))
+	assert.False(network.WantsConnection(nodeID))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestPinnedPeersAndAllowedNodeIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	pinnedID := ids.GenerateTestNodeID()
+	allowedID := ids.GenerateTestNodeID()
+	otherID := ids.GenerateTestNodeID()
+	ip := ips.IPPort{
+		IP:   net.IPv4(1, 2, 3, 4),
+		Port: 9651,
+	}
+
+	// All nodes are allowed by default
+	assert.Empty(network.AllowedNodeIDs())
+	assert.True(network.AllowConnection(otherID))
+	assert.False(network.WantsConnection(pinnedID))
+
+	network.PinPeer(pinnedID, ip)
+	assert.Equal([]PinnedPeer{{NodeID: pinnedID, IP: ip}}, network.PinnedPeers())
+	assert.True(network.WantsConnection(pinnedID))
+
+	// Pinned peers are allowed even if they aren't in the allowed node IDs
+	network.SetAllowedNodeIDs([]ids.NodeID{allowedID})
+	assert.Equal([]ids.NodeID{allowedID}, network.AllowedNodeIDs())
+	assert.True(network.AllowConnection(pinnedID))
+	assert.True(network.AllowConnection(allowedID))
+	assert.False(network.AllowConnection(otherID))
+
+	// Banned nodes aren't allowed even if they are pinned
+	err := network.config.ReputationManager.BanNode(pinnedID, time.Hour, "")
+	assert.NoError(err)
+	assert.False(network.AllowConnection(pinnedID))
+	assert.False(network.WantsConnection(pinnedID))
+	err = network.config.ReputationManager.UnbanNode(pinnedID)
+	assert.NoError(err)
+
+	assert.True(network.UnpinPeer(pinnedID))
+	assert.False(network.UnpinPeer(pinnedID))
+	assert.Empty(network.PinnedPeers())
+	assert.False(network.AllowConnection(pinnedID))
+	assert.False(network.WantsConnection(pinnedID))
+
+	network.SetAllowedNodeIDs(nil)
+	assert.True(network.AllowConnection(otherID))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestPinnedPeersDialedOnDispatch(t *testing.T) {
+	assert := assert.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2)
+
+	config := configs[0]
+	config.Beacons = validators.NewSet()
+	config.Validators = validators.NewManager()
+	err := config.Validators.AddWeight(constants.PrimaryNetworkID, nodeIDs[0], 1)
+	assert.NoError(err)
+	config.PinnedPeers = []PinnedPeer{{
+		NodeID: nodeIDs[1],
+		IP:     configs[1].MyIPPort.IPPort(),
+	}}
+
+	net, err := NewNetwork(
+		config,
+		newMessageCreator(t),
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		listeners[0],
+		dialer,
+		&testHandler{},
+	)
+	assert.NoError(err)
+
+	network := net.(*network)
+	assert.Equal(config.PinnedPeers, network.PinnedPeers())
+
+	// Pinned peers aren't dialed before the network is dispatched
+	network.peersLock.RLock()
+	_, isTracked := network.trackedIPs[nodeIDs[1]]
+	network.peersLock.RUnlock()
+	assert.False(isTracked)
+
+	network.dialPinnedPeers()
+
+	network.peersLock.RLock()
+	_, isTracked = network.trackedIPs[nodeIDs[1]]
+	network.peersLock.RUnlock()
+	assert.True(isTracked)
+
+	network.StartClose()
+}
+
+func TestPinnedPeersNotBannedAutomatically(t *testing.T) {
+	assert := assert.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	pinnedID := ids.GenerateTestNodeID()
+	otherID := ids.GenerateTestNodeID()
+	ip := ips.IPPort{
+		IP:   net.IPv4(1, 2, 3, 4),
+		Port: 9651,
+	}
+	network.PinPeer(pinnedID, ip)
+
+	// Pinned peers aren't scored, so they're never banned automatically
+	for i := 0; i < 10; i++ {
+		network.Violated(pinnedID, reputation.InvalidSignedIP)
+		network.Violated(otherID, reputation.InvalidSignedIP)
+	}
+	assert.False(network.config.ReputationManager.IsBanned(pinnedID))
+	assert.True(network.config.ReputationManager.IsBanned(otherID))
+	assert.True(network.AllowConnection(pinnedID))
+
+	// Pinned peers that are banned manually keep being dialed, so that
+	// they're reconnected to once the ban is lifted
+	err := network.config.ReputationManager.BanNode(pinnedID, time.Hour, "")
+	assert.NoError(err)
+	assert.False(network.WantsConnection(pinnedID))
+
+	network.disconnectedFromConnecting(pinnedID)
+	network.peersLock.RLock()
+	tracked, isTracked := network.trackedIPs[pinnedID]
+	network.peersLock.RUnlock()
+	assert.True(isTracked)
+	assert.Equal(ip, tracked.ip.IP)
+
+	err = network.config.ReputationManager.UnbanNode(pinnedID)
+	assert.NoError(err)
+	assert.True(network.WantsConnection(pinnedID))
+
+	// Once unpinned, the peer is no longer dialed
+	assert.True(network.UnpinPeer(pinnedID))
+	network.disconnectedFromConnecting(pinnedID)
+	network.peersLock.RLock()
+	_, isTracked = network.trackedIPs[pinnedID]
+	network.peersLock.RUnlock()
+	assert.False(isTracked)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
@@ -45,4 +46,17 @@ type Config struct {
 	ResourceTracker tracker.ResourceTracker
 
 	PingMessage message.OutboundMessage
+
+	// If true, messages are sent protobuf encoded to peers that advertise
+	// message.ProtoCodecCapability
+	ProtoCodecEnabled bool
+
+	// Type messages are compressed with for peers that advertise support for
+	// it. Other peers are sent gzip compressed messages.
+	CompressionType compression.Type
+
+	// ZstdDictID is the ID of the configured zstd dictionary, or 0 if none is
+	// configured. Zstd compressed messages are compressed with the dictionary
+	// for peers that advertise the same ID.
+	ZstdDictID uint32
 }
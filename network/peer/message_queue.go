@@ -5,6 +5,7 @@ package peer
 
 import (
 	"context"
+	"math"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -16,6 +17,15 @@ import (
 var (
 	_ MessageQueue = &throttledMessageQueue{}
 	_ MessageQueue = &blockingMessageQueue{}
+
+	// ClassWeights is the share of the outbound bandwidth to a peer that each
+	// message class is given when messages of multiple classes are queued.
+	ClassWeights = map[message.Class]float64{
+		message.ConsensusClass: 8,
+		message.GossipClass:    4,
+		message.AppClass:       2,
+		message.BootstrapClass: 2,
+	}
 )
 
 type SendFailedCallback interface {
@@ -45,6 +55,17 @@ type MessageQueue interface {
 	Close()
 }
 
+type queuedMessage struct {
+	msg message.OutboundMessage
+	// Virtual time at which the message finishes being sent. Messages are
+	// popped in increasing order of their finish tags.
+	finishTag float64
+}
+
+// throttledMessageQueue queues the messages of each class separately and pops
+// them with self-clocked fair queueing. Each class gets a share of the bytes
+// sent proportional to its weight in [ClassWeights], so large or frequent
+// messages of one class don't delay the messages of the others.
 type throttledMessageQueue struct {
 	onFailed SendFailedCallback
 	// [id] of the peer we're sending messages to
@@ -60,9 +81,19 @@ type throttledMessageQueue struct {
 	// [cond.L] must be held while accessing [closed].
 	closed bool
 
-	// queue of the messages
-	// [cond.L] must be held while accessing [queue].
-	queue []message.OutboundMessage
+	// Message class --> queue of the messages of that class
+	// [cond.L] must be held while accessing [queues].
+	queues map[message.Class][]queuedMessage
+	// Number of messages in [queues]
+	// [cond.L] must be held while accessing [size].
+	size int
+	// Finish tag of the last message popped from the queue
+	// [cond.L] must be held while accessing [virtualTime].
+	virtualTime float64
+	// Message class --> Finish tag of the last message of that class pushed
+	// onto the queue
+	// [cond.L] must be held while accessing [lastFinishTags].
+	lastFinishTags map[message.Class]float64
 }
 
 func NewThrottledMessageQueue(
@@ -77,7 +108,9 @@ func NewThrottledMessageQueue(
 		log:                  log,
 		outboundMsgThrottler: outboundMsgThrottler,
 
-		cond: sync.NewCond(&sync.Mutex{}),
+		cond:           sync.NewCond(&sync.Mutex{}),
+		queues:         make(map[message.Class][]queuedMessage, len(message.Classes)),
+		lastFinishTags: make(map[message.Class]float64, len(message.Classes)),
 	}
 }
 
@@ -118,7 +151,15 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 		return false
 	}
 
-	q.queue = append(q.queue, msg)
+	class := msg.Op().Class()
+	startTag := math.Max(q.virtualTime, q.lastFinishTags[class])
+	finishTag := startTag + float64(len(msg.Bytes()))/ClassWeights[class]
+	q.lastFinishTags[class] = finishTag
+	q.queues[class] = append(q.queues[class], queuedMessage{
+		msg:       msg,
+		finishTag: finishTag,
+	})
+	q.size++
 	q.cond.Signal()
 	return true
 }
@@ -131,7 +172,7 @@ func (q *throttledMessageQueue) Pop() (message.OutboundMessage, bool) {
 		if q.closed {
 			return nil, false
 		}
-		if len(q.queue) > 0 {
+		if q.size > 0 {
 			// There is a message
 			break
 		}
@@ -146,7 +187,7 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
 
-	if len(q.queue) == 0 {
+	if q.size == 0 {
 		// There isn't a message
 		return nil, false
 	}
@@ -154,10 +195,41 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	return q.pop(), true
 }
 
+// pop removes the queued message with the lowest finish tag. Ties are broken
+// in favor of the class that comes first in [message.Classes].
+// Assumes [cond.L] is held and the queue isn't empty.
 func (q *throttledMessageQueue) pop() message.OutboundMessage {
-	msg := q.queue[0]
-	q.queue[0] = nil
-	q.queue = q.queue[1:]
+	var (
+		nextClass message.Class
+		next      *queuedMessage
+	)
+	for _, class := range message.Classes {
+		queue := q.queues[class]
+		if len(queue) == 0 {
+			continue
+		}
+		if next == nil || queue[0].finishTag < next.finishTag {
+			nextClass = class
+			next = &queue[0]
+		}
+	}
+
+	msg := next.msg
+	q.virtualTime = next.finishTag
+
+	queue := q.queues[nextClass]
+	queue[0] = queuedMessage{}
+	q.queues[nextClass] = queue[1:]
+	q.size--
+
+	// Once the queue is empty, no finish tag is referenced anymore, so the
+	// virtual clock can be reset.
+	if q.size == 0 {
+		q.virtualTime = 0
+		for class := range q.lastFinishTags {
+			delete(q.lastFinishTags, class)
+		}
+	}
 
 	q.outboundMsgThrottler.Release(msg, q.id)
 	return msg
@@ -169,11 +241,14 @@ func (q *throttledMessageQueue) Close() {
 
 	q.closed = true
 
-	for _, msg := range q.queue {
-		q.outboundMsgThrottler.Release(msg, q.id)
-		q.onFailed.SendFailed(msg)
+	for _, class := range message.Classes {
+		for _, queued := range q.queues[class] {
+			q.outboundMsgThrottler.Release(queued.msg, q.id)
+			q.onFailed.SendFailed(queued.msg)
+		}
 	}
-	q.queue = nil
+	q.queues = nil
+	q.size = 0
 
 	q.cond.Broadcast()
 }
@@ -9,7 +9,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -48,3 +50,108 @@ func TestBlockingMessageQueue(t *testing.T) {
 	_, ok = q.Pop()
 	assert.False(ok)
 }
+
+func TestThrottledMessageQueuePriority(t *testing.T) {
+	assert := assert.New(t)
+
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(msg message.OutboundMessage) {
+			t.Fail()
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	// Queue a large bootstrapping message and a gossip flood before a
+	// consensus message.
+	ancestors := message.NewTestMsg(message.Ancestors, make([]byte, 1024), false)
+	assert.True(q.Push(context.Background(), ancestors))
+	for i := 0; i < 10; i++ {
+		gossip := message.NewTestMsg(message.AppGossip, make([]byte, 128), false)
+		assert.True(q.Push(context.Background(), gossip))
+	}
+	chits := message.NewTestMsg(message.Chits, make([]byte, 64), false)
+	assert.True(q.Push(context.Background(), chits))
+
+	// The consensus message shouldn't wait behind the other classes.
+	msg, ok := q.PopNow()
+	assert.True(ok)
+	assert.Equal(message.Chits, msg.Op())
+
+	// The remaining classes share the bandwidth by weight, so the small
+	// gossip messages are sent before the large bootstrapping message.
+	ops := []message.Op{}
+	for {
+		msg, ok := q.PopNow()
+		if !ok {
+			break
+		}
+		ops = append(ops, msg.Op())
+	}
+	assert.Len(ops, 11)
+	assert.Equal(message.AppGossip, ops[0])
+	assert.NotEqual(message.Ancestors, ops[len(ops)-1])
+
+	q.Close()
+
+	_, ok = q.Pop()
+	assert.False(ok)
+}
+
+func TestThrottledMessageQueueFIFOWithinClass(t *testing.T) {
+	assert := assert.New(t)
+
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(msg message.OutboundMessage) {
+			t.Fail()
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	msgs := []message.OutboundMessage{
+		message.NewTestMsg(message.Put, make([]byte, 1024), false),
+		message.NewTestMsg(message.PushQuery, make([]byte, 16), false),
+		message.NewTestMsg(message.Chits, make([]byte, 32), false),
+	}
+	for _, msg := range msgs {
+		assert.True(q.Push(context.Background(), msg))
+	}
+	for _, expected := range msgs {
+		msg, ok := q.Pop()
+		assert.True(ok)
+		assert.Equal(expected, msg)
+	}
+
+	_, ok := q.PopNow()
+	assert.False(ok)
+}
+
+func TestThrottledMessageQueueClose(t *testing.T) {
+	assert := assert.New(t)
+
+	numFailed := 0
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(msg message.OutboundMessage) {
+			numFailed++
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	for _, op := range []message.Op{message.Chits, message.Ancestors, message.AppGossip, message.PeerList} {
+		assert.True(q.Push(context.Background(), message.NewTestMsg(op, make([]byte, 8), false)))
+	}
+
+	q.Close()
+	assert.Equal(4, numFailed)
+
+	assert.False(q.Push(context.Background(), message.NewTestMsg(message.Chits, nil, false)))
+	assert.Equal(5, numFailed)
+
+	_, ok := q.PopNow()
+	assert.False(ok)
+}
@@ -6,6 +6,7 @@ package peer
 import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
@@ -44,4 +45,7 @@ type Network interface {
 	// Pong provides the peer with a Pong message to send to the peer in
 	// response to a Ping message.
 	Pong(ids.NodeID) (message.OutboundMessage, error)
+
+	// Violated is called by the peer when it breaches the p2p protocol.
+	Violated(ids.NodeID, reputation.Violation)
 }
@@ -18,7 +18,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -138,6 +140,19 @@ type peer struct {
 	// Only modified on the connection's reader routine.
 	finishedHandshake utils.AtomicBool
 
+	// True if messages should be sent to this peer protobuf encoded.
+	// Only modified on the connection's reader routine.
+	sendProto utils.AtomicBool
+
+	// True if messages should be sent to this peer zstd compressed.
+	// Only modified on the connection's reader routine.
+	sendZstd utils.AtomicBool
+
+	// True if zstd compressed messages should be sent to this peer compressed
+	// with the configured dictionary.
+	// Only modified on the connection's reader routine.
+	sendZstdDict utils.AtomicBool
+
 	// onFinishHandshake is closed when the peer finishes the p2p handshake.
 	onFinishHandshake chan struct{}
 
@@ -251,6 +266,20 @@ func (p *peer) ObservedUptime() uint8 {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
+	// Pack the message with this peer's encoding before it's queued, so that
+	// it's throttled on the number of bytes that are sent to the peer.
+	if multiFormatMsg, ok := msg.(message.MultiFormatMessage); ok {
+		encodedMsg, err := multiFormatMsg.Encode(p.encoding())
+		if err != nil {
+			p.Log.Error(
+				"failed to encode %s message to %s: %s",
+				msg.Op(), p.id, err,
+			)
+			p.Metrics.SendFailed(msg)
+			return false
+		}
+		msg = encodedMsg
+	}
 	return p.messageQueue.Push(ctx, msg)
 }
 
@@ -407,6 +436,12 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.FailedToParse.Inc()
+			// Peers running a newer version may send ops, or compress
+			// messages in ways, that this node doesn't support, so only
+			// malformed messages of known ops are penalized.
+			if !errors.Is(err, message.ErrUnknownOp) && !errors.Is(err, message.ErrDecompression) {
+				p.Network.Violated(p.id, reputation.UnparseableMessage)
+			}
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -501,6 +536,17 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	p.Metrics.Sent(msg)
 }
 
+// encoding returns the encoding of the messages sent to this peer
+func (p *peer) encoding() message.Encoding {
+	encoding := message.DefaultEncoding
+	encoding.Proto = p.sendProto.GetValue()
+	if p.sendZstd.GetValue() {
+		encoding.Compression = compression.TypeZstd
+		encoding.ZstdDict = p.sendZstdDict.GetValue()
+	}
+	return encoding
+}
+
 func (p *peer) sendPings() {
 	sendPingsTicker := time.NewTicker(p.PingFrequency)
 	defer func() {
@@ -618,6 +664,7 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 				p.id, uint64(peerTime), uint64(myTime),
 			)
 		}
+		p.Network.Violated(p.id, reputation.ClockSkew)
 		p.StartClose()
 		return
 	}
@@ -656,6 +703,28 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 		return
 	}
 
+	// Messages are only sent protobuf encoded or zstd compressed once the peer
+	// has advertised that it can parse them, so older peers are always sent
+	// gzip compressed messages in the legacy format.
+	capabilities := message.Capability(msg.Get(message.Capabilities).(uint32))
+	if p.ProtoCodecEnabled && capabilities.Has(message.ProtoCodecCapability) {
+		p.sendProto.SetValue(true)
+	}
+	if p.CompressionType == compression.TypeZstd && capabilities.Has(message.ZstdCapability) {
+		p.sendZstd.SetValue(true)
+		// The dictionary is only used if the peer is configured with the same
+		// one. Otherwise, the peer is sent plain zstd compressed messages.
+		peerZstdDictID := capabilities.ZstdDictID()
+		switch {
+		case p.ZstdDictID != 0 && peerZstdDictID == p.ZstdDictID:
+			p.sendZstdDict.SetValue(true)
+		case p.ZstdDictID != 0 && peerZstdDictID != 0:
+			p.Log.Debug("peer %s is configured with a different zstd dictionary (%d != %d)",
+				p.id, peerZstdDictID, p.ZstdDictID,
+			)
+		}
+	}
+
 	// Note that it is expected that the [versionTime] can be in the past. We
 	// are just verifying that the claimed signing time isn't too far in the
 	// future here.
@@ -665,6 +734,7 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 			"peer %s attempting to connect with version timestamp (%d) too far in the future",
 			p.id, versionTime,
 		)
+		p.Network.Violated(p.id, reputation.ClockSkew)
 		p.StartClose()
 		return
 	}
@@ -700,6 +770,7 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 		p.Log.Debug("signature verification failed for %s: %s",
 			p.id, err,
 		)
+		p.Network.Violated(p.id, reputation.InvalidSignedIP)
 		p.StartClose()
 		return
 	}
@@ -17,11 +17,13 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -50,6 +52,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 		true,
 		"",
 		10*time.Second,
+		nil,
 	)
 	assert.NoError(t, err)
 	return mc
@@ -98,6 +101,8 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
 		PingMessage:          pingMessage,
+		ProtoCodecEnabled:    true,
+		CompressionType:      compression.TypeZstd,
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
@@ -269,6 +274,12 @@ func TestSend(t *testing.T) {
 	peer0, peer1 := makeReadyTestPeers(t)
 	mc := newMessageCreator(t)
 
+	// Both peers advertise that they can parse protobuf encoded and zstd
+	// compressed messages, but neither is configured with a zstd dictionary
+	assert.True(peer0.Peer.(*peer).sendProto.GetValue())
+	assert.True(peer0.Peer.(*peer).sendZstd.GetValue())
+	assert.False(peer0.Peer.(*peer).sendZstdDict.GetValue())
+
 	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
 	assert.NoError(err)
 
@@ -284,3 +295,59 @@ func TestSend(t *testing.T) {
 	err = peer1.AwaitClosed(context.Background())
 	assert.NoError(err)
 }
+
+// rawMessage is sent to a peer as is
+type rawMessage struct {
+	op    message.Op
+	bytes []byte
+}
+
+func (*rawMessage) BytesSavedCompression() int { return 0 }
+func (m *rawMessage) Bytes() []byte            { return m.bytes }
+func (m *rawMessage) Op() message.Op           { return m.op }
+func (*rawMessage) BypassThrottling() bool     { return true }
+func (*rawMessage) AddRef()                    {}
+func (*rawMessage) DecRef()                    {}
+
+// Test that only malformed messages of known ops are reported as violations,
+// so that peers running a newer version aren't penalized
+func TestUnparseableMessageViolations(t *testing.T) {
+	assert := assert.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t)
+	mc := newMessageCreator(t)
+
+	for _, msgBytes := range [][]byte{
+		// Unknown legacy op
+		{0x7f},
+		// Unknown protobuf message type, in field 100
+		{0xa0, 0x06, 0x01},
+		// Payload that can't be decompressed
+		{byte(message.Put), byte(compression.TypeZstd), 1, 2, 3},
+		// Malformed message of a known op
+		{byte(message.Get)},
+	} {
+		sent := peer1.Send(context.Background(), &rawMessage{
+			op:    message.Get,
+			bytes: msgBytes,
+		})
+		assert.True(sent)
+	}
+
+	// The messages are parsed in order, so once a valid message is received,
+	// the previous ones have been parsed.
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	assert.NoError(err)
+	assert.True(peer1.Send(context.Background(), outboundGetMsg))
+	inboundGetMsg := <-peer0.inboundMsgChan
+	assert.Equal(message.Get, inboundGetMsg.Op())
+
+	network := peer0.Peer.(*peer).Network.(*testNetwork)
+	assert.Equal([]reputation.Violation{reputation.UnparseableMessage}, network.Violations())
+
+	peer0.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	assert.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	assert.NoError(err)
+}
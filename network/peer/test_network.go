@@ -5,10 +5,12 @@ package peer
 
 import (
 	"crypto"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/version"
 )
@@ -26,6 +28,9 @@ type testNetwork struct {
 	subnets   ids.Set
 
 	uptime uint8
+
+	violationsLock sync.Mutex
+	violations     []reputation.Violation
 }
 
 // NewTestNetwork creates and returns a new TestNetwork
@@ -85,3 +90,18 @@ func (n *testNetwork) Peers() (message.OutboundMessage, error) {
 func (n *testNetwork) Pong(ids.NodeID) (message.OutboundMessage, error) {
 	return n.mc.Pong(n.uptime)
 }
+
+func (n *testNetwork) Violated(_ ids.NodeID, violation reputation.Violation) {
+	n.violationsLock.Lock()
+	defer n.violationsLock.Unlock()
+
+	n.violations = append(n.violations, violation)
+}
+
+// Violations returns the violations reported to the network
+func (n *testNetwork) Violations() []reputation.Violation {
+	n.violationsLock.Lock()
+	defer n.violationsLock.Unlock()
+
+	return append([]reputation.Violation{}, n.violations...)
+}
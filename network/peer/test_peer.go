@@ -72,6 +72,7 @@ func StartTestPeer(
 		true,
 		"",
 		10*time.Second,
+		nil,
 	)
 	if err != nil {
 		return nil, err
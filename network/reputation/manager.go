@@ -0,0 +1,707 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	maxReasonLen = 1024
+
+	// Scores that have decayed below this are dropped
+	minScore = 1
+)
+
+var (
+	scorePrefix   = []byte("score")
+	nodeBanPrefix = []byte("nodeBan")
+	ipBanPrefix   = []byte("ipBan")
+
+	errInvalidIP        = errors.New("invalid IP")
+	errNegativeDuration = errors.New("ban duration must be non-negative")
+	errReasonTooLong    = errors.New("ban reason is too long")
+	errNotBanned        = errors.New("not banned")
+
+	_ Manager = &manager{}
+)
+
+// Manager scores nodes by the protocol violations they commit and bans nodes
+// whose score gets too high. Nodes and IPs can also be banned manually. Scores
+// and bans are persisted, so they survive restarts. Manual bans are persisted
+// immediately, while scores and automatic bans are persisted by Sync.
+type Manager interface {
+	// Violated records that [nodeID] committed [violation]. If this raises the
+	// node's score to the ban threshold, the node is banned.
+	Violated(nodeID ids.NodeID, violation Violation)
+
+	// IsBanned returns true if [nodeID] is currently banned
+	IsBanned(nodeID ids.NodeID) bool
+
+	// IsIPBanned returns true if [ip] is currently banned
+	IsIPBanned(ip net.IP) bool
+
+	// BanNode bans [nodeID] for [duration]. If [duration] is 0, the configured
+	// ban duration is used. Replaces any existing ban of [nodeID].
+	BanNode(nodeID ids.NodeID, duration time.Duration, reason string) error
+
+	// BanIP bans [ip] for [duration]. If [duration] is 0, the configured ban
+	// duration is used. Replaces any existing ban of [ip].
+	BanIP(ip net.IP, duration time.Duration, reason string) error
+
+	// UnbanNode removes the ban of [nodeID] and resets its score
+	UnbanNode(nodeID ids.NodeID) error
+
+	// UnbanIP removes the ban of [ip]
+	UnbanIP(ip net.IP) error
+
+	// Bans returns the bans that haven't expired, ordered by expiry
+	Bans() []Ban
+
+	// Sync persists the scores and automatic bans that changed since the last
+	// call, and deletes the expired bans and the scores that have decayed to
+	// nothing. It should be called every [SyncFrequency] and before shutting
+	// down.
+	Sync() error
+}
+
+// Config defines the configuration of the reputation manager
+type Config struct {
+	// Score at which a node is banned. If 0, nodes are never banned because
+	// of their score.
+	BanThreshold float64 `json:"banThreshold"`
+
+	// Duration of the bans of nodes whose score reached [BanThreshold], and of
+	// the bans requested without a duration
+	BanDuration time.Duration `json:"banDuration"`
+
+	// Maximum number of scores kept. Once reached, the score of the node that
+	// least recently committed a violation is dropped.
+	MaxScores int `json:"maxScores"`
+
+	// Frequency that scores and automatic bans are persisted at, and that
+	// expired bans and decayed scores are deleted at
+	SyncFrequency time.Duration `json:"syncFrequency"`
+
+	// Time it takes for a node's score to decay by half
+	ScoreHalflife time.Duration `json:"scoreHalflife"`
+
+	// Number of times a node can commit a tolerated violation, such as clock
+	// skew or a timeout, within [ToleratedViolationsWindow] before it's
+	// penalized for it
+	ToleratedViolations int `json:"toleratedViolations"`
+
+	// Duration of the window the tolerated violations of a node are counted
+	// in. A new window starts with the first violation after the previous
+	// window ended.
+	ToleratedViolationsWindow time.Duration `json:"toleratedViolationsWindow"`
+}
+
+// Ban describes the ban of either a node or an IP
+type Ban struct {
+	// Banned node. Empty if an IP is banned.
+	NodeID ids.NodeID
+	// Banned IP. Nil if a node is banned.
+	IP     net.IP
+	Reason string
+	Expiry time.Time
+}
+
+type score struct {
+	value float64
+	// Time [value] was last updated at
+	updated time.Time
+}
+
+type ban struct {
+	reason string
+	expiry time.Time
+}
+
+type occurrencesKey struct {
+	nodeID    ids.NodeID
+	violation Violation
+}
+
+// occurrences of a tolerated violation in the window that started at
+// [windowStart]
+type occurrences struct {
+	windowStart time.Time
+	count       int
+}
+
+type manager struct {
+	config  Config
+	log     logging.Logger
+	metrics metrics
+	clock   mockable.Clock
+
+	lock      sync.RWMutex
+	scoreDB   database.Database
+	nodeBanDB database.Database
+	ipBanDB   database.Database
+	// Node ID --> Score of the node, as of the time it was last updated.
+	// Ordered from least to most recently updated.
+	scores linkedhashmap.LinkedHashmap
+	// Nodes whose score changed since the last sync
+	dirtyScores ids.NodeIDSet
+	// Nodes that were automatically banned since the last sync
+	dirtyNodeBans ids.NodeIDSet
+	// Node ID and tolerated violation --> Occurrences of the violation in
+	// the current window. Ordered from least to most recently committed. Not
+	// persisted.
+	occurrences linkedhashmap.LinkedHashmap
+	// Node ID --> Ban of the node
+	nodeBans map[ids.NodeID]*ban
+	// IP, in its 16 byte representation --> Ban of the IP
+	ipBans map[string]*ban
+}
+
+// NewManager returns a reputation manager that persists scores and bans to
+// [db]. The scores and bans previously persisted to [db] are loaded.
+func NewManager(
+	config Config,
+	db database.Database,
+	log logging.Logger,
+	namespace string,
+	registerer prometheus.Registerer,
+) (Manager, error) {
+	m := &manager{
+		config:      config,
+		log:         log,
+		scoreDB:     prefixdb.New(scorePrefix, db),
+		nodeBanDB:   prefixdb.New(nodeBanPrefix, db),
+		ipBanDB:     prefixdb.New(ipBanPrefix, db),
+		scores:      linkedhashmap.New(),
+		occurrences: linkedhashmap.New(),
+		nodeBans:    make(map[ids.NodeID]*ban),
+		ipBans:      make(map[string]*ban),
+	}
+	if err := m.metrics.initialize(namespace, registerer); err != nil {
+		return nil, err
+	}
+	if err := m.load(); err != nil {
+		return nil, fmt.Errorf("couldn't load peer reputations: %w", err)
+	}
+	return m, nil
+}
+
+func (m *manager) Violated(nodeID ids.NodeID, violation Violation) {
+	m.metrics.violations.WithLabelValues(violation.String()).Inc()
+
+	penalty := violation.Penalty()
+	if penalty == 0 {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Time()
+	if violation.Tolerated() && m.tolerate(nodeID, violation, now) {
+		m.log.Debug("tolerating violation %s committed by %s", violation, nodeID)
+		return
+	}
+
+	value := m.score(nodeID, now) + penalty
+	m.log.Debug(
+		"%s committed violation %s, increasing its score to %.2f",
+		nodeID, violation, value,
+	)
+
+	m.dirtyScores.Add(nodeID)
+	if m.config.BanThreshold <= 0 || value < m.config.BanThreshold {
+		m.putScore(nodeID, &score{
+			value:   value,
+			updated: now,
+		})
+		return
+	}
+
+	// The node is being banned, so its score starts over.
+	m.scores.Delete(nodeID)
+
+	expiry := now.Add(m.config.BanDuration)
+	if b, ok := m.nodeBans[nodeID]; ok && !b.expiry.Before(expiry) {
+		// Don't shorten an existing ban
+		return
+	}
+
+	m.log.Info(
+		"banning %s until %s because its score reached %.2f",
+		nodeID, expiry, value,
+	)
+	m.metrics.bans.Inc()
+	m.nodeBans[nodeID] = &ban{
+		reason: fmt.Sprintf("score reached %.2f after violation %s", value, violation),
+		expiry: expiry,
+	}
+	m.dirtyNodeBans.Add(nodeID)
+}
+
+func (m *manager) IsBanned(nodeID ids.NodeID) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	b, ok := m.nodeBans[nodeID]
+	return ok && m.clock.Time().Before(b.expiry)
+}
+
+func (m *manager) IsIPBanned(ip net.IP) bool {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return false
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	b, ok := m.ipBans[string(ip16)]
+	return ok && m.clock.Time().Before(b.expiry)
+}
+
+func (m *manager) BanNode(nodeID ids.NodeID, duration time.Duration, reason string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	b, err := m.newBan(duration, reason)
+	if err != nil {
+		return err
+	}
+	if err := m.putBan(m.nodeBanDB, nodeID[:], b); err != nil {
+		return err
+	}
+	m.nodeBans[nodeID] = b
+	m.log.Info("banned %s until %s: %s", nodeID, b.expiry, reason)
+	return nil
+}
+
+func (m *manager) BanIP(ip net.IP, duration time.Duration, reason string) error {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return errInvalidIP
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	b, err := m.newBan(duration, reason)
+	if err != nil {
+		return err
+	}
+	if err := m.putBan(m.ipBanDB, ip16, b); err != nil {
+		return err
+	}
+	m.ipBans[string(ip16)] = b
+	m.log.Info("banned %s until %s: %s", ip, b.expiry, reason)
+	return nil
+}
+
+func (m *manager) UnbanNode(nodeID ids.NodeID) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.nodeBans[nodeID]; !ok {
+		return fmt.Errorf("%s is %w", nodeID, errNotBanned)
+	}
+	if err := m.nodeBanDB.Delete(nodeID[:]); err != nil {
+		return err
+	}
+	delete(m.nodeBans, nodeID)
+
+	// Give the node a clean slate so that it isn't immediately banned again
+	if err := m.scoreDB.Delete(nodeID[:]); err != nil {
+		return err
+	}
+	m.scores.Delete(nodeID)
+	m.dirtyScores.Remove(nodeID)
+	m.dirtyNodeBans.Remove(nodeID)
+	for _, violation := range Violations {
+		m.occurrences.Delete(occurrencesKey{nodeID: nodeID, violation: violation})
+	}
+
+	m.log.Info("unbanned %s", nodeID)
+	return nil
+}
+
+func (m *manager) UnbanIP(ip net.IP) error {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return errInvalidIP
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.ipBans[string(ip16)]; !ok {
+		return fmt.Errorf("%s is %w", ip, errNotBanned)
+	}
+	if err := m.ipBanDB.Delete(ip16); err != nil {
+		return err
+	}
+	delete(m.ipBans, string(ip16))
+
+	m.log.Info("unbanned %s", ip)
+	return nil
+}
+
+func (m *manager) Bans() []Ban {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	now := m.clock.Time()
+	bans := make([]Ban, 0, len(m.nodeBans)+len(m.ipBans))
+	for nodeID, b := range m.nodeBans {
+		if !now.Before(b.expiry) {
+			continue
+		}
+		bans = append(bans, Ban{
+			NodeID: nodeID,
+			Reason: b.reason,
+			Expiry: b.expiry,
+		})
+	}
+	for ipStr, b := range m.ipBans {
+		if !now.Before(b.expiry) {
+			continue
+		}
+		bans = append(bans, Ban{
+			IP:     net.IP(ipStr),
+			Reason: b.reason,
+			Expiry: b.expiry,
+		})
+	}
+	sort.SliceStable(bans, func(i, j int) bool {
+		return bans[i].Expiry.Before(bans[j].Expiry)
+	})
+	return bans
+}
+
+func (m *manager) Sync() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Time()
+
+	var decayed []ids.NodeID
+	it := m.scores.NewIterator()
+	for it.Next() {
+		if m.decay(it.Value().(*score), now) < minScore {
+			decayed = append(decayed, it.Key().(ids.NodeID))
+		}
+	}
+	for _, nodeID := range decayed {
+		m.scores.Delete(nodeID)
+		m.dirtyScores.Add(nodeID)
+	}
+
+	scoreBatch := m.scoreDB.NewBatch()
+	for nodeID := range m.dirtyScores {
+		nodeID := nodeID
+		sIntf, ok := m.scores.Get(nodeID)
+		if !ok {
+			if err := scoreBatch.Delete(nodeID[:]); err != nil {
+				return err
+			}
+			continue
+		}
+		s := sIntf.(*score)
+		if err := scoreBatch.Put(nodeID[:], marshalScore(s.value, s.updated)); err != nil {
+			return err
+		}
+	}
+
+	nodeBanBatch := m.nodeBanDB.NewBatch()
+	for nodeID, b := range m.nodeBans {
+		nodeID := nodeID
+		if !now.Before(b.expiry) {
+			delete(m.nodeBans, nodeID)
+			if err := nodeBanBatch.Delete(nodeID[:]); err != nil {
+				return err
+			}
+			continue
+		}
+		if !m.dirtyNodeBans.Contains(nodeID) {
+			continue
+		}
+		if err := m.putBan(nodeBanBatch, nodeID[:], b); err != nil {
+			return err
+		}
+	}
+
+	ipBanBatch := m.ipBanDB.NewBatch()
+	for ipStr, b := range m.ipBans {
+		if !now.Before(b.expiry) {
+			delete(m.ipBans, ipStr)
+			if err := ipBanBatch.Delete([]byte(ipStr)); err != nil {
+				return err
+			}
+		}
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		scoreBatch.Write(),
+		nodeBanBatch.Write(),
+		ipBanBatch.Write(),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+	m.dirtyScores.Clear()
+	m.dirtyNodeBans.Clear()
+	return nil
+}
+
+// score returns the score of [nodeID] at [now], taking into account how much
+// it has decayed since it was last updated.
+// Assumes [m.lock] is held.
+func (m *manager) score(nodeID ids.NodeID, now time.Time) float64 {
+	s, ok := m.scores.Get(nodeID)
+	if !ok {
+		return 0
+	}
+	return m.decay(s.(*score), now)
+}
+
+// putScore sets the score of [nodeID] to [s], and drops the least recently
+// updated scores if there are more than [MaxScores].
+// Assumes [m.lock] is held.
+func (m *manager) putScore(nodeID ids.NodeID, s *score) {
+	m.scores.Put(nodeID, s)
+	for m.scores.Len() > m.config.MaxScores {
+		oldestID, _, _ := m.scores.Oldest()
+		m.scores.Delete(oldestID)
+		m.dirtyScores.Add(oldestID.(ids.NodeID))
+	}
+}
+
+// tolerate records that [nodeID] committed the tolerated [violation] at [now].
+// Returns true if the node committed it at most [ToleratedViolations] times in
+// the current window, so that it shouldn't be penalized.
+// Assumes [m.lock] is held.
+func (m *manager) tolerate(nodeID ids.NodeID, violation Violation, now time.Time) bool {
+	key := occurrencesKey{nodeID: nodeID, violation: violation}
+	o := &occurrences{windowStart: now}
+	if value, ok := m.occurrences.Get(key); ok {
+		if current := value.(*occurrences); now.Sub(current.windowStart) < m.config.ToleratedViolationsWindow {
+			o = current
+		}
+	}
+	o.count++
+
+	m.occurrences.Put(key, o)
+	for m.occurrences.Len() > m.config.MaxScores {
+		oldestKey, _, _ := m.occurrences.Oldest()
+		m.occurrences.Delete(oldestKey)
+	}
+	return o.count <= m.config.ToleratedViolations
+}
+
+// decay returns the value of [s] at [now]
+func (m *manager) decay(s *score, now time.Time) float64 {
+	elapsed := now.Sub(s.updated)
+	if m.config.ScoreHalflife <= 0 || elapsed <= 0 {
+		return s.value
+	}
+	return s.value * math.Exp2(-float64(elapsed)/float64(m.config.ScoreHalflife))
+}
+
+// newBan returns a ban starting now that lasts for [duration], or for the
+// configured ban duration if [duration] is 0.
+// Assumes [m.lock] is held.
+func (m *manager) newBan(duration time.Duration, reason string) (*ban, error) {
+	switch {
+	case duration < 0:
+		return nil, errNegativeDuration
+	case len(reason) > maxReasonLen:
+		return nil, errReasonTooLong
+	case duration == 0:
+		duration = m.config.BanDuration
+	}
+	return &ban{
+		reason: reason,
+		expiry: m.clock.Time().Add(duration),
+	}, nil
+}
+
+func (m *manager) putBan(db database.KeyValueWriter, key []byte, b *ban) error {
+	p := wrappers.Packer{MaxSize: wrappers.LongLen + wrappers.ShortLen + len(b.reason)}
+	p.PackLong(uint64(b.expiry.Unix()))
+	p.PackStr(b.reason)
+	if p.Errored() {
+		return p.Err
+	}
+	return db.Put(key, p.Bytes)
+}
+
+// load the persisted scores and bans. Expired bans and scores that have
+// decayed to nothing are deleted.
+func (m *manager) load() error {
+	now := m.clock.Time()
+
+	expiredScores, err := m.loadScores(now)
+	if err != nil {
+		return err
+	}
+	expiredNodeBans, err := m.loadNodeBans(now)
+	if err != nil {
+		return err
+	}
+	expiredIPBans, err := m.loadIPBans(now)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range expiredScores {
+		if err := m.scoreDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	for _, key := range expiredNodeBans {
+		if err := m.nodeBanDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	for _, key := range expiredIPBans {
+		if err := m.ipBanDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadScores loads the scores that haven't decayed to nothing by [now], and
+// returns the keys of those that have. If there are more than [MaxScores]
+// scores, the least recently updated ones are dropped on the next sync.
+func (m *manager) loadScores(now time.Time) ([][]byte, error) {
+	it := m.scoreDB.NewIterator()
+	defer it.Release()
+
+	var (
+		expired [][]byte
+		nodeIDs []ids.NodeID
+		scores  = make(map[ids.NodeID]*score)
+	)
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		s, err := unmarshalScore(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse the score of %s: %w", nodeID, err)
+		}
+		if m.decay(s, now) < minScore {
+			expired = append(expired, nodeID[:])
+			continue
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+		scores[nodeID] = s
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(nodeIDs, func(i, j int) bool {
+		return scores[nodeIDs[i]].updated.Before(scores[nodeIDs[j]].updated)
+	})
+	for _, nodeID := range nodeIDs {
+		m.putScore(nodeID, scores[nodeID])
+	}
+	return expired, nil
+}
+
+// loadNodeBans loads the bans of nodes that haven't expired by [now], and
+// returns the keys of those that have.
+func (m *manager) loadNodeBans(now time.Time) ([][]byte, error) {
+	it := m.nodeBanDB.NewIterator()
+	defer it.Release()
+
+	var expired [][]byte
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		b, err := unmarshalBan(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse the ban of %s: %w", nodeID, err)
+		}
+		if !now.Before(b.expiry) {
+			expired = append(expired, nodeID[:])
+			continue
+		}
+		m.nodeBans[nodeID] = b
+	}
+	return expired, it.Error()
+}
+
+// loadIPBans loads the bans of IPs that haven't expired by [now], and returns
+// the keys of those that have.
+func (m *manager) loadIPBans(now time.Time) ([][]byte, error) {
+	it := m.ipBanDB.NewIterator()
+	defer it.Release()
+
+	var expired [][]byte
+	for it.Next() {
+		ip16 := net.IP(it.Key())
+		if len(ip16) != net.IPv6len {
+			return nil, fmt.Errorf("%w: %x", errInvalidIP, []byte(ip16))
+		}
+		b, err := unmarshalBan(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse the ban of %s: %w", ip16, err)
+		}
+		if !now.Before(b.expiry) {
+			expired = append(expired, ip16)
+			continue
+		}
+		m.ipBans[string(ip16)] = b
+	}
+	return expired, it.Error()
+}
+
+func marshalScore(value float64, updated time.Time) []byte {
+	p := wrappers.Packer{MaxSize: 2 * wrappers.LongLen}
+	p.PackLong(math.Float64bits(value))
+	p.PackLong(uint64(updated.Unix()))
+	return p.Bytes
+}
+
+func unmarshalScore(bytes []byte) (*score, error) {
+	p := wrappers.Packer{Bytes: bytes}
+	value := math.Float64frombits(p.UnpackLong())
+	updated := time.Unix(int64(p.UnpackLong()), 0)
+	return &score{
+		value:   value,
+		updated: updated,
+	}, p.Err
+}
+
+func unmarshalBan(bytes []byte) (*ban, error) {
+	p := wrappers.Packer{Bytes: bytes}
+	expiry := time.Unix(int64(p.UnpackLong()), 0)
+	reason := p.UnpackStr()
+	return &ban{
+		reason: reason,
+		expiry: expiry,
+	}, p.Err
+}
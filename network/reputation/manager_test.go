@@ -0,0 +1,298 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var testConfig = Config{
+	BanThreshold:              100,
+	BanDuration:               time.Hour,
+	ScoreHalflife:             time.Minute,
+	MaxScores:                 10,
+	SyncFrequency:             time.Minute,
+	ToleratedViolations:       3,
+	ToleratedViolationsWindow: time.Minute,
+}
+
+func newTestManager(t *testing.T, db database.Database, now time.Time) *manager {
+	m, err := NewManager(testConfig, db, logging.NoLog{}, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+	manager := m.(*manager)
+	manager.clock.Set(now)
+	return manager
+}
+
+func TestViolationsBan(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	m := newTestManager(t, memdb.New(), now)
+	nodeID := ids.GenerateTestNodeID()
+
+	m.Violated(nodeID, InvalidSignedIP)
+	assert.False(m.IsBanned(nodeID))
+	assert.Equal(InvalidSignedIP.Penalty(), m.score(nodeID, now))
+
+	// The score decays by half every halflife
+	now = now.Add(testConfig.ScoreHalflife)
+	m.clock.Set(now)
+	assert.InDelta(InvalidSignedIP.Penalty()/2, m.score(nodeID, now), 0.001)
+
+	m.Violated(nodeID, InvalidSignedIP)
+	assert.False(m.IsBanned(nodeID))
+
+	m.Violated(nodeID, InvalidSignedIP)
+	assert.True(m.IsBanned(nodeID))
+	assert.Zero(m.score(nodeID, now))
+
+	bans := m.Bans()
+	assert.Len(bans, 1)
+	assert.Equal(nodeID, bans[0].NodeID)
+	assert.Nil(bans[0].IP)
+	assert.Equal(now.Add(testConfig.BanDuration), bans[0].Expiry)
+
+	// The ban expires
+	m.clock.Set(now.Add(testConfig.BanDuration))
+	assert.False(m.IsBanned(nodeID))
+	assert.Empty(m.Bans())
+}
+
+func TestNoBanThreshold(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig
+	config.BanThreshold = 0
+	m, err := NewManager(config, memdb.New(), logging.NoLog{}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	nodeID := ids.GenerateTestNodeID()
+
+	for i := 0; i < 10; i++ {
+		m.Violated(nodeID, InvalidSignedIP)
+	}
+	assert.False(m.IsBanned(nodeID))
+}
+
+func TestManualBans(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	m := newTestManager(t, memdb.New(), now)
+	nodeID := ids.GenerateTestNodeID()
+	ip := net.IPv4(1, 2, 3, 4)
+
+	assert.NoError(m.BanNode(nodeID, 0, "misbehaving"))
+	assert.NoError(m.BanIP(ip, 2*time.Hour, "spamming"))
+	assert.True(m.IsBanned(nodeID))
+	assert.True(m.IsIPBanned(ip))
+	assert.True(m.IsIPBanned(ip.To4()))
+	assert.False(m.IsIPBanned(net.IPv4(1, 2, 3, 5)))
+
+	bans := m.Bans()
+	assert.Len(bans, 2)
+	assert.Equal(Ban{
+		NodeID: nodeID,
+		Reason: "misbehaving",
+		Expiry: now.Add(testConfig.BanDuration),
+	}, bans[0])
+	assert.True(ip.Equal(bans[1].IP))
+	assert.Equal("spamming", bans[1].Reason)
+	assert.Equal(now.Add(2*time.Hour), bans[1].Expiry)
+
+	assert.ErrorIs(m.BanNode(nodeID, -time.Second, ""), errNegativeDuration)
+	assert.ErrorIs(m.BanIP(net.IP{1}, time.Second, ""), errInvalidIP)
+	assert.ErrorIs(m.BanNode(nodeID, time.Second, string(make([]byte, maxReasonLen+1))), errReasonTooLong)
+
+	assert.NoError(m.UnbanNode(nodeID))
+	assert.NoError(m.UnbanIP(ip))
+	assert.False(m.IsBanned(nodeID))
+	assert.False(m.IsIPBanned(ip))
+	assert.Empty(m.Bans())
+
+	assert.ErrorIs(m.UnbanNode(nodeID), errNotBanned)
+	assert.ErrorIs(m.UnbanIP(ip), errNotBanned)
+}
+
+func TestPersistence(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(time.Now().Unix(), 0)
+	db := memdb.New()
+	m := newTestManager(t, db, now)
+
+	scoredID := ids.GenerateTestNodeID()
+	bannedID := ids.GenerateTestNodeID()
+	expiredID := ids.GenerateTestNodeID()
+	ip := net.IPv6loopback
+	m.Violated(scoredID, UnparseableMessage)
+	assert.NoError(m.BanNode(bannedID, time.Hour, "banned"))
+	assert.NoError(m.BanNode(expiredID, time.Second, "expired"))
+	assert.NoError(m.BanIP(ip, time.Hour, "banned"))
+	assert.NoError(m.Sync())
+
+	// The manager is restarted with the clock after the short ban expired
+	restartedIntf, err := NewManager(testConfig, db, logging.NoLog{}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	restarted := restartedIntf.(*manager)
+	restarted.clock.Set(now.Add(time.Second))
+
+	assert.Equal(UnparseableMessage.Penalty(), restarted.score(scoredID, now))
+	assert.True(restarted.IsBanned(bannedID))
+	assert.False(restarted.IsBanned(expiredID))
+	assert.True(restarted.IsIPBanned(ip))
+	assert.Len(restarted.Bans(), 2)
+
+	// The expired ban is deleted by the next sync
+	assert.NoError(restarted.Sync())
+	has, err := prefixdb.New(nodeBanPrefix, db).Has(expiredID[:])
+	assert.NoError(err)
+	assert.False(has)
+}
+
+func TestToleratedViolations(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	m := newTestManager(t, memdb.New(), now)
+	nodeID := ids.GenerateTestNodeID()
+
+	// Honest nodes may have a skewed clock or be overloaded, so these
+	// violations are tolerated a few times per window
+	for i := 0; i < testConfig.ToleratedViolations; i++ {
+		m.Violated(nodeID, ClockSkew)
+		m.Violated(nodeID, Timeout)
+	}
+	assert.Zero(m.score(nodeID, now))
+	assert.Zero(m.scores.Len())
+
+	m.Violated(nodeID, ClockSkew)
+	assert.Equal(ClockSkew.Penalty(), m.score(nodeID, now))
+	m.Violated(nodeID, Timeout)
+	assert.Equal(ClockSkew.Penalty()+Timeout.Penalty(), m.score(nodeID, now))
+
+	// Once the window ends, the violations are tolerated again
+	now = now.Add(testConfig.ToleratedViolationsWindow)
+	m.clock.Set(now)
+	value := m.score(nodeID, now)
+	for i := 0; i < testConfig.ToleratedViolations; i++ {
+		m.Violated(nodeID, ClockSkew)
+	}
+	assert.Equal(value, m.score(nodeID, now))
+	m.Violated(nodeID, ClockSkew)
+	assert.Equal(value+ClockSkew.Penalty(), m.score(nodeID, now))
+
+	// Every node's violations are counted separately
+	otherNodeID := ids.GenerateTestNodeID()
+	m.Violated(otherNodeID, ClockSkew)
+	assert.Zero(m.score(otherNodeID, now))
+}
+
+func TestMaxScores(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(time.Now().Unix(), 0)
+	db := memdb.New()
+	m := newTestManager(t, db, now)
+	scoreDB := prefixdb.New(scorePrefix, db)
+
+	nodeIDs := make([]ids.NodeID, testConfig.MaxScores+1)
+	for i := range nodeIDs {
+		nodeIDs[i] = ids.GenerateTestNodeID()
+		m.Violated(nodeIDs[i], UnparseableMessage)
+	}
+	assert.NoError(m.Sync())
+
+	// The score of the node that least recently committed a violation is
+	// dropped
+	assert.Equal(testConfig.MaxScores, m.scores.Len())
+	assert.Zero(m.score(nodeIDs[0], now))
+	has, err := scoreDB.Has(nodeIDs[0][:])
+	assert.NoError(err)
+	assert.False(has)
+	for _, nodeID := range nodeIDs[1:] {
+		assert.Equal(UnparseableMessage.Penalty(), m.score(nodeID, now))
+		has, err := scoreDB.Has(nodeID[:])
+		assert.NoError(err)
+		assert.True(has)
+	}
+
+	// Updating a score makes it the most recently updated
+	m.Violated(nodeIDs[1], UnparseableMessage)
+	m.Violated(nodeIDs[0], UnparseableMessage)
+	assert.Equal(2*UnparseableMessage.Penalty(), m.score(nodeIDs[1], now))
+	assert.Zero(m.score(nodeIDs[2], now))
+}
+
+func TestSync(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Unix(time.Now().Unix(), 0)
+	db := memdb.New()
+	m := newTestManager(t, db, now)
+	scoreDB := prefixdb.New(scorePrefix, db)
+	nodeBanDB := prefixdb.New(nodeBanPrefix, db)
+	ipBanDB := prefixdb.New(ipBanPrefix, db)
+
+	scoredID := ids.GenerateTestNodeID()
+	bannedID := ids.GenerateTestNodeID()
+	ip := net.IPv4(1, 2, 3, 4)
+
+	// Scores and automatic bans aren't persisted until the next sync
+	m.Violated(scoredID, UnparseableMessage)
+	m.Violated(bannedID, InvalidSignedIP)
+	m.Violated(bannedID, InvalidSignedIP)
+	assert.True(m.IsBanned(bannedID))
+	assert.NoError(m.BanIP(ip, time.Minute, "banned"))
+
+	has, err := scoreDB.Has(scoredID[:])
+	assert.NoError(err)
+	assert.False(has)
+	has, err = nodeBanDB.Has(bannedID[:])
+	assert.NoError(err)
+	assert.False(has)
+
+	assert.NoError(m.Sync())
+	has, err = scoreDB.Has(scoredID[:])
+	assert.NoError(err)
+	assert.True(has)
+	has, err = nodeBanDB.Has(bannedID[:])
+	assert.NoError(err)
+	assert.True(has)
+
+	// Expired bans are no longer reported, but are only deleted by the next
+	// sync
+	now = now.Add(testConfig.BanDuration)
+	m.clock.Set(now)
+	assert.Empty(m.Bans())
+	assert.Len(m.nodeBans, 1)
+	assert.Len(m.ipBans, 1)
+
+	// By then, the score has also decayed to nothing
+	assert.NoError(m.Sync())
+	assert.Empty(m.nodeBans)
+	assert.Empty(m.ipBans)
+	assert.Zero(m.scores.Len())
+	has, err = scoreDB.Has(scoredID[:])
+	assert.NoError(err)
+	assert.False(has)
+	has, err = nodeBanDB.Has(bannedID[:])
+	assert.NoError(err)
+	assert.False(has)
+	has, err = ipBanDB.Has(ip.To16())
+	assert.NoError(err)
+	assert.False(has)
+}
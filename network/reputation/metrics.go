@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	violations *prometheus.CounterVec
+	bans       prometheus.Counter
+}
+
+func (m *metrics) initialize(namespace string, registerer prometheus.Registerer) error {
+	m.violations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reputation_violations",
+			Help:      "Number of protocol violations committed by peers",
+		},
+		[]string{"violation"},
+	)
+	m.bans = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reputation_bans",
+		Help:      "Number of nodes banned because their score reached the ban threshold",
+	})
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.violations),
+		registerer.Register(m.bans),
+	)
+	return errs.Err
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+// Violation is a breach of the p2p protocol committed by a peer
+type Violation byte
+
+const (
+	// The peer sent a malformed message of a known op
+	UnparseableMessage Violation = iota
+	// The peer reported a time too far out of sync with ours
+	ClockSkew
+	// The peer sent an IP with an invalid signature
+	InvalidSignedIP
+	// The peer didn't respond to a request in time
+	Timeout
+)
+
+// Violations is the list of all violations
+var Violations = []Violation{
+	UnparseableMessage,
+	ClockSkew,
+	InvalidSignedIP,
+	Timeout,
+}
+
+func (v Violation) String() string {
+	switch v {
+	case UnparseableMessage:
+		return "unparseable_message"
+	case ClockSkew:
+		return "clock_skew"
+	case InvalidSignedIP:
+		return "invalid_signed_ip"
+	case Timeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
+// Penalty returns the amount a node's score is increased by when it commits
+// the violation
+func (v Violation) Penalty() float64 {
+	switch v {
+	case UnparseableMessage:
+		return 10
+	case ClockSkew:
+		return 5
+	case InvalidSignedIP:
+		return 50
+	case Timeout:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Tolerated returns true if honest but misconfigured or overloaded nodes may
+// commit the violation occasionally, such as clock skew and timeouts. A node
+// is only penalized for a tolerated violation once it commits it more often
+// than the configured tolerance.
+func (v Violation) Tolerated() bool {
+	switch v {
+	case ClockSkew, Timeout:
+		return true
+	default:
+		return false
+	}
+}
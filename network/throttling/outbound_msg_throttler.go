@@ -33,9 +33,24 @@ type OutboundMsgThrottler interface {
 	Release(msg message.OutboundMessage, nodeID ids.NodeID)
 }
 
+type OutboundMsgThrottlerConfig struct {
+	MsgByteThrottlerConfig
+
+	// Message class --> Max number of bytes of messages of that class that may
+	// be awaiting release for a given node. If a class isn't present or maps
+	// to 0, its messages are only limited by the byte allocations.
+	NodeMaxClassBytes map[message.Class]uint64 `json:"nodeMaxClassBytes"`
+}
+
 type outboundMsgThrottler struct {
 	commonMsgThrottler
 	metrics outboundMsgThrottlerMetrics
+	// Message class --> Max number of bytes of messages of that class that
+	// may be awaiting release for a given node
+	nodeMaxClassBytes map[message.Class]uint64
+	// Node ID --> Message class --> Bytes of messages of that class awaiting
+	// release for the node
+	nodeToClassBytesUsed map[ids.NodeID]map[message.Class]uint64
 }
 
 func NewSybilOutboundMsgThrottler(
@@ -43,7 +58,7 @@ func NewSybilOutboundMsgThrottler(
 	namespace string,
 	registerer prometheus.Registerer,
 	vdrs validators.Set,
-	config MsgByteThrottlerConfig,
+	config OutboundMsgThrottlerConfig,
 ) (OutboundMsgThrottler, error) {
 	t := &outboundMsgThrottler{
 		commonMsgThrottler: commonMsgThrottler{
@@ -56,6 +71,8 @@ func NewSybilOutboundMsgThrottler(
 			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
 			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
 		},
+		nodeMaxClassBytes:    config.NodeMaxClassBytes,
+		nodeToClassBytesUsed: make(map[ids.NodeID]map[message.Class]uint64),
 	}
 	return t, t.metrics.initialize(namespace, registerer)
 }
@@ -69,8 +86,19 @@ func (t *outboundMsgThrottler) Acquire(msg message.OutboundMessage, nodeID ids.N
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
+	// Make sure [nodeID] doesn't have too many bytes of this message's class
+	// waiting to be sent, so that one class can't use up the allocations.
+	msgSize := uint64(len(msg.Bytes()))
+	class := msg.Op().Class()
+	classBytesUsed := t.nodeToClassBytesUsed[nodeID][class]
+	if maxClassBytes := t.nodeMaxClassBytes[class]; maxClassBytes != 0 && classBytesUsed+msgSize > maxClassBytes {
+		t.metrics.acquireFailures.Inc()
+		t.metrics.classAcquireFailures.WithLabelValues(class.String()).Inc()
+		return false
+	}
+
 	// Take as many bytes as we can from the at-large allocation.
-	bytesNeeded := uint64(len(msg.Bytes()))
+	bytesNeeded := msgSize
 	atLargeBytesUsed := math.Min64(
 		// only give as many bytes as needed
 		bytesNeeded,
@@ -118,6 +146,14 @@ func (t *outboundMsgThrottler) Acquire(msg message.OutboundMessage, nodeID ids.N
 		t.nodeToVdrBytesUsed[nodeID] += vdrBytesUsed
 		t.metrics.remainingVdrBytes.Set(float64(t.remainingVdrBytes))
 	}
+	if msgSize > 0 {
+		classToBytesUsed, ok := t.nodeToClassBytesUsed[nodeID]
+		if !ok {
+			classToBytesUsed = make(map[message.Class]uint64, len(message.Classes))
+			t.nodeToClassBytesUsed[nodeID] = classToBytesUsed
+		}
+		classToBytesUsed[class] = classBytesUsed + msgSize
+	}
 	t.metrics.acquireSuccesses.Inc()
 	t.metrics.awaitingRelease.Inc()
 	return true
@@ -157,6 +193,21 @@ func (t *outboundMsgThrottler) Release(msg message.OutboundMessage, nodeID ids.N
 	if t.nodeToAtLargeBytesUsed[nodeID] == 0 {
 		delete(t.nodeToAtLargeBytesUsed, nodeID)
 	}
+
+	// Mark that [nodeID] no longer has this message's class bytes waiting to
+	// be sent.
+	classToBytesUsed, ok := t.nodeToClassBytesUsed[nodeID]
+	if !ok {
+		return
+	}
+	class := msg.Op().Class()
+	classToBytesUsed[class] -= math.Min64(msgSize, classToBytesUsed[class])
+	if classToBytesUsed[class] == 0 {
+		delete(classToBytesUsed, class)
+	}
+	if len(classToBytesUsed) == 0 {
+		delete(t.nodeToClassBytesUsed, nodeID)
+	}
 }
 
 type outboundMsgThrottlerMetrics struct {
@@ -165,6 +216,7 @@ type outboundMsgThrottlerMetrics struct {
 	remainingAtLargeBytes prometheus.Gauge
 	remainingVdrBytes     prometheus.Gauge
 	awaitingRelease       prometheus.Gauge
+	classAcquireFailures  *prometheus.CounterVec
 }
 
 func (m *outboundMsgThrottlerMetrics) initialize(namespace string, registerer prometheus.Registerer) error {
@@ -193,6 +245,14 @@ func (m *outboundMsgThrottlerMetrics) initialize(namespace string, registerer pr
 		Name:      "throttler_outbound_awaiting_release",
 		Help:      "Number of messages waiting to be sent",
 	})
+	m.classAcquireFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "throttler_outbound_class_acquire_failures",
+			Help:      "Outbound messages dropped due to the per-node limit of their message class",
+		},
+		[]string{"class"},
+	)
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(m.acquireSuccesses),
@@ -200,6 +260,7 @@ func (m *outboundMsgThrottlerMetrics) initialize(namespace string, registerer pr
 		registerer.Register(m.remainingAtLargeBytes),
 		registerer.Register(m.remainingVdrBytes),
 		registerer.Register(m.awaitingRelease),
+		registerer.Register(m.classAcquireFailures),
 	)
 	return errs.Err
 }
@@ -18,10 +18,12 @@ import (
 
 func TestSybilOutboundMsgThrottler(t *testing.T) {
 	assert := assert.New(t)
-	config := MsgByteThrottlerConfig{
-		VdrAllocSize:        1024,
-		AtLargeAllocSize:    1024,
-		NodeMaxAtLargeBytes: 1024,
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        1024,
+			AtLargeAllocSize:    1024,
+			NodeMaxAtLargeBytes: 1024,
+		},
 	}
 	vdrs := validators.NewSet()
 	vdr1ID := ids.GenerateTestNodeID()
@@ -161,10 +163,12 @@ func TestSybilOutboundMsgThrottler(t *testing.T) {
 // Ensure that the limit on taking from the at-large allocation is enforced
 func TestSybilOutboundMsgThrottlerMaxNonVdr(t *testing.T) {
 	assert := assert.New(t)
-	config := MsgByteThrottlerConfig{
-		VdrAllocSize:        100,
-		AtLargeAllocSize:    100,
-		NodeMaxAtLargeBytes: 10,
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        100,
+			AtLargeAllocSize:    100,
+			NodeMaxAtLargeBytes: 10,
+		},
 	}
 	vdrs := validators.NewSet()
 	vdr1ID := ids.GenerateTestNodeID()
@@ -207,10 +211,12 @@ func TestSybilOutboundMsgThrottlerMaxNonVdr(t *testing.T) {
 // Ensure that the throttler honors requested bypasses
 func TestBypassThrottling(t *testing.T) {
 	assert := assert.New(t)
-	config := MsgByteThrottlerConfig{
-		VdrAllocSize:        100,
-		AtLargeAllocSize:    100,
-		NodeMaxAtLargeBytes: 10,
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        100,
+			AtLargeAllocSize:    100,
+			NodeMaxAtLargeBytes: 10,
+		},
 	}
 	vdrs := validators.NewSet()
 	vdr1ID := ids.GenerateTestNodeID()
@@ -251,3 +257,54 @@ func TestBypassThrottling(t *testing.T) {
 func testMsgWithSize(size uint64) message.OutboundMessage {
 	return message.NewTestMsg(message.AppGossip, make([]byte, size), false)
 }
+
+// Ensure that the per-node limits on the bytes of each message class are
+// enforced
+func TestSybilOutboundMsgThrottlerClassLimits(t *testing.T) {
+	assert := assert.New(t)
+	config := OutboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        1024,
+			AtLargeAllocSize:    1024,
+			NodeMaxAtLargeBytes: 1024,
+		},
+		NodeMaxClassBytes: map[message.Class]uint64{
+			message.AppClass: 100,
+		},
+	}
+	throttlerIntf, err := NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		validators.NewSet(),
+		config,
+	)
+	assert.NoError(err)
+	throttler := throttlerIntf.(*outboundMsgThrottler)
+	nodeID := ids.GenerateTestNodeID()
+
+	gossip := message.NewTestMsg(message.AppGossip, make([]byte, 100), false)
+	assert.True(throttlerIntf.Acquire(gossip, nodeID))
+	assert.EqualValues(100, throttler.nodeToClassBytesUsed[nodeID][message.AppClass])
+
+	// The app class limit is reached
+	msg := message.NewTestMsg(message.AppResponse, make([]byte, 1), false)
+	assert.False(throttlerIntf.Acquire(msg, nodeID))
+	assert.EqualValues(config.AtLargeAllocSize-100, throttler.remainingAtLargeBytes)
+
+	// Other classes are unaffected
+	chits := message.NewTestMsg(message.Chits, make([]byte, 200), false)
+	assert.True(throttlerIntf.Acquire(chits, nodeID))
+	assert.EqualValues(200, throttler.nodeToClassBytesUsed[nodeID][message.ConsensusClass])
+
+	// Other nodes are unaffected
+	assert.True(throttlerIntf.Acquire(msg, ids.GenerateTestNodeID()))
+
+	// Releasing the bytes allows more messages of the class to be acquired
+	throttlerIntf.Release(gossip, nodeID)
+	assert.True(throttlerIntf.Acquire(msg, nodeID))
+
+	throttlerIntf.Release(msg, nodeID)
+	throttlerIntf.Release(chits, nodeID)
+	assert.NotContains(throttler.nodeToClassBytesUsed, nodeID)
+}
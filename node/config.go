@@ -7,6 +7,8 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
@@ -50,8 +52,22 @@ type HTTPConfig struct {
 	HTTPSKey     []byte `json:"-"`
 	HTTPSCert    []byte `json:"-"`
 
+	// PEM encoded CA certificates that must have issued client certificates.
+	// If empty, clients aren't authenticated by their certificates.
+	HTTPSClientCAs          []byte                 `json:"-"`
+	HTTPSClientCertRequired bool                   `json:"httpsClientCertRequired"`
+	ClientCertificateConfig auth.CertificateConfig `json:"clientCertificateConfig"`
+
 	APIAllowedOrigins []string `json:"apiAllowedOrigins"`
 
+	RateLimitConfig server.RateLimitConfig `json:"rateLimitConfig"`
+	MaxRequestSize  int64                  `json:"maxRequestSize"`
+	MaxBatchSize    int                    `json:"maxBatchSize"`
+
+	// If true, the gRPC API is served on [HTTPHost]:[GRPCPort]
+	GRPCAPIEnabled bool   `json:"grpcAPIEnabled"`
+	GRPCPort       uint16 `json:"grpcPort"`
+
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
 }
@@ -127,6 +143,13 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// Path to the directory that database archives are exported to
+	ExportDir string `json:"exportDir"`
+
+	// Frequency at which the disk usage of the registered database prefixes
+	// is estimated. If 0, it's never estimated in the background.
+	PrefixStatsFrequency time.Duration `json:"prefixStatsFrequency"`
 }
 
 // Config contains all of the configurations of an Avalanche node.
@@ -182,6 +205,9 @@ type Config struct {
 
 	// Metrics
 	MeterVMEnabled bool `json:"meterVMEnabled"`
+	// If true, the metrics of chains are labeled with the IDs of the chain
+	// and subnet, rather than namespaced by the alias of the chain
+	MetricsChainLabelsEnabled bool `json:"metricsChainLabelsEnabled"`
 
 	// Router that is used to handle incoming consensus messages
 	ConsensusRouter          router.Router       `json:"-"`
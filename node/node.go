@@ -16,12 +16,15 @@ import (
 
 	"github.com/hashicorp/go-plugin"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/ava-labs/avalanchego/api/admin"
 	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/grpcserver"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/api/keystore"
@@ -33,6 +36,8 @@ import (
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/meterdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/rocksdb"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -43,6 +48,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -74,11 +80,18 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	ipcsapi "github.com/ava-labs/avalanchego/api/ipcs"
+	avmpb "github.com/ava-labs/avalanchego/proto/pb/avm"
+	healthpb "github.com/ava-labs/avalanchego/proto/pb/health"
+	infopb "github.com/ava-labs/avalanchego/proto/pb/info"
+	platformpb "github.com/ava-labs/avalanchego/proto/pb/platform"
 )
 
 var (
-	genesisHashKey  = []byte("genesisID")
-	indexerDBPrefix = []byte{0x00}
+	genesisHashKey     = []byte("genesisID")
+	indexerDBPrefix    = []byte{0x00}
+	authDBPrefix       = []byte("auth")
+	keystoreDBPrefix   = []byte("keystore")
+	reputationDBPrefix = []byte("reputation")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -142,6 +155,9 @@ type Node struct {
 	// Handles HTTP API calls
 	APIServer server.Server
 
+	// Handles gRPC API calls. Nil if the gRPC API is disabled.
+	GRPCServer grpcserver.Server
+
 	// This node's configuration
 	Config *Config
 
@@ -278,6 +294,17 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 
+	n.Config.NetworkConfig.ReputationManager, err = reputation.NewManager(
+		n.Config.NetworkConfig.ReputationConfig,
+		prefixdb.New(reputationDBPrefix, n.DB),
+		n.Log,
+		n.networkNamespace,
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return fmt.Errorf("problem initializing peer reputation manager: %w", err)
+	}
+
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
 		n.msgCreator,
@@ -362,7 +389,10 @@ func (n *Node) Dispatch() error {
 		var err error
 		if n.Config.HTTPSEnabled {
 			n.Log.Debug("initializing API server with TLS")
-			err = n.APIServer.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey)
+			err = n.APIServer.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey, server.ClientAuthConfig{
+				CACerts:  n.Config.HTTPSClientCAs,
+				Required: n.Config.HTTPSClientCertRequired,
+			})
 		} else {
 			n.Log.Debug("initializing API server without TLS")
 			err = n.APIServer.Dispatch()
@@ -378,6 +408,19 @@ func (n *Node) Dispatch() error {
 		n.Shutdown(1)
 	})
 
+	// Start the gRPC API server
+	if n.GRPCServer != nil {
+		go n.Log.RecoverAndPanic(func() {
+			err := n.GRPCServer.Dispatch(n.Config.HTTPHost, n.Config.GRPCPort)
+			// When [n].Shutdown() is called, [n.GRPCServer].Shutdown() is
+			// called, which causes [n.GRPCServer].Dispatch() to return.
+			if !n.shuttingDown.GetValue() {
+				n.Log.Fatal("gRPC API server dispatch failed with %s", err)
+			}
+			n.Shutdown(1)
+		})
+	}
+
 	// Add state sync nodes to the peer network
 	for i, peerIP := range n.Config.StateSyncIPs {
 		n.Net.ManuallyTrack(n.Config.StateSyncIDs[i], peerIP)
@@ -416,16 +459,20 @@ func (n *Node) initDatabase() error {
 	case rocksdb.Name:
 		path := filepath.Join(n.Config.DatabaseConfig.Path, rocksdb.Name)
 		dbManager, err = manager.NewRocksDB(path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
+	case pebbledb.Name:
+		path := filepath.Join(n.Config.DatabaseConfig.Path, pebbledb.Name)
+		dbManager, err = manager.NewPebbleDB(path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case leveldb.Name:
 		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
 	default:
 		err = fmt.Errorf(
-			"db-type was %q but should have been one of {%s, %s, %s}",
+			"db-type was %q but should have been one of {%s, %s, %s, %s}",
 			n.Config.DatabaseConfig.Name,
 			leveldb.Name,
 			rocksdb.Name,
+			pebbledb.Name,
 			memdb.Name,
 		)
 	}
@@ -439,6 +486,11 @@ func (n *Node) initDatabase() error {
 	}
 
 	n.DBManager = meterDBManager
+	if frequency := n.Config.DatabaseConfig.PrefixStatsFrequency; frequency > 0 {
+		if db, ok := meterDBManager.Current().Database.(*meterdb.Database); ok {
+			db.EstimatePrefixSizesPeriodically(frequency)
+		}
+	}
 
 	currentDB := dbManager.Current()
 	n.Log.Info("current database version: %s", currentDB.Version)
@@ -518,6 +570,8 @@ func (n *Node) initIndexer() error {
 		DecisionAcceptorGroup:  n.DecisionAcceptorGroup,
 		ConsensusAcceptorGroup: n.ConsensusAcceptorGroup,
 		APIServer:              n.APIServer,
+		AllowedOrigins:         n.Config.APIAllowedOrigins,
+		GRPCServer:             n.GRPCServer,
 		ShutdownF:              func() { n.Shutdown(0) }, // TODO put exit code here
 	})
 	if err != nil {
@@ -550,22 +604,43 @@ func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
 	n.APIServer = server.New()
 
-	if !n.Config.APIRequireAuthToken {
-		n.APIServer.Initialize(
-			n.Log,
-			n.LogFactory,
-			n.Config.HTTPHost,
-			n.Config.HTTPPort,
-			n.Config.APIAllowedOrigins,
-			n.Config.ShutdownTimeout,
-			n.ID,
-		)
-		return nil
+	rateLimitConfig := n.Config.RateLimitConfig
+	rateLimitConfig.LimitByToken = n.Config.APIRequireAuthToken
+	rateLimiter, err := server.NewRateLimiter(rateLimitConfig, "api_rate_limiter", n.MetricsRegisterer)
+	if err != nil {
+		return fmt.Errorf("couldn't create API rate limiter: %w", err)
 	}
 
-	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword)
-	if err != nil {
-		return err
+	var (
+		wrappers       []server.Wrapper
+		a              auth.Auth
+		grpcAuthorizer auth.GRPCAuthorizer
+	)
+	if n.Config.APIRequireAuthToken {
+		authDB := prefixdb.New(authDBPrefix, n.DB)
+		a, err = auth.New(n.Log, authDB, "auth", n.Config.APIAuthPassword)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, a)
+		grpcAuthorizer = a
+	}
+
+	certConfig := n.Config.ClientCertificateConfig
+	if len(certConfig.Certificates) > 0 || certConfig.Default != nil {
+		// Clients that present a mapped certificate don't need an auth token,
+		// so the certificate authorizer replaces the auth token wrapper.
+		authorizer, err := auth.NewCertificateAuthorizer(certConfig, a)
+		if err != nil {
+			return fmt.Errorf("couldn't create client certificate authorizer: %w", err)
+		}
+		wrappers = []server.Wrapper{authorizer}
+		grpcAuthorizer = authorizer
+		n.Log.Info("API client certificates are mapped to permissions")
+	}
+
+	if err := n.initGRPCServer(grpcAuthorizer, rateLimiter); err != nil {
+		return fmt.Errorf("couldn't create gRPC API server: %w", err)
 	}
 
 	n.APIServer.Initialize(
@@ -576,8 +651,14 @@ func (n *Node) initAPIServer() error {
 		n.Config.APIAllowedOrigins,
 		n.Config.ShutdownTimeout,
 		n.ID,
-		a,
+		rateLimiter,
+		n.Config.MaxRequestSize,
+		n.Config.MaxBatchSize,
+		wrappers...,
 	)
+	if !n.Config.APIRequireAuthToken {
+		return nil
+	}
 
 	// only create auth service if token authorization is required
 	n.Log.Info("API authorization is enabled. Auth tokens must be passed in the header of API requests, except requests to the auth service.")
@@ -592,6 +673,36 @@ func (n *Node) initAPIServer() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "auth", "")
 }
 
+// initGRPCServer initializes the server that handles gRPC calls, if the gRPC
+// API is enabled. Calls are authorized by [authorizer], unless it's nil, and
+// limited by [rateLimiter], as the calls of the HTTP API are.
+func (n *Node) initGRPCServer(authorizer auth.GRPCAuthorizer, rateLimiter *server.RateLimiter) error {
+	if !n.Config.GRPCAPIEnabled {
+		return nil
+	}
+
+	n.Log.Info("initializing gRPC API server")
+	var opts []grpc.ServerOption
+	if n.Config.HTTPSEnabled {
+		tlsConfig, err := server.NewTLSConfig(n.Config.HTTPSCert, n.Config.HTTPSKey, server.ClientAuthConfig{
+			CACerts:  n.Config.HTTPSClientCAs,
+			Required: n.Config.HTTPSClientCertRequired,
+		})
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	// The X-Chain and P-Chain register their services once they're created.
+	chainServices := []*grpc.ServiceDesc{
+		&avmpb.AVM_ServiceDesc,
+		&platformpb.Platform_ServiceDesc,
+	}
+	n.GRPCServer = grpcserver.New(n.Log, authorizer, rateLimiter, n.Config.ShutdownTimeout, chainServices, opts...)
+	return nil
+}
+
 // Add the default VM aliases
 func (n *Node) addDefaultVMAliases() error {
 	n.Log.Info("adding the default VM aliases")
@@ -631,10 +742,19 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		cChainID,
 	)
 
-	// Manages network timeouts
+	// Manages network timeouts. Peers that repeatedly fail to respond to
+	// requests in time are penalized.
+	onTimeout := func(nodeID ids.NodeID) {
+		// Requests this node sends to itself also time out, but it shouldn't
+		// score itself.
+		if nodeID != n.ID {
+			n.Net.Violated(nodeID, reputation.Timeout)
+		}
+	}
 	timeoutManager, err := timeout.NewManager(
 		&n.Config.AdaptiveTimeoutConfig,
 		n.benchlistManager,
+		onTimeout,
 		"requests",
 		n.MetricsRegisterer,
 	)
@@ -678,6 +798,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		NodeID:                                  n.ID,
 		NetworkID:                               n.Config.NetworkID,
 		Server:                                  n.APIServer,
+		GRPCServer:                              n.GRPCServer,
 		Keystore:                                n.keystore,
 		AtomicMemory:                            n.sharedMemory,
 		AVAXAssetID:                             avaxAssetID,
@@ -690,6 +811,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		RetryBootstrapWarnFrequency:             n.Config.RetryBootstrapWarnFrequency,
 		ShutdownNodeFunc:                        n.Shutdown,
 		MeterVMEnabled:                          n.Config.MeterVMEnabled,
+		MetricsChainLabels:                      n.Config.MetricsChainLabelsEnabled,
 		Metrics:                                 n.MetricsGatherer,
 		SubnetConfigs:                           n.Config.SubnetConfigs,
 		ChainConfigs:                            n.Config.ChainConfigs,
@@ -798,7 +920,7 @@ func (n *Node) initSharedMemory() {
 // Assumes n.APIServer is already set
 func (n *Node) initKeystoreAPI() error {
 	n.Log.Info("initializing keystore")
-	keystoreDB := n.DBManager.NewPrefixDBManager([]byte("keystore"))
+	keystoreDB := n.DBManager.NewPrefixDBManager(keystoreDBPrefix)
 	n.keystore = keystore.New(n.Log, keystoreDB)
 	keystoreHandler, err := n.keystore.CreateHandler()
 	if err != nil {
@@ -819,9 +941,6 @@ func (n *Node) initKeystoreAPI() error {
 // initMetricsAPI initializes the Metrics API
 // Assumes n.APIServer is already set
 func (n *Node) initMetricsAPI() error {
-	n.MetricsRegisterer = prometheus.NewRegistry()
-	n.MetricsGatherer = metrics.NewMultiGatherer()
-
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -848,10 +967,7 @@ func (n *Node) initMetricsAPI() error {
 	return n.APIServer.AddRoute(
 		&common.HTTPHandler{
 			LockOptions: common.NoLock,
-			Handler: promhttp.HandlerFor(
-				n.MetricsGatherer,
-				promhttp.HandlerOpts{},
-			),
+			Handler:     metrics.NewHandler(n.MetricsGatherer),
 		},
 		&sync.RWMutex{},
 		"metrics",
@@ -881,8 +997,19 @@ func (n *Node) initAdminAPI() error {
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
 			LogFactory:   n.LogFactory,
 			NodeConfig:   n.Config,
+			NetworkID:    n.Config.NetworkID,
+			DBManager:    n.DBManager,
 			VMManager:    n.Config.VMManager,
 			VMRegistry:   n.VMRegistry,
+
+			ReputationManager: n.Config.NetworkConfig.ReputationManager,
+			Network:           n.Net,
+
+			ExportDir: n.Config.DatabaseConfig.ExportDir,
+			SecretDBPrefixes: [][]byte{
+				prefixdb.MakePrefix(authDBPrefix),
+				prefixdb.MakePrefix(keystoreDBPrefix),
+			},
 		},
 	)
 	if err != nil {
@@ -922,17 +1049,18 @@ func (n *Node) initInfoAPI() error {
 	n.Log.Info("initializing info API")
 
 	primaryValidators, _ := n.vdrs.GetValidators(constants.PrimaryNetworkID)
+	infoParameters := info.Parameters{
+		Version:               version.CurrentApp,
+		NodeID:                n.ID,
+		NetworkID:             n.Config.NetworkID,
+		TxFee:                 n.Config.TxFee,
+		CreateAssetTxFee:      n.Config.CreateAssetTxFee,
+		CreateSubnetTxFee:     n.Config.CreateSubnetTxFee,
+		CreateBlockchainTxFee: n.Config.CreateBlockchainTxFee,
+		VMManager:             n.Config.VMManager,
+	}
 	service, err := info.NewService(
-		info.Parameters{
-			Version:               version.CurrentApp,
-			NodeID:                n.ID,
-			NetworkID:             n.Config.NetworkID,
-			TxFee:                 n.Config.TxFee,
-			CreateAssetTxFee:      n.Config.CreateAssetTxFee,
-			CreateSubnetTxFee:     n.Config.CreateSubnetTxFee,
-			CreateBlockchainTxFee: n.Config.CreateBlockchainTxFee,
-			VMManager:             n.Config.VMManager,
-		},
+		infoParameters,
 		n.Log,
 		n.chainManager,
 		n.Config.VMManager,
@@ -944,6 +1072,9 @@ func (n *Node) initInfoAPI() error {
 	if err != nil {
 		return err
 	}
+	if n.GRPCServer != nil {
+		infopb.RegisterInfoServer(n.GRPCServer, info.NewGRPCServer(infoParameters, n.Log, n.chainManager, n.Config.VMManager))
+	}
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "info", "")
 }
 
@@ -962,18 +1093,18 @@ func (n *Node) initHealthAPI() error {
 	}
 
 	n.Log.Info("initializing Health API")
-	err = healthChecker.RegisterHealthCheck("network", n.Net)
+	err = healthChecker.RegisterHealthCheck("network", n.Net, "network")
 	if err != nil {
 		return fmt.Errorf("couldn't register network health check: %w", err)
 	}
 
-	err = healthChecker.RegisterHealthCheck("router", n.Config.ConsensusRouter)
+	err = healthChecker.RegisterHealthCheck("router", n.Config.ConsensusRouter, "router")
 	if err != nil {
 		return fmt.Errorf("couldn't register router health check: %w", err)
 	}
 
 	// TODO: add database health to liveness check
-	err = healthChecker.RegisterHealthCheck("database", n.DB)
+	err = healthChecker.RegisterHealthCheck("database", n.DB, "database")
 	if err != nil {
 		return fmt.Errorf("couldn't register database health check: %w", err)
 	}
@@ -998,7 +1129,7 @@ func (n *Node) initHealthAPI() error {
 		}, err
 	})
 
-	err = n.health.RegisterHealthCheck("diskspace", diskSpaceCheck)
+	err = n.health.RegisterHealthCheck("diskspace", diskSpaceCheck, "resources")
 	if err != nil {
 		return fmt.Errorf("couldn't register resource health check: %w", err)
 	}
@@ -1007,6 +1138,9 @@ func (n *Node) initHealthAPI() error {
 	if err != nil {
 		return err
 	}
+	if n.GRPCServer != nil {
+		healthpb.RegisterHealthServer(n.GRPCServer, health.NewGRPCServer(healthChecker))
+	}
 
 	err = n.APIServer.AddRoute(
 		&common.HTTPHandler{
@@ -1178,6 +1312,17 @@ func (n *Node) Initialize(
 		return fmt.Errorf("problem initializing node beacons: %w", err)
 	}
 
+	// The metrics are created before the API server, so that the API server
+	// can report its own metrics.
+	n.MetricsRegisterer = prometheus.NewRegistry()
+	n.MetricsGatherer = metrics.NewMultiGatherer(n.Log)
+
+	// The database is initialized before the API server, so that the API
+	// server can persist the issued auth tokens.
+	if err := n.initDatabase(); err != nil { // Set up the node's database
+		return fmt.Errorf("problem initializing database: %w", err)
+	}
+
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
 	}
@@ -1186,10 +1331,6 @@ func (n *Node) Initialize(
 		return fmt.Errorf("couldn't initialize metrics API: %w", err)
 	}
 
-	if err := n.initDatabase(); err != nil { // Set up the node's database
-		return fmt.Errorf("problem initializing database: %w", err)
-	}
-
 	if err := n.initKeystoreAPI(); err != nil { // Start the Keystore API
 		return fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
@@ -1205,6 +1346,7 @@ func (n *Node) Initialize(
 		n.Config.NetworkConfig.CompressionEnabled,
 		n.networkNamespace,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
+		n.Config.NetworkConfig.CompressionZstdDict,
 	)
 	if err != nil {
 		return fmt.Errorf("problem initializing message creator: %w", err)
@@ -1319,6 +1461,9 @@ func (n *Node) shutdown() {
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown: %s", err)
 	}
+	if n.GRPCServer != nil {
+		n.GRPCServer.Shutdown()
+	}
 	if err := n.indexer.Close(); err != nil {
 		n.Log.Debug("error closing tx indexer: %s", err)
 	}
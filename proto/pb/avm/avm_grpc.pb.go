@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: avm/avm.proto
+
+package avm
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AVMClient is the client API for AVM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AVMClient interface {
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	GetAssetDescription(ctx context.Context, in *GetAssetDescriptionRequest, opts ...grpc.CallOption) (*GetAssetDescriptionResponse, error)
+}
+
+type aVMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAVMClient(cc grpc.ClientConnInterface) AVMClient {
+	return &aVMClient{cc}
+}
+
+func (c *aVMClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/avm.AVM/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, "/avm.AVM/GetTxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, "/avm.AVM/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aVMClient) GetAssetDescription(ctx context.Context, in *GetAssetDescriptionRequest, opts ...grpc.CallOption) (*GetAssetDescriptionResponse, error) {
+	out := new(GetAssetDescriptionResponse)
+	err := c.cc.Invoke(ctx, "/avm.AVM/GetAssetDescription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AVMServer is the server API for AVM service.
+// All implementations must embed UnimplementedAVMServer
+// for forward compatibility
+type AVMServer interface {
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	GetAssetDescription(context.Context, *GetAssetDescriptionRequest) (*GetAssetDescriptionResponse, error)
+	mustEmbedUnimplementedAVMServer()
+}
+
+// UnimplementedAVMServer must be embedded to have forward compatible implementations.
+type UnimplementedAVMServer struct {
+}
+
+func (UnimplementedAVMServer) GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (UnimplementedAVMServer) GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+func (UnimplementedAVMServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedAVMServer) GetAssetDescription(context.Context, *GetAssetDescriptionRequest) (*GetAssetDescriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssetDescription not implemented")
+}
+func (UnimplementedAVMServer) mustEmbedUnimplementedAVMServer() {}
+
+// UnsafeAVMServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AVMServer will
+// result in compilation errors.
+type UnsafeAVMServer interface {
+	mustEmbedUnimplementedAVMServer()
+}
+
+func RegisterAVMServer(s grpc.ServiceRegistrar, srv AVMServer) {
+	s.RegisterService(&AVM_ServiceDesc, srv)
+}
+
+func _AVM_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avm.AVM/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avm.AVM/GetTxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avm.AVM/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AVM_GetAssetDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetDescriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AVMServer).GetAssetDescription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/avm.AVM/GetAssetDescription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AVMServer).GetAssetDescription(ctx, req.(*GetAssetDescriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AVM_ServiceDesc is the grpc.ServiceDesc for AVM service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AVM_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "avm.AVM",
+	HandlerType: (*AVMServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTx",
+			Handler:    _AVM_GetTx_Handler,
+		},
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _AVM_GetTxStatus_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _AVM_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetAssetDescription",
+			Handler:    _AVM_GetAssetDescription_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "avm/avm.proto",
+}
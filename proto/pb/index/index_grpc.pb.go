@@ -0,0 +1,353 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: index/index.proto
+
+package index
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// IndexClient is the client API for Index service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IndexClient interface {
+	GetLastAccepted(ctx context.Context, in *GetLastAcceptedRequest, opts ...grpc.CallOption) (*Container, error)
+	GetContainerByIndex(ctx context.Context, in *GetContainerByIndexRequest, opts ...grpc.CallOption) (*Container, error)
+	GetContainerRange(ctx context.Context, in *GetContainerRangeRequest, opts ...grpc.CallOption) (*GetContainerRangeResponse, error)
+	GetContainerByID(ctx context.Context, in *GetContainerByIDRequest, opts ...grpc.CallOption) (*Container, error)
+	GetIndex(ctx context.Context, in *GetIndexRequest, opts ...grpc.CallOption) (*GetIndexResponse, error)
+	IsAccepted(ctx context.Context, in *IsAcceptedRequest, opts ...grpc.CallOption) (*IsAcceptedResponse, error)
+	// Streams the containers accepted into the index, in the order they were
+	// accepted
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Index_SubscribeClient, error)
+}
+
+type indexClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIndexClient(cc grpc.ClientConnInterface) IndexClient {
+	return &indexClient{cc}
+}
+
+func (c *indexClient) GetLastAccepted(ctx context.Context, in *GetLastAcceptedRequest, opts ...grpc.CallOption) (*Container, error) {
+	out := new(Container)
+	err := c.cc.Invoke(ctx, "/index.Index/GetLastAccepted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerByIndex(ctx context.Context, in *GetContainerByIndexRequest, opts ...grpc.CallOption) (*Container, error) {
+	out := new(Container)
+	err := c.cc.Invoke(ctx, "/index.Index/GetContainerByIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerRange(ctx context.Context, in *GetContainerRangeRequest, opts ...grpc.CallOption) (*GetContainerRangeResponse, error) {
+	out := new(GetContainerRangeResponse)
+	err := c.cc.Invoke(ctx, "/index.Index/GetContainerRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerByID(ctx context.Context, in *GetContainerByIDRequest, opts ...grpc.CallOption) (*Container, error) {
+	out := new(Container)
+	err := c.cc.Invoke(ctx, "/index.Index/GetContainerByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetIndex(ctx context.Context, in *GetIndexRequest, opts ...grpc.CallOption) (*GetIndexResponse, error) {
+	out := new(GetIndexResponse)
+	err := c.cc.Invoke(ctx, "/index.Index/GetIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) IsAccepted(ctx context.Context, in *IsAcceptedRequest, opts ...grpc.CallOption) (*IsAcceptedResponse, error) {
+	out := new(IsAcceptedResponse)
+	err := c.cc.Invoke(ctx, "/index.Index/IsAccepted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Index_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Index_ServiceDesc.Streams[0], "/index.Index/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &indexSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Index_SubscribeClient interface {
+	Recv() (*Container, error)
+	grpc.ClientStream
+}
+
+type indexSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *indexSubscribeClient) Recv() (*Container, error) {
+	m := new(Container)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IndexServer is the server API for Index service.
+// All implementations must embed UnimplementedIndexServer
+// for forward compatibility
+type IndexServer interface {
+	GetLastAccepted(context.Context, *GetLastAcceptedRequest) (*Container, error)
+	GetContainerByIndex(context.Context, *GetContainerByIndexRequest) (*Container, error)
+	GetContainerRange(context.Context, *GetContainerRangeRequest) (*GetContainerRangeResponse, error)
+	GetContainerByID(context.Context, *GetContainerByIDRequest) (*Container, error)
+	GetIndex(context.Context, *GetIndexRequest) (*GetIndexResponse, error)
+	IsAccepted(context.Context, *IsAcceptedRequest) (*IsAcceptedResponse, error)
+	// Streams the containers accepted into the index, in the order they were
+	// accepted
+	Subscribe(*SubscribeRequest, Index_SubscribeServer) error
+	mustEmbedUnimplementedIndexServer()
+}
+
+// UnimplementedIndexServer must be embedded to have forward compatible implementations.
+type UnimplementedIndexServer struct {
+}
+
+func (UnimplementedIndexServer) GetLastAccepted(context.Context, *GetLastAcceptedRequest) (*Container, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastAccepted not implemented")
+}
+func (UnimplementedIndexServer) GetContainerByIndex(context.Context, *GetContainerByIndexRequest) (*Container, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerByIndex not implemented")
+}
+func (UnimplementedIndexServer) GetContainerRange(context.Context, *GetContainerRangeRequest) (*GetContainerRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerRange not implemented")
+}
+func (UnimplementedIndexServer) GetContainerByID(context.Context, *GetContainerByIDRequest) (*Container, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerByID not implemented")
+}
+func (UnimplementedIndexServer) GetIndex(context.Context, *GetIndexRequest) (*GetIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIndex not implemented")
+}
+func (UnimplementedIndexServer) IsAccepted(context.Context, *IsAcceptedRequest) (*IsAcceptedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAccepted not implemented")
+}
+func (UnimplementedIndexServer) Subscribe(*SubscribeRequest, Index_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedIndexServer) mustEmbedUnimplementedIndexServer() {}
+
+// UnsafeIndexServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IndexServer will
+// result in compilation errors.
+type UnsafeIndexServer interface {
+	mustEmbedUnimplementedIndexServer()
+}
+
+func RegisterIndexServer(s grpc.ServiceRegistrar, srv IndexServer) {
+	s.RegisterService(&Index_ServiceDesc, srv)
+}
+
+func _Index_GetLastAccepted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastAcceptedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetLastAccepted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index.Index/GetLastAccepted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetLastAccepted(ctx, req.(*GetLastAcceptedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerByIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerByIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerByIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index.Index/GetContainerByIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerByIndex(ctx, req.(*GetContainerByIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index.Index/GetContainerRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerRange(ctx, req.(*GetContainerRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index.Index/GetContainerByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerByID(ctx, req.(*GetContainerByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index.Index/GetIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetIndex(ctx, req.(*GetIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_IsAccepted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsAcceptedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).IsAccepted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index.Index/IsAccepted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).IsAccepted(ctx, req.(*IsAcceptedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IndexServer).Subscribe(m, &indexSubscribeServer{stream})
+}
+
+type Index_SubscribeServer interface {
+	Send(*Container) error
+	grpc.ServerStream
+}
+
+type indexSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *indexSubscribeServer) Send(m *Container) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Index_ServiceDesc is the grpc.ServiceDesc for Index service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Index_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "index.Index",
+	HandlerType: (*IndexServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLastAccepted",
+			Handler:    _Index_GetLastAccepted_Handler,
+		},
+		{
+			MethodName: "GetContainerByIndex",
+			Handler:    _Index_GetContainerByIndex_Handler,
+		},
+		{
+			MethodName: "GetContainerRange",
+			Handler:    _Index_GetContainerRange_Handler,
+		},
+		{
+			MethodName: "GetContainerByID",
+			Handler:    _Index_GetContainerByID_Handler,
+		},
+		{
+			MethodName: "GetIndex",
+			Handler:    _Index_GetIndex_Handler,
+		},
+		{
+			MethodName: "IsAccepted",
+			Handler:    _Index_IsAccepted_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Index_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "index/index.proto",
+}
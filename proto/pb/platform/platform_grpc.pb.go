@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: platform/platform.proto
+
+package platform
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PlatformClient is the client API for Platform service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PlatformClient interface {
+	GetHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeightResponse, error)
+	GetTimestamp(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTimestampResponse, error)
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+	GetValidatorsAt(ctx context.Context, in *GetValidatorsAtRequest, opts ...grpc.CallOption) (*GetValidatorsAtResponse, error)
+}
+
+type platformClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlatformClient(cc grpc.ClientConnInterface) PlatformClient {
+	return &platformClient{cc}
+}
+
+func (c *platformClient) GetHeight(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetHeightResponse, error) {
+	out := new(GetHeightResponse)
+	err := c.cc.Invoke(ctx, "/platform.Platform/GetHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTimestamp(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTimestampResponse, error) {
+	out := new(GetTimestampResponse)
+	err := c.cc.Invoke(ctx, "/platform.Platform/GetTimestamp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/platform.Platform/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, "/platform.Platform/GetTxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetValidatorsAt(ctx context.Context, in *GetValidatorsAtRequest, opts ...grpc.CallOption) (*GetValidatorsAtResponse, error) {
+	out := new(GetValidatorsAtResponse)
+	err := c.cc.Invoke(ctx, "/platform.Platform/GetValidatorsAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlatformServer is the server API for Platform service.
+// All implementations must embed UnimplementedPlatformServer
+// for forward compatibility
+type PlatformServer interface {
+	GetHeight(context.Context, *emptypb.Empty) (*GetHeightResponse, error)
+	GetTimestamp(context.Context, *emptypb.Empty) (*GetTimestampResponse, error)
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+	GetValidatorsAt(context.Context, *GetValidatorsAtRequest) (*GetValidatorsAtResponse, error)
+	mustEmbedUnimplementedPlatformServer()
+}
+
+// UnimplementedPlatformServer must be embedded to have forward compatible implementations.
+type UnimplementedPlatformServer struct {
+}
+
+func (UnimplementedPlatformServer) GetHeight(context.Context, *emptypb.Empty) (*GetHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeight not implemented")
+}
+func (UnimplementedPlatformServer) GetTimestamp(context.Context, *emptypb.Empty) (*GetTimestampResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimestamp not implemented")
+}
+func (UnimplementedPlatformServer) GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (UnimplementedPlatformServer) GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+func (UnimplementedPlatformServer) GetValidatorsAt(context.Context, *GetValidatorsAtRequest) (*GetValidatorsAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidatorsAt not implemented")
+}
+func (UnimplementedPlatformServer) mustEmbedUnimplementedPlatformServer() {}
+
+// UnsafePlatformServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlatformServer will
+// result in compilation errors.
+type UnsafePlatformServer interface {
+	mustEmbedUnimplementedPlatformServer()
+}
+
+func RegisterPlatformServer(s grpc.ServiceRegistrar, srv PlatformServer) {
+	s.RegisterService(&Platform_ServiceDesc, srv)
+}
+
+func _Platform_GetHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platform.Platform/GetHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetHeight(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTimestamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTimestamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platform.Platform/GetTimestamp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTimestamp(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platform.Platform/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platform.Platform/GetTxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetValidatorsAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidatorsAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetValidatorsAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platform.Platform/GetValidatorsAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetValidatorsAt(ctx, req.(*GetValidatorsAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Platform_ServiceDesc is the grpc.ServiceDesc for Platform service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Platform_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "platform.Platform",
+	HandlerType: (*PlatformServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHeight",
+			Handler:    _Platform_GetHeight_Handler,
+		},
+		{
+			MethodName: "GetTimestamp",
+			Handler:    _Platform_GetTimestamp_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _Platform_GetTx_Handler,
+		},
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _Platform_GetTxStatus_Handler,
+		},
+		{
+			MethodName: "GetValidatorsAt",
+			Handler:    _Platform_GetValidatorsAt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "platform/platform.proto",
+}
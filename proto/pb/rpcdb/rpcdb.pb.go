@@ -866,6 +866,116 @@ func (x *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
 	return 0
 }
 
+type NewIteratorWithRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start   []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Limit   []byte `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Reverse bool   `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse,omitempty"`
+}
+
+func (x *NewIteratorWithRangeRequest) Reset() {
+	*x = NewIteratorWithRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewIteratorWithRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewIteratorWithRangeRequest) ProtoMessage() {}
+
+func (x *NewIteratorWithRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewIteratorWithRangeRequest.ProtoReflect.Descriptor instead.
+func (*NewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NewIteratorWithRangeRequest) GetStart() []byte {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *NewIteratorWithRangeRequest) GetLimit() []byte {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+func (x *NewIteratorWithRangeRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type NewIteratorWithRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *NewIteratorWithRangeResponse) Reset() {
+	*x = NewIteratorWithRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewIteratorWithRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewIteratorWithRangeResponse) ProtoMessage() {}
+
+func (x *NewIteratorWithRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewIteratorWithRangeResponse.ProtoReflect.Descriptor instead.
+func (*NewIteratorWithRangeResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NewIteratorWithRangeResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type IteratorNextRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -877,7 +987,7 @@ type IteratorNextRequest struct {
 func (x *IteratorNextRequest) Reset() {
 	*x = IteratorNextRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -890,7 +1000,7 @@ func (x *IteratorNextRequest) String() string {
 func (*IteratorNextRequest) ProtoMessage() {}
 
 func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -903,7 +1013,7 @@ func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorNextRequest.ProtoReflect.Descriptor instead.
 func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *IteratorNextRequest) GetId() uint64 {
@@ -918,26 +1028,402 @@ type IteratorNextResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data []*PutRequest `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Data []*PutRequest `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *IteratorNextResponse) Reset() {
+	*x = IteratorNextResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorNextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorNextResponse) ProtoMessage() {}
+
+func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorNextResponse.ProtoReflect.Descriptor instead.
+func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IteratorNextResponse) GetData() []*PutRequest {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type IteratorErrorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *IteratorErrorRequest) Reset() {
+	*x = IteratorErrorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorErrorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorErrorRequest) ProtoMessage() {}
+
+func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorErrorRequest.ProtoReflect.Descriptor instead.
+func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IteratorErrorRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type IteratorErrorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (x *IteratorErrorResponse) Reset() {
+	*x = IteratorErrorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorErrorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorErrorResponse) ProtoMessage() {}
+
+func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorErrorResponse.ProtoReflect.Descriptor instead.
+func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *IteratorErrorResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
+	}
+	return 0
+}
+
+type IteratorReleaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *IteratorReleaseRequest) Reset() {
+	*x = IteratorReleaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorReleaseRequest) ProtoMessage() {}
+
+func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorReleaseRequest.ProtoReflect.Descriptor instead.
+func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IteratorReleaseRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type IteratorReleaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (x *IteratorReleaseResponse) Reset() {
+	*x = IteratorReleaseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorReleaseResponse) ProtoMessage() {}
+
+func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorReleaseResponse.ProtoReflect.Descriptor instead.
+func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *IteratorReleaseResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
+	}
+	return 0
+}
+
+type HealthCheckResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Details []byte `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HealthCheckResponse) GetDetails() []byte {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type NewSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NewSnapshotRequest) Reset() {
+	*x = NewSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewSnapshotRequest) ProtoMessage() {}
+
+func (x *NewSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*NewSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{26}
+}
+
+type NewSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Err uint32 `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (x *NewSnapshotResponse) Reset() {
+	*x = NewSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewSnapshotResponse) ProtoMessage() {}
+
+func (x *NewSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*NewSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *NewSnapshotResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *NewSnapshotResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
+	}
+	return 0
+}
+
+type SnapshotHasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (x *IteratorNextResponse) Reset() {
-	*x = IteratorNextResponse{}
+func (x *SnapshotHasRequest) Reset() {
+	*x = SnapshotHasRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorNextResponse) String() string {
+func (x *SnapshotHasRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorNextResponse) ProtoMessage() {}
+func (*SnapshotHasRequest) ProtoMessage() {}
 
-func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+func (x *SnapshotHasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,43 +1434,51 @@ func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorNextResponse.ProtoReflect.Descriptor instead.
-func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use SnapshotHasRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotHasRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *IteratorNextResponse) GetData() []*PutRequest {
+func (x *SnapshotHasRequest) GetId() uint64 {
 	if x != nil {
-		return x.Data
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SnapshotHasRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
 	}
 	return nil
 }
 
-type IteratorErrorRequest struct {
+type SnapshotGetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id  uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (x *IteratorErrorRequest) Reset() {
-	*x = IteratorErrorRequest{}
+func (x *SnapshotGetRequest) Reset() {
+	*x = SnapshotGetRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorErrorRequest) String() string {
+func (x *SnapshotGetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorErrorRequest) ProtoMessage() {}
+func (*SnapshotGetRequest) ProtoMessage() {}
 
-func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+func (x *SnapshotGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -995,43 +1489,56 @@ func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorErrorRequest.ProtoReflect.Descriptor instead.
-func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SnapshotGetRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotGetRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *IteratorErrorRequest) GetId() uint64 {
+func (x *SnapshotGetRequest) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type IteratorErrorResponse struct {
+func (x *SnapshotGetRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type SnapshotIterateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+	Id      uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start   []byte `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Prefix  []byte `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit   []byte `protobuf:"bytes,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Reverse bool   `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	// If true, iterate over [start, limit) rather than over [start] and [prefix]
+	Range bool `protobuf:"varint,6,opt,name=range,proto3" json:"range,omitempty"`
 }
 
-func (x *IteratorErrorResponse) Reset() {
-	*x = IteratorErrorResponse{}
+func (x *SnapshotIterateRequest) Reset() {
+	*x = SnapshotIterateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorErrorResponse) String() string {
+func (x *SnapshotIterateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorErrorResponse) ProtoMessage() {}
+func (*SnapshotIterateRequest) ProtoMessage() {}
 
-func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+func (x *SnapshotIterateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1042,43 +1549,79 @@ func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorErrorResponse.ProtoReflect.Descriptor instead.
-func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SnapshotIterateRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotIterateRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *IteratorErrorResponse) GetErr() uint32 {
+func (x *SnapshotIterateRequest) GetId() uint64 {
 	if x != nil {
-		return x.Err
+		return x.Id
 	}
 	return 0
 }
 
-type IteratorReleaseRequest struct {
+func (x *SnapshotIterateRequest) GetStart() []byte {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *SnapshotIterateRequest) GetPrefix() []byte {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *SnapshotIterateRequest) GetLimit() []byte {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+func (x *SnapshotIterateRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+func (x *SnapshotIterateRequest) GetRange() bool {
+	if x != nil {
+		return x.Range
+	}
+	return false
+}
+
+type SnapshotIterateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data []*PutRequest `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Err  uint32        `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
 }
 
-func (x *IteratorReleaseRequest) Reset() {
-	*x = IteratorReleaseRequest{}
+func (x *SnapshotIterateResponse) Reset() {
+	*x = SnapshotIterateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorReleaseRequest) String() string {
+func (x *SnapshotIterateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorReleaseRequest) ProtoMessage() {}
+func (*SnapshotIterateResponse) ProtoMessage() {}
 
-func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+func (x *SnapshotIterateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1089,43 +1632,50 @@ func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorReleaseRequest.ProtoReflect.Descriptor instead.
-func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use SnapshotIterateResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotIterateResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *IteratorReleaseRequest) GetId() uint64 {
+func (x *SnapshotIterateResponse) GetData() []*PutRequest {
 	if x != nil {
-		return x.Id
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SnapshotIterateResponse) GetErr() uint32 {
+	if x != nil {
+		return x.Err
 	}
 	return 0
 }
 
-type IteratorReleaseResponse struct {
+type SnapshotReleaseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *IteratorReleaseResponse) Reset() {
-	*x = IteratorReleaseResponse{}
+func (x *SnapshotReleaseRequest) Reset() {
+	*x = SnapshotReleaseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IteratorReleaseResponse) String() string {
+func (x *SnapshotReleaseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IteratorReleaseResponse) ProtoMessage() {}
+func (*SnapshotReleaseRequest) ProtoMessage() {}
 
-func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+func (x *SnapshotReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1136,43 +1686,43 @@ func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IteratorReleaseResponse.ProtoReflect.Descriptor instead.
-func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use SnapshotReleaseRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *IteratorReleaseResponse) GetErr() uint32 {
+func (x *SnapshotReleaseRequest) GetId() uint64 {
 	if x != nil {
-		return x.Err
+		return x.Id
 	}
 	return 0
 }
 
-type HealthCheckResponse struct {
+type SnapshotReleaseResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Details []byte `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	Err uint32 `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
 }
 
-func (x *HealthCheckResponse) Reset() {
-	*x = HealthCheckResponse{}
+func (x *SnapshotReleaseResponse) Reset() {
+	*x = SnapshotReleaseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HealthCheckResponse) String() string {
+func (x *SnapshotReleaseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheckResponse) ProtoMessage() {}
+func (*SnapshotReleaseResponse) ProtoMessage() {}
 
-func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+func (x *SnapshotReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1183,16 +1733,16 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
-func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use SnapshotReleaseResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *HealthCheckResponse) GetDetails() []byte {
+func (x *SnapshotReleaseResponse) GetErr() uint32 {
 	if x != nil {
-		return x.Details
+		return x.Err
 	}
-	return nil
+	return 0
 }
 
 var File_rpcdb_rpcdb_proto protoreflect.FileDescriptor
@@ -1253,81 +1803,152 @@ var file_rpcdb_rpcdb_proto_rawDesc = []byte{
 	0x65, 0x66, 0x69, 0x78, 0x22, 0x37, 0x0a, 0x25, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61,
 	0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50,
 	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x25, 0x0a,
-	0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
-	0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x63, 0x0a,
+	0x1b, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65,
+	0x72, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72,
+	0x73, 0x65, 0x22, 0x2e, 0x0a, 0x1c, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x25, 0x0a, 0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65,
+	0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x14, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x25, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x29, 0x0a, 0x15, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2b, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65,
+	0x72, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x37, 0x0a, 0x13, 0x4e, 0x65, 0x77,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65,
+	0x72, 0x72, 0x22, 0x36, 0x0a, 0x12, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x36, 0x0a, 0x12, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x22, 0x9c, 0x01, 0x0a, 0x16, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x22, 0x52, 0x0a, 0x17, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04,
 	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63,
 	0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x29, 0x0a, 0x15, 0x49,
-	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
-	0x22, 0x2b, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65,
-	0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x2f, 0x0a,
-	0x13, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x32, 0xa2,
-	0x06, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x48,
-	0x61, 0x73, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x47, 0x65, 0x74,
-	0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x11,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
-	0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12,
-	0x13, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f,
-	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x48, 0x65,
-	0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
-	0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x7a, 0x0a, 0x1d, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57,
-	0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69,
-	0x78, 0x12, 0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e,
-	0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72,
-	0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c,
-	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72,
-	0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
-	0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
-	0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49,
-	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x50, 0x0a, 0x0f, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61,
-	0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f,
-	0x72, 0x70, 0x63, 0x64, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x2b, 0x0a, 0x17, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x72,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x65, 0x72, 0x72, 0x32, 0xeb, 0x09, 0x0a,
+	0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x48, 0x61, 0x73,
+	0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x11,
+	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x11, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7a,
+	0x0a, 0x1d, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x14, 0x4e, 0x65,
+	0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x22, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e,
+	0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
+	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x50, 0x0a, 0x0f, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x12, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x47, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63,
+	0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62,
+	0x73, 0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x72, 0x70, 0x63, 0x64, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1342,7 +1963,7 @@ func file_rpcdb_rpcdb_proto_rawDescGZIP() []byte {
 	return file_rpcdb_rpcdb_proto_rawDescData
 }
 
-var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_rpcdb_rpcdb_proto_goTypes = []interface{}{
 	(*HasRequest)(nil),                            // 0: rpcdb.HasRequest
 	(*HasResponse)(nil),                           // 1: rpcdb.HasResponse
@@ -1361,48 +1982,71 @@ var file_rpcdb_rpcdb_proto_goTypes = []interface{}{
 	(*NewIteratorRequest)(nil),                    // 14: rpcdb.NewIteratorRequest
 	(*NewIteratorWithStartAndPrefixRequest)(nil),  // 15: rpcdb.NewIteratorWithStartAndPrefixRequest
 	(*NewIteratorWithStartAndPrefixResponse)(nil), // 16: rpcdb.NewIteratorWithStartAndPrefixResponse
-	(*IteratorNextRequest)(nil),                   // 17: rpcdb.IteratorNextRequest
-	(*IteratorNextResponse)(nil),                  // 18: rpcdb.IteratorNextResponse
-	(*IteratorErrorRequest)(nil),                  // 19: rpcdb.IteratorErrorRequest
-	(*IteratorErrorResponse)(nil),                 // 20: rpcdb.IteratorErrorResponse
-	(*IteratorReleaseRequest)(nil),                // 21: rpcdb.IteratorReleaseRequest
-	(*IteratorReleaseResponse)(nil),               // 22: rpcdb.IteratorReleaseResponse
-	(*HealthCheckResponse)(nil),                   // 23: rpcdb.HealthCheckResponse
-	(*emptypb.Empty)(nil),                         // 24: google.protobuf.Empty
+	(*NewIteratorWithRangeRequest)(nil),           // 17: rpcdb.NewIteratorWithRangeRequest
+	(*NewIteratorWithRangeResponse)(nil),          // 18: rpcdb.NewIteratorWithRangeResponse
+	(*IteratorNextRequest)(nil),                   // 19: rpcdb.IteratorNextRequest
+	(*IteratorNextResponse)(nil),                  // 20: rpcdb.IteratorNextResponse
+	(*IteratorErrorRequest)(nil),                  // 21: rpcdb.IteratorErrorRequest
+	(*IteratorErrorResponse)(nil),                 // 22: rpcdb.IteratorErrorResponse
+	(*IteratorReleaseRequest)(nil),                // 23: rpcdb.IteratorReleaseRequest
+	(*IteratorReleaseResponse)(nil),               // 24: rpcdb.IteratorReleaseResponse
+	(*HealthCheckResponse)(nil),                   // 25: rpcdb.HealthCheckResponse
+	(*NewSnapshotRequest)(nil),                    // 26: rpcdb.NewSnapshotRequest
+	(*NewSnapshotResponse)(nil),                   // 27: rpcdb.NewSnapshotResponse
+	(*SnapshotHasRequest)(nil),                    // 28: rpcdb.SnapshotHasRequest
+	(*SnapshotGetRequest)(nil),                    // 29: rpcdb.SnapshotGetRequest
+	(*SnapshotIterateRequest)(nil),                // 30: rpcdb.SnapshotIterateRequest
+	(*SnapshotIterateResponse)(nil),               // 31: rpcdb.SnapshotIterateResponse
+	(*SnapshotReleaseRequest)(nil),                // 32: rpcdb.SnapshotReleaseRequest
+	(*SnapshotReleaseResponse)(nil),               // 33: rpcdb.SnapshotReleaseResponse
+	(*emptypb.Empty)(nil),                         // 34: google.protobuf.Empty
 }
 var file_rpcdb_rpcdb_proto_depIdxs = []int32{
 	4,  // 0: rpcdb.WriteBatchRequest.puts:type_name -> rpcdb.PutRequest
 	6,  // 1: rpcdb.WriteBatchRequest.deletes:type_name -> rpcdb.DeleteRequest
 	4,  // 2: rpcdb.IteratorNextResponse.data:type_name -> rpcdb.PutRequest
-	0,  // 3: rpcdb.Database.Has:input_type -> rpcdb.HasRequest
-	2,  // 4: rpcdb.Database.Get:input_type -> rpcdb.GetRequest
-	4,  // 5: rpcdb.Database.Put:input_type -> rpcdb.PutRequest
-	6,  // 6: rpcdb.Database.Delete:input_type -> rpcdb.DeleteRequest
-	8,  // 7: rpcdb.Database.Compact:input_type -> rpcdb.CompactRequest
-	10, // 8: rpcdb.Database.Close:input_type -> rpcdb.CloseRequest
-	24, // 9: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
-	12, // 10: rpcdb.Database.WriteBatch:input_type -> rpcdb.WriteBatchRequest
-	15, // 11: rpcdb.Database.NewIteratorWithStartAndPrefix:input_type -> rpcdb.NewIteratorWithStartAndPrefixRequest
-	17, // 12: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
-	19, // 13: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
-	21, // 14: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
-	1,  // 15: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
-	3,  // 16: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
-	5,  // 17: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
-	7,  // 18: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
-	9,  // 19: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
-	11, // 20: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
-	23, // 21: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
-	13, // 22: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
-	16, // 23: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
-	18, // 24: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
-	20, // 25: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
-	22, // 26: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
-	15, // [15:27] is the sub-list for method output_type
-	3,  // [3:15] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	4,  // 3: rpcdb.SnapshotIterateResponse.data:type_name -> rpcdb.PutRequest
+	0,  // 4: rpcdb.Database.Has:input_type -> rpcdb.HasRequest
+	2,  // 5: rpcdb.Database.Get:input_type -> rpcdb.GetRequest
+	4,  // 6: rpcdb.Database.Put:input_type -> rpcdb.PutRequest
+	6,  // 7: rpcdb.Database.Delete:input_type -> rpcdb.DeleteRequest
+	8,  // 8: rpcdb.Database.Compact:input_type -> rpcdb.CompactRequest
+	10, // 9: rpcdb.Database.Close:input_type -> rpcdb.CloseRequest
+	34, // 10: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
+	12, // 11: rpcdb.Database.WriteBatch:input_type -> rpcdb.WriteBatchRequest
+	15, // 12: rpcdb.Database.NewIteratorWithStartAndPrefix:input_type -> rpcdb.NewIteratorWithStartAndPrefixRequest
+	17, // 13: rpcdb.Database.NewIteratorWithRange:input_type -> rpcdb.NewIteratorWithRangeRequest
+	19, // 14: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
+	21, // 15: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
+	23, // 16: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
+	26, // 17: rpcdb.Database.NewSnapshot:input_type -> rpcdb.NewSnapshotRequest
+	28, // 18: rpcdb.Database.SnapshotHas:input_type -> rpcdb.SnapshotHasRequest
+	29, // 19: rpcdb.Database.SnapshotGet:input_type -> rpcdb.SnapshotGetRequest
+	30, // 20: rpcdb.Database.SnapshotIterate:input_type -> rpcdb.SnapshotIterateRequest
+	32, // 21: rpcdb.Database.SnapshotRelease:input_type -> rpcdb.SnapshotReleaseRequest
+	1,  // 22: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
+	3,  // 23: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
+	5,  // 24: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
+	7,  // 25: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
+	9,  // 26: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
+	11, // 27: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
+	25, // 28: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
+	13, // 29: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
+	16, // 30: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
+	18, // 31: rpcdb.Database.NewIteratorWithRange:output_type -> rpcdb.NewIteratorWithRangeResponse
+	20, // 32: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
+	22, // 33: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
+	24, // 34: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
+	27, // 35: rpcdb.Database.NewSnapshot:output_type -> rpcdb.NewSnapshotResponse
+	1,  // 36: rpcdb.Database.SnapshotHas:output_type -> rpcdb.HasResponse
+	3,  // 37: rpcdb.Database.SnapshotGet:output_type -> rpcdb.GetResponse
+	31, // 38: rpcdb.Database.SnapshotIterate:output_type -> rpcdb.SnapshotIterateResponse
+	33, // 39: rpcdb.Database.SnapshotRelease:output_type -> rpcdb.SnapshotReleaseResponse
+	22, // [22:40] is the sub-list for method output_type
+	4,  // [4:22] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_rpcdb_rpcdb_proto_init() }
@@ -1616,7 +2260,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextRequest); i {
+			switch v := v.(*NewIteratorWithRangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1628,7 +2272,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextResponse); i {
+			switch v := v.(*NewIteratorWithRangeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1640,7 +2284,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorRequest); i {
+			switch v := v.(*IteratorNextRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1652,7 +2296,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorResponse); i {
+			switch v := v.(*IteratorNextResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1664,7 +2308,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseRequest); i {
+			switch v := v.(*IteratorErrorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1676,7 +2320,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseResponse); i {
+			switch v := v.(*IteratorErrorResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1688,6 +2332,30 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HealthCheckResponse); i {
 			case 0:
 				return &v.state
@@ -1699,6 +2367,102 @@ func file_rpcdb_rpcdb_proto_init() {
 				return nil
 			}
 		}
+		file_rpcdb_rpcdb_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotHasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotIterateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotIterateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1706,7 +2470,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpcdb_rpcdb_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,20 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 const (
-	dbCacheSize = 10000
-	idCacheSize = 1000
+	// vertexCacheSize is the maximum number of bytes of vertices to cache.
+	vertexCacheSize = 64 * units.MiB
+	// vertexCacheEntryOverhead is a rough estimate of the bookkeeping the cache
+	// keeps for each vertex.
+	vertexCacheEntryOverhead = 128
+
+	statusCacheSize = 10000
+	// Only the frontier of the DAG is stored as an edge.
+	edgeCacheSize = 1
+	idCacheSize   = 1000
 )
 
 var (
@@ -51,7 +60,6 @@ type SerializerConfig struct {
 
 func NewSerializer(config SerializerConfig) vertex.Manager {
 	versionDB := versiondb.New(config.DB)
-	dbCache := &cache.LRU{Size: dbCacheSize}
 	s := Serializer{
 		SerializerConfig: config,
 		versionDB:        versionDB,
@@ -60,8 +68,13 @@ func NewSerializer(config SerializerConfig) vertex.Manager {
 	rawState := &state{
 		serializer: &s,
 		log:        config.Log,
-		dbCache:    dbCache,
-		db:         versionDB,
+		vtxCache: &cache.SizedLRU[ids.ID, vertex.StatelessVertex]{
+			MaxSize: vertexCacheSize,
+			Size:    cachedVertexSize,
+		},
+		statusCache: &cache.SizedLRU[ids.ID, choices.Status]{MaxSize: statusCacheSize},
+		edgeCache:   &cache.SizedLRU[ids.ID, []ids.ID]{MaxSize: edgeCacheSize},
+		db:          versionDB,
 	}
 
 	s.state = newPrefixedState(rawState, idCacheSize)
@@ -19,8 +19,19 @@ type state struct {
 	serializer *Serializer
 	log        logging.Logger
 
-	dbCache cache.Cacher
-	db      database.Database
+	// A nil vertex is cached if the vertex isn't in storage.
+	vtxCache    cache.TypedCacher[ids.ID, vertex.StatelessVertex]
+	statusCache cache.TypedCacher[ids.ID, choices.Status]
+	// A nil frontier is cached if the frontier isn't in storage.
+	edgeCache cache.TypedCacher[ids.ID, []ids.ID]
+	db        database.Database
+}
+
+func cachedVertexSize(_ ids.ID, vtx vertex.StatelessVertex) int {
+	if vtx == nil {
+		return hashing.HashLen + vertexCacheEntryOverhead
+	}
+	return hashing.HashLen + len(vtx.Bytes()) + vertexCacheEntryOverhead
 }
 
 // Vertex retrieves the vertex with the given id from cache/disk.
@@ -33,32 +44,31 @@ func (s *state) Vertex(id ids.ID) vertex.StatelessVertex {
 		err   error
 	)
 
-	if vtxIntf, found := s.dbCache.Get(id); found {
-		vtx, _ = vtxIntf.(vertex.StatelessVertex)
+	if vtx, found := s.vtxCache.Get(id); found {
 		return vtx
 	}
 
 	if bytes, err = s.db.Get(id[:]); err != nil {
 		s.log.Verbo("Failed to get vertex %s from database due to %s", id, err)
-		s.dbCache.Put(id, nil)
+		s.vtxCache.Put(id, nil)
 		return nil
 	}
 
 	if vtx, err = s.serializer.parseVertex(bytes); err != nil {
 		s.log.Error("Parsing failed on saved vertex. Prefixed key = %s, Bytes = %s due to %s",
 			id, formatting.DumpBytes(bytes), err)
-		s.dbCache.Put(id, nil)
+		s.vtxCache.Put(id, nil)
 		return nil
 	}
 
-	s.dbCache.Put(id, vtx)
+	s.vtxCache.Put(id, vtx)
 	return vtx
 }
 
 // SetVertex persists the vertex to the database and returns an error if it
 // fails to write to the db
 func (s *state) SetVertex(id ids.ID, vtx vertex.StatelessVertex) error {
-	s.dbCache.Put(id, vtx)
+	s.vtxCache.Put(id, vtx)
 
 	if vtx == nil {
 		return s.db.Delete(id[:])
@@ -68,25 +78,24 @@ func (s *state) SetVertex(id ids.ID, vtx vertex.StatelessVertex) error {
 }
 
 func (s *state) Status(id ids.ID) choices.Status {
-	if statusIntf, found := s.dbCache.Get(id); found {
-		status, _ := statusIntf.(choices.Status)
+	if status, found := s.statusCache.Get(id); found {
 		return status
 	}
 
 	if val, err := database.GetUInt32(s.db, id[:]); err == nil {
 		// The key was in the database
 		status := choices.Status(val)
-		s.dbCache.Put(id, status)
+		s.statusCache.Put(id, status)
 		return status
 	}
 
-	s.dbCache.Put(id, choices.Unknown)
+	s.statusCache.Put(id, choices.Unknown)
 	return choices.Unknown
 }
 
 // SetStatus sets the status of the vertex and returns an error if it fails to write to the db
 func (s *state) SetStatus(id ids.ID, status choices.Status) error {
-	s.dbCache.Put(id, status)
+	s.statusCache.Put(id, status)
 
 	if status == choices.Unknown {
 		return s.db.Delete(id[:])
@@ -95,8 +104,7 @@ func (s *state) SetStatus(id ids.ID, status choices.Status) error {
 }
 
 func (s *state) Edge(id ids.ID) []ids.ID {
-	if frontierIntf, found := s.dbCache.Get(id); found {
-		frontier, _ := frontierIntf.([]ids.ID)
+	if frontier, found := s.edgeCache.Get(id); found {
 		return frontier
 	}
 
@@ -112,7 +120,7 @@ func (s *state) Edge(id ids.ID) []ids.ID {
 		}
 
 		if p.Offset == len(b) && !p.Errored() {
-			s.dbCache.Put(id, frontier)
+			s.edgeCache.Put(id, frontier)
 			return frontier
 		}
 		s.log.Error("Parsing failed on saved ids.\nPrefixed key = %s\nBytes = %s",
@@ -120,13 +128,13 @@ func (s *state) Edge(id ids.ID) []ids.ID {
 			formatting.DumpBytes(b))
 	}
 
-	s.dbCache.Put(id, nil) // Cache the miss
+	s.edgeCache.Put(id, nil) // Cache the miss
 	return nil
 }
 
 // SetEdge sets the frontier and returns an error if it fails to write to the db
 func (s *state) SetEdge(id ids.ID, frontier []ids.ID) error {
-	s.dbCache.Put(id, frontier)
+	s.edgeCache.Put(id, frontier)
 
 	if len(frontier) == 0 {
 		return s.db.Delete(id[:])
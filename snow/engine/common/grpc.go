@@ -0,0 +1,19 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import "google.golang.org/grpc"
+
+// GRPCVM is implemented by VMs that serve a gRPC API in addition to their HTTP
+// handlers.
+type GRPCVM interface {
+	// Registers the gRPC services of the chain with [registrar].
+	//
+	// The node serves the services of every chain on the same gRPC server, and
+	// routes each call to a chain by the "chain" metadata of the call, which is
+	// the alias or ID of the chain. Unary calls are made with the chain's
+	// context lock held, and calls are rejected until the chain has
+	// bootstrapped.
+	RegisterGRPCServices(registrar grpc.ServiceRegistrar) error
+}
@@ -26,7 +26,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 	called := make(chan struct{})
 
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	ctx := snow.DefaultConsensusContextTest()
@@ -111,7 +111,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 	err := vdrs.AddWeight(ids.GenerateTestNodeID(), 1)
 	assert.NoError(t, err)
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
@@ -182,7 +182,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	err := vdrs.AddWeight(ids.GenerateTestNodeID(), 1)
 	assert.NoError(t, err)
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
@@ -245,7 +245,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	err := vdrs.AddWeight(ids.GenerateTestNodeID(), 1)
 	assert.NoError(t, err)
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
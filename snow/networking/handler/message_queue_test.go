@@ -36,7 +36,7 @@ func TestQueue(t *testing.T) {
 	currentTime := time.Now()
 	u.clock.Set(currentTime)
 
-	mc, err := message.NewCreator(prometheus.NewRegistry(), true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(prometheus.NewRegistry(), true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(err)
 	mc.SetTime(currentTime)
 	msg1 := mc.InboundPut(ids.Empty,
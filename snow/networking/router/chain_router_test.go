@@ -42,6 +42,7 @@ func TestShutdown(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -52,7 +53,7 @@ func TestShutdown(t *testing.T) {
 
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -138,6 +139,7 @@ func TestShutdownTimesOut(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		nil,
 		"",
 		metrics,
 	)
@@ -148,7 +150,7 @@ func TestShutdownTimesOut(t *testing.T) {
 
 	chainRouter := ChainRouter{}
 
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID,
@@ -247,6 +249,7 @@ func TestRouterTimeout(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -258,7 +261,7 @@ func TestRouterTimeout(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -371,6 +374,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -382,7 +386,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
@@ -483,6 +487,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -494,7 +499,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 	// Create a router
 	chainRouter := ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
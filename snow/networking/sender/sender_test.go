@@ -51,6 +51,7 @@ func TestTimeout(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -61,7 +62,7 @@ func TestTimeout(t *testing.T) {
 
 	chainRouter := router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
@@ -142,6 +143,7 @@ func TestReliableMessages(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -152,7 +154,7 @@ func TestReliableMessages(t *testing.T) {
 
 	chainRouter := router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
@@ -238,6 +240,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -248,7 +251,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 
 	chainRouter := router.ChainRouter{}
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second, nil)
 	assert.NoError(t, err)
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
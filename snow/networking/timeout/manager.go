@@ -63,9 +63,12 @@ type Manager interface {
 	RemoveRequest(requestID ids.ID)
 }
 
+// NewManager returns a Manager of the timeouts of requests. If [onTimeout]
+// isn't nil, it's called with the node ID of every request that times out.
 func NewManager(
 	timeoutConfig *timer.AdaptiveTimeoutConfig,
 	benchlistMgr benchlist.Manager,
+	onTimeout func(ids.NodeID),
 	metricsNamespace string,
 	metricsRegister prometheus.Registerer,
 ) (Manager, error) {
@@ -79,6 +82,7 @@ func NewManager(
 	}
 	return &manager{
 		benchlistMgr: benchlistMgr,
+		onTimeout:    onTimeout,
 		tm:           tm,
 	}, nil
 }
@@ -86,6 +90,7 @@ func NewManager(
 type manager struct {
 	tm           timer.AdaptiveTimeoutManager
 	benchlistMgr benchlist.Manager
+	onTimeout    func(ids.NodeID)
 	metrics      metrics
 }
 
@@ -126,6 +131,9 @@ func (m *manager) RegisterRequest(
 	newTimeoutHandler := func() {
 		// If this request timed out, tell the benchlist manager
 		m.benchlistMgr.RegisterFailure(chainID, nodeID)
+		if m.onTimeout != nil {
+			m.onTimeout(nodeID)
+		}
 		timeoutHandler()
 	}
 	m.tm.Put(requestID, op, newTimeoutHandler)
@@ -26,6 +26,7 @@ func TestManagerFire(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -42,6 +43,37 @@ func TestManagerFire(t *testing.T) {
 	wg.Wait()
 }
 
+func TestManagerOnTimeout(t *testing.T) {
+	benchlist := benchlist.NewNoBenchlist()
+	timedOut := make(chan ids.NodeID, 1)
+	manager, err := NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Millisecond,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutCoefficient: 1.25,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist,
+		func(nodeID ids.NodeID) {
+			timedOut <- nodeID
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go manager.Dispatch()
+
+	nodeID := ids.GenerateTestNodeID()
+	manager.RegisterRequest(nodeID, ids.ID{}, message.PullQuery, ids.GenerateTestID(), func() {})
+
+	if timedOutNodeID := <-timedOut; timedOutNodeID != nodeID {
+		t.Fatalf("expected %s to time out but got %s", nodeID, timedOutNodeID)
+	}
+}
+
 func TestManagerCancel(t *testing.T) {
 	benchlist := benchlist.NewNoBenchlist()
 	manager, err := NewManager(
@@ -53,6 +85,7 @@ func TestManagerCancel(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		nil,
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	errNotZstdFrame = errors.New("msg isn't a zstd frame")
+
+	// Every zstd frame starts with these bytes
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+	_ Compressor = &zstdCompressor{}
+)
+
+type zstdCompressor struct {
+	maxSize int64
+
+	// Both are safe to use concurrently
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// NewZstdCompressor returns a new zstd Compressor. If [dict] is non-empty,
+// messages are compressed with it. Messages compressed with [dict] or any of
+// [decoderDicts] can be decompressed.
+func NewZstdCompressor(maxSize int64, dict []byte, decoderDicts ...[]byte) (Compressor, error) {
+	var encoderOpts []zstd.EOption
+	if len(dict) > 0 {
+		encoderOpts = append(encoderOpts, zstd.WithEncoderDict(dict))
+		decoderDicts = append(decoderDicts, dict)
+	}
+	encoder, err := zstd.NewWriter(nil, encoderOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create zstd encoder: %w", err)
+	}
+	decoder, err := zstd.NewReader(
+		nil,
+		zstd.WithDecoderMaxMemory(uint64(maxSize)),
+		zstd.WithDecoderDicts(decoderDicts...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create zstd decoder: %w", err)
+	}
+	return &zstdCompressor{
+		maxSize: maxSize,
+		encoder: encoder,
+		decoder: decoder,
+	}, nil
+}
+
+// Compress [msg] and returns the compressed bytes.
+func (z *zstdCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("msg length (%d) > maximum msg length (%d)", len(msg), z.maxSize)
+	}
+	return z.encoder.EncodeAll(msg, nil), nil
+}
+
+// Decompress decompresses [msg].
+func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The decoder treats input that's too short to be a frame as empty, so it
+	// must be checked here.
+	if !bytes.HasPrefix(msg, zstdMagic) {
+		return nil, errNotZstdFrame
+	}
+	decompressed, err := z.decoder.DecodeAll(msg, nil)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > z.maxSize {
+		return nil, fmt.Errorf("msg length > maximum msg length (%d)", z.maxSize)
+	}
+	return decompressed, nil
+}
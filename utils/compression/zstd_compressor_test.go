@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZstdCompressDecompress(t *testing.T) {
+	data := make([]byte, 4096)
+	for i := 0; i < len(data); i++ {
+		data[i] = byte(rand.Intn(256)) // #nosec G404
+	}
+
+	compressor, err := NewZstdCompressor(2*units.MiB, nil)
+	assert.NoError(t, err)
+
+	dataCompressed, err := compressor.Compress(data)
+	assert.NoError(t, err)
+
+	dataDecompressed, err := compressor.Decompress(dataCompressed)
+	assert.NoError(t, err)
+	assert.EqualValues(t, data, dataDecompressed)
+
+	nonZstdData := []byte{1, 2, 3}
+	_, err = compressor.Decompress(nonZstdData)
+	assert.Error(t, err)
+}
+
+func TestZstdSizeLimiting(t *testing.T) {
+	data := make([]byte, 3*units.MiB)
+	compressor, err := NewZstdCompressor(2*units.MiB, nil)
+	assert.NoError(t, err)
+	_, err = compressor.Compress(data) // should be too large
+	assert.Error(t, err)
+
+	compressor2, err := NewZstdCompressor(4*units.MiB, nil)
+	assert.NoError(t, err)
+	dataCompressed, err := compressor2.Compress(data)
+	assert.NoError(t, err)
+
+	_, err = compressor.Decompress(dataCompressed) // should be too large
+	assert.Error(t, err)
+}
+
+func TestZstdDictionary(t *testing.T) {
+	assert := assert.New(t)
+
+	// Dictionary trained on JSON objects like [data]
+	dict, err := os.ReadFile("testdata/zstd.dict")
+	assert.NoError(err)
+	data := []byte(`{"chainID":"0x5f1ab9ce","height":1337,"parentID":"0x24c5e3f0"}`)
+
+	compressor, err := NewZstdCompressor(2*units.MiB, dict)
+	assert.NoError(err)
+	dataCompressed, err := compressor.Compress(data)
+	assert.NoError(err)
+
+	dataDecompressed, err := compressor.Decompress(dataCompressed)
+	assert.NoError(err)
+	assert.Equal(data, dataDecompressed)
+
+	// A compressor that was given the dictionary to decode can decompress the
+	// data, but one that wasn't can't.
+	decompressor, err := NewZstdCompressor(2*units.MiB, nil, dict)
+	assert.NoError(err)
+	dataDecompressed, err = decompressor.Decompress(dataCompressed)
+	assert.NoError(err)
+	assert.Equal(data, dataDecompressed)
+
+	noDictCompressor, err := NewZstdCompressor(2*units.MiB, nil)
+	assert.NoError(err)
+	_, err = noDictCompressor.Decompress(dataCompressed)
+	assert.Error(err)
+}
+
+func TestTypeFromString(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, compressionType := range []Type{TypeNone, TypeGzip, TypeZstd} {
+		parsedType, err := TypeFromString(compressionType.String())
+		assert.NoError(err)
+		assert.Equal(compressionType, parsedType)
+		assert.True(compressionType.Valid())
+	}
+
+	_, err := TypeFromString("lz4")
+	assert.ErrorIs(err, errUnknownCompressionType)
+	assert.False(Type(TypeZstd + 1).Valid())
+}
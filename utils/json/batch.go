@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+// DefaultMaxBatchSize is the default maximum number of requests in a JSON-RPC
+// batch.
+const DefaultMaxBatchSize = 100
+
+// NewBatchHandler returns a handler that serves JSON-RPC 2.0 batches, which
+// are arrays of requests, by calling [handler] with each request of the batch
+// in order. Other requests are passed to [handler] unmodified. Batches with
+// more than [maxBatchSize] requests are rejected. If [maxBatchSize] isn't
+// positive, batches aren't supported.
+//
+// Because each request of a batch is a separate call to [handler], any
+// middleware wrapped by the returned handler, such as locking, is applied to
+// every request of the batch rather than to the batch as a whole.
+func NewBatchHandler(handler http.Handler, maxBatchSize int) http.Handler {
+	if maxBatchSize <= 0 {
+		return handler
+	}
+	return &batchHandler{
+		handler:      handler,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+type batchHandler struct {
+	handler      http.Handler
+	maxBatchSize int
+}
+
+// batchError is an error response to a batch or to a request of a batch
+type batchError struct {
+	Version string          `json:"jsonrpc"`
+	Error   *json2.Error    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+func (b *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		b.handler.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := RequestBody(r)
+	if err != nil {
+		writeBatchError(w, json2.E_PARSE, fmt.Sprintf("couldn't read request: %s", err))
+		return
+	}
+	if trimmed := bytes.TrimLeft(body, " \t\r\n"); len(trimmed) == 0 || trimmed[0] != '[' {
+		b.handler.ServeHTTP(w, r)
+		return
+	}
+
+	var requests []json.RawMessage
+	if err := json.Unmarshal(body, &requests); err != nil {
+		writeBatchError(w, json2.E_PARSE, fmt.Sprintf("couldn't parse batch: %s", err))
+		return
+	}
+	switch {
+	case len(requests) == 0:
+		writeBatchError(w, json2.E_INVALID_REQ, "batch is empty")
+		return
+	case len(requests) > b.maxBatchSize:
+		writeBatchError(w, json2.E_INVALID_REQ, fmt.Sprintf("batch has %d requests, which exceeds the maximum of %d", len(requests), b.maxBatchSize))
+		return
+	}
+
+	responses := make([]json.RawMessage, 0, len(requests))
+	for _, request := range requests {
+		if response, ok := b.serve(r, request); ok {
+			responses = append(responses, response)
+		}
+	}
+	// If every request was a notification, nothing is returned.
+	if len(responses) == 0 {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	// Doesn't matter if there's an error while writing. The client will be
+	// unable to parse the response.
+	_ = json.NewEncoder(w).Encode(responses)
+}
+
+// serve calls the handler with [request], made as part of the batch [r].
+// Returns the response to [request], or false if [request] doesn't have a
+// response.
+func (b *batchHandler) serve(r *http.Request, request json.RawMessage) (json.RawMessage, bool) {
+	subRequest := WithBody(r.Clone(r.Context()), request)
+
+	recorder := &responseRecorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+	b.handler.ServeHTTP(recorder, subRequest)
+
+	response := bytes.TrimSpace(recorder.body.Bytes())
+	if recorder.status == http.StatusOK && json.Valid(response) {
+		return response, true
+	}
+
+	// The handler didn't produce a JSON-RPC response, such as when the call
+	// was rejected by a middleware, so the failure is reported as an error.
+	id := struct {
+		ID json.RawMessage `json:"id"`
+	}{}
+	if err := json.Unmarshal(request, &id); err != nil || len(id.ID) == 0 || bytes.Equal(id.ID, []byte(Null)) {
+		// Notifications don't have a response.
+		return nil, false
+	}
+	// Middlewares may reject the call with a JSON-RPC error, which is reported
+	// as is.
+	rejection := batchError{}
+	if err := json.Unmarshal(response, &rejection); err != nil || rejection.Error == nil {
+		message := string(response)
+		if len(message) == 0 {
+			message = http.StatusText(recorder.status)
+		}
+		rejection.Error = &json2.Error{
+			Code:    json2.E_SERVER,
+			Message: message,
+		}
+	}
+	errResponse, err := json.Marshal(batchError{
+		Version: json2.Version,
+		Error:   rejection.Error,
+		ID:      id.ID,
+	})
+	return errResponse, err == nil
+}
+
+// writeBatchError writes the response to a batch that couldn't be handled
+func writeBatchError(w http.ResponseWriter, code json2.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	// Doesn't matter if there's an error while writing. The client will be
+	// unable to parse the response.
+	_ = json.NewEncoder(w).Encode(batchError{
+		Version: json2.Version,
+		Error: &json2.Error{
+			Code:    code,
+			Message: message,
+		},
+		ID: json.RawMessage(Null),
+	})
+}
+
+// responseRecorder records the response to a request of a batch
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header { return r.header }
+
+func (r *responseRecorder) Write(b []byte) (int, error) { return r.body.Write(b) }
+
+func (r *responseRecorder) WriteHeader(status int) { r.status = status }
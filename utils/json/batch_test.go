@@ -0,0 +1,202 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errOdd = errors.New("odd")
+
+type testService struct{}
+
+type DoubleArgs struct {
+	Value int `json:"value"`
+}
+
+type DoubleReply struct {
+	Value int `json:"value"`
+}
+
+func (*testService) Double(_ *http.Request, args *DoubleArgs, reply *DoubleReply) error {
+	if args.Value%2 != 0 {
+		return errOdd
+	}
+	reply.Value = 2 * args.Value
+	return nil
+}
+
+func newTestServer(t *testing.T) http.Handler {
+	server := rpc.NewServer()
+	server.RegisterCodec(NewCodec(), "application/json")
+	if err := server.RegisterService(&testService{}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+func post(handler http.Handler, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+type testResponse struct {
+	ID     *int         `json:"id"`
+	Result *DoubleReply `json:"result"`
+	Error  *json2.Error `json:"error"`
+}
+
+func TestBatchHandlerSingleRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewBatchHandler(newTestServer(t), 10)
+	w := post(h, `{"jsonrpc":"2.0","id":1,"method":"test.double","params":{"value":2}}`)
+	assert.Equal(http.StatusOK, w.Code)
+
+	response := testResponse{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Nil(response.Error)
+	assert.Equal(4, response.Result.Value)
+}
+
+func TestBatchHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	calls := 0
+	server := newTestServer(t)
+	h := NewBatchHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		server.ServeHTTP(w, r)
+	}), 10)
+
+	w := post(h, `[
+		{"jsonrpc":"2.0","id":1,"method":"test.double","params":{"value":2}},
+		{"jsonrpc":"2.0","method":"test.double","params":{"value":4}},
+		{"jsonrpc":"2.0","id":2,"method":"test.double","params":{"value":3}},
+		{"jsonrpc":"2.0","id":3,"method":"test.double","params":{"value":6}}
+	]`)
+	assert.Equal(http.StatusOK, w.Code)
+	// Each request in the batch should be a separate call.
+	assert.Equal(4, calls)
+
+	responses := []testResponse{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	// Notifications shouldn't have a response.
+	assert.Len(responses, 3)
+
+	assert.Equal(1, *responses[0].ID)
+	assert.Equal(4, responses[0].Result.Value)
+	assert.Equal(2, *responses[1].ID)
+	assert.Nil(responses[1].Result)
+	assert.Equal(errOdd.Error(), responses[1].Error.Message)
+	assert.Equal(3, *responses[2].ID)
+	assert.Equal(12, responses[2].Result.Value)
+}
+
+func TestBatchHandlerRejectedRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	// Mimic a middleware that rejects calls without calling the service.
+	h := NewBatchHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("rejected"))
+	}), 10)
+
+	w := post(h, `[
+		{"jsonrpc":"2.0","id":"a","method":"test.double","params":{"value":1}},
+		{"jsonrpc":"2.0","method":"test.double","params":{"value":3}}
+	]`)
+	assert.Equal(http.StatusOK, w.Code)
+
+	responses := []struct {
+		ID    string       `json:"id"`
+		Error *json2.Error `json:"error"`
+	}{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	assert.Len(responses, 1)
+	assert.Equal("a", responses[0].ID)
+	assert.Equal(json2.E_SERVER, responses[0].Error.Code)
+	assert.Equal("rejected", responses[0].Error.Message)
+}
+
+func TestBatchHandlerRejectedRequestWithError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Mimic a middleware that rejects calls with a JSON-RPC error.
+	h := NewBatchHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32000,"message":"rejected"},"id":null}`))
+	}), 10)
+
+	w := post(h, `[{"jsonrpc":"2.0","id":"a","method":"test.double","params":{"value":1}}]`)
+	assert.Equal(http.StatusOK, w.Code)
+
+	responses := []struct {
+		ID    string       `json:"id"`
+		Error *json2.Error `json:"error"`
+	}{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	assert.Len(responses, 1)
+	assert.Equal("a", responses[0].ID)
+	assert.Equal(json2.E_SERVER, responses[0].Error.Code)
+	assert.Equal("rejected", responses[0].Error.Message)
+}
+
+func TestBatchHandlerInvalidBatch(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		code json2.ErrorCode
+	}{
+		{
+			name: "empty",
+			body: `[]`,
+			code: json2.E_INVALID_REQ,
+		},
+		{
+			name: "too large",
+			body: `[{}, {}, {}]`,
+			code: json2.E_INVALID_REQ,
+		},
+		{
+			name: "malformed",
+			body: `[{}`,
+			code: json2.E_PARSE,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			h := NewBatchHandler(newTestServer(t), 2)
+			w := post(h, test.body)
+			assert.Equal(http.StatusOK, w.Code)
+
+			response := testResponse{}
+			assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Nil(response.ID)
+			assert.Equal(test.code, response.Error.Code)
+		})
+	}
+}
+
+func TestBatchHandlerDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	server := newTestServer(t)
+	assert.Equal(server, NewBatchHandler(server, 0))
+}
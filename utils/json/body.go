@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// DefaultMaxRequestSize is the default maximum size, in bytes, of the body of
+// an API request.
+const DefaultMaxRequestSize = 16 * units.MiB
+
+var errRequestTooLarge = errors.New("request body is too large")
+
+// bodyKey is the context key of a request's body
+type bodyKey struct{}
+
+// NewBodyHandler returns a handler that reads the body of each request, once,
+// before calling [handler]. Bodies larger than [maxSize] bytes are rejected.
+// The body is shared with the middlewares of [handler] through the request's
+// context, so that they don't each need to buffer it. See RequestBody.
+func NewBodyHandler(handler http.Handler, maxSize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := readBody(r.Body, maxSize)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, errRequestTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(status)
+			writeBatchError(w, json2.E_PARSE, fmt.Sprintf("couldn't read request: %s", err))
+			return
+		}
+		handler.ServeHTTP(w, WithBody(r, body))
+	})
+}
+
+// WithBody returns a shallow copy of [r] whose body is [body]. [body] is also
+// shared through the request's context. See RequestBody.
+func WithBody(r *http.Request, body []byte) *http.Request {
+	r = r.WithContext(context.WithValue(r.Context(), bodyKey{}, body))
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	return r
+}
+
+// RequestBody returns the body of [r] without consuming it. The returned bytes
+// must not be modified.
+//
+// If the body wasn't read by NewBodyHandler, it's read here, up to
+// DefaultMaxRequestSize bytes, and the body of [r] is restored afterwards.
+func RequestBody(r *http.Request) ([]byte, error) {
+	if body, ok := r.Context().Value(bodyKey{}).([]byte); ok {
+		return body, nil
+	}
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := readBody(r.Body, DefaultMaxRequestSize)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// readBody reads and closes [body]. Returns an error if [body] is larger than
+// [maxSize] bytes.
+func readBody(body io.ReadCloser, maxSize int64) ([]byte, error) {
+	defer body.Close()
+
+	contents, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(contents)) > maxSize {
+		return nil, fmt.Errorf("%w: exceeds the maximum of %d bytes", errRequestTooLarge, maxSize)
+	}
+	return contents, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyHandlerSharesBody(t *testing.T) {
+	assert := assert.New(t)
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"test.double","params":{"value":2}}`
+	server := newTestServer(t)
+	h := NewBodyHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Middlewares can read the body without consuming it.
+		for i := 0; i < 2; i++ {
+			body, err := RequestBody(r)
+			assert.NoError(err)
+			assert.Equal(request, string(body))
+		}
+		server.ServeHTTP(w, r)
+	}), int64(len(request)))
+
+	w := post(h, request)
+	assert.Equal(http.StatusOK, w.Code)
+
+	response := testResponse{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Nil(response.Error)
+	assert.Equal(4, response.Result.Value)
+}
+
+func TestBodyHandlerRejectsLargeBody(t *testing.T) {
+	assert := assert.New(t)
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"test.double","params":{"value":2}}`
+	called := false
+	h := NewBodyHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}), int64(len(request)-1))
+
+	w := post(h, request)
+	assert.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	assert.False(called)
+
+	response := testResponse{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotNil(response.Error)
+}
+
+func TestBodyHandlerBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	h := NewBodyHandler(NewBatchHandler(newTestServer(t), 10), DefaultMaxRequestSize)
+	w := post(h, `[
+		{"jsonrpc":"2.0","id":1,"method":"test.double","params":{"value":2}},
+		{"jsonrpc":"2.0","id":2,"method":"test.double","params":{"value":4}}
+	]`)
+	assert.Equal(http.StatusOK, w.Code)
+
+	responses := []testResponse{}
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	assert.Len(responses, 2)
+	assert.Equal(4, responses[0].Result.Value)
+	assert.Equal(8, responses[1].Result.Value)
+}
+
+func TestRequestBodyWithoutBodyHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := http.NewRequest(http.MethodPost, "/", strings.NewReader("body"))
+	assert.NoError(err)
+
+	body, err := RequestBody(r)
+	assert.NoError(err)
+	assert.Equal("body", string(body))
+
+	// The body should be restored after being read.
+	restored, err := io.ReadAll(r.Body)
+	assert.NoError(err)
+	assert.Equal("body", string(restored))
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+
+	avmpb "github.com/ava-labs/avalanchego/proto/pb/avm"
+)
+
+var (
+	_ common.GRPCVM   = &VM{}
+	_ avmpb.AVMServer = &grpcServer{}
+)
+
+// RegisterGRPCServices registers the read methods of the AVM API as a gRPC
+// service
+func (vm *VM) RegisterGRPCServices(registrar grpc.ServiceRegistrar) error {
+	avmpb.RegisterAVMServer(registrar, &grpcServer{
+		service: &Service{vm: vm},
+	})
+	return nil
+}
+
+// grpcServer serves the read methods of the AVM API over gRPC. It's called
+// with the context lock held.
+type grpcServer struct {
+	avmpb.UnsafeAVMServer
+
+	service *Service
+}
+
+func (s *grpcServer) GetTx(_ context.Context, req *avmpb.GetTxRequest) (*avmpb.GetTxResponse, error) {
+	txID, err := parseTxID(req.GetTxId())
+	if err != nil {
+		return nil, err
+	}
+	tx := UniqueTx{
+		vm:   s.service.vm,
+		txID: txID,
+	}
+	if status := tx.Status(); !status.Fetched() {
+		return nil, grpcNotFound(errUnknownTx)
+	}
+	return &avmpb.GetTxResponse{Tx: tx.Bytes()}, nil
+}
+
+func (s *grpcServer) GetTxStatus(_ context.Context, req *avmpb.GetTxStatusRequest) (*avmpb.GetTxStatusResponse, error) {
+	txID, err := parseTxID(req.GetTxId())
+	if err != nil {
+		return nil, err
+	}
+	reply := GetTxStatusReply{}
+	if err := s.service.GetTxStatus(nil, &api.JSONTxID{TxID: txID}, &reply); err != nil {
+		return nil, err
+	}
+	return &avmpb.GetTxStatusResponse{Status: reply.Status.String()}, nil
+}
+
+func (s *grpcServer) GetBalance(_ context.Context, req *avmpb.GetBalanceRequest) (*avmpb.GetBalanceResponse, error) {
+	args := GetBalanceArgs{
+		Address:        req.GetAddress(),
+		AssetID:        req.GetAssetId(),
+		IncludePartial: req.GetIncludePartial(),
+	}
+	reply := GetBalanceReply{}
+	if err := s.service.GetBalance(nil, &args, &reply); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &avmpb.GetBalanceResponse{Balance: uint64(reply.Balance)}, nil
+}
+
+func (s *grpcServer) GetAssetDescription(_ context.Context, req *avmpb.GetAssetDescriptionRequest) (*avmpb.GetAssetDescriptionResponse, error) {
+	args := GetAssetDescriptionArgs{
+		AssetID: req.GetAssetId(),
+	}
+	reply := GetAssetDescriptionReply{}
+	if err := s.service.GetAssetDescription(nil, &args, &reply); err != nil {
+		return nil, grpcNotFound(err)
+	}
+	return &avmpb.GetAssetDescriptionResponse{
+		AssetId:      reply.AssetID[:],
+		Name:         reply.Name,
+		Symbol:       reply.Symbol,
+		Denomination: uint32(reply.Denomination),
+	}, nil
+}
+
+// parseTxID returns the non-empty tx ID [txIDBytes]
+func parseTxID(txIDBytes []byte) (ids.ID, error) {
+	txID, err := ids.ToID(txIDBytes)
+	if err != nil {
+		return ids.Empty, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if txID == ids.Empty {
+		return ids.Empty, status.Error(codes.InvalidArgument, errNilTxID.Error())
+	}
+	return txID, nil
+}
+
+func grpcNotFound(err error) error {
+	return status.Error(codes.NotFound, err.Error())
+}
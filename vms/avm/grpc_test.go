@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+
+	avmpb "github.com/ava-labs/avalanchego/proto/pb/avm"
+)
+
+func TestGRPCGetTx(t *testing.T) {
+	assert := assert.New(t)
+
+	genesisBytes, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	server := &grpcServer{service: s}
+
+	txID := genesisTx.ID()
+	txResp, err := server.GetTx(context.Background(), &avmpb.GetTxRequest{TxId: txID[:]})
+	assert.NoError(err)
+	assert.Equal(genesisTx.Bytes(), txResp.Tx)
+
+	unknownTxID := NewTx(t, genesisBytes, vm).ID()
+	_, err = server.GetTx(context.Background(), &avmpb.GetTxRequest{TxId: unknownTxID[:]})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	_, err = server.GetTx(context.Background(), &avmpb.GetTxRequest{TxId: ids.Empty[:]})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = server.GetTx(context.Background(), &avmpb.GetTxRequest{TxId: []byte{1}})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	statusResp, err := server.GetTxStatus(context.Background(), &avmpb.GetTxStatusRequest{TxId: unknownTxID[:]})
+	assert.NoError(err)
+	assert.Equal(choices.Unknown.String(), statusResp.Status)
+
+	_, err = server.GetTxStatus(context.Background(), &avmpb.GetTxStatusRequest{})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCGetBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	server := &grpcServer{service: s}
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+
+	resp, err := server.GetBalance(context.Background(), &avmpb.GetBalanceRequest{
+		Address: addrStr,
+		AssetId: genesisTx.ID().String(),
+	})
+	assert.NoError(err)
+	assert.EqualValues(startBalance, resp.Balance)
+
+	_, err = server.GetBalance(context.Background(), &avmpb.GetBalanceRequest{
+		Address: "not an address",
+		AssetId: genesisTx.ID().String(),
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCGetAssetDescription(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+	server := &grpcServer{service: s}
+
+	avaxAssetID := genesisTx.ID()
+	resp, err := server.GetAssetDescription(context.Background(), &avmpb.GetAssetDescriptionRequest{
+		AssetId: avaxAssetID.String(),
+	})
+	assert.NoError(err)
+	assert.Equal(avaxAssetID[:], resp.AssetId)
+	assert.Equal("AVAX", resp.Name)
+	assert.Equal("SYMB", resp.Symbol)
+
+	_, err = server.GetAssetDescription(context.Background(), &avmpb.GetAssetDescriptionRequest{
+		AssetId: ids.GenerateTestID().String(),
+	})
+	assert.Equal(codes.NotFound, status.Code(err))
+}
@@ -12,10 +12,9 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
@@ -294,7 +293,10 @@ func (vm *VM) Version() (string, error) {
 func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 	codec := json.NewCodec()
 
-	rpcServer := rpc.NewServer()
+	rpcServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	rpcServer.RegisterCodec(codec, "application/json")
 	rpcServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	rpcServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
@@ -304,13 +306,16 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		return nil, err
 	}
 
-	walletServer := rpc.NewServer()
+	walletServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	walletServer.RegisterCodec(codec, "application/json")
 	walletServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	walletServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
 	walletServer.RegisterAfterFunc(vm.metrics.apiRequestMetric.AfterRequest)
 	// name this service "wallet"
-	err := walletServer.RegisterService(&vm.walletService, "wallet")
+	err = walletServer.RegisterService(&vm.walletService, "wallet")
 
 	return map[string]*common.HTTPHandler{
 		"":        {Handler: rpcServer},
@@ -320,7 +325,10 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 }
 
 func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
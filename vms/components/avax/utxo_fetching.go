@@ -51,6 +51,9 @@ func GetAllUTXOs(db UTXOReader, addrs ids.ShortSet) ([]*UTXO, error) {
 // For address [startAddr], only returns UTXOs whose IDs are greater than
 // [startUTXOID].
 //
+// If [db] is a UTXOSnapshotter, the UTXOs are read from a point-in-time view
+// of [db] so that the page isn't torn by concurrent writes.
+//
 // Returns:
 // * The fetched UTXOs
 // * The address associated with the last UTXO fetched
@@ -62,6 +65,15 @@ func GetPaginatedUTXOs(
 	lastUTXOID ids.ID,
 	limit int,
 ) ([]*UTXO, ids.ShortID, ids.ID, error) {
+	if snapshotter, ok := db.(UTXOSnapshotter); ok {
+		snapshot, release, err := snapshotter.NewUTXOSnapshot()
+		if err != nil {
+			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't snapshot UTXOs: %w", err)
+		}
+		defer release()
+		db = snapshot
+	}
+
 	var (
 		utxos      []*UTXO
 		seen       ids.Set              // IDs of UTXOs already in the list
@@ -59,6 +59,9 @@ func TestFetchUTXOs(t *testing.T) {
 	utxos, err := GetAllUTXOs(s, addrs)
 	assert.NoError(err)
 	assert.Len(utxos, 1)
+	// UTXOs are read from a snapshot of the database, so their cached ID is
+	// only populated once it is requested.
+	assert.Equal(utxo.InputID(), utxos[0].InputID())
 	assert.Equal(utxo, utxos[0])
 
 	balance, err := GetBalance(s, addrs)
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/linkeddb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/snapshotdb"
 	"github.com/ava-labs/avalanchego/ids"
 )
 
@@ -30,6 +31,7 @@ const (
 type UTXOState interface {
 	UTXOReader
 	UTXOWriter
+	UTXOSnapshotter
 }
 
 // UTXOReader is a thin wrapper around a database to provide fetching of UTXOs.
@@ -49,6 +51,14 @@ type UTXOGetter interface {
 	GetUTXO(utxoID ids.ID) (*UTXO, error)
 }
 
+// UTXOSnapshotter wraps the NewUTXOSnapshot method of a UTXO store.
+type UTXOSnapshotter interface {
+	// NewUTXOSnapshot returns a reader of a point-in-time view of the UTXOs.
+	// The returned function must be called to release the view once it is no
+	// longer used.
+	NewUTXOSnapshot() (UTXOReader, func(), error)
+}
+
 // UTXOWriter is a thin wrapper around a database to provide storage and
 // deletion of UTXOs.
 type UTXOWriter interface {
@@ -211,6 +221,34 @@ func (s *utxoState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, err
 	return utxoIDs, iter.Error()
 }
 
+func (s *utxoState) NewUTXOSnapshot() (UTXOReader, func(), error) {
+	utxoDB, ok := s.utxoDB.(*prefixdb.Database)
+	if !ok {
+		// [s] is already a snapshot.
+		return s, func() {}, nil
+	}
+	indexDB, ok := s.indexDB.(*prefixdb.Database)
+	if !ok {
+		return s, func() {}, nil
+	}
+
+	snapshots, err := prefixdb.NewSnapshots(utxoDB, indexDB)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The caches of [s] may contain UTXOs that were written after the snapshot
+	// was taken, so the snapshot is read without them.
+	return &utxoState{
+		codec: s.codec,
+
+		utxoCache: &cache.LRU{Size: utxoCacheSize},
+		utxoDB:    snapshotdb.New(snapshots[0]),
+
+		indexDB:    snapshotdb.New(snapshots[1]),
+		indexCache: &cache.LRU{Size: indexCacheSize},
+	}, snapshots[0].Release, nil
+}
+
 func (s *utxoState) getIndexDB(addr []byte) linkeddb.LinkedDB {
 	addrStr := string(addr)
 	if indexList, exists := s.indexCache.Get(addrStr); exists {
@@ -36,24 +36,26 @@ type State struct {
 	// therefore currently in consensus.
 	verifiedBlocks map[ids.ID]*BlockWrapper
 	// decidedBlocks is an LRU cache of decided blocks.
-	// Every value in [decidedBlocks] is a (*BlockWrapper)
-	decidedBlocks cache.Cacher
+	decidedBlocks cache.TypedCacher[ids.ID, *BlockWrapper]
 	// unverifiedBlocks is an LRU cache of blocks with status processing
 	// that have not yet passed verification.
-	// Every value in [unverifiedBlocks] is a (*BlockWrapper)
-	unverifiedBlocks cache.Cacher
+	unverifiedBlocks cache.TypedCacher[ids.ID, *BlockWrapper]
 	// missingBlocks is an LRU cache of missing blocks
-	// Every value in [missingBlocks] is an empty struct.
-	missingBlocks cache.Cacher
+	missingBlocks cache.TypedCacher[ids.ID, struct{}]
 	// string([byte repr. of block]) --> the block's ID
-	bytesToIDCache    cache.Cacher
+	bytesToIDCache    cache.TypedCacher[string, ids.ID]
 	lastAcceptedBlock *BlockWrapper
 }
 
 // Config defines all of the parameters necessary to initialize State
 type Config struct {
-	// Cache configuration:
+	// Cache configuration. [BytesToIDCacheSize] bounds the total number of
+	// block bytes used as keys of the cache rather than the number of keys.
 	DecidedCacheSize, MissingCacheSize, UnverifiedCacheSize, BytesToIDCacheSize int
+	// BlockSize returns the size of a block. If non-nil, [DecidedCacheSize]
+	// and [UnverifiedCacheSize] bound the total size of the blocks in the
+	// caches rather than the number of blocks.
+	BlockSize func(snowman.Block) int
 
 	LastAcceptedBlock  snowman.Block
 	GetBlock           func(ids.ID) (snowman.Block, error)
@@ -125,13 +127,36 @@ func (s *State) initialize(config *Config) {
 	s.decidedBlocks.Put(config.LastAcceptedBlock.ID(), s.lastAcceptedBlock)
 }
 
+// newBlockCache returns a cache of at most [size] blocks, or of blocks with a
+// total size of at most [size] if [blockSize] is non-nil.
+func newBlockCache(size int, blockSize func(snowman.Block) int) *cache.SizedLRU[ids.ID, *BlockWrapper] {
+	c := &cache.SizedLRU[ids.ID, *BlockWrapper]{MaxSize: size}
+	if blockSize != nil {
+		c.Size = func(_ ids.ID, blk *BlockWrapper) int {
+			return blockSize(blk.Block)
+		}
+	}
+	return c
+}
+
+// newBytesToIDCache returns a cache of block IDs keyed by the block bytes,
+// whose keys have a total length of at most [size] bytes.
+func newBytesToIDCache(size int) *cache.SizedLRU[string, ids.ID] {
+	return &cache.SizedLRU[string, ids.ID]{
+		MaxSize: size,
+		Size: func(key string, _ ids.ID) int {
+			return len(key)
+		},
+	}
+}
+
 func NewState(config *Config) *State {
 	c := &State{
 		verifiedBlocks:   make(map[ids.ID]*BlockWrapper),
-		decidedBlocks:    &cache.LRU{Size: config.DecidedCacheSize},
-		missingBlocks:    &cache.LRU{Size: config.MissingCacheSize},
-		unverifiedBlocks: &cache.LRU{Size: config.UnverifiedCacheSize},
-		bytesToIDCache:   &cache.LRU{Size: config.BytesToIDCacheSize},
+		decidedBlocks:    newBlockCache(config.DecidedCacheSize, config.BlockSize),
+		missingBlocks:    &cache.SizedLRU[ids.ID, struct{}]{MaxSize: config.MissingCacheSize},
+		unverifiedBlocks: newBlockCache(config.UnverifiedCacheSize, config.BlockSize),
+		bytesToIDCache:   newBytesToIDCache(config.BytesToIDCacheSize),
 	}
 	c.initialize(config)
 	return c
@@ -141,34 +166,34 @@ func NewMeteredState(
 	registerer prometheus.Registerer,
 	config *Config,
 ) (*State, error) {
-	decidedCache, err := metercacher.New(
+	decidedCache, err := metercacher.NewTyped[ids.ID, *BlockWrapper](
 		"decided_cache",
 		registerer,
-		&cache.LRU{Size: config.DecidedCacheSize},
+		newBlockCache(config.DecidedCacheSize, config.BlockSize),
 	)
 	if err != nil {
 		return nil, err
 	}
-	missingCache, err := metercacher.New(
+	missingCache, err := metercacher.NewTyped[ids.ID, struct{}](
 		"missing_cache",
 		registerer,
-		&cache.LRU{Size: config.MissingCacheSize},
+		&cache.SizedLRU[ids.ID, struct{}]{MaxSize: config.MissingCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
-	unverifiedCache, err := metercacher.New(
+	unverifiedCache, err := metercacher.NewTyped[ids.ID, *BlockWrapper](
 		"unverified_cache",
 		registerer,
-		&cache.LRU{Size: config.UnverifiedCacheSize},
+		newBlockCache(config.UnverifiedCacheSize, config.BlockSize),
 	)
 	if err != nil {
 		return nil, err
 	}
-	bytesToIDCache, err := metercacher.New(
+	bytesToIDCache, err := metercacher.NewTyped[string, ids.ID](
 		"bytes_to_id_cache",
 		registerer,
-		&cache.LRU{Size: config.BytesToIDCacheSize},
+		newBytesToIDCache(config.BytesToIDCacheSize),
 	)
 	if err != nil {
 		return nil, err
@@ -252,11 +277,11 @@ func (s *State) getCachedBlock(blkID ids.ID) (snowman.Block, bool) {
 	}
 
 	if blk, ok := s.decidedBlocks.Get(blkID); ok {
-		return blk.(snowman.Block), true
+		return blk, true
 	}
 
 	if blk, ok := s.unverifiedBlocks.Get(blkID); ok {
-		return blk.(snowman.Block), true
+		return blk, true
 	}
 
 	return nil, false
@@ -276,9 +301,8 @@ func (s *State) GetBlockInternal(blkID ids.ID) (snowman.Block, error) {
 // caching layer if successful.
 func (s *State) ParseBlock(b []byte) (snowman.Block, error) {
 	// See if we've cached this block's ID by its byte repr.
-	blkIDIntf, blkIDCached := s.bytesToIDCache.Get(string(b))
+	blkID, blkIDCached := s.bytesToIDCache.Get(string(b))
 	if blkIDCached {
-		blkID := blkIDIntf.(ids.ID)
 		// See if we have this block cached
 		if cachedBlk, ok := s.getCachedBlock(blkID); ok {
 			return cachedBlk, nil
@@ -291,7 +315,7 @@ func (s *State) ParseBlock(b []byte) (snowman.Block, error) {
 	if err != nil {
 		return nil, err
 	}
-	blkID := blk.ID()
+	blkID = blk.ID()
 	s.bytesToIDCache.Put(string(b), blkID)
 
 	// Only check the caches if we didn't do so above
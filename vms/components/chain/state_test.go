@@ -715,6 +715,22 @@ func TestStateBytesToIDCache(t *testing.T) {
 	assert.False(t, ok)
 }
 
+// Test that the bytesToIDCache is bounded by the length of the block bytes
+func TestBytesToIDCacheSize(t *testing.T) {
+	c := newBytesToIDCache(5)
+	c.Put("abc", ids.GenerateTestID())
+	c.Put("de", ids.GenerateTestID())
+	assert.Equal(t, 5, c.CurrentSize())
+
+	// Adding another block should evict the oldest one
+	c.Put("f", ids.GenerateTestID())
+	_, ok := c.Get("abc")
+	assert.False(t, ok)
+	_, ok = c.Get("de")
+	assert.True(t, ok)
+	assert.Equal(t, 3, c.CurrentSize())
+}
+
 // TestSetLastAcceptedBlock ensures chainState's last accepted block
 // can be updated by calling [SetLastAcceptedBlock].
 func TestSetLastAcceptedBlock(t *testing.T) {
@@ -878,3 +894,46 @@ func TestStateParseTransitivelyAcceptedBlock(t *testing.T) {
 		t.Fatalf("Parsed blk1 reported incorrect height. Expected %d got %d", blk1.Height(), parsedBlk1.Height())
 	}
 }
+
+func TestStateBlockSizeBoundsCaches(t *testing.T) {
+	assert := assert.New(t)
+
+	testBlks := NewTestBlocks(4)
+	genesisBlock := testBlks[0]
+	genesisBlock.SetStatus(choices.Accepted)
+	for _, blk := range testBlks[1:] {
+		blk.SetStatus(choices.Processing)
+	}
+
+	getBlock, parseBlock, getCanonicalBlockID := createInternalBlockFuncs(t, testBlks)
+	chainState := NewState(&Config{
+		DecidedCacheSize:    2,
+		MissingCacheSize:    2,
+		UnverifiedCacheSize: 20,
+		BytesToIDCacheSize:  2,
+		BlockSize: func(blk snowman.Block) int {
+			return 10 * len(blk.Bytes())
+		},
+		LastAcceptedBlock:  genesisBlock,
+		GetBlock:           getBlock,
+		UnmarshalBlock:     parseBlock,
+		BuildBlock:         cantBuildBlock,
+		GetBlockIDAtHeight: getCanonicalBlockID,
+	})
+
+	// The decided cache is too small to hold any blocks.
+	_, ok := chainState.decidedBlocks.Get(genesisBlock.ID())
+	assert.False(ok)
+
+	// The unverified cache can only hold two blocks.
+	for _, blk := range testBlks[1:] {
+		_, err := chainState.ParseBlock(blk.Bytes())
+		assert.NoError(err)
+	}
+	_, ok = chainState.unverifiedBlocks.Get(testBlks[1].ID())
+	assert.False(ok)
+	for _, blk := range testBlks[2:] {
+		_, ok = chainState.unverifiedBlocks.Get(blk.ID())
+		assert.True(ok)
+	}
+}
@@ -68,7 +68,7 @@ func (vm *blockVM) Initialize(
 	}
 
 	optionalGatherer := metrics.NewOptionalGatherer()
-	multiGatherer := metrics.NewMultiGatherer()
+	multiGatherer := metrics.NewMultiGatherer(ctx.Log)
 	if err := multiGatherer.Register("metervm", registerer); err != nil {
 		return err
 	}
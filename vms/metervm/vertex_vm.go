@@ -49,7 +49,7 @@ func (vm *vertexVM) Initialize(
 	}
 
 	optionalGatherer := metrics.NewOptionalGatherer()
-	multiGatherer := metrics.NewMultiGatherer()
+	multiGatherer := metrics.NewMultiGatherer(ctx.Log)
 	if err := multiGatherer.Register("metervm", registerer); err != nil {
 		return err
 	}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/json"
+
+	platformpb "github.com/ava-labs/avalanchego/proto/pb/platform"
+)
+
+var (
+	_ common.GRPCVM             = &VM{}
+	_ platformpb.PlatformServer = &grpcServer{}
+)
+
+// RegisterGRPCServices registers the read methods of the platform API as a
+// gRPC service
+func (vm *VM) RegisterGRPCServices(registrar grpc.ServiceRegistrar) error {
+	platformpb.RegisterPlatformServer(registrar, &grpcServer{
+		service: &Service{vm: vm},
+	})
+	return nil
+}
+
+// grpcServer serves the read methods of the platform API over gRPC. It's
+// called with the context lock held.
+type grpcServer struct {
+	platformpb.UnsafePlatformServer
+
+	service *Service
+}
+
+func (s *grpcServer) GetHeight(context.Context, *emptypb.Empty) (*platformpb.GetHeightResponse, error) {
+	reply := GetHeightResponse{}
+	if err := s.service.GetHeight(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetHeightResponse{Height: uint64(reply.Height)}, nil
+}
+
+func (s *grpcServer) GetTimestamp(context.Context, *emptypb.Empty) (*platformpb.GetTimestampResponse, error) {
+	reply := GetTimestampReply{}
+	if err := s.service.GetTimestamp(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetTimestampResponse{Timestamp: timestamppb.New(reply.Timestamp)}, nil
+}
+
+func (s *grpcServer) GetTx(_ context.Context, req *platformpb.GetTxRequest) (*platformpb.GetTxResponse, error) {
+	txID, err := ids.ToID(req.GetTxId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	tx, _, err := s.service.vm.internalState.GetTx(txID)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "couldn't get tx: %s", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &platformpb.GetTxResponse{Tx: tx.Bytes()}, nil
+}
+
+func (s *grpcServer) GetTxStatus(_ context.Context, req *platformpb.GetTxStatusRequest) (*platformpb.GetTxStatusResponse, error) {
+	txID, err := ids.ToID(req.GetTxId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	reply := GetTxStatusResponse{}
+	if err := s.service.GetTxStatus(nil, &GetTxStatusArgs{TxID: txID}, &reply); err != nil {
+		return nil, err
+	}
+	return &platformpb.GetTxStatusResponse{
+		Status: reply.Status.String(),
+		Reason: reply.Reason,
+	}, nil
+}
+
+func (s *grpcServer) GetValidatorsAt(_ context.Context, req *platformpb.GetValidatorsAtRequest) (*platformpb.GetValidatorsAtResponse, error) {
+	subnetID, err := ids.ToID(req.GetSubnetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	args := GetValidatorsAtArgs{
+		Height:   json.Uint64(req.GetHeight()),
+		SubnetID: subnetID,
+	}
+	reply := GetValidatorsAtReply{}
+	if err := s.service.GetValidatorsAt(nil, &args, &reply); err != nil {
+		return nil, err
+	}
+
+	resp := &platformpb.GetValidatorsAtResponse{
+		Validators: make([]*platformpb.Validator, 0, len(reply.Validators)),
+	}
+	for nodeID, weight := range reply.Validators {
+		nodeID := nodeID
+		resp.Validators = append(resp.Validators, &platformpb.Validator{
+			NodeId: nodeID[:],
+			Weight: weight,
+		})
+	}
+	// Sort the validators so that responses are deterministic
+	sort.Slice(resp.Validators, func(i, j int) bool {
+		return string(resp.Validators[i].NodeId) < string(resp.Validators[j].NodeId)
+	})
+	return resp, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+
+	platformpb "github.com/ava-labs/avalanchego/proto/pb/platform"
+)
+
+func TestGRPCGetHeightAndTimestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	server := &grpcServer{service: service}
+
+	heightResp, err := server.GetHeight(context.Background(), &emptypb.Empty{})
+	assert.NoError(err)
+	reply := GetHeightResponse{}
+	assert.NoError(service.GetHeight(nil, nil, &reply))
+	assert.EqualValues(reply.Height, heightResp.Height)
+
+	timestampResp, err := server.GetTimestamp(context.Background(), &emptypb.Empty{})
+	assert.NoError(err)
+	assert.Equal(service.vm.internalState.GetTimestamp().Unix(), timestampResp.Timestamp.AsTime().Unix())
+}
+
+func TestGRPCGetTx(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	server := &grpcServer{service: service}
+
+	tx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	txID := tx.ID()
+
+	_, err = server.GetTx(context.Background(), &platformpb.GetTxRequest{TxId: txID[:]})
+	assert.Equal(codes.NotFound, grpcstatus.Code(err))
+
+	statusResp, err := server.GetTxStatus(context.Background(), &platformpb.GetTxStatusRequest{TxId: txID[:]})
+	assert.NoError(err)
+	assert.Equal(status.Unknown.String(), statusResp.Status)
+
+	service.vm.internalState.AddTx(tx, status.Committed)
+
+	txResp, err := server.GetTx(context.Background(), &platformpb.GetTxRequest{TxId: txID[:]})
+	assert.NoError(err)
+	assert.Equal(tx.Bytes(), txResp.Tx)
+
+	statusResp, err = server.GetTxStatus(context.Background(), &platformpb.GetTxStatusRequest{TxId: txID[:]})
+	assert.NoError(err)
+	assert.Equal(status.Committed.String(), statusResp.Status)
+
+	_, err = server.GetTx(context.Background(), &platformpb.GetTxRequest{TxId: []byte{1}})
+	assert.Equal(codes.InvalidArgument, grpcstatus.Code(err))
+
+	_, err = server.GetTxStatus(context.Background(), &platformpb.GetTxStatusRequest{TxId: []byte{1}})
+	assert.Equal(codes.InvalidArgument, grpcstatus.Code(err))
+}
+
+func TestGRPCGetValidatorsAt(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+	server := &grpcServer{service: service}
+
+	resp, err := server.GetValidatorsAt(context.Background(), &platformpb.GetValidatorsAtRequest{
+		Height:   1,
+		SubnetId: constants.PrimaryNetworkID[:],
+	})
+	assert.NoError(err)
+
+	expected, err := service.vm.GetValidatorSet(1, constants.PrimaryNetworkID)
+	assert.NoError(err)
+	assert.Len(resp.Validators, len(expected))
+	for i, validator := range resp.Validators {
+		nodeID, err := ids.ToNodeID(validator.NodeId)
+		assert.NoError(err)
+		assert.Equal(expected[nodeID], validator.Weight)
+
+		// The validators are sorted by node ID
+		if i > 0 {
+			assert.Equal(-1, bytes.Compare(resp.Validators[i-1].NodeId, validator.NodeId))
+		}
+	}
+
+	_, err = server.GetValidatorsAt(context.Background(), &platformpb.GetValidatorsAtRequest{
+		Height:   1,
+		SubnetId: []byte{1},
+	})
+	assert.Equal(codes.InvalidArgument, grpcstatus.Code(err))
+}
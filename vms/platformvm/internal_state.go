@@ -137,13 +137,18 @@ func NewState(vm *VM, db database.Database, genesis []byte, metrics prometheus.R
 		return nil, err
 	}
 
+	blockDB := prefixdb.New(blockPrefix, baseDB)
+	if err := database.RegisterPrefix(blockDB, "P/block", nil, true); err != nil {
+		vm.ctx.Log.Warn("couldn't register database prefix P/block: %s", err)
+	}
+
 	is := &internalStateImpl{
 		State:       state,
 		vm:          vm,
 		baseDB:      baseDB,
 		addedBlocks: make(map[ids.ID]Block),
 		blockCache:  blockCache,
-		blockDB:     prefixdb.New(blockPrefix, baseDB),
+		blockDB:     blockDB,
 	}
 
 	if err := is.sync(genesis); err != nil {
@@ -299,6 +299,30 @@ func New(
 		metrics,
 		&cache.LRU{Size: chainDBCacheSize},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	txDB := prefixdb.New(txPrefix, baseDB)
+	chainDB := prefixdb.New(chainPrefix, baseDB)
+	singletonDB := prefixdb.New(singletonPrefix, baseDB)
+
+	// Report the storage used by each part of the state in the node's
+	// database metrics. The metrics are best effort, so failing to register
+	// a prefix isn't fatal.
+	for name, db := range map[string]database.Database{
+		"P/validators":  validatorsDB,
+		"P/tx":          txDB,
+		"P/rewardUTXOs": rewardUTXODB,
+		"P/utxo":        utxoDB,
+		"P/subnet":      subnetBaseDB,
+		"P/chain":       chainDB,
+		"P/singleton":   singletonDB,
+	} {
+		if err := database.RegisterPrefix(db, name, nil, true); err != nil {
+			ctx.Log.Warn("couldn't register database prefix %s: %s", name, err)
+		}
+	}
 
 	return &state{
 		cfg:        cfg,
@@ -334,7 +358,7 @@ func New(
 		validatorDiffsCache:          validatorDiffsCache,
 
 		addedTxs: make(map[ids.ID]*txAndStatus),
-		txDB:     prefixdb.New(txPrefix, baseDB),
+		txDB:     txDB,
 		txCache:  txCache,
 
 		addedRewardUTXOs: make(map[ids.ID][]*avax.UTXO),
@@ -349,12 +373,12 @@ func New(
 		subnetDB:     linkeddb.NewDefault(subnetBaseDB),
 
 		addedChains:  make(map[ids.ID][]*txs.Tx),
-		chainDB:      prefixdb.New(chainPrefix, baseDB),
+		chainDB:      chainDB,
 		chainCache:   chainCache,
 		chainDBCache: chainDBCache,
 
-		singletonDB: prefixdb.New(singletonPrefix, baseDB),
-	}, err
+		singletonDB: singletonDB,
+	}, nil
 }
 
 func (s *state) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
@@ -8,10 +8,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/api/openrpc"
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
@@ -412,7 +411,10 @@ func (vm *VM) Version() (string, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.apiRequestMetrics.InterceptRequest)
@@ -432,7 +434,10 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server, err := openrpc.NewServer()
+	if err != nil {
+		return nil, err
+	}
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	if err := server.RegisterService(&api.StaticService{}, "platform"); err != nil {
@@ -78,7 +78,7 @@ type heightIndex struct {
 	versiondb.Commitable
 
 	// Caches block height -> proposerVMBlockID.
-	heightsCache cache.Cacher
+	heightsCache cache.TypedCacher[uint64, ids.ID]
 
 	heightDB   database.Database
 	metadataDB database.Database
@@ -88,7 +88,7 @@ func NewHeightIndex(db database.Database, commitable versiondb.Commitable) Heigh
 	return &heightIndex{
 		Commitable: commitable,
 
-		heightsCache: &cache.LRU{Size: cacheSize},
+		heightsCache: &cache.SizedLRU[uint64, ids.ID]{MaxSize: cacheSize},
 		heightDB:     prefixdb.New(heightPrefix, db),
 		metadataDB:   prefixdb.New(metadataPrefix, db),
 	}
@@ -189,9 +189,8 @@ func (hi *heightIndex) ResetHeightIndex(log logging.Logger, baseDB versiondb.Com
 }
 
 func (hi *heightIndex) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
-	if blkIDIntf, found := hi.heightsCache.Get(height); found {
-		res, _ := blkIDIntf.(ids.ID)
-		return res, nil
+	if blkID, found := hi.heightsCache.Get(height); found {
+		return blkID, nil
 	}
 
 	key := database.PackUInt64(height)
@@ -14,11 +14,17 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/proposervm/block"
 )
 
 const (
-	blockCacheSize = 8192
+	// blockCacheSize is the maximum number of bytes of blocks to cache.
+	blockCacheSize = 64 * units.MiB
+	// blockCacheEntryOverhead approximates the memory used by a cache entry
+	// in addition to its block bytes.
+	blockCacheEntryOverhead = 128
 )
 
 var (
@@ -35,7 +41,7 @@ type BlockState interface {
 type blockState struct {
 	// Caches BlockID -> Block. If the Block is nil, that means the block is not
 	// in storage.
-	blkCache cache.Cacher
+	blkCache cache.TypedCacher[ids.ID, *blockWrapper]
 
 	db database.Database
 }
@@ -49,16 +55,16 @@ type blockWrapper struct {
 
 func NewBlockState(db database.Database) BlockState {
 	return &blockState{
-		blkCache: &cache.LRU{Size: blockCacheSize},
+		blkCache: newBlockCache(),
 		db:       db,
 	}
 }
 
 func NewMeteredBlockState(db database.Database, namespace string, metrics prometheus.Registerer) (BlockState, error) {
-	blkCache, err := metercacher.New(
+	blkCache, err := metercacher.NewTyped[ids.ID, *blockWrapper](
 		fmt.Sprintf("%s_block_cache", namespace),
 		metrics,
-		&cache.LRU{Size: blockCacheSize},
+		newBlockCache(),
 	)
 
 	return &blockState{
@@ -67,13 +73,23 @@ func NewMeteredBlockState(db database.Database, namespace string, metrics promet
 	}, err
 }
 
+func newBlockCache() *cache.SizedLRU[ids.ID, *blockWrapper] {
+	return &cache.SizedLRU[ids.ID, *blockWrapper]{
+		MaxSize: blockCacheSize,
+		Size:    cachedBlockSize,
+	}
+}
+
+func cachedBlockSize(_ ids.ID, blk *blockWrapper) int {
+	if blk == nil {
+		return hashing.HashLen + blockCacheEntryOverhead
+	}
+	return hashing.HashLen + len(blk.Block) + blockCacheEntryOverhead
+}
+
 func (s *blockState) GetBlock(blkID ids.ID) (block.Block, choices.Status, error) {
-	if blkIntf, found := s.blkCache.Get(blkID); found {
-		if blkIntf == nil {
-			return nil, choices.Unknown, database.ErrNotFound
-		}
-		blk, ok := blkIntf.(*blockWrapper)
-		if !ok {
+	if blk, found := s.blkCache.Get(blkID); found {
+		if blk == nil {
 			return nil, choices.Unknown, database.ErrNotFound
 		}
 		return blk.block, blk.Status, nil
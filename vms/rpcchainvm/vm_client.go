@@ -38,6 +38,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common/appsender"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
@@ -73,10 +74,12 @@ var (
 )
 
 const (
-	decidedCacheSize    = 2048
+	// decidedCacheSize, unverifiedCacheSize and bytesToIDCacheSize bound the
+	// total number of bytes of the blocks in the caches.
+	decidedCacheSize    = 64 * units.MiB
 	missingCacheSize    = 2048
-	unverifiedCacheSize = 2048
-	bytesToIDCacheSize  = 2048
+	unverifiedCacheSize = 64 * units.MiB
+	bytesToIDCacheSize  = 64 * units.MiB
 )
 
 // VMClient is an implementation of a VM that talks over RPC.
@@ -136,7 +139,7 @@ func (vm *VMClient) Initialize(
 
 	// Register metrics
 	registerer := prometheus.NewRegistry()
-	multiGatherer := metrics.NewMultiGatherer()
+	multiGatherer := metrics.NewMultiGatherer(ctx.Log)
 	vm.grpcServerMetrics = grpc_prometheus.NewServerMetrics()
 	if err := registerer.Register(vm.grpcServerMetrics); err != nil {
 		return err
@@ -234,6 +237,7 @@ func (vm *VMClient) Initialize(
 			MissingCacheSize:    missingCacheSize,
 			UnverifiedCacheSize: unverifiedCacheSize,
 			BytesToIDCacheSize:  bytesToIDCacheSize,
+			BlockSize:           blockSize,
 			LastAcceptedBlock:   lastAcceptedBlk,
 			GetBlock:            vm.getBlock,
 			UnmarshalBlock:      vm.parseBlock,
@@ -793,6 +797,11 @@ func (vm *VMClient) GetStateSummary(summaryHeight uint64) (block.StateSummary, e
 	}, err
 }
 
+// blockSize returns the number of bytes a block takes up in the block caches.
+func blockSize(blk snowman.Block) int {
+	return len(blk.Bytes())
+}
+
 type blockClient struct {
 	vm *VMClient
 